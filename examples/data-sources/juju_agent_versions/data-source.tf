@@ -0,0 +1,7 @@
+data "juju_agent_versions" "development" {
+  model = juju_model.development.name
+}
+
+output "model_upgrade_available" {
+  value = data.juju_agent_versions.development.model_upgrade_target != ""
+}
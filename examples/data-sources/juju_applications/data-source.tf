@@ -0,0 +1,18 @@
+data "juju_applications" "production" {
+  model = "production"
+}
+
+resource "juju_integration" "cos_agent" {
+  for_each = { for app in data.juju_applications.production.applications : app.name => app if !app.subordinate && app.name != "grafana-agent" }
+
+  model = "production"
+
+  application {
+    name     = each.key
+    endpoint = "cos-agent"
+  }
+
+  application {
+    name = "grafana-agent"
+  }
+}
@@ -0,0 +1,8 @@
+data "juju_bundle_diff" "this" {
+  model  = juju_model.development.name
+  bundle = file("${path.module}/bundle.yaml")
+}
+
+output "bundle_drift" {
+  value = data.juju_bundle_diff.this.in_sync ? "" : data.juju_bundle_diff.this.diff
+}
@@ -0,0 +1,18 @@
+data "juju_charm_resources" "grafana" {
+  model   = juju_model.development.name
+  name    = "grafana-k8s"
+  channel = "latest/stable"
+}
+
+resource "juju_application" "grafana" {
+  model = juju_model.development.name
+
+  charm {
+    name     = data.juju_charm_resources.grafana.name
+    channel  = data.juju_charm_resources.grafana.channel
+    revision = data.juju_charm_resources.grafana.revision
+  }
+
+  trust     = true
+  resources = data.juju_charm_resources.grafana.revisions
+}
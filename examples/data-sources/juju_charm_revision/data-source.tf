@@ -0,0 +1,17 @@
+data "juju_charm_revision" "postgresql" {
+  model   = juju_model.development.name
+  name    = "postgresql"
+  channel = "14/stable"
+  base    = "ubuntu@22.04"
+}
+
+resource "juju_application" "postgresql" {
+  model = juju_model.development.name
+
+  charm {
+    name     = data.juju_charm_revision.postgresql.name
+    channel  = data.juju_charm_revision.postgresql.channel
+    revision = data.juju_charm_revision.postgresql.revision
+    base     = data.juju_charm_revision.postgresql.base
+  }
+}
@@ -0,0 +1,19 @@
+data "juju_credentials" "aws" {
+  cloud = "aws"
+}
+
+locals {
+  production_credential = one([
+    for c in data.juju_credentials.aws.credentials : c.name if startswith(c.name, "production-") && c.valid
+  ])
+}
+
+resource "juju_model" "production" {
+  name = "production"
+
+  cloud {
+    name = "aws"
+  }
+
+  credential = local.production_credential
+}
@@ -0,0 +1,15 @@
+data "juju_full_status" "development" {
+  model = juju_model.development.name
+}
+
+locals {
+  status = jsondecode(data.juju_full_status.development.json)
+}
+
+output "blocked_units" {
+  value = [for name, status in data.juju_full_status.development.units : name if status == "blocked"]
+}
+
+output "controller_timestamp" {
+  value = local.status.controller-timestamp
+}
@@ -0,0 +1,8 @@
+data "juju_model_config" "this" {
+  model = var.model_name
+}
+
+locals {
+  # Only set the proxy if the model does not already have one.
+  needs_proxy = data.juju_model_config.this.config["juju-http-proxy"].value == ""
+}
@@ -0,0 +1,12 @@
+data "juju_models" "admin" {
+  owner = "admin"
+}
+
+resource "juju_application" "monitoring" {
+  for_each = { for m in data.juju_models.admin.models : m.name => m if m.life == "alive" }
+
+  model = each.key
+  charm {
+    name = "grafana-agent"
+  }
+}
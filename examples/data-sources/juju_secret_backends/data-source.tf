@@ -0,0 +1,20 @@
+data "juju_secret_backends" "all" {}
+
+output "secrets_per_backend" {
+  value = { for b in data.juju_secret_backends.all.backends : b.name => b.num_secrets }
+}
+
+resource "juju_model" "development" {
+  name = "development"
+
+  config = {
+    secret-backend = "myvault"
+  }
+
+  lifecycle {
+    precondition {
+      condition     = contains(data.juju_secret_backends.all.backends[*].name, "myvault")
+      error_message = "The myvault secret backend must be added to the controller."
+    }
+  }
+}
@@ -0,0 +1,19 @@
+data "juju_spaces" "this" {
+  model = juju_model.development.name
+}
+
+locals {
+  space_names = [for s in data.juju_spaces.this.spaces : s.name]
+}
+
+resource "juju_application" "this" {
+  model = juju_model.development.name
+
+  charm {
+    name = "ubuntu"
+  }
+
+  endpoint_bindings = [{
+    space = contains(local.space_names, "internal") ? "internal" : "alpha"
+  }]
+}
@@ -0,0 +1,16 @@
+data "juju_storage_pools" "this" {
+  model = juju_model.development.name
+}
+
+resource "juju_application" "postgresql" {
+  model = juju_model.development.name
+
+  charm {
+    name    = "postgresql"
+    channel = "14/stable"
+  }
+
+  storage_directives = {
+    pgdata = contains(data.juju_storage_pools.this.names, "fast") ? "fast,10G" : "10G"
+  }
+}
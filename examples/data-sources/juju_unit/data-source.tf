@@ -0,0 +1,8 @@
+data "juju_unit" "mysql_leader" {
+  model = juju_model.development.name
+  name  = "mysql/0"
+}
+
+output "mysql_address" {
+  value = data.juju_unit.mysql_leader.private_address
+}
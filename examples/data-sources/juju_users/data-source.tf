@@ -0,0 +1,11 @@
+data "juju_users" "all" {
+  include_disabled = true
+}
+
+output "superusers" {
+  value = [for u in data.juju_users.all.users : u.name if u.access == "superuser"]
+}
+
+output "never_connected" {
+  value = [for u in data.juju_users.all.users : u.name if u.last_connection == ""]
+}
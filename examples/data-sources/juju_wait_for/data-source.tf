@@ -0,0 +1,19 @@
+data "juju_wait_for" "database" {
+  model   = juju_model.development.name
+  type    = "application"
+  name    = juju_application.database.name
+  status  = ["active"]
+  timeout = "15m"
+}
+
+resource "juju_integration" "this" {
+  model = juju_model.development.name
+
+  application {
+    name = data.juju_wait_for.database.name
+  }
+
+  application {
+    name = juju_application.wordpress.name
+  }
+}
@@ -0,0 +1,3 @@
+output "cloud" {
+  value = provider::juju::cloud_tag("localhost")
+}
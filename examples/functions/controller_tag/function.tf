@@ -0,0 +1,3 @@
+output "controller" {
+  value = provider::juju::controller_tag(var.controller_uuid)
+}
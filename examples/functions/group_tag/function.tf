@@ -0,0 +1,3 @@
+output "group_members" {
+  value = "${provider::juju::group_tag(var.group_uuid)}#member"
+}
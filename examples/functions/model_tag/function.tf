@@ -0,0 +1,3 @@
+output "model" {
+  value = provider::juju::model_tag(juju_model.model.uuid)
+}
@@ -0,0 +1,12 @@
+locals {
+  base = provider::juju::normalize_base(var.base)
+}
+
+resource "juju_machine" "machine" {
+  model = juju_model.model.name
+  base  = local.base.base
+}
+
+output "os" {
+  value = local.base.os
+}
@@ -0,0 +1,11 @@
+resource "juju_integration" "wordpress_db" {
+  model = juju_model.wordpress.name
+
+  application {
+    name = juju_application.wordpress.name
+  }
+
+  application {
+    offer_url = provider::juju::offer_url("admin", "database", "postgresql")
+  }
+}
@@ -0,0 +1,16 @@
+locals {
+  placements = [for p in var.placements : provider::juju::parse_placement(p)]
+}
+
+resource "juju_application" "app" {
+  model = juju_model.model.name
+  units = length(local.placements)
+  charm {
+    name = "ubuntu"
+  }
+  placement = join(",", [for p in local.placements : p.placement])
+}
+
+output "hosts" {
+  value = distinct([for p in local.placements : p.machine if p.machine != ""])
+}
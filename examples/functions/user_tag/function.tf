@@ -0,0 +1,3 @@
+output "alice" {
+  value = provider::juju::user_tag("alice@canonical.com")
+}
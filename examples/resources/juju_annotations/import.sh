@@ -0,0 +1,3 @@
+# Annotations can be imported using the format: `model_name:entity_tag`, or `model_name` for the
+# annotations of the model itself, for example:
+$ terraform import juju_annotations.wordpress development:application-wordpress
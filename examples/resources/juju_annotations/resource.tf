@@ -0,0 +1,15 @@
+resource "juju_annotations" "model" {
+  model = juju_model.development.name
+  annotations = {
+    owner = "platform-team"
+  }
+}
+
+resource "juju_annotations" "wordpress" {
+  model  = juju_model.development.name
+  entity = "application-${juju_application.wordpress.name}"
+  annotations = {
+    owner       = "web-team"
+    cost-center = "1234"
+  }
+}
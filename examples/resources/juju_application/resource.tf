@@ -25,4 +25,12 @@ resource "juju_application" "this" {
   config = {
     external-hostname = "..."
   }
-}
\ No newline at end of file
+}
+resource "juju_application" "local" {
+  model = juju_model.development.name
+
+  charm {
+    name = "my-charm"
+    path = "${path.module}/my-charm_ubuntu-22.04-amd64.charm"
+  }
+}
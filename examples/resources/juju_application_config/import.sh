@@ -0,0 +1,2 @@
+# Application config can be imported using the format: `model_name:application_name`, for example:
+$ terraform import juju_application_config.postgresql_tuning development:postgresql
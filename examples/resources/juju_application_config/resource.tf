@@ -0,0 +1,8 @@
+resource "juju_application_config" "postgresql_tuning" {
+  model       = juju_model.development.name
+  application = juju_application.postgresql.name
+  config = {
+    profile                      = "production"
+    experimental_max_connections = 200
+  }
+}
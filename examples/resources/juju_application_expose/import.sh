@@ -0,0 +1,2 @@
+# Application exposure can be imported using the format: `model_name:application_name`, for example:
+$ terraform import juju_application_expose.wordpress development:wordpress
@@ -0,0 +1,6 @@
+resource "juju_application_expose" "wordpress" {
+  model       = juju_model.development.name
+  application = juju_application.wordpress.name
+  endpoints   = ["website"]
+  cidrs       = ["10.0.0.0/24", "192.168.1.0/24"]
+}
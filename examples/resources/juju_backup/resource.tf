@@ -0,0 +1,9 @@
+resource "juju_backup" "nightly" {
+  notes         = "nightly backup"
+  download_path = "/var/backups/juju/controller.tar.gz"
+
+  # Changing any value in triggers creates a new backup.
+  triggers = {
+    date = formatdate("YYYY-MM-DD", timestamp())
+  }
+}
@@ -0,0 +1,11 @@
+resource "juju_bundle" "wordpress" {
+  model    = juju_model.development.name
+  bundle   = file("bundle.yaml")
+  overlays = [file("overlay.yaml")]
+}
+
+resource "juju_bundle" "kubeflow" {
+  model   = juju_model.kubeflow.name
+  name    = "kubeflow"
+  channel = "1.9/stable"
+}
@@ -0,0 +1,2 @@
+# Charm resources can be imported using the format: `model_name:application_name:resource_name`, for example:
+$ terraform import juju_charm_resource.grafana_image development:grafana:grafana-image
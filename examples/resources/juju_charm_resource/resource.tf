@@ -0,0 +1,6 @@
+resource "juju_charm_resource" "grafana_image" {
+  model       = juju_model.development.name
+  application = juju_application.grafana.name
+  name        = "grafana-image"
+  value       = "ghcr.io/canonical/grafana:10.4"
+}
@@ -0,0 +1,15 @@
+resource "juju_exec" "reload_nginx" {
+  model   = juju_model.development.name
+  command = "systemctl reload nginx"
+  units   = ["nginx/0", "nginx/1"]
+  timeout = "1m"
+
+  # Changing any value in triggers runs the command again.
+  triggers = {
+    config = sha1(file("nginx.conf"))
+  }
+}
+
+output "nginx_reload_exit_codes" {
+  value = { for r in juju_exec.reload_nginx.results : r.target => r.exit_code }
+}
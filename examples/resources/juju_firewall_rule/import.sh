@@ -0,0 +1,2 @@
+# Firewall rules can be imported using the name or the UUID of the model and the service, for example:
+$ terraform import juju_firewall_rule.offers development:juju-application-offer
@@ -0,0 +1,5 @@
+resource "juju_firewall_rule" "offers" {
+  model           = juju_model.development.name
+  service         = "juju-application-offer"
+  whitelist_cidrs = ["10.0.0.0/8", "192.168.1.0/24"]
+}
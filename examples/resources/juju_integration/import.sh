@@ -1,2 +1,2 @@
-# Integrations can be imported by using the format: model_name:provider_app_name:endpoint:requirer_app_name:endpoint, for example:
+# Integrations can be imported by using the format: model_name:provider_app_name:provider_endpoint:requirer_app_name:requirer_endpoint, for example:
 $ terraform import juju_integration.wordpress_db development:percona-cluster:server:wordpress:db
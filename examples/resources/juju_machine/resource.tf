@@ -3,4 +3,10 @@ resource "juju_machine" "this_machine" {
   base        = "ubuntu@22.04"
   name        = "this_machine"
   constraints = "tags=my-machine-tag"
-}
\ No newline at end of file
+}
+
+resource "juju_machine" "this_container" {
+  model          = juju_model.development.name
+  parent_machine = juju_machine.this_machine.machine_id
+  container_type = "lxd"
+}
@@ -0,0 +1,2 @@
+# Model config can be imported using the name or the UUID of the model, for example:
+$ terraform import juju_model_config.logging shared
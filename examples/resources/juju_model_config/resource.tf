@@ -0,0 +1,7 @@
+resource "juju_model_config" "logging" {
+  model = "shared"
+  config = {
+    logging-config = "<root>=INFO;unit=DEBUG"
+    apt-mirror     = "http://mirror.example.com/ubuntu"
+  }
+}
@@ -0,0 +1,4 @@
+# Model defaults can be imported using the cloud, optionally followed by
+# the region, for example:
+$ terraform import juju_model_defaults.aws aws
+$ terraform import juju_model_defaults.us_east_1 aws:us-east-1
@@ -0,0 +1,15 @@
+resource "juju_model_defaults" "aws" {
+  cloud = "aws"
+  config = {
+    automatically-retry-hooks = false
+    image-stream              = "daily"
+  }
+}
+
+resource "juju_model_defaults" "us_east_1" {
+  cloud  = "aws"
+  region = "us-east-1"
+  config = {
+    apt-mirror = "http://mirror.us-east-1.example.com/ubuntu"
+  }
+}
@@ -0,0 +1,17 @@
+provider "juju" {
+  experimental_features = ["model_migration"]
+}
+
+resource "juju_model_migration" "development" {
+  model                   = "development"
+  target_controller_uuid  = "b9c5b8e1-7d1e-4b8a-8a4c-5f1d6e3c2a10"
+  target_controller_alias = "new-controller"
+  target_addresses        = ["10.0.0.2:17070"]
+  target_ca_cert          = file("target-ca.crt")
+  target_user             = "admin"
+  target_password         = var.target_password
+
+  timeouts {
+    create = "1h"
+  }
+}
@@ -0,0 +1,129 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	"github.com/juju/juju/api/base"
+	apiannotations "github.com/juju/juju/api/client/annotations"
+	"github.com/juju/names/v5"
+)
+
+// getEntityAnnotations returns the annotations of the entity with the
+// given tag.
+func getEntityAnnotations(conn base.APICallCloser, tag string) (map[string]string, error) {
+	results, err := apiannotations.NewClient(conn).Get([]string{tag})
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting annotations of %q", tag)
+	}
+	if len(results) != 1 {
+		return nil, errors.Errorf("expected one annotations result for %q, got %d", tag, len(results))
+	}
+	if results[0].Error.Error != nil {
+		return nil, typedError(results[0].Error.Error)
+	}
+	return results[0].Annotations, nil
+}
+
+// setEntityAnnotations sets the annotations of the entity with the given
+// tag. An empty value removes the annotation.
+func setEntityAnnotations(conn base.APICallCloser, tag string, annotations map[string]string) error {
+	results, err := apiannotations.NewClient(conn).Set(map[string]map[string]string{tag: annotations})
+	if err != nil {
+		return errors.Annotatef(err, "setting annotations of %q", tag)
+	}
+	for _, result := range results {
+		if result.Error != nil {
+			return typedError(result.Error)
+		}
+	}
+	return nil
+}
+
+type annotationsClient struct {
+	SharedClient
+}
+
+type SetAnnotationsInput struct {
+	ModelName string
+	// EntityTag is the tag of the annotated entity, e.g. application-mysql.
+	// The model itself is annotated when empty.
+	EntityTag   string
+	Annotations map[string]string
+}
+
+type GetAnnotationsInput struct {
+	ModelName string
+	EntityTag string
+}
+
+type GetAnnotationsResponse struct {
+	Annotations map[string]string
+}
+
+func newAnnotationsClient(sc SharedClient) *annotationsClient {
+	return &annotationsClient{
+		SharedClient: sc,
+	}
+}
+
+// SetAnnotations sets annotations on an entity of a model. Annotations
+// with an empty value are removed.
+func (c *annotationsClient) SetAnnotations(ctx context.Context, input SetAnnotationsInput) error {
+	tag, err := c.entityTag(ctx, input.ModelName, input.EntityTag)
+	if err != nil {
+		return err
+	}
+	conn, err := c.GetConnection(ctx, &input.ModelName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	c.Tracef("Setting annotations", map[string]interface{}{"entity": tag, "annotations": input.Annotations})
+	return setEntityAnnotations(conn, tag, input.Annotations)
+}
+
+// GetAnnotations returns the annotations of an entity of a model.
+func (c *annotationsClient) GetAnnotations(ctx context.Context, input GetAnnotationsInput) (*GetAnnotationsResponse, error) {
+	tag, err := c.entityTag(ctx, input.ModelName, input.EntityTag)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := c.GetConnection(ctx, &input.ModelName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	annotations, err := getEntityAnnotations(conn, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &GetAnnotationsResponse{Annotations: annotations}, nil
+}
+
+// entityTag validates the given tag, or returns the tag of the model
+// when empty.
+func (c *annotationsClient) entityTag(ctx context.Context, modelName, entityTag string) (string, error) {
+	if entityTag == "" {
+		modelUUID, err := c.ModelUUID(ctx, modelName)
+		if err != nil {
+			return "", err
+		}
+		return names.NewModelTag(modelUUID).String(), nil
+	}
+	tag, err := names.ParseTag(entityTag)
+	if err != nil {
+		return "", err
+	}
+	switch tag.Kind() {
+	case names.ModelTagKind, names.ApplicationTagKind, names.MachineTagKind, names.UnitTagKind:
+		return tag.String(), nil
+	default:
+		return "", errors.NotSupportedf("annotating %q entities", tag.Kind())
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"context"
+	"testing"
+
+	"github.com/juju/errors"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type AnnotationsSuite struct {
+	JujuSuite
+}
+
+func (s *AnnotationsSuite) TestEntityTag() {
+	defer s.setupMocks(s.T()).Finish()
+	s.mockSharedClient.EXPECT().ModelUUID(gomock.Any(), s.testModelName).Return("4f1ae5d6-0c5e-4a5b-8a4a-2f0d7d0e3b1c", nil)
+
+	client := newAnnotationsClient(s.mockSharedClient)
+
+	tag, err := client.entityTag(context.Background(), s.testModelName, "")
+	s.Require().NoError(err)
+	s.Assert().Equal("model-4f1ae5d6-0c5e-4a5b-8a4a-2f0d7d0e3b1c", tag)
+
+	for _, entity := range []string{"application-mysql", "machine-0-lxd-1", "unit-mysql-0"} {
+		tag, err = client.entityTag(context.Background(), s.testModelName, entity)
+		s.Require().NoError(err)
+		s.Assert().Equal(entity, tag)
+	}
+
+	_, err = client.entityTag(context.Background(), s.testModelName, "user-admin")
+	s.Assert().True(errors.Is(err, errors.NotSupported), err)
+
+	_, err = client.entityTag(context.Background(), s.testModelName, "mysql")
+	s.Assert().Error(err)
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestAnnotationsSuite(t *testing.T) {
+	suite.Run(t, new(AnnotationsSuite))
+}
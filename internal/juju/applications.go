@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -26,20 +27,27 @@ import (
 	jujuerrors "github.com/juju/errors"
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
+	apiaction "github.com/juju/juju/api/client/action"
 	apiapplication "github.com/juju/juju/api/client/application"
 	apicharms "github.com/juju/juju/api/client/charms"
 	apiclient "github.com/juju/juju/api/client/client"
+	apimachinemanager "github.com/juju/juju/api/client/machinemanager"
 	apimodelconfig "github.com/juju/juju/api/client/modelconfig"
 	apiresources "github.com/juju/juju/api/client/resources"
 	apispaces "github.com/juju/juju/api/client/spaces"
+	apistorage "github.com/juju/juju/api/client/storage"
 	apicommoncharm "github.com/juju/juju/api/common/charm"
 	"github.com/juju/juju/cmd/juju/application/utils"
 	resourcecmd "github.com/juju/juju/cmd/juju/resource"
+	"github.com/juju/juju/core/arch"
 	corebase "github.com/juju/juju/core/base"
+	corecharm "github.com/juju/juju/core/charm"
 	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
+	coreresources "github.com/juju/juju/core/resources"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/rpc/params"
 	jujustorage "github.com/juju/juju/storage"
@@ -61,6 +69,11 @@ func (ae *applicationNotFoundError) Error() string {
 	return fmt.Sprintf("application %s not found", ae.appName)
 }
 
+// Is reports the error to be a NotFound error.
+func (ae *applicationNotFoundError) Is(target error) bool {
+	return target == jujuerrors.NotFound
+}
+
 var StorageNotFoundError = &storageNotFoundError{}
 
 // StorageNotFoundError
@@ -72,6 +85,11 @@ func (se *storageNotFoundError) Error() string {
 	return fmt.Sprintf("storage %s not found", se.storageName)
 }
 
+// Is reports the error to be a NotFound error.
+func (se *storageNotFoundError) Is(target error) bool {
+	return target == jujuerrors.NotFound
+}
+
 var RetryReadError = &retryReadError{}
 
 // retryReadError
@@ -87,21 +105,29 @@ type applicationsClient struct {
 	SharedClient
 	controllerVersion version.Number
 
-	getApplicationAPIClient func(base.APICallCloser) ApplicationAPIClient
-	getClientAPIClient      func(api.Connection) ClientAPIClient
-	getModelConfigAPIClient func(api.Connection) ModelConfigAPIClient
-	getResourceAPIClient    func(connection api.Connection) (ResourceAPIClient, error)
+	getActionAPIClient         func(base.APICallCloser) ActionAPIClient
+	getApplicationAPIClient    func(base.APICallCloser) ApplicationAPIClient
+	getClientAPIClient         func(api.Connection) ClientAPIClient
+	getMachineManagerAPIClient func(base.APICallCloser) MachineManagerAPIClient
+	getModelConfigAPIClient    func(api.Connection) ModelConfigAPIClient
+	getResourceAPIClient       func(connection api.Connection) (ResourceAPIClient, error)
 }
 
 func newApplicationClient(sc SharedClient) *applicationsClient {
 	return &applicationsClient{
 		SharedClient: sc,
+		getActionAPIClient: func(closer base.APICallCloser) ActionAPIClient {
+			return apiaction.NewClient(closer)
+		},
 		getApplicationAPIClient: func(closer base.APICallCloser) ApplicationAPIClient {
 			return apiapplication.NewClient(closer)
 		},
 		getClientAPIClient: func(conn api.Connection) ClientAPIClient {
 			return apiclient.NewClient(conn, sc.JujuLogger())
 		},
+		getMachineManagerAPIClient: func(closer base.APICallCloser) MachineManagerAPIClient {
+			return apimachinemanager.NewClient(closer)
+		},
 		getModelConfigAPIClient: func(conn api.Connection) ModelConfigAPIClient {
 			return apimodelconfig.NewClient(conn)
 		},
@@ -156,6 +182,7 @@ type CreateApplicationInput struct {
 	CharmBase          string
 	CharmSeries        string
 	CharmRevision      int
+	CharmPath          string
 	Units              int
 	Trust              bool
 	Expose             map[string]interface{}
@@ -165,6 +192,19 @@ type CreateApplicationInput struct {
 	EndpointBindings   map[string]string
 	Resources          map[string]string
 	StorageConstraints map[string]jujustorage.Constraints
+	// Devices are the device constraints of the application, keyed by
+	// the name of the device defined by the charm.
+	Devices map[string]devices.Constraints
+	// Placements are the placement directives of the units, in
+	// order, used instead of Placement.
+	Placements []string
+	// AttachStorage are the IDs of existing storage instances to
+	// attach to the unit deployed, like `juju deploy --attach-storage`.
+	AttachStorage []string
+	// CharmArchitecture is the architecture of the charm revision to
+	// deploy, e.g. arm64, set as the arch constraint of the
+	// application. The model constraints are used if empty.
+	CharmArchitecture string
 }
 
 // validateAndTransform returns transformedCreateApplicationInput which
@@ -175,13 +215,38 @@ func (input CreateApplicationInput) validateAndTransform(conn api.Connection) (p
 	parsed.charmChannel = input.CharmChannel
 	parsed.charmName = input.CharmName
 	parsed.charmRevision = input.CharmRevision
+	parsed.charmPath = input.CharmPath
 	parsed.constraints = input.Constraints
+	if input.CharmArchitecture != "" {
+		if !arch.IsSupportedArch(input.CharmArchitecture) {
+			err = jujuerrors.NotValidf("architecture %q", input.CharmArchitecture)
+			return
+		}
+		if input.Constraints.HasArch() && *input.Constraints.Arch != input.CharmArchitecture {
+			err = fmt.Errorf("charm architecture %q conflicts with the arch constraint %q", input.CharmArchitecture, *input.Constraints.Arch)
+			return
+		}
+		parsed.constraints.Arch = &input.CharmArchitecture
+	}
 	parsed.config = input.Config
 	parsed.expose = input.Expose
 	parsed.trust = input.Trust
 	parsed.units = input.Units
 	parsed.resources = input.Resources
 	parsed.storage = input.StorageConstraints
+	parsed.devices = input.Devices
+
+	if len(input.AttachStorage) > 0 && input.Units != 1 {
+		err = fmt.Errorf("cannot attach existing storage when %d units are requested, only one unit is allowed", input.Units)
+		return
+	}
+	for _, id := range input.AttachStorage {
+		if !names.IsValidStorage(id) {
+			err = jujuerrors.NotValidf("storage ID %q", id)
+			return
+		}
+	}
+	parsed.attachStorage = input.AttachStorage
 
 	appName := input.ApplicationName
 	if appName == "" {
@@ -211,7 +276,12 @@ func (input CreateApplicationInput) validateAndTransform(conn api.Connection) (p
 	parsed.charmBase = userSuppliedBase
 
 	placements := []*instance.Placement{}
-	if input.Placement == "" {
+	if len(input.Placements) > 0 {
+		placements, err = parsePlacements(input.Placements)
+		if err != nil {
+			return
+		}
+	} else if input.Placement == "" {
 		placements = nil
 	} else {
 		placementDirectives := strings.Split(input.Placement, ",")
@@ -259,6 +329,7 @@ type transformedCreateApplicationInput struct {
 	charmChannel     string
 	charmBase        corebase.Base
 	charmRevision    int
+	charmPath        string
 	config           map[string]string
 	constraints      constraints.Value
 	expose           map[string]interface{}
@@ -268,6 +339,8 @@ type transformedCreateApplicationInput struct {
 	endpointBindings map[string]string
 	resources        map[string]string
 	storage          map[string]jujustorage.Constraints
+	attachStorage    []string
+	devices          map[string]devices.Constraints
 }
 
 type CreateApplicationResponse struct {
@@ -285,6 +358,7 @@ type ReadApplicationResponse struct {
 	Revision         int
 	Base             string
 	Series           string
+	Architecture     string
 	Units            int
 	Trust            bool
 	Config           map[string]ConfigEntry
@@ -295,6 +369,24 @@ type ReadApplicationResponse struct {
 	EndpointBindings map[string]string
 	Storage          map[string]jujustorage.Constraints
 	Resources        map[string]string
+	// PublicAddress is the address of the Kubernetes service of the
+	// application, or the public address of its leader unit on
+	// machine clouds.
+	PublicAddress string
+	// LeaderUnit is the name of the leader unit, empty if none.
+	LeaderUnit string
+	// UnitAddresses and UnitMachines are the public addresses and
+	// the machine IDs of the units, by unit name.
+	UnitAddresses map[string]string
+	UnitMachines  map[string]string
+	// UnitNames are the names of the units, ordered by unit number.
+	UnitNames []string
+	// Status and StatusMessage are the workload status of the
+	// application, as shown by `juju status`.
+	Status          string
+	StatusMessage   string
+	CharmVersion    string
+	WorkloadVersion string
 }
 
 type UpdateApplicationInput struct {
@@ -304,22 +396,65 @@ type UpdateApplicationInput struct {
 	Units     *int
 	Revision  *int
 	Channel   string
+	// CharmName is the name of the charm to switch the application
+	// to, like `juju refresh --switch`.
+	CharmName string
+	// CharmPath is the path of a local charm archive to refresh
+	// the application with.
+	CharmPath string
 	Trust     *bool
 	Expose    map[string]interface{}
 	// Unexpose indicates what endpoints to unexpose
 	Unexpose []string
 	Config   map[string]string
-	//Series    string // Unsupported today
+	// Base or Series is the operating system of the application. The
+	// machines of its units are upgraded to it in place.
+	Base               string
+	Series             string
 	Placement          map[string]interface{}
 	Constraints        *constraints.Value
 	EndpointBindings   map[string]string
 	StorageConstraints map[string]jujustorage.Constraints
-	Resources          map[string]string
+	// StorageAdditions are the storage instances to add to each
+	// unit of the application, by storage label.
+	StorageAdditions map[string]jujustorage.Constraints
+	// StorageDirectives are the storage directives of the application,
+	// by storage label. The units added get the storage instances
+	// missing from the ones they are created with, as the count of the
+	// storage directives of the application cannot be increased.
+	StorageDirectives map[string]jujustorage.Constraints
+	Resources         map[string]string
+	// UnitPlacements are the placement directives of the units
+	// added, in order.
+	UnitPlacements []string
+	// ScaleStrategy is how the units of an application on
+	// Kubernetes are scaled down, one of ScaleStrategyGraceful or
+	// ScaleStrategyImmediate. If empty, the units are scaled down
+	// without waiting for their removal.
+	ScaleStrategy string
 }
 
+const (
+	// ScaleStrategyGraceful scales the application down waiting
+	// for the units removed to run their teardown hooks.
+	ScaleStrategyGraceful = "graceful"
+	// ScaleStrategyImmediate forces the removal of the units
+	// scaled down.
+	ScaleStrategyImmediate = "immediate"
+)
+
+// scaleDownTimeout is how long a graceful scale down waits for the
+// units to be removed.
+const scaleDownTimeout = 10 * time.Minute
+
 type DestroyApplicationInput struct {
 	ApplicationName string
 	ModelName       string
+	// KeepStorage detaches the storage of the application rather
+	// than destroying it, so that it can be attached again, e.g. on
+	// deploy with CreateApplicationInput.AttachStorage.
+	KeepStorage bool
+	DestroyOptions
 }
 
 func resolveCharmURL(charmName string) (*charm.URL, error) {
@@ -335,8 +470,66 @@ func resolveCharmURL(charmName string) (*charm.URL, error) {
 	return charmURL, nil
 }
 
+// parsePlacements parses the placement directives, keeping their order.
+func parsePlacements(directives []string) ([]*instance.Placement, error) {
+	placements := make([]*instance.Placement, 0, len(directives))
+	for _, directive := range directives {
+		placement, err := instance.ParsePlacement(directive)
+		if err != nil {
+			return nil, err
+		}
+		placements = append(placements, placement)
+	}
+	return placements, nil
+}
+
+// PlacementSatisfied reports whether the machines of the units, as read
+// in the placement of ReadApplicationResponse, satisfy the placement
+// directives, comma separated. A machine satisfies a directive to it, or
+// to a container of the same type on it, e.g. 1/lxd/0 satisfies lxd:1.
+// The directives of other scopes, e.g. to the cloud provider, cannot be
+// checked and are satisfied by any machine.
+func PlacementSatisfied(directives, machines string) bool {
+	if machines == "" {
+		return true
+	}
+	var placements []*instance.Placement
+	for _, directive := range strings.Split(directives, ",") {
+		placement, err := instance.ParsePlacement(directive)
+		if err != nil {
+			return false
+		}
+		if placement != nil {
+			placements = append(placements, placement)
+		}
+	}
+	for _, machine := range strings.Split(machines, ",") {
+		if !slices.ContainsFunc(placements, func(p *instance.Placement) bool {
+			return machineSatisfies(machine, p)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+func machineSatisfies(machine string, placement *instance.Placement) bool {
+	if placement.Scope == instance.MachineScope {
+		return placement.Directive == machine
+	}
+	if _, err := instance.ParseContainerType(placement.Scope); err != nil {
+		return true
+	}
+	parts := strings.Split(machine, "/")
+	if len(parts) < 3 || parts[len(parts)-2] != placement.Scope {
+		return false
+	}
+	parent := strings.Join(parts[:len(parts)-2], "/")
+	return placement.Directive == "" || placement.Directive == parent
+}
+
 func (c applicationsClient) CreateApplication(ctx context.Context, input *CreateApplicationInput) (*CreateApplicationResponse, error) {
-	conn, err := c.GetConnection(&input.ModelName)
+	conn, err := c.GetConnection(ctx, &input.ModelName)
 	if err != nil {
 		return nil, err
 	}
@@ -352,10 +545,18 @@ func (c applicationsClient) CreateApplication(ctx context.Context, input *Create
 	if err != nil {
 		return nil, err
 	}
-	if applicationAPIClient.BestAPIVersion() >= 19 {
-		err := c.deployFromRepository(applicationAPIClient, resourceAPIClient, transformedInput)
-		if err != nil {
+	if transformedInput.charmPath != "" {
+		err = c.deployLocalCharm(ctx, conn, applicationAPIClient, transformedInput)
+	} else if applicationAPIClient.BestAPIVersion() >= 19 {
+		deployed, err := c.deployFromRepository(applicationAPIClient, resourceAPIClient, transformedInput)
+		if err != nil && !deployed {
 			return nil, err
+		} else if err != nil {
+			// The application is deployed, return it with the error so
+			// that it is tracked.
+			return &CreateApplicationResponse{
+				AppName: transformedInput.applicationName,
+			}, err
 		}
 	} else {
 		err = c.legacyDeploy(ctx, conn, applicationAPIClient, transformedInput)
@@ -366,7 +567,8 @@ func (c applicationsClient) CreateApplication(ctx context.Context, input *Create
 	}
 
 	// If we have managed to deploy something, now we have
-	// to check if we have to expose something
+	// to check if we have to expose something. The response is
+	// returned with the error, the application being deployed.
 	err = c.processExpose(applicationAPIClient, transformedInput.applicationName, transformedInput.expose)
 
 	return &CreateApplicationResponse{
@@ -374,11 +576,14 @@ func (c applicationsClient) CreateApplication(ctx context.Context, input *Create
 	}, err
 }
 
-func (c applicationsClient) deployFromRepository(applicationAPIClient ApplicationAPIClient, resourceAPIClient ResourceAPIClient, transformedInput transformedCreateApplicationInput) error {
+// deployFromRepository deploys the application, uploading its local
+// resources. deployed reports whether the application is deployed,
+// including when uploading the resources fails.
+func (c applicationsClient) deployFromRepository(applicationAPIClient ApplicationAPIClient, resourceAPIClient ResourceAPIClient, transformedInput transformedCreateApplicationInput) (deployed bool, _ error) {
 	settingsForYaml := map[interface{}]interface{}{transformedInput.applicationName: transformedInput.config}
 	configYaml, err := goyaml.Marshal(settingsForYaml)
 	if err != nil {
-		return jujuerrors.Trace(err)
+		return false, jujuerrors.Trace(err)
 	}
 	c.Tracef("Calling DeployFromRepository")
 	deployInfo, localPendingResources, errs := applicationAPIClient.DeployFromRepository(apiapplication.DeployFromRepositoryArg{
@@ -395,10 +600,12 @@ func (c applicationsClient) deployFromRepository(applicationAPIClient Applicatio
 		Trust:            transformedInput.trust,
 		Resources:        transformedInput.resources,
 		Storage:          transformedInput.storage,
+		Devices:          transformedInput.devices,
+		AttachStorage:    transformedInput.attachStorage,
 	})
 
 	if len(errs) != 0 {
-		return errors.Join(errs...)
+		return false, errors.Join(errs...)
 	}
 
 	fileSystem := osFilesystem{}
@@ -406,9 +613,9 @@ func (c applicationsClient) deployFromRepository(applicationAPIClient Applicatio
 	uploadErr := uploadExistingPendingResources(deployInfo.Name, localPendingResources, fileSystem, resourceAPIClient)
 
 	if uploadErr != nil {
-		return uploadErr
+		return true, uploadErr
 	}
-	return nil
+	return true, nil
 }
 
 // TODO (hml) 23-Feb-2024
@@ -503,60 +710,186 @@ func (c applicationsClient) legacyDeploy(ctx context.Context, conn api.Connectio
 	}
 	appConfig["trust"] = fmt.Sprintf("%v", transformedInput.trust)
 
-	// If a plan element, with RequiresReplace in the schema, is
-	// changed. Terraform calls the Destroy method then the Create
-	// method for resource. This provider does not wait for Destroy
-	// to be complete before returning. Therefore, a race may occur
-	// of tearing down and reading the same charm.
-	//
-	// Do the actual work to create an application within Retry.
-	// Errors seen so far include:
-	// * cannot add application "replace": charm "ch:amd64/jammy/mysql-196" not found
-	// * cannot add application "replace": application already exists
-	// * cannot add application "replace": charm: not found or not alive
-	return retry.Call(retry.CallArgs{
-		Func: func() error {
-			c.Tracef("AddCharm ", map[string]interface{}{"resolvedURL": resolvedURL, "resolvedOrigin": resolvedOrigin})
-			resultOrigin, err := charmsAPIClient.AddCharm(resolvedURL, resolvedOrigin, false)
-			if err != nil {
-				err2 := typedError(err)
-				// If the charm is AlreadyExists, keep going, we
-				// may still be able to create the application. It's
-				// also possible we have multiple applications using
-				// the same charm.
-				if !jujuerrors.Is(err2, jujuerrors.AlreadyExists) {
-					return err2
-				}
+	return c.retryDeploy(ctx, transformedInput.applicationName, func() error {
+		c.Tracef("AddCharm ", map[string]interface{}{"resolvedURL": resolvedURL, "resolvedOrigin": resolvedOrigin})
+		resultOrigin, err := charmsAPIClient.AddCharm(resolvedURL, resolvedOrigin, false)
+		if err != nil {
+			err2 := typedError(err)
+			// If the charm is AlreadyExists, keep going, we
+			// may still be able to create the application. It's
+			// also possible we have multiple applications using
+			// the same charm.
+			if !jujuerrors.Is(err2, jujuerrors.AlreadyExists) {
+				return err2
 			}
+		}
 
-			charmID := apiapplication.CharmID{
-				URL:    resolvedURL.String(),
-				Origin: resultOrigin,
-			}
+		charmID := apiapplication.CharmID{
+			URL:    resolvedURL.String(),
+			Origin: resultOrigin,
+		}
 
-			resources, err := c.processResources(charmsAPIClient, conn, charmID, transformedInput.applicationName, transformedInput.resources)
-			if err != nil && !jujuerrors.Is(err, jujuerrors.AlreadyExists) {
-				return err
-			}
+		// Subordinates are deployed without units, they follow
+		// the units of their principals.
+		numUnits := transformedInput.units
+		charmInfo, err := charmsAPIClient.CharmInfo(resolvedURL.String())
+		if err != nil {
+			return err
+		}
+		if charmInfo.Meta != nil && charmInfo.Meta.Subordinate {
+			numUnits = 0
+		}
 
-			args := apiapplication.DeployArgs{
-				CharmID:          charmID,
-				ApplicationName:  transformedInput.applicationName,
-				NumUnits:         transformedInput.units,
-				CharmOrigin:      resultOrigin,
-				Config:           appConfig,
-				Cons:             transformedInput.constraints,
-				Resources:        resources,
-				Storage:          transformedInput.storage,
-				Placement:        transformedInput.placement,
-				EndpointBindings: transformedInput.endpointBindings,
-			}
-			c.Tracef("Calling Deploy", map[string]interface{}{"args": args})
-			if err = applicationAPIClient.Deploy(args); err != nil {
-				return typedError(err)
-			}
-			return nil
-		},
+		resources, err := c.processResources(charmsAPIClient, conn, charmID, transformedInput.applicationName, transformedInput.resources)
+		if err != nil && !jujuerrors.Is(err, jujuerrors.AlreadyExists) {
+			return err
+		}
+
+		args := apiapplication.DeployArgs{
+			CharmID:          charmID,
+			ApplicationName:  transformedInput.applicationName,
+			NumUnits:         numUnits,
+			CharmOrigin:      resultOrigin,
+			Config:           appConfig,
+			Cons:             transformedInput.constraints,
+			Resources:        resources,
+			Storage:          transformedInput.storage,
+			Devices:          transformedInput.devices,
+			AttachStorage:    transformedInput.attachStorage,
+			Placement:        transformedInput.placement,
+			EndpointBindings: transformedInput.endpointBindings,
+		}
+		c.Tracef("Calling Deploy", map[string]interface{}{"args": args})
+		if err = applicationAPIClient.Deploy(args); err != nil {
+			return typedError(err)
+		}
+		return nil
+	})
+}
+
+// deployLocalCharm uploads the local charm archive and deploys the
+// application from it. Local charms have no channel, their revision
+// is assigned by the controller on upload.
+func (c applicationsClient) deployLocalCharm(ctx context.Context, conn api.Connection, applicationAPIClient *apiapplication.Client, transformedInput transformedCreateApplicationInput) error {
+	ch, err := readLocalCharm(transformedInput.charmPath, transformedInput.charmName)
+	if err != nil {
+		return err
+	}
+
+	charmsAPIClient := apicharms.NewClient(conn)
+	modelconfigAPIClient := apimodelconfig.NewClient(conn)
+	localCharmAPIClient, err := apicharms.NewLocalCharmClient(conn)
+	if err != nil {
+		return err
+	}
+
+	charmBases, err := corecharm.ComputedBases(ch)
+	if err != nil {
+		return err
+	}
+	baseToUse, err := c.baseToUse(modelconfigAPIClient, transformedInput.charmBase, corebase.Base{}, charmBases)
+	if err != nil {
+		return err
+	}
+	series, err := corebase.GetSeriesFromBase(baseToUse)
+	if err != nil {
+		return err
+	}
+	platformCons, err := modelconfigAPIClient.GetModelConstraints()
+	if err != nil {
+		return err
+	}
+	platform := utils.MakePlatform(transformedInput.constraints, baseToUse, platformCons)
+
+	agentVersion, _ := conn.ServerVersion()
+	localURL := &charm.URL{
+		Schema:   charm.Local.String(),
+		Name:     ch.Meta().Name,
+		Series:   series,
+		Revision: ch.Revision(),
+	}
+	c.Tracef("AddLocalCharm", map[string]interface{}{"path": transformedInput.charmPath, "url": localURL})
+	charmURL, err := localCharmAPIClient.AddLocalCharm(localURL, ch, false, agentVersion)
+	if err != nil {
+		return typedError(err)
+	}
+	origin, err := utils.MakeOrigin(charm.Local, charmURL.Revision, charm.Channel{}, platform)
+	if err != nil {
+		return err
+	}
+	charmID := apiapplication.CharmID{
+		URL:    charmURL.String(),
+		Origin: origin,
+	}
+
+	appConfig := transformedInput.config
+	if appConfig == nil {
+		appConfig = make(map[string]string)
+	}
+	appConfig["trust"] = fmt.Sprintf("%v", transformedInput.trust)
+
+	numUnits := transformedInput.units
+	if ch.Meta().Subordinate {
+		numUnits = 0
+	}
+
+	return c.retryDeploy(ctx, transformedInput.applicationName, func() error {
+		resources, err := c.processResources(charmsAPIClient, conn, charmID, transformedInput.applicationName, transformedInput.resources)
+		if err != nil && !jujuerrors.Is(err, jujuerrors.AlreadyExists) {
+			return err
+		}
+
+		args := apiapplication.DeployArgs{
+			CharmID:          charmID,
+			ApplicationName:  transformedInput.applicationName,
+			NumUnits:         numUnits,
+			CharmOrigin:      origin,
+			Config:           appConfig,
+			Cons:             transformedInput.constraints,
+			Resources:        resources,
+			Storage:          transformedInput.storage,
+			Devices:          transformedInput.devices,
+			AttachStorage:    transformedInput.attachStorage,
+			Placement:        transformedInput.placement,
+			EndpointBindings: transformedInput.endpointBindings,
+		}
+		c.Tracef("Calling Deploy", map[string]interface{}{"args": args})
+		if err = applicationAPIClient.Deploy(args); err != nil {
+			return typedError(err)
+		}
+		return nil
+	})
+}
+
+// readLocalCharm reads the charm archive at path, which must contain
+// the charm called name.
+func readLocalCharm(path, name string) (*charm.CharmArchive, error) {
+	ch, err := charm.ReadCharmArchive(path)
+	if err != nil {
+		return nil, jujuerrors.Annotatef(err, "reading charm archive %q", path)
+	}
+	if ch.Meta().Name != name {
+		return nil, fmt.Errorf("charm archive %q contains charm %q, not %q", path, ch.Meta().Name, name)
+	}
+	return ch, nil
+}
+
+// retryDeploy calls deploy until the application is deployed.
+//
+// If a plan element, with RequiresReplace in the schema, is
+// changed. Terraform calls the Destroy method then the Create
+// method for resource. This provider does not wait for Destroy
+// to be complete before returning. Therefore, a race may occur
+// of tearing down and reading the same charm.
+//
+// Do the actual work to create an application within Retry.
+// Errors seen so far include:
+// * cannot add application "replace": charm "ch:amd64/jammy/mysql-196" not found
+// * cannot add application "replace": application already exists
+// * cannot add application "replace": charm: not found or not alive
+func (c applicationsClient) retryDeploy(ctx context.Context, appName string, deploy func() error) error {
+	return retry.Call(retry.CallArgs{
+		Func: deploy,
 		IsFatalError: func(err error) bool {
 			// If we hit AlreadyExists, it is from Deploy only under 2
 			// scenarios:
@@ -566,8 +899,8 @@ func (c applicationsClient) legacyDeploy(ctx context.Context, conn api.Connectio
 			return !errors.Is(err, jujuerrors.NotFound) && !errors.Is(err, jujuerrors.AlreadyExists)
 		},
 		NotifyFunc: func(err error, attempt int) {
-			c.Errorf(err, fmt.Sprintf("deploy application %q retry", transformedInput.applicationName))
-			message := fmt.Sprintf("waiting for application %q deploy, attempt %d", transformedInput.applicationName, attempt)
+			c.Errorf(err, fmt.Sprintf("deploy application %q retry", appName))
+			message := fmt.Sprintf("waiting for application %q deploy, attempt %d", appName, attempt)
 			c.Debugf(message)
 		},
 		BackoffFunc: retry.DoubleDelay,
@@ -770,14 +1103,14 @@ func (c applicationsClient) processResources(charmsAPIClient *apicharms.Client,
 // not found. Delay indicates how long to wait between attempts.
 func (c applicationsClient) ReadApplicationWithRetryOnNotFound(ctx context.Context, input *ReadApplicationInput) (*ReadApplicationResponse, error) {
 	var output *ReadApplicationResponse
-	modelType, err := c.ModelType(input.ModelName)
+	modelType, err := c.ModelType(ctx, input.ModelName)
 	if err != nil {
 		return nil, jujuerrors.Annotatef(err, "getting model type")
 	}
 	retryErr := retry.Call(retry.CallArgs{
 		Func: func() error {
 			var err error
-			output, err = c.ReadApplication(input)
+			output, err = c.ReadApplication(ctx, input)
 			if errors.As(err, &ApplicationNotFoundError) || errors.As(err, &StorageNotFoundError) {
 				return err
 			} else if err != nil {
@@ -902,8 +1235,8 @@ func getStorageLabel(storageTag string) string {
 	return strings.TrimSuffix(strings.TrimPrefix(storageTag, PrefixStorage), "-0")
 }
 
-func (c applicationsClient) ReadApplication(input *ReadApplicationInput) (*ReadApplicationResponse, error) {
-	conn, err := c.GetConnection(&input.ModelName)
+func (c applicationsClient) ReadApplication(ctx context.Context, input *ReadApplicationInput) (*ReadApplicationResponse, error) {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
 	if err != nil {
 		return nil, err
 	}
@@ -935,7 +1268,7 @@ func (c applicationsClient) ReadApplication(input *ReadApplicationInput) (*ReadA
 	// the data on storage to the specific application too. Storage is not
 	// provided by application, rather storage data buries a unit name deep
 	// in the structure.
-	status, err := clientAPIClient.Status(&apiclient.StatusArgs{
+	status, err := readStatus(ctx, c.SharedClient, conn, clientAPIClient, &apiclient.StatusArgs{
 		Patterns:       []string{input.AppName},
 		IncludeStorage: true,
 	})
@@ -955,7 +1288,7 @@ func (c applicationsClient) ReadApplication(input *ReadApplicationInput) (*ReadA
 		return nil, fmt.Errorf("no status returned for application: %s", input.AppName)
 	}
 
-	storages := c.transformToStorageConstraints(status.Storage, status.Filesystems, status.Volumes)
+	storages := c.transformToStorageConstraints(applicationStorage(status, input.AppName), status.Filesystems, status.Volumes)
 
 	allocatedMachines := set.NewStrings()
 	for _, v := range appStatus.Units {
@@ -971,7 +1304,7 @@ func (c applicationsClient) ReadApplication(input *ReadApplicationInput) (*ReadA
 
 	unitCount := len(appStatus.Units)
 	// if we have a CAAS we use scale instead of units length
-	modelType, err := c.ModelType(input.ModelName)
+	modelType, err := c.ModelType(ctx, input.ModelName)
 	if err != nil {
 		return nil, err
 	}
@@ -979,6 +1312,12 @@ func (c applicationsClient) ReadApplication(input *ReadApplicationInput) (*ReadA
 		unitCount = appStatus.Scale
 	}
 
+	leaderUnit, unitAddresses, unitMachines := unitDetails(appStatus)
+	publicAddress := unitAddresses[leaderUnit]
+	if modelType == model.CAAS {
+		publicAddress = appStatus.PublicAddress
+	}
+
 	// NOTE: we are assuming that this charm comes from CharmHub
 	charmURL, err := charm.ParseURL(appStatus.Charm)
 	if err != nil {
@@ -990,35 +1329,7 @@ func (c applicationsClient) ReadApplication(input *ReadApplicationInput) (*ReadA
 		return nil, fmt.Errorf("failed to get app configuration %v", err)
 	}
 
-	conf := make(map[string]ConfigEntry, 0)
-	if returnedConf.ApplicationConfig != nil {
-		for k, v := range returnedConf.ApplicationConfig {
-			// skip the trust value. We have an independent field for that
-			if k == "trust" {
-				continue
-			}
-			// The API returns the configuration entries as interfaces
-			aux := v.(map[string]interface{})
-			// set if we find the value key and this is not a default
-			// value.
-			if value, found := aux["value"]; found {
-				conf[k] = ConfigEntry{
-					Value:     value,
-					IsDefault: aux["source"] == "default",
-				}
-			}
-		}
-		// repeat the same steps for charm config values
-		for k, v := range returnedConf.CharmConfig {
-			aux := v.(map[string]interface{})
-			if value, found := aux["value"]; found {
-				conf[k] = ConfigEntry{
-					Value:     value,
-					IsDefault: aux["source"] == "default",
-				}
-			}
-		}
-	}
+	conf := configEntries(returnedConf)
 
 	// trust field which has to be included into the configuration
 	trustValue := false
@@ -1115,6 +1426,7 @@ func (c applicationsClient) ReadApplication(input *ReadApplicationInput) (*ReadA
 		Revision:         charmURL.Revision,
 		Base:             fmt.Sprintf("%s@%s", appInfo.Base.Name, baseChannel.Track),
 		Series:           seriesString,
+		Architecture:     charmURL.Architecture,
 		Units:            unitCount,
 		Trust:            trustValue,
 		Expose:           exposed,
@@ -1125,11 +1437,90 @@ func (c applicationsClient) ReadApplication(input *ReadApplicationInput) (*ReadA
 		EndpointBindings: endpointBindings,
 		Storage:          storages,
 		Resources:        usedResources,
+		PublicAddress:    publicAddress,
+		LeaderUnit:       leaderUnit,
+		UnitAddresses:    unitAddresses,
+		UnitMachines:     unitMachines,
+		UnitNames:        sortedUnitNames(appStatus),
+		Status:           appStatus.Status.Status,
+		StatusMessage:    appStatus.Status.Info,
+		CharmVersion:     appStatus.CharmVersion,
+		WorkloadVersion:  appStatus.WorkloadVersion,
 	}
 
 	return response, nil
 }
 
+// unitDetails returns the name of the leader unit of the application,
+// and the public addresses and the machine IDs of its units, by unit
+// name. Units without an address or a machine yet are left out.
+func unitDetails(appStatus params.ApplicationStatus) (string, map[string]string, map[string]string) {
+	var leader string
+	addresses := make(map[string]string)
+	machines := make(map[string]string)
+	for name, unit := range appStatus.Units {
+		if unit.Leader {
+			leader = name
+		}
+		if unit.PublicAddress != "" {
+			addresses[name] = unit.PublicAddress
+		}
+		if unit.Machine != "" {
+			machines[name] = unit.Machine
+		}
+	}
+	return leader, addresses, machines
+}
+
+// sortedUnitNames returns the names of the units of the application,
+// ordered by unit number rather than lexically, e.g. app/2 before
+// app/10.
+func sortedUnitNames(appStatus params.ApplicationStatus) []string {
+	unitNames := make([]string, 0, len(appStatus.Units))
+	for name := range appStatus.Units {
+		unitNames = append(unitNames, name)
+	}
+	slices.SortFunc(unitNames, func(a, b string) int {
+		return unitNumber(a) - unitNumber(b)
+	})
+	return unitNames
+}
+
+// configEntries returns the config entries of the application and of
+// its charm, trust excepted.
+func configEntries(returnedConf *params.ApplicationGetResults) map[string]ConfigEntry {
+	conf := make(map[string]ConfigEntry, 0)
+	if returnedConf.ApplicationConfig != nil {
+		for k, v := range returnedConf.ApplicationConfig {
+			// skip the trust value. We have an independent field for that
+			if k == "trust" {
+				continue
+			}
+			// The API returns the configuration entries as interfaces
+			aux := v.(map[string]interface{})
+			// set if we find the value key and this is not a default
+			// value.
+			if value, found := aux["value"]; found {
+				conf[k] = ConfigEntry{
+					Value:     value,
+					IsDefault: aux["source"] == "default",
+				}
+			}
+		}
+		// repeat the same steps for charm config values
+		for k, v := range returnedConf.CharmConfig {
+			aux := v.(map[string]interface{})
+			if value, found := aux["value"]; found {
+				conf[k] = ConfigEntry{
+					Value:     value,
+					IsDefault: aux["source"] == "default",
+				}
+			}
+		}
+	}
+	return conf
+}
+
 // removeDefaultCidrs is an auxiliar function to remove
 // the "0.0.0.0/0 and ::/0" strings from an array of
 // cidrs
@@ -1143,8 +1534,8 @@ func removeDefaultCidrs(cidrs []string) []string {
 	return toReturn
 }
 
-func (c applicationsClient) UpdateApplication(input *UpdateApplicationInput) error {
-	conn, err := c.GetConnection(&input.ModelName)
+func (c applicationsClient) UpdateApplication(ctx context.Context, input *UpdateApplicationInput) error {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
 	if err != nil {
 		return err
 	}
@@ -1194,8 +1585,13 @@ func (c applicationsClient) UpdateApplication(input *UpdateApplicationInput) err
 	// before the operations with config. Because the config params
 	// can be changed from one revision to another. So "Revision-Config"
 	// ordering will help to prevent issues with the configuration parsing.
-	if input.Revision != nil || input.Channel != "" || len(input.Resources) != 0 {
-		setCharmConfig, err := c.computeSetCharmConfig(input, applicationAPIClient, charmsAPIClient, resourcesAPIClient)
+	if input.CharmPath != "" || input.CharmName != "" || input.Revision != nil || input.Channel != "" || len(input.Resources) != 0 {
+		var setCharmConfig *apiapplication.SetCharmConfig
+		if input.CharmPath != "" {
+			setCharmConfig, err = c.computeSetLocalCharmConfig(conn, input, applicationAPIClient, charmsAPIClient, resourcesAPIClient)
+		} else {
+			setCharmConfig, err = c.computeSetCharmConfig(input, applicationAPIClient, charmsAPIClient, resourcesAPIClient)
+		}
 		if err != nil {
 			return err
 		}
@@ -1208,6 +1604,15 @@ func (c applicationsClient) UpdateApplication(input *UpdateApplicationInput) err
 		}
 	}
 
+	// Change the base after the charm, which may be refreshed to a
+	// revision supporting it.
+	if input.Base != "" || input.Series != "" {
+		if err := c.upgradeApplicationBase(ctx, conn, input, appStatus, status.Machines); err != nil {
+			c.Errorf(err, "upgrading application base")
+			return err
+		}
+	}
+
 	if auxConfig != nil {
 		err := applicationAPIClient.SetConfig("master", input.AppName, "", auxConfig)
 		if err != nil {
@@ -1258,9 +1663,20 @@ func (c applicationsClient) UpdateApplication(input *UpdateApplicationInput) err
 		}
 	}
 
+	if len(input.StorageAdditions) > 0 {
+		var unitNames []string
+		for unitName := range appStatus.Units {
+			unitNames = append(unitNames, unitName)
+		}
+		if err := c.addStorage(conn, input.AppName, unitStorageAdditions(unitNames, input.StorageAdditions)); err != nil {
+			c.Errorf(err, "adding storage")
+			return err
+		}
+	}
+
 	if input.Units != nil {
 		// TODO: Refactor this to a separate function
-		modelType, err := c.ModelType(input.ModelName)
+		modelType, err := c.ModelType(ctx, input.ModelName)
 		if err != nil {
 			return err
 		}
@@ -1268,29 +1684,51 @@ func (c applicationsClient) UpdateApplication(input *UpdateApplicationInput) err
 			_, err := applicationAPIClient.ScaleApplication(apiapplication.ScaleApplicationParams{
 				ApplicationName: input.AppName,
 				Scale:           *input.Units,
-				Force:           false,
+				Force:           input.ScaleStrategy == ScaleStrategyImmediate,
 			})
 			if err != nil {
 				return err
 			}
+			if input.ScaleStrategy == ScaleStrategyGraceful && *input.Units < len(appStatus.Units) {
+				err := waitForModel(ctx, c.SharedClient, conn, UnitsAtMost(input.AppName, *input.Units), scaleDownTimeout)
+				if err != nil {
+					return jujuerrors.Annotate(err, "scaling down")
+				}
+			}
 		} else {
 			unitDiff := *input.Units - len(appStatus.Units)
 
 			if unitDiff > 0 {
-				_, err := applicationAPIClient.AddUnits(apiapplication.AddUnitsParams{
+				placements, err := parsePlacements(input.UnitPlacements)
+				if err != nil {
+					return err
+				}
+				addedUnits, err := applicationAPIClient.AddUnits(apiapplication.AddUnitsParams{
 					ApplicationName: input.AppName,
 					NumUnits:        unitDiff,
+					Placement:       placements,
 				})
 				if err != nil {
 					return err
 				}
+				if len(input.StorageDirectives) > 0 {
+					if err := c.addMissingStorage(conn, input.AppName, addedUnits, input.StorageDirectives); err != nil {
+						c.Errorf(err, "adding storage to new units")
+						return err
+					}
+				}
 			}
 
 			if unitDiff < 0 {
+				// Remove the last units first, so that the placements
+				// of the remaining units are kept.
 				var unitNames []string
 				for unitName := range appStatus.Units {
 					unitNames = append(unitNames, unitName)
 				}
+				sort.Slice(unitNames, func(i, j int) bool {
+					return unitNumber(unitNames[i]) > unitNumber(unitNames[j])
+				})
 
 				unitAbs := int(math.Abs(float64(unitDiff)))
 				var unitsToDestroy []string
@@ -1311,49 +1749,165 @@ func (c applicationsClient) UpdateApplication(input *UpdateApplicationInput) err
 	return nil
 }
 
-func (c applicationsClient) DestroyApplication(input *DestroyApplicationInput) error {
-	conn, err := c.GetConnection(&input.ModelName)
-	if err != nil {
-		return err
-	}
-	defer func() { _ = conn.Close() }()
-
-	applicationAPIClient := apiapplication.NewClient(conn)
-
-	var destroyParams = apiapplication.DestroyApplicationsParams{
-		Applications: []string{
-			input.ApplicationName,
-		},
-		DestroyStorage: true,
+// unitNumber returns the number of the unit, -1 if its name is not
+// valid.
+func unitNumber(unitName string) int {
+	if !names.IsValidUnit(unitName) {
+		return -1
 	}
+	return names.NewUnitTag(unitName).Number()
+}
 
-	_, err = applicationAPIClient.DestroyApplications(destroyParams)
-
-	if err != nil {
-		return err
+// unitStorageAdditions returns the storage instances to add to each of
+// the units, by storage label.
+func unitStorageAdditions(unitNames []string, additions map[string]jujustorage.Constraints) []params.StorageAddParams {
+	var storages []params.StorageAddParams
+	for _, unitName := range unitNames {
+		for label, cons := range additions {
+			count := cons.Count
+			storageCons := params.StorageConstraints{
+				Pool:  cons.Pool,
+				Count: &count,
+			}
+			if cons.Size > 0 {
+				size := cons.Size
+				storageCons.Size = &size
+			}
+			storages = append(storages, params.StorageAddParams{
+				UnitTag:     names.NewUnitTag(unitName).String(),
+				StorageName: label,
+				Constraints: storageCons,
+			})
+		}
 	}
-
-	return nil
+	return storages
 }
 
-// computeSetCharmConfig populates the corresponding configuration object
-// to indicate juju what charm to be deployed.
-func (c applicationsClient) computeSetCharmConfig(
-	input *UpdateApplicationInput,
-	applicationAPIClient ApplicationAPIClient,
-	charmsAPIClient *apicharms.Client,
-	resourcesAPIClient ResourceAPIClient,
-) (*apiapplication.SetCharmConfig, error) {
-	oldURL, oldOrigin, err := applicationAPIClient.GetCharmURLOrigin("", input.AppName)
+// addMissingStorage adds to the units the storage instances missing
+// from the count of the storage directives. Units are created with the
+// storage directives of the application when it was deployed, which
+// are lower than the ones of the resource once their count has been
+// increased.
+func (c applicationsClient) addMissingStorage(conn api.Connection, appName string, unitNames []string, directives map[string]jujustorage.Constraints) error {
+	details, err := apistorage.NewClient(conn).ListStorageDetails()
 	if err != nil {
-		return nil, err
+		return typedError(err)
 	}
+	// The number of storage instances of each unit, by label.
+	counts := make(map[string]map[string]uint64)
+	for _, d := range details {
+		unitTag, err := names.ParseUnitTag(d.OwnerTag)
+		if err != nil {
+			continue
+		}
+		storageTag, err := names.ParseStorageTag(d.StorageTag)
+		if err != nil {
+			continue
+		}
+		label, err := names.StorageName(storageTag.Id())
+		if err != nil {
+			continue
+		}
+		if counts[unitTag.Id()] == nil {
+			counts[unitTag.Id()] = make(map[string]uint64)
+		}
+		counts[unitTag.Id()][label]++
+	}
+
+	var storages []params.StorageAddParams
+	for _, unitName := range unitNames {
+		additions := make(map[string]jujustorage.Constraints)
+		for label, cons := range directives {
+			if count := counts[unitName][label]; cons.Count > count {
+				cons.Count -= count
+				additions[label] = cons
+			}
+		}
+		storages = append(storages, unitStorageAdditions([]string{unitName}, additions)...)
+	}
+	if len(storages) == 0 {
+		return nil
+	}
+	return c.addStorage(conn, appName, storages)
+}
+
+// addStorage adds the storage instances to the units, like juju
+// add-storage.
+func (c applicationsClient) addStorage(conn api.Connection, appName string, storages []params.StorageAddParams) error {
+	c.Tracef("Adding storage", map[string]interface{}{"application": appName, "storage": storages})
+	results, err := apistorage.NewClient(conn).AddToUnit(storages)
+	if err != nil {
+		return typedError(err)
+	}
+	for _, result := range results {
+		if result.Error != nil {
+			return typedError(result.Error)
+		}
+	}
+	return nil
+}
+
+func (c applicationsClient) DestroyApplication(ctx context.Context, input *DestroyApplicationInput) error {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	applicationAPIClient := apiapplication.NewClient(conn)
+
+	var destroyParams = apiapplication.DestroyApplicationsParams{
+		Applications: []string{
+			input.ApplicationName,
+		},
+		DestroyStorage: !input.KeepStorage,
+	}
+	force, maxWait := input.forceArgs()
+	destroyParams.Force = *force
+	destroyParams.MaxWait = maxWait
 
-	// You can only refresh on the revision OR the channel at once.
+	results, err := applicationAPIClient.DestroyApplications(destroyParams)
+	if err != nil {
+		return input.destroyError(err)
+	}
+	if len(results) == 1 && results[0].Error != nil {
+		return input.destroyError(results[0].Error)
+	}
+
+	return nil
+}
+
+// computeSetCharmConfig populates the corresponding configuration object
+// to indicate juju what charm to be deployed.
+func (c applicationsClient) computeSetCharmConfig(
+	input *UpdateApplicationInput,
+	applicationAPIClient ApplicationAPIClient,
+	charmsAPIClient *apicharms.Client,
+	resourcesAPIClient ResourceAPIClient,
+) (*apiapplication.SetCharmConfig, error) {
+	oldURL, oldOrigin, err := applicationAPIClient.GetCharmURLOrigin("", input.AppName)
+	if err != nil {
+		return nil, err
+	}
+
+	// The revision and the channel can be refreshed at once, like
+	// `juju refresh --revision --channel`: the revision is deployed
+	// and the channel is tracked by the next refreshes.
 	newURL := oldURL
 	newOrigin := oldOrigin
+	if input.CharmName != "" {
+		newURL, err = resolveCharmURL(input.CharmName)
+		if err != nil {
+			return nil, err
+		}
+		// The ID, hash and revision of the origin are those of the
+		// current charm, the new one is resolved by name.
+		newOrigin.ID = ""
+		newOrigin.Hash = ""
+		newOrigin.Revision = nil
+	}
 	if input.Revision != nil {
-		newURL = oldURL.WithRevision(*input.Revision)
+		newURL = newURL.WithRevision(*input.Revision)
 		newOrigin.Revision = input.Revision
 		// If the charm has an ID and Hash, it's been deployed before.
 		// Remove to trick juju into finding the new revision the user
@@ -1366,7 +1920,8 @@ func (c applicationsClient) computeSetCharmConfig(
 		// the ID. This needs to be fixed in Juju.
 		newOrigin.ID = ""
 		newOrigin.Hash = ""
-	} else if input.Channel != "" {
+	}
+	if input.Channel != "" {
 		parsedChannel, err := charm.ParseChannel(input.Channel)
 		if err != nil {
 			return nil, err
@@ -1398,16 +1953,23 @@ func (c applicationsClient) computeSetCharmConfig(
 
 	// Ensure the new revision or channel is contained
 	// in the origin to be saved by juju when AddCharm
-	// is called.
-	if input.Revision != nil {
-		oldOrigin.Revision = input.Revision
-	} else if input.Channel != "" {
-		oldOrigin.Track = newOrigin.Track
-		oldOrigin.Risk = newOrigin.Risk
-		oldOrigin.Branch = newOrigin.Branch
+	// is called. A switched charm is saved with the
+	// origin resolved.
+	addOrigin := oldOrigin
+	if input.CharmName != "" {
+		addOrigin = resolvedOrigin
+	} else {
+		if input.Revision != nil {
+			addOrigin.Revision = input.Revision
+		}
+		if input.Channel != "" {
+			addOrigin.Track = newOrigin.Track
+			addOrigin.Risk = newOrigin.Risk
+			addOrigin.Branch = newOrigin.Branch
+		}
 	}
 
-	resultOrigin, err := charmsAPIClient.AddCharm(resolvedURL, oldOrigin, false)
+	resultOrigin, err := charmsAPIClient.AddCharm(resolvedURL, addOrigin, false)
 	if err != nil {
 		return nil, err
 	}
@@ -1431,6 +1993,76 @@ func (c applicationsClient) computeSetCharmConfig(
 	return &toReturn, nil
 }
 
+// computeSetLocalCharmConfig uploads the local charm archive to refresh
+// the application with, keeping its architecture and operating system.
+func (c applicationsClient) computeSetLocalCharmConfig(
+	conn api.Connection,
+	input *UpdateApplicationInput,
+	applicationAPIClient ApplicationAPIClient,
+	charmsAPIClient *apicharms.Client,
+	resourcesAPIClient ResourceAPIClient,
+) (*apiapplication.SetCharmConfig, error) {
+	oldURL, oldOrigin, err := applicationAPIClient.GetCharmURLOrigin("", input.AppName)
+	if err != nil {
+		return nil, err
+	}
+	ch, err := readLocalCharm(input.CharmPath, oldURL.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	charmBases, err := corecharm.ComputedBases(ch)
+	if err != nil {
+		return nil, err
+	}
+	if !basesContain(oldOrigin.Base, charmBases) {
+		msg := fmt.Sprintf("the new charm does not support the current operating system %q", oldOrigin.Base.String())
+		return nil, errors.New(msg)
+	}
+	series, err := corebase.GetSeriesFromBase(oldOrigin.Base)
+	if err != nil {
+		return nil, err
+	}
+
+	localCharmAPIClient, err := apicharms.NewLocalCharmClient(conn)
+	if err != nil {
+		return nil, err
+	}
+	agentVersion, _ := conn.ServerVersion()
+	charmURL, err := localCharmAPIClient.AddLocalCharm(&charm.URL{
+		Schema:   charm.Local.String(),
+		Name:     ch.Meta().Name,
+		Series:   series,
+		Revision: ch.Revision(),
+	}, ch, false, agentVersion)
+	if err != nil {
+		return nil, typedError(err)
+	}
+	origin, err := utils.MakeOrigin(charm.Local, charmURL.Revision, charm.Channel{}, corecharm.Platform{
+		Architecture: oldOrigin.Architecture,
+		OS:           oldOrigin.Base.OS,
+		Channel:      oldOrigin.Base.Channel.Track,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	apiCharmID := apiapplication.CharmID{
+		URL:    charmURL.String(),
+		Origin: origin,
+	}
+	resourceIDs, err := c.updateResources(input.AppName, input.Resources, charmsAPIClient, apiCharmID, resourcesAPIClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiapplication.SetCharmConfig{
+		ApplicationName: input.AppName,
+		CharmID:         apiCharmID,
+		ResourceIDs:     resourceIDs,
+	}, nil
+}
+
 func resolveCharm(charmsAPIClient *apicharms.Client, curl *charm.URL, origin apicommoncharm.Origin) (*charm.URL, apicommoncharm.Origin, []corebase.Base, error) {
 	// Charm or bundle has been supplied as a URL, so we resolve and
 	// deploy using the store but pass in the origin command line
@@ -1615,3 +2247,516 @@ func getModelDefaultSpace(modelconfigAPIClient ModelConfigAPIClient) (string, er
 	}
 	return defaultSpace, nil
 }
+
+// ExposeManagedAnnotation is set on applications whose exposure is
+// managed by the juju_application_expose resource. The inline expose
+// block of juju_application must not be used for these applications.
+const ExposeManagedAnnotation = "terraform-provider-juju/expose-managed"
+
+type ExposeApplicationInput struct {
+	ModelName string
+	AppName   string
+	Endpoints []string
+	Spaces    []string
+	CIDRs     []string
+}
+
+type ReadApplicationExposeInput struct {
+	ModelName string
+	AppName   string
+}
+
+type ReadApplicationExposeResponse struct {
+	Exposed bool
+	// AllEndpoints is true when the wildcard endpoint "" is exposed,
+	// exposing all the endpoints of the application.
+	AllEndpoints bool
+	Endpoints    []string
+	Spaces       []string
+	CIDRs        []string
+	// Managed is true when the exposure is managed by the standalone
+	// expose resource.
+	Managed bool
+}
+
+type UnexposeApplicationInput struct {
+	ModelName string
+	AppName   string
+}
+
+// ExposeApplication exposes the application and marks its exposure as
+// managed by the standalone expose resource. Previously exposed endpoints
+// not listed in the input are unexposed.
+func (c applicationsClient) ExposeApplication(ctx context.Context, input ExposeApplicationInput) error {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	applicationAPIClient := c.getApplicationAPIClient(conn)
+
+	current, err := c.readApplicationExpose(ctx, conn, input.AppName)
+	if err != nil {
+		return err
+	}
+	unexpose := endpointsToUnexpose(current, input.Endpoints)
+	if len(unexpose) != 0 {
+		c.Tracef("Unexposing endpoints", map[string]interface{}{"endpoints": unexpose})
+		if err := applicationAPIClient.Unexpose(input.AppName, unexpose); err != nil {
+			return typedError(err)
+		}
+	}
+
+	expose := map[string]interface{}{
+		"endpoints": strings.Join(input.Endpoints, ","),
+		"spaces":    strings.Join(input.Spaces, ","),
+		"cidrs":     strings.Join(input.CIDRs, ","),
+	}
+	if err := c.processExpose(applicationAPIClient, input.AppName, expose); err != nil {
+		return typedError(err)
+	}
+
+	return setEntityAnnotations(conn, names.NewApplicationTag(input.AppName).String(),
+		map[string]string{ExposeManagedAnnotation: "true"})
+}
+
+// endpointsToUnexpose returns the endpoints currently exposed but not
+// wanted. The wildcard endpoint "", exposing all the endpoints, is
+// unexposed when specific endpoints are wanted instead.
+func endpointsToUnexpose(current *ReadApplicationExposeResponse, wanted []string) []string {
+	var unexpose []string
+	if current.AllEndpoints && len(wanted) != 0 {
+		unexpose = append(unexpose, "")
+	}
+	keep := set.NewStrings(wanted...)
+	for _, endpoint := range current.Endpoints {
+		if !keep.Contains(endpoint) {
+			unexpose = append(unexpose, endpoint)
+		}
+	}
+	return unexpose
+}
+
+// ReadApplicationExpose returns the expose settings of the application.
+func (c applicationsClient) ReadApplicationExpose(ctx context.Context, input ReadApplicationExposeInput) (*ReadApplicationExposeResponse, error) {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	return c.readApplicationExpose(ctx, conn, input.AppName)
+}
+
+// UnexposeApplication unexposes the application and removes the managed
+// marker.
+func (c applicationsClient) UnexposeApplication(ctx context.Context, input UnexposeApplicationInput) error {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	// Without endpoints, all of them are unexposed, including the
+	// wildcard endpoint "", not reported as an endpoint exposed.
+	if err := c.getApplicationAPIClient(conn).Unexpose(input.AppName, nil); err != nil {
+		return typedError(err)
+	}
+	return setEntityAnnotations(conn, names.NewApplicationTag(input.AppName).String(),
+		map[string]string{ExposeManagedAnnotation: ""})
+}
+
+// IsExposeManaged returns true when the exposure of the application is
+// managed by the standalone expose resource.
+func (c applicationsClient) IsExposeManaged(ctx context.Context, modelName, appName string) (bool, error) {
+	conn, err := c.GetConnection(ctx, &modelName)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	annotations, err := getEntityAnnotations(conn, names.NewApplicationTag(appName).String())
+	if err != nil {
+		return false, err
+	}
+	return annotations[ExposeManagedAnnotation] == "true", nil
+}
+
+func (c applicationsClient) readApplicationExpose(ctx context.Context, conn api.Connection, appName string) (*ReadApplicationExposeResponse, error) {
+	status, err := readStatus(ctx, c.SharedClient, conn, c.getClientAPIClient(conn), &apiclient.StatusArgs{
+		Patterns: []string{appName},
+	})
+	if err != nil {
+		return nil, err
+	}
+	appStatus, exists := status.Applications[appName]
+	if !exists {
+		return nil, &applicationNotFoundError{appName}
+	}
+
+	annotations, err := getEntityAnnotations(conn, names.NewApplicationTag(appName).String())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReadApplicationExposeResponse{
+		Exposed:   appStatus.Exposed,
+		Endpoints: []string{},
+		Spaces:    []string{},
+		CIDRs:     []string{},
+		Managed:   annotations[ExposeManagedAnnotation] == "true",
+	}
+	if !appStatus.Exposed {
+		return response, nil
+	}
+	spaces := set.NewStrings()
+	cidrs := set.NewStrings()
+	for endpoint, value := range appStatus.ExposedEndpoints {
+		if endpoint == "" {
+			response.AllEndpoints = true
+		} else {
+			response.Endpoints = append(response.Endpoints, endpoint)
+		}
+		spaces = spaces.Union(set.NewStrings(value.ExposeToSpaces...))
+		cidrs = cidrs.Union(set.NewStrings(removeDefaultCidrs(value.ExposeToCIDRs)...))
+	}
+	sort.Strings(response.Endpoints)
+	response.Spaces = spaces.SortedValues()
+	response.CIDRs = cidrs.SortedValues()
+	return response, nil
+}
+
+var UnitNotFoundError = &unitNotFoundError{}
+
+type unitNotFoundError struct {
+	unitName string
+}
+
+func (ue *unitNotFoundError) Error() string {
+	return fmt.Sprintf("unit %q not found", ue.unitName)
+}
+
+// Is reports the error to be a NotFound error.
+func (ue *unitNotFoundError) Is(target error) bool {
+	return target == jujuerrors.NotFound
+}
+
+type ReadUnitInput struct {
+	ModelName string
+	UnitName  string
+}
+
+type ReadUnitResponse struct {
+	Machine         string
+	PublicAddress   string
+	PrivateAddress  string
+	OpenedPorts     []string
+	WorkloadStatus  string
+	WorkloadMessage string
+	AgentStatus     string
+	Leader          bool
+	// Principal is the name of the principal unit for subordinate units.
+	Principal string
+}
+
+// ReadUnit returns the details of a single unit from the model status.
+func (c applicationsClient) ReadUnit(ctx context.Context, input ReadUnitInput) (*ReadUnitResponse, error) {
+	if !names.IsValidUnit(input.UnitName) {
+		return nil, jujuerrors.NotValidf("unit name %q", input.UnitName)
+	}
+	appName, err := names.UnitApplication(input.UnitName)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := c.GetConnection(ctx, &input.ModelName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	status, err := readStatus(ctx, c.SharedClient, conn, c.getClientAPIClient(conn), &apiclient.StatusArgs{
+		Patterns: []string{input.UnitName},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return unitFromStatus(status, appName, input.UnitName)
+}
+
+// unitFromStatus finds the unit in the status, looking into the
+// subordinates of principal units if needed.
+func unitFromStatus(status *params.FullStatus, appName, unitName string) (*ReadUnitResponse, error) {
+	var (
+		unit      params.UnitStatus
+		principal string
+		found     bool
+	)
+	if appStatus, ok := status.Applications[appName]; ok {
+		unit, found = appStatus.Units[unitName]
+	}
+	if !found {
+		for _, appStatus := range status.Applications {
+			for principalName, principalUnit := range appStatus.Units {
+				if sub, ok := principalUnit.Subordinates[unitName]; ok {
+					unit, principal, found = sub, principalName, true
+					// Subordinates run on the machine of their principal.
+					unit.Machine = principalUnit.Machine
+				}
+			}
+		}
+	}
+	if !found {
+		return nil, &unitNotFoundError{unitName: unitName}
+	}
+
+	privateAddress := unit.Address
+	if machine, ok := status.Machines[unit.Machine]; ok && privateAddress == "" && len(machine.IPAddresses) > 0 {
+		privateAddress = machine.IPAddresses[0]
+	}
+	openedPorts := unit.OpenedPorts
+	if openedPorts == nil {
+		openedPorts = []string{}
+	}
+	sort.Strings(openedPorts)
+
+	return &ReadUnitResponse{
+		Machine:         unit.Machine,
+		PublicAddress:   unit.PublicAddress,
+		PrivateAddress:  privateAddress,
+		OpenedPorts:     openedPorts,
+		WorkloadStatus:  unit.WorkloadStatus.Status,
+		WorkloadMessage: unit.WorkloadStatus.Info,
+		AgentStatus:     unit.AgentStatus.Status,
+		Leader:          unit.Leader,
+		Principal:       principal,
+	}, nil
+}
+
+type ListApplicationsInput struct {
+	ModelName string
+}
+
+type ListApplicationsResponse struct {
+	// Applications are sorted by name.
+	Applications []ApplicationSummary
+}
+
+// ApplicationSummary holds the details of an application listed.
+type ApplicationSummary struct {
+	Name        string
+	Charm       string
+	Channel     string
+	Revision    int
+	Units       int
+	Exposed     bool
+	Subordinate bool
+	Status      string
+}
+
+// ListApplications lists the applications of the model, from its
+// status. Remote applications are not listed.
+func (c applicationsClient) ListApplications(ctx context.Context, input ListApplicationsInput) (*ListApplicationsResponse, error) {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	modelType, err := c.ModelType(ctx, input.ModelName)
+	if err != nil {
+		return nil, err
+	}
+	status, err := readStatus(ctx, c.SharedClient, conn, c.getClientAPIClient(conn), nil)
+	if err != nil {
+		return nil, err
+	}
+	return applicationsFromStatus(status, modelType)
+}
+
+// applicationsFromStatus returns the summaries of the applications of
+// the status. The units of the applications of CAAS models are their
+// scale.
+func applicationsFromStatus(status *params.FullStatus, modelType model.ModelType) (*ListApplicationsResponse, error) {
+	response := &ListApplicationsResponse{Applications: make([]ApplicationSummary, 0, len(status.Applications))}
+	for name, appStatus := range status.Applications {
+		if appStatus.Err != nil {
+			return nil, appStatus.Err
+		}
+		charmURL, err := charm.ParseURL(appStatus.Charm)
+		if err != nil {
+			return nil, jujuerrors.Annotatef(err, "parsing charm of application %q", name)
+		}
+		units := len(appStatus.Units)
+		if modelType == model.CAAS {
+			units = appStatus.Scale
+		}
+		response.Applications = append(response.Applications, ApplicationSummary{
+			Name:        name,
+			Charm:       charmURL.Name,
+			Channel:     appStatus.CharmChannel,
+			Revision:    charmURL.Revision,
+			Units:       units,
+			Exposed:     appStatus.Exposed,
+			Subordinate: len(appStatus.SubordinateTo) > 0,
+			Status:      appStatus.Status.Status,
+		})
+	}
+	sort.Slice(response.Applications, func(i, j int) bool {
+		return response.Applications[i].Name < response.Applications[j].Name
+	})
+	return response, nil
+}
+
+type SetApplicationResourceInput struct {
+	ModelName    string
+	AppName      string
+	ResourceName string
+	// Value is either a resource revision, a path to a local file or
+	// an OCI image, as accepted by the resources of the application.
+	// A revision of -1 resets the resource to the charm default.
+	Value string
+}
+
+type ReadApplicationResourceInput struct {
+	ModelName    string
+	AppName      string
+	ResourceName string
+}
+
+type ReadApplicationResourceResponse struct {
+	Revision int
+	Origin   string
+	Type     string
+}
+
+// SetApplicationResource attaches a resource to an application, as
+// `juju refresh --resource` does, without changing the charm.
+func (c applicationsClient) SetApplicationResource(ctx context.Context, input SetApplicationResourceInput) error {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	applicationAPIClient := c.getApplicationAPIClient(conn)
+	charmsAPIClient := apicharms.NewClient(conn)
+	resourcesAPIClient, err := c.getResourceAPIClient(conn)
+	if err != nil {
+		return err
+	}
+
+	setCharmConfig, err := c.computeSetCharmConfig(&UpdateApplicationInput{
+		ModelName: input.ModelName,
+		AppName:   input.AppName,
+		Resources: map[string]string{input.ResourceName: input.Value},
+	}, applicationAPIClient, charmsAPIClient, resourcesAPIClient)
+	if err != nil {
+		return err
+	}
+	if len(setCharmConfig.ResourceIDs) == 0 {
+		c.Tracef("resource already up to date", map[string]interface{}{"application": input.AppName, "resource": input.ResourceName})
+		return nil
+	}
+	return applicationAPIClient.SetCharm(model.GenerationMaster, *setCharmConfig)
+}
+
+// ReadApplicationResource returns the resource currently used by an
+// application.
+func (c applicationsClient) ReadApplicationResource(ctx context.Context, input ReadApplicationResourceInput) (*ReadApplicationResourceResponse, error) {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	resourcesAPIClient, err := c.getResourceAPIClient(conn)
+	if err != nil {
+		return nil, err
+	}
+	appResources, err := resourcesAPIClient.ListResources([]string{input.AppName})
+	if err != nil {
+		return nil, typedError(jujuerrors.Annotate(err, "failed to list application resources"))
+	}
+	return applicationResourceFromList(appResources, input.AppName, input.ResourceName)
+}
+
+func applicationResourceFromList(appResources []coreresources.ApplicationResources, appName, resourceName string) (*ReadApplicationResourceResponse, error) {
+	for _, iResources := range appResources {
+		for _, resource := range iResources.Resources {
+			if resource.Name != resourceName {
+				continue
+			}
+			return &ReadApplicationResourceResponse{
+				Revision: resource.Revision,
+				Origin:   resource.Origin.String(),
+				Type:     resource.Type.String(),
+			}, nil
+		}
+	}
+	return nil, jujuerrors.NotFoundf("resource %q of application %q", resourceName, appName)
+}
+
+type ReadApplicationConfigInput struct {
+	ModelName string
+	AppName   string
+}
+
+type ReadApplicationConfigResponse struct {
+	Config map[string]ConfigEntry
+}
+
+type SetApplicationConfigInput struct {
+	ModelName string
+	AppName   string
+	Config    map[string]string
+}
+
+type UnsetApplicationConfigInput struct {
+	ModelName string
+	AppName   string
+	Keys      []string
+}
+
+// ReadApplicationConfig returns the config of the application, the
+// options of its charm included.
+func (c applicationsClient) ReadApplicationConfig(ctx context.Context, input ReadApplicationConfigInput) (*ReadApplicationConfigResponse, error) {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	returnedConf, err := c.getApplicationAPIClient(conn).Get(model.GenerationMaster, input.AppName)
+	if err != nil {
+		return nil, typedError(err)
+	}
+	return &ReadApplicationConfigResponse{Config: configEntries(returnedConf)}, nil
+}
+
+// SetApplicationConfig sets the given config options of the
+// application, leaving the others untouched.
+func (c applicationsClient) SetApplicationConfig(ctx context.Context, input SetApplicationConfigInput) error {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	c.Tracef("Setting application config", map[string]interface{}{"application": input.AppName, "config": input.Config})
+	return typedError(c.getApplicationAPIClient(conn).SetConfig(model.GenerationMaster, input.AppName, "", input.Config))
+}
+
+// UnsetApplicationConfig resets the given config options of the
+// application to the defaults of its charm.
+func (c applicationsClient) UnsetApplicationConfig(ctx context.Context, input UnsetApplicationConfigInput) error {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	c.Tracef("Unsetting application config", map[string]interface{}{"application": input.AppName, "keys": input.Keys})
+	return typedError(c.getApplicationAPIClient(conn).UnsetApplicationConfig(model.GenerationMaster, input.AppName, input.Keys))
+}
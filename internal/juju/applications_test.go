@@ -6,12 +6,16 @@ package juju
 // Basic imports
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	charmresources "github.com/juju/charm/v12/resource"
+	jujuerrors "github.com/juju/errors"
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
+	apiaction "github.com/juju/juju/api/client/action"
 	apiapplication "github.com/juju/juju/api/client/application"
 	apicharm "github.com/juju/juju/api/common/charm"
 	corebase "github.com/juju/juju/core/base"
@@ -20,6 +24,7 @@ import (
 	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/rpc/params"
+	jujustorage "github.com/juju/juju/storage"
 	"github.com/juju/names/v4"
 	"github.com/juju/utils/v3"
 	"github.com/juju/version/v2"
@@ -35,12 +40,14 @@ type ApplicationSuite struct {
 
 	testModelName string
 
-	mockApplicationClient *MockApplicationAPIClient
-	mockClient            *MockClientAPIClient
-	mockResourceAPIClient *MockResourceAPIClient
-	mockConnection        *MockConnection
-	mockModelConfigClient *MockModelConfigAPIClient
-	mockSharedClient      *MockSharedClient
+	mockActionClient         *MockActionAPIClient
+	mockApplicationClient    *MockApplicationAPIClient
+	mockClient               *MockClientAPIClient
+	mockResourceAPIClient    *MockResourceAPIClient
+	mockConnection           *MockConnection
+	mockMachineManagerClient *MockMachineManagerAPIClient
+	mockModelConfigClient    *MockModelConfigAPIClient
+	mockSharedClient         *MockSharedClient
 }
 
 func (s *ApplicationSuite) SetupTest() {}
@@ -49,8 +56,10 @@ func (s *ApplicationSuite) setupMocks(t *testing.T) *gomock.Controller {
 	s.testModelName = "testmodel"
 
 	ctlr := gomock.NewController(t)
+	s.mockActionClient = NewMockActionAPIClient(ctlr)
 	s.mockApplicationClient = NewMockApplicationAPIClient(ctlr)
 	s.mockClient = NewMockClientAPIClient(ctlr)
+	s.mockMachineManagerClient = NewMockMachineManagerAPIClient(ctlr)
 
 	s.mockConnection = NewMockConnection(ctlr)
 	s.mockConnection.EXPECT().Close().Return(nil).AnyTimes()
@@ -86,7 +95,7 @@ func (s *ApplicationSuite) setupMocks(t *testing.T) *gomock.Controller {
 	s.mockSharedClient.EXPECT().Errorf(gomock.Any(), gomock.Any()).Do(log).AnyTimes()
 	s.mockSharedClient.EXPECT().Tracef(gomock.Any(), gomock.Any()).Do(log).AnyTimes()
 	s.mockSharedClient.EXPECT().JujuLogger().Return(&jujuLoggerShim{}).AnyTimes()
-	s.mockSharedClient.EXPECT().GetConnection(&s.testModelName).Return(s.mockConnection, nil).AnyTimes()
+	s.mockSharedClient.EXPECT().GetConnection(gomock.Any(), &s.testModelName).Return(s.mockConnection, nil).AnyTimes()
 	return ctlr
 }
 
@@ -94,12 +103,18 @@ func (s *ApplicationSuite) getApplicationsClient() applicationsClient {
 	return applicationsClient{
 		SharedClient:      s.mockSharedClient,
 		controllerVersion: version.Number{},
+		getActionAPIClient: func(_ base.APICallCloser) ActionAPIClient {
+			return s.mockActionClient
+		},
 		getApplicationAPIClient: func(_ base.APICallCloser) ApplicationAPIClient {
 			return s.mockApplicationClient
 		},
 		getClientAPIClient: func(_ api.Connection) ClientAPIClient {
 			return s.mockClient
 		},
+		getMachineManagerAPIClient: func(_ base.APICallCloser) MachineManagerAPIClient {
+			return s.mockMachineManagerClient
+		},
 		getModelConfigAPIClient: func(_ api.Connection) ModelConfigAPIClient {
 			return s.mockModelConfigClient
 		},
@@ -111,7 +126,7 @@ func (s *ApplicationSuite) getApplicationsClient() applicationsClient {
 
 func (s *ApplicationSuite) TestReadApplicationRetry() {
 	defer s.setupMocks(s.T()).Finish()
-	s.mockSharedClient.EXPECT().ModelType(gomock.Any()).Return(model.IAAS, nil).AnyTimes()
+	s.mockSharedClient.EXPECT().ModelType(gomock.Any(), gomock.Any()).Return(model.IAAS, nil).AnyTimes()
 
 	appName := "testapplication"
 	aExp := s.mockApplicationClient.EXPECT()
@@ -150,9 +165,16 @@ func (s *ApplicationSuite) TestReadApplicationRetry() {
 	aExp.Get("master", appName).Return(getResult, nil)
 	statusResult := &params.FullStatus{
 		Applications: map[string]params.ApplicationStatus{appName: {
-			Charm: "ch:amd64/jammy/testcharm-5",
+			Charm:           "ch:amd64/jammy/testcharm-5",
+			CharmVersion:    "1.2",
+			WorkloadVersion: "22.04",
+			Status:          params.DetailedStatus{Status: "active", Info: "ready"},
 			Units: map[string]params.UnitStatus{"testapplication/0": {
 				Machine: "0",
+			}, "testapplication/1": {
+				Machine:       "1",
+				PublicAddress: "10.0.0.2",
+				Leader:        true,
 			}},
 		}},
 	}
@@ -171,11 +193,21 @@ func (s *ApplicationSuite) TestReadApplicationRetry() {
 	s.Assert().Equal("stable", resp.Channel)
 	s.Assert().Equal(5, resp.Revision)
 	s.Assert().Equal("ubuntu@22.04", resp.Base)
+	s.Assert().Equal("amd64", resp.Architecture)
+	s.Assert().Equal("testapplication/1", resp.LeaderUnit)
+	s.Assert().Equal("10.0.0.2", resp.PublicAddress)
+	s.Assert().Equal(map[string]string{"testapplication/1": "10.0.0.2"}, resp.UnitAddresses)
+	s.Assert().Equal(map[string]string{"testapplication/0": "0", "testapplication/1": "1"}, resp.UnitMachines)
+	s.Assert().Equal([]string{"testapplication/0", "testapplication/1"}, resp.UnitNames)
+	s.Assert().Equal("active", resp.Status)
+	s.Assert().Equal("ready", resp.StatusMessage)
+	s.Assert().Equal("1.2", resp.CharmVersion)
+	s.Assert().Equal("22.04", resp.WorkloadVersion)
 }
 
 func (s *ApplicationSuite) TestReadApplicationRetryDoNotPanic() {
 	defer s.setupMocks(s.T()).Finish()
-	s.mockSharedClient.EXPECT().ModelType(gomock.Any()).Return(model.IAAS, nil).AnyTimes()
+	s.mockSharedClient.EXPECT().ModelType(gomock.Any(), gomock.Any()).Return(model.IAAS, nil).AnyTimes()
 
 	appName := "testapplication"
 	aExp := s.mockApplicationClient.EXPECT()
@@ -193,7 +225,7 @@ func (s *ApplicationSuite) TestReadApplicationRetryDoNotPanic() {
 
 func (s *ApplicationSuite) TestReadApplicationRetryWaitForMachines() {
 	defer s.setupMocks(s.T()).Finish()
-	s.mockSharedClient.EXPECT().ModelType(gomock.Any()).Return(model.IAAS, nil).AnyTimes()
+	s.mockSharedClient.EXPECT().ModelType(gomock.Any(), gomock.Any()).Return(model.IAAS, nil).AnyTimes()
 
 	appName := "testapplication"
 	aExp := s.mockApplicationClient.EXPECT()
@@ -269,7 +301,7 @@ func (s *ApplicationSuite) TestReadApplicationRetryWaitForMachines() {
 
 func (s *ApplicationSuite) TestReadApplicationRetrySubordinate() {
 	defer s.setupMocks(s.T()).Finish()
-	s.mockSharedClient.EXPECT().ModelType(gomock.Any()).Return(model.IAAS, nil).AnyTimes()
+	s.mockSharedClient.EXPECT().ModelType(gomock.Any(), gomock.Any()).Return(model.IAAS, nil).AnyTimes()
 
 	appName := "testapplication"
 	aExp := s.mockApplicationClient.EXPECT()
@@ -325,7 +357,7 @@ func (s *ApplicationSuite) TestReadApplicationRetrySubordinate() {
 // The second response is a real application.
 func (s *ApplicationSuite) TestReadApplicationRetryNotFoundStorageNotFoundError() {
 	defer s.setupMocks(s.T()).Finish()
-	s.mockSharedClient.EXPECT().ModelType(gomock.Any()).Return(model.IAAS, nil).AnyTimes()
+	s.mockSharedClient.EXPECT().ModelType(gomock.Any(), gomock.Any()).Return(model.IAAS, nil).AnyTimes()
 
 	appName := "testapplication"
 	aExp := s.mockApplicationClient.EXPECT()
@@ -393,7 +425,7 @@ func (s *ApplicationSuite) TestReadApplicationRetryNotFoundStorageNotFoundError(
 // One resource ID is returned in the resource list.
 func (s *ApplicationSuite) TestAddPendingResourceCustomImageResourceProvidedCharmResourcesToAddExistsUploadPendingResourceCalled() {
 	defer s.setupMocks(s.T()).Finish()
-	s.mockSharedClient.EXPECT().ModelType(gomock.Any()).Return(model.IAAS, nil).AnyTimes()
+	s.mockSharedClient.EXPECT().ModelType(gomock.Any(), gomock.Any()).Return(model.IAAS, nil).AnyTimes()
 
 	appName := "testapplication"
 	deployValue := "ausf-image"
@@ -449,7 +481,7 @@ func (s *ApplicationSuite) TestAddPendingResourceCustomImageResourceProvidedChar
 // Empty resource list is returned.
 func (s *ApplicationSuite) TestAddPendingResourceCustomImageResourceProvidedNoCharmResourcesToAddEmptyResourceListReturned() {
 	defer s.setupMocks(s.T()).Finish()
-	s.mockSharedClient.EXPECT().ModelType(gomock.Any()).Return(model.IAAS, nil).AnyTimes()
+	s.mockSharedClient.EXPECT().ModelType(gomock.Any(), gomock.Any()).Return(model.IAAS, nil).AnyTimes()
 
 	appName := "testapplication"
 	charmResourcesToAdd := make(map[string]charmresources.Meta)
@@ -492,7 +524,7 @@ func (s *ApplicationSuite) TestAddPendingResourceCustomImageResourceProvidedNoCh
 // ResourceAPIClient.AddPendingResource and ResourceAPIClient.UploadPendingResource is called.
 func (s *ApplicationSuite) TestAddPendingResourceOneCustomResourceOneRevisionProvidedMultipleCharmResourcesToAddUploadPendingResourceAndAddPendingResourceCalled() {
 	defer s.setupMocks(s.T()).Finish()
-	s.mockSharedClient.EXPECT().ModelType(gomock.Any()).Return(model.IAAS, nil).AnyTimes()
+	s.mockSharedClient.EXPECT().ModelType(gomock.Any(), gomock.Any()).Return(model.IAAS, nil).AnyTimes()
 
 	appName := "testapplication"
 	ausfDeployValue := "ausf-image"
@@ -558,7 +590,7 @@ func (s *ApplicationSuite) TestAddPendingResourceOneCustomResourceOneRevisionPro
 // Only ResourceAPIClient.AddPendingResource called, ResourceAPIClient.UploadPendingResource is not called.
 func (s *ApplicationSuite) TestAddPendingResourceOneRevisionProvidedMultipleCharmResourcesToAddOnlyAddPendingResourceCalled() {
 	defer s.setupMocks(s.T()).Finish()
-	s.mockSharedClient.EXPECT().ModelType(gomock.Any()).Return(model.IAAS, nil).AnyTimes()
+	s.mockSharedClient.EXPECT().ModelType(gomock.Any(), gomock.Any()).Return(model.IAAS, nil).AnyTimes()
 
 	appName := "testapplication"
 	ausfDeployValue := "ausf-image"
@@ -619,7 +651,7 @@ func (s *ApplicationSuite) TestAddPendingResourceOneRevisionProvidedMultipleChar
 // Error is not returned.
 func (s *ApplicationSuite) TestUploadExistingPendingResourcesUploadSuccessful() {
 	defer s.setupMocks(s.T()).Finish()
-	s.mockSharedClient.EXPECT().ModelType(gomock.Any()).Return(model.IAAS, nil).AnyTimes()
+	s.mockSharedClient.EXPECT().ModelType(gomock.Any(), gomock.Any()).Return(model.IAAS, nil).AnyTimes()
 	appName := "testapplication"
 	resource := apiapplication.PendingResourceUpload{
 		Name:     "custom-image",
@@ -640,7 +672,7 @@ func (s *ApplicationSuite) TestUploadExistingPendingResourcesUploadSuccessful()
 // Returns error that upload failed for provided file name.
 func (s *ApplicationSuite) TestUploadExistingPendingResourcesUploadFailedReturnError() {
 	defer s.setupMocks(s.T()).Finish()
-	s.mockSharedClient.EXPECT().ModelType(gomock.Any()).Return(model.IAAS, nil).AnyTimes()
+	s.mockSharedClient.EXPECT().ModelType(gomock.Any(), gomock.Any()).Return(model.IAAS, nil).AnyTimes()
 	appName := "testapplication"
 	fileName := "my-image"
 	resource := apiapplication.PendingResourceUpload{
@@ -662,7 +694,7 @@ func (s *ApplicationSuite) TestUploadExistingPendingResourcesUploadFailedReturnE
 // ResourceAPIClient.Upload is not called and returns error that resource type is invalid.
 func (s *ApplicationSuite) TestUploadExistingPendingResourcesResourceTypeUnknownReturnError() {
 	defer s.setupMocks(s.T()).Finish()
-	s.mockSharedClient.EXPECT().ModelType(gomock.Any()).Return(model.IAAS, nil).AnyTimes()
+	s.mockSharedClient.EXPECT().ModelType(gomock.Any(), gomock.Any()).Return(model.IAAS, nil).AnyTimes()
 	appName := "testapplication"
 	var pendingResources []apiapplication.PendingResourceUpload
 	resource := apiapplication.PendingResourceUpload{
@@ -680,7 +712,7 @@ func (s *ApplicationSuite) TestUploadExistingPendingResourcesResourceTypeUnknown
 // ResourceAPIClient.Upload is not called and returns error that unable to open resource.
 func (s *ApplicationSuite) TestUploadExistingPendingResourcesInvalidFileNameReturnError() {
 	defer s.setupMocks(s.T()).Finish()
-	s.mockSharedClient.EXPECT().ModelType(gomock.Any()).Return(model.IAAS, nil).AnyTimes()
+	s.mockSharedClient.EXPECT().ModelType(gomock.Any(), gomock.Any()).Return(model.IAAS, nil).AnyTimes()
 	appName := "testapplication"
 	var pendingResources []apiapplication.PendingResourceUpload
 	resource := apiapplication.PendingResourceUpload{
@@ -694,6 +726,429 @@ func (s *ApplicationSuite) TestUploadExistingPendingResourcesInvalidFileNameRetu
 	s.Assert().Equal("unable to open resource custom-image: filepath or registry path:  not valid", err.Error(), "Error is expected.")
 }
 
+func (s *ApplicationSuite) TestReadUnit() {
+	defer s.setupMocks(s.T()).Finish()
+
+	statusResult := &params.FullStatus{
+		Machines: map[string]params.MachineStatus{"1": {
+			IPAddresses: []string{"10.0.0.5", "192.168.0.5"},
+		}},
+		Applications: map[string]params.ApplicationStatus{"wordpress": {
+			Units: map[string]params.UnitStatus{"wordpress/0": {
+				Machine:        "1",
+				PublicAddress:  "203.0.113.5",
+				OpenedPorts:    []string{"443/tcp", "80/tcp"},
+				WorkloadStatus: params.DetailedStatus{Status: "active", Info: "ready"},
+				AgentStatus:    params.DetailedStatus{Status: "idle"},
+				Leader:         true,
+				Subordinates: map[string]params.UnitStatus{"telegraf/2": {
+					WorkloadStatus: params.DetailedStatus{Status: "blocked"},
+				}},
+			}},
+		}},
+	}
+	s.mockClient.EXPECT().Status(gomock.Any()).Return(statusResult, nil).Times(2)
+
+	client := s.getApplicationsClient()
+	resp, err := client.ReadUnit(context.Background(), ReadUnitInput{ModelName: s.testModelName, UnitName: "wordpress/0"})
+	s.Require().NoError(err)
+	s.Assert().Equal(&ReadUnitResponse{
+		Machine:         "1",
+		PublicAddress:   "203.0.113.5",
+		PrivateAddress:  "10.0.0.5",
+		OpenedPorts:     []string{"443/tcp", "80/tcp"},
+		WorkloadStatus:  "active",
+		WorkloadMessage: "ready",
+		AgentStatus:     "idle",
+		Leader:          true,
+	}, resp)
+
+	resp, err = client.ReadUnit(context.Background(), ReadUnitInput{ModelName: s.testModelName, UnitName: "telegraf/2"})
+	s.Require().NoError(err)
+	s.Assert().Equal("1", resp.Machine)
+	s.Assert().Equal("blocked", resp.WorkloadStatus)
+	s.Assert().Equal("wordpress/0", resp.Principal)
+}
+
+func (s *ApplicationSuite) TestReadUnitNotFound() {
+	defer s.setupMocks(s.T()).Finish()
+
+	s.mockClient.EXPECT().Status(gomock.Any()).Return(&params.FullStatus{}, nil)
+
+	client := s.getApplicationsClient()
+	_, err := client.ReadUnit(context.Background(), ReadUnitInput{ModelName: s.testModelName, UnitName: "wordpress/1"})
+	s.Assert().ErrorAs(err, &UnitNotFoundError)
+
+	_, err = client.ReadUnit(context.Background(), ReadUnitInput{ModelName: s.testModelName, UnitName: "wordpress"})
+	s.Assert().Error(err)
+}
+
+func (s *ApplicationSuite) TestListApplications() {
+	defer s.setupMocks(s.T()).Finish()
+	s.mockSharedClient.EXPECT().ModelType(gomock.Any(), gomock.Any()).Return(model.IAAS, nil).AnyTimes()
+
+	s.mockClient.EXPECT().Status(gomock.Any()).Return(&params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"wordpress": {
+				Charm:        "ch:amd64/jammy/wordpress-12",
+				CharmChannel: "latest/stable",
+				Exposed:      true,
+				Status:       params.DetailedStatus{Status: "active"},
+				Units: map[string]params.UnitStatus{
+					"wordpress/0": {}, "wordpress/1": {},
+				},
+			},
+			"telegraf": {
+				Charm:         "ch:amd64/jammy/telegraf-75",
+				CharmChannel:  "latest/edge",
+				SubordinateTo: []string{"wordpress"},
+				Status:        params.DetailedStatus{Status: "waiting"},
+			},
+		},
+	}, nil)
+
+	client := s.getApplicationsClient()
+	resp, err := client.ListApplications(context.Background(), ListApplicationsInput{ModelName: s.testModelName})
+	s.Require().NoError(err)
+	s.Assert().Equal([]ApplicationSummary{{
+		Name:        "telegraf",
+		Charm:       "telegraf",
+		Channel:     "latest/edge",
+		Revision:    75,
+		Subordinate: true,
+		Status:      "waiting",
+	}, {
+		Name:     "wordpress",
+		Charm:    "wordpress",
+		Channel:  "latest/stable",
+		Revision: 12,
+		Units:    2,
+		Exposed:  true,
+		Status:   "active",
+	}}, resp.Applications)
+}
+
+func (s *ApplicationSuite) TestEndpointsToUnexpose() {
+	current := &ReadApplicationExposeResponse{Exposed: true, AllEndpoints: true, Endpoints: []string{"db", "website"}}
+	s.Assert().Equal([]string{"", "db"}, endpointsToUnexpose(current, []string{"website"}))
+	s.Assert().Equal([]string{"db", "website"}, endpointsToUnexpose(current, nil))
+
+	current = &ReadApplicationExposeResponse{Exposed: true, Endpoints: []string{"website"}}
+	s.Assert().Empty(endpointsToUnexpose(current, []string{"website", "db"}))
+}
+
+func (s *ApplicationSuite) TestApplicationResourceFromList() {
+	appResources := []resources.ApplicationResources{{
+		Resources: []resources.Resource{{
+			Resource: charmresources.Resource{
+				Meta:     charmresources.Meta{Name: "image", Type: charmresources.TypeContainerImage},
+				Origin:   charmresources.OriginUpload,
+				Revision: 3,
+			},
+		}, {
+			Resource: charmresources.Resource{
+				Meta:     charmresources.Meta{Name: "config", Type: charmresources.TypeFile},
+				Origin:   charmresources.OriginStore,
+				Revision: 12,
+			},
+		}},
+	}}
+
+	resp, err := applicationResourceFromList(appResources, "app", "config")
+	s.Require().NoError(err)
+	s.Assert().Equal(&ReadApplicationResourceResponse{Revision: 12, Origin: "store", Type: "file"}, resp)
+
+	resp, err = applicationResourceFromList(appResources, "app", "image")
+	s.Require().NoError(err)
+	s.Assert().Equal(&ReadApplicationResourceResponse{Revision: 3, Origin: "upload", Type: "oci-image"}, resp)
+
+	_, err = applicationResourceFromList(appResources, "app", "missing")
+	s.Assert().True(jujuerrors.Is(err, jujuerrors.NotFound), err)
+}
+
+func (s *ApplicationSuite) TestUpgradeApplicationBaseWithoutMachines() {
+	defer s.setupMocks(s.T()).Finish()
+	client := s.getApplicationsClient()
+
+	s.mockApplicationClient.EXPECT().UpdateApplicationBase("ubuntu", corebase.MustParseBaseFromString("ubuntu@24.04"), false).Return(nil)
+	err := client.upgradeApplicationBase(context.Background(), s.mockConnection,
+		&UpdateApplicationInput{AppName: "ubuntu", Base: "ubuntu@24.04"}, params.ApplicationStatus{}, nil)
+	s.Require().NoError(err)
+
+	s.mockApplicationClient.EXPECT().UpdateApplicationBase("ubuntu", corebase.MustParseBaseFromString("ubuntu@22.04"), false).Return(nil)
+	err = client.upgradeApplicationBase(context.Background(), s.mockConnection,
+		&UpdateApplicationInput{AppName: "ubuntu", Series: "jammy"}, params.ApplicationStatus{}, nil)
+	s.Require().NoError(err)
+
+	err = client.upgradeApplicationBase(context.Background(), s.mockConnection,
+		&UpdateApplicationInput{AppName: "ubuntu", Base: "ubuntu"}, params.ApplicationStatus{}, nil)
+	s.Require().Error(err)
+}
+
+func (s *ApplicationSuite) TestUpgradeApplicationBase() {
+	defer s.setupMocks(s.T()).Finish()
+	defer func(delay time.Duration) { execPollDelay = delay }(execPollDelay)
+	defer func(delay time.Duration) { upgradeMachineRebootDelay = delay }(upgradeMachineRebootDelay)
+	execPollDelay = time.Millisecond
+	upgradeMachineRebootDelay = time.Millisecond
+	client := s.getApplicationsClient()
+	s.mockSharedClient.EXPECT().ModelType(gomock.Any(), s.testModelName).Return(model.IAAS, nil).AnyTimes()
+	s.mockConnection.EXPECT().BestFacadeVersion(gomock.Any()).Return(1).AnyTimes()
+
+	base := corebase.MustParseBaseFromString("ubuntu@24.04")
+	appStatus := params.ApplicationStatus{Units: map[string]params.UnitStatus{
+		"ubuntu/0": {Machine: "0"},
+		"ubuntu/1": {Machine: "1/lxd/0"},
+	}}
+	machines := map[string]params.MachineStatus{
+		"0": {Base: params.Base{Name: "ubuntu", Channel: "22.04"}},
+		"1": {
+			Base: params.Base{Name: "ubuntu", Channel: "22.04"},
+			Containers: map[string]params.MachineStatus{
+				"1/lxd/0": {Base: params.Base{Name: "ubuntu", Channel: "24.04"}},
+			},
+		},
+	}
+
+	// Only machine 0 is upgraded, the container already runs the base.
+	exec := func(id, command, stdout string) *gomock.Call {
+		run := s.mockActionClient.EXPECT().Run(gomock.Any()).DoAndReturn(
+			func(run apiaction.RunParams) (apiaction.EnqueuedActions, error) {
+				s.Assert().Equal(command, run.Commands)
+				s.Assert().Equal([]string{"0"}, run.Machines)
+				return apiaction.EnqueuedActions{Actions: []apiaction.ActionResult{{Action: &apiaction.Action{ID: id}}}}, nil
+			})
+		return s.mockActionClient.EXPECT().Actions([]string{id}).Return([]apiaction.ActionResult{{
+			Action: &apiaction.Action{ID: id, Receiver: "machine-0"},
+			Status: params.ActionCompleted,
+			Output: map[string]interface{}{"stdout": stdout, "return-code": 0},
+		}}, nil).After(run)
+	}
+	watch := func(id, message string) *gomock.Call {
+		s.mockConnection.EXPECT().APICall("Client", 1, "", "WatchAll", nil, gomock.Any()).DoAndReturn(
+			func(_ string, _ int, _, _ string, _, response interface{}) error {
+				*response.(*params.AllWatcherId) = params.AllWatcherId{AllWatcherId: id}
+				return nil
+			})
+		stopped := make(chan struct{})
+		sent := false
+		s.mockConnection.EXPECT().APICall("AllWatcher", 1, id, "Next", nil, gomock.Any()).DoAndReturn(
+			func(_ string, _ int, _, _ string, _, response interface{}) error {
+				if sent {
+					<-stopped
+					return errors.New("watcher stopped")
+				}
+				sent = true
+				*response.(*params.AllWatcherNextResults) = params.AllWatcherNextResults{Deltas: []params.Delta{{
+					Entity: &params.MachineInfo{Id: "0", InstanceStatus: params.StatusInfo{Message: message}},
+				}}}
+				return nil
+			}).MinTimes(1)
+		return s.mockConnection.EXPECT().APICall("AllWatcher", 1, id, "Stop", nil, nil).DoAndReturn(
+			func(_ string, _ int, _, _ string, _, _ interface{}) error {
+				close(stopped)
+				return nil
+			})
+	}
+	gomock.InOrder(
+		s.mockApplicationClient.EXPECT().UpdateApplicationBase("ubuntu", base, false).Return(nil),
+		exec("1", bootIDCommand, "boot-1\n"),
+		s.mockMachineManagerClient.EXPECT().UpgradeSeriesPrepare("0", "24.04/stable", false).Return(nil),
+		watch("10", "series upgrade prepare completed: waiting for completion command"),
+		exec("2", upgradeMachineCommand, ""),
+		exec("3", bootIDCommand, "boot-1\n"),
+		exec("4", bootIDCommand, "boot-2\n"),
+		s.mockMachineManagerClient.EXPECT().UpgradeSeriesComplete("0").Return(nil),
+		watch("11", "series upgrade completed: success"),
+	)
+
+	err := client.upgradeApplicationBase(context.Background(), s.mockConnection,
+		&UpdateApplicationInput{ModelName: s.testModelName, AppName: "ubuntu", Base: "ubuntu@24.04"}, appStatus, machines)
+	s.Require().NoError(err)
+}
+
+func (s *ApplicationSuite) TestMachineUpgradeStatusFailed() {
+	state := newModelState()
+	state.apply([]params.Delta{{Entity: &params.MachineInfo{
+		Id:             "0",
+		InstanceStatus: params.StatusInfo{Message: "series upgrade error: unit ubuntu/0 failed"},
+	}}})
+	_, _, err := machineUpgradeStatus("0", model.UpgradeSeriesPrepareCompleted, "").Check(state)
+	s.Assert().ErrorContains(err, "unit ubuntu/0 failed")
+}
+
+func (s *ApplicationSuite) TestReadApplicationConfig() {
+	defer s.setupMocks(s.T()).Finish()
+	client := s.getApplicationsClient()
+
+	s.mockApplicationClient.EXPECT().Get("master", "postgresql").Return(&params.ApplicationGetResults{
+		ApplicationConfig: map[string]interface{}{
+			"trust": map[string]interface{}{"value": true, "source": "user"},
+		},
+		CharmConfig: map[string]interface{}{
+			"port":    map[string]interface{}{"value": 5432, "source": "default"},
+			"profile": map[string]interface{}{"value": "production", "source": "user"},
+		},
+	}, nil)
+	resp, err := client.ReadApplicationConfig(context.Background(), ReadApplicationConfigInput{
+		ModelName: s.testModelName,
+		AppName:   "postgresql",
+	})
+	s.Require().NoError(err)
+	s.Assert().Equal(map[string]ConfigEntry{
+		"port":    {Value: 5432, IsDefault: true},
+		"profile": {Value: "production"},
+	}, resp.Config)
+}
+
+func (s *ApplicationSuite) TestSetAndUnsetApplicationConfig() {
+	defer s.setupMocks(s.T()).Finish()
+	client := s.getApplicationsClient()
+
+	s.mockApplicationClient.EXPECT().SetConfig("master", "postgresql", "", map[string]string{"profile": "testing"}).Return(nil)
+	err := client.SetApplicationConfig(context.Background(), SetApplicationConfigInput{
+		ModelName: s.testModelName,
+		AppName:   "postgresql",
+		Config:    map[string]string{"profile": "testing"},
+	})
+	s.Require().NoError(err)
+
+	s.mockApplicationClient.EXPECT().UnsetApplicationConfig("master", "postgresql", []string{"profile"}).Return(
+		jujuerrors.New(`application "postgresql" not found`))
+	err = client.UnsetApplicationConfig(context.Background(), UnsetApplicationConfigInput{
+		ModelName: s.testModelName,
+		AppName:   "postgresql",
+		Keys:      []string{"profile"},
+	})
+	s.Assert().True(jujuerrors.Is(err, jujuerrors.NotFound), err)
+}
+
+func (s *ApplicationSuite) TestValidateAndTransformAttachStorage() {
+	input := CreateApplicationInput{
+		ApplicationName: "postgresql",
+		CharmName:       "postgresql",
+		Units:           1,
+		AttachStorage:   []string{"pgdata/0"},
+	}
+	parsed, err := input.validateAndTransform(nil)
+	s.Require().NoError(err)
+	s.Assert().Equal([]string{"pgdata/0"}, parsed.attachStorage)
+
+	input.Units = 2
+	_, err = input.validateAndTransform(nil)
+	s.Assert().ErrorContains(err, "only one unit is allowed")
+
+	input.Units = 1
+	input.AttachStorage = []string{"pgdata"}
+	_, err = input.validateAndTransform(nil)
+	s.Assert().True(jujuerrors.Is(err, jujuerrors.NotValid))
+}
+
+func (s *ApplicationSuite) TestValidateAndTransformCharmArchitecture() {
+	input := CreateApplicationInput{
+		ApplicationName:   "postgresql",
+		CharmName:         "postgresql",
+		CharmArchitecture: "arm64",
+		Units:             1,
+		Constraints:       constraints.MustParse("mem=4G"),
+	}
+	parsed, err := input.validateAndTransform(nil)
+	s.Require().NoError(err)
+	s.Assert().Equal("arch=arm64 mem=4096M", parsed.constraints.String())
+	s.Assert().Equal("mem=4096M", input.Constraints.String())
+
+	input.Constraints = constraints.MustParse("arch=amd64")
+	_, err = input.validateAndTransform(nil)
+	s.Assert().ErrorContains(err, "conflicts with the arch constraint")
+
+	input.Constraints = constraints.Value{}
+	input.CharmArchitecture = "sparc"
+	_, err = input.validateAndTransform(nil)
+	s.Assert().True(jujuerrors.Is(err, jujuerrors.NotValid))
+}
+
+func (s *ApplicationSuite) TestSortedUnitNames() {
+	appStatus := params.ApplicationStatus{Units: map[string]params.UnitStatus{
+		"app/10": {},
+		"app/2":  {},
+		"app/0":  {},
+	}}
+	s.Assert().Equal([]string{"app/0", "app/2", "app/10"}, sortedUnitNames(appStatus))
+}
+
+func (s *ApplicationSuite) TestPlacementSatisfied() {
+	s.Assert().True(PlacementSatisfied("0,1", "0,1"))
+	s.Assert().True(PlacementSatisfied("lxd:3", "3/lxd/0"))
+	s.Assert().True(PlacementSatisfied("lxd:3/lxd/0", "3/lxd/0/lxd/1"))
+	s.Assert().True(PlacementSatisfied("lxd", "4/lxd/0"))
+	s.Assert().True(PlacementSatisfied("lxd:3", ""))
+	s.Assert().False(PlacementSatisfied("lxd:3", "3"))
+	s.Assert().False(PlacementSatisfied("lxd:3", "2/lxd/0"))
+	s.Assert().False(PlacementSatisfied("kvm:3", "3/lxd/0"))
+	s.Assert().False(PlacementSatisfied("0", "0,1"))
+}
+
+func (s *ApplicationSuite) TestUpdateApplicationScaleUpAfterStorageIncrease() {
+	defer s.setupMocks(s.T()).Finish()
+	s.mockSharedClient.EXPECT().ModelType(gomock.Any(), s.testModelName).Return(model.IAAS, nil).AnyTimes()
+	s.mockConnection.EXPECT().BestFacadeVersion(gomock.Any()).Return(6).AnyTimes()
+
+	s.mockClient.EXPECT().Status(gomock.Any()).Return(&params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"app": {Units: map[string]params.UnitStatus{"app/0": {}}},
+		},
+	}, nil)
+
+	addToUnit := func(unitTag string, count uint64) *gomock.Call {
+		return s.mockConnection.EXPECT().APICall("Storage", 6, "", "AddToUnit", params.StoragesAddParams{
+			Storages: []params.StorageAddParams{{
+				UnitTag:     unitTag,
+				StorageName: "data",
+				Constraints: params.StorageConstraints{Pool: "rootfs", Count: &count},
+			}},
+		}, gomock.Any()).Return(nil)
+	}
+	// The count of the storage directive is increased from 1 to 2, so
+	// storage is added to the existing unit.
+	added := addToUnit("unit-app-0", 1)
+	units := s.mockApplicationClient.EXPECT().AddUnits(gomock.Any()).DoAndReturn(
+		func(args apiapplication.AddUnitsParams) ([]string, error) {
+			s.Assert().Equal("app", args.ApplicationName)
+			s.Assert().Equal(1, args.NumUnits)
+			return []string{"app/1"}, nil
+		}).After(added)
+	// The unit added is created with the storage directive the
+	// application was deployed with, the storage missing is added.
+	listed := s.mockConnection.EXPECT().APICall("Storage", 6, "", "ListStorageDetails", gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ string, _ int, _, _ string, _, response interface{}) error {
+			*response.(*params.StorageDetailsListResults) = params.StorageDetailsListResults{
+				Results: []params.StorageDetailsListResult{{Result: []params.StorageDetails{{
+					StorageTag: "storage-data-0",
+					OwnerTag:   "unit-app-0",
+				}, {
+					StorageTag: "storage-data-2",
+					OwnerTag:   "unit-app-0",
+				}, {
+					StorageTag: "storage-data-1",
+					OwnerTag:   "unit-app-1",
+				}}}},
+			}
+			return nil
+		}).After(units)
+	addToUnit("unit-app-1", 1).After(listed)
+
+	client := s.getApplicationsClient()
+	unitCount := 2
+	err := client.UpdateApplication(context.Background(), &UpdateApplicationInput{
+		ModelName:         s.testModelName,
+		AppName:           "app",
+		Units:             &unitCount,
+		StorageAdditions:  map[string]jujustorage.Constraints{"data": {Pool: "rootfs", Count: 1}},
+		StorageDirectives: map[string]jujustorage.Constraints{"data": {Pool: "rootfs", Count: 2}},
+	})
+	s.Require().NoError(err)
+}
+
 // In order for 'go test' to run this suite, we need to create
 // a normal test function and pass our suite to suite.Run
 func TestApplicationSuite(t *testing.T) {
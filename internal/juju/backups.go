@@ -0,0 +1,104 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/juju/errors"
+	apibackups "github.com/juju/juju/api/client/backups"
+)
+
+// ControllerModelName is the name of the model hosting the controller
+// machines. Controller-wide operations, e.g. backups, are performed
+// against it.
+const ControllerModelName = "controller"
+
+type backupsClient struct {
+	SharedClient
+}
+
+type CreateBackupInput struct {
+	Notes string
+	// DownloadPath is the local file the backup archive is written to.
+	// If empty, the archive is only stored on the controller.
+	DownloadPath string
+}
+
+type CreateBackupResponse struct {
+	ID             string
+	Filename       string
+	Checksum       string
+	Size           int64
+	Started        time.Time
+	Finished       time.Time
+	ControllerUUID string
+	Version        string
+}
+
+func newBackupsClient(sc SharedClient) *backupsClient {
+	return &backupsClient{
+		SharedClient: sc,
+	}
+}
+
+// CreateBackup triggers a backup of the controller and optionally
+// downloads the resulting archive to a local file.
+func (c *backupsClient) CreateBackup(ctx context.Context, input CreateBackupInput) (*CreateBackupResponse, error) {
+	modelName := ControllerModelName
+	conn, err := c.GetConnection(ctx, &modelName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	client := apibackups.NewClient(conn)
+
+	noDownload := input.DownloadPath == ""
+	result, err := client.Create(input.Notes, noDownload)
+	if err != nil {
+		return nil, errors.Annotate(err, "creating backup")
+	}
+	c.Tracef("Created controller backup", map[string]interface{}{"id": result.ID, "filename": result.Filename})
+
+	if !noDownload {
+		if err := c.downloadBackup(client, result.Filename, input.DownloadPath); err != nil {
+			return nil, err
+		}
+	}
+
+	return &CreateBackupResponse{
+		ID:             result.ID,
+		Filename:       result.Filename,
+		Checksum:       result.Checksum,
+		Size:           result.Size,
+		Started:        result.Started,
+		Finished:       result.Finished,
+		ControllerUUID: result.ControllerUUID,
+		Version:        result.Version.String(),
+	}, nil
+}
+
+func (c *backupsClient) downloadBackup(client *apibackups.Client, filename, path string) error {
+	archive, err := client.Download(filename)
+	if err != nil {
+		return errors.Annotatef(err, "downloading backup %q", filename)
+	}
+	defer func() { _ = archive.Close() }()
+
+	file, err := os.Create(path)
+	if err != nil {
+		return errors.Annotatef(err, "creating local archive file %q", path)
+	}
+	defer func() { _ = file.Close() }()
+
+	if _, err := io.Copy(file, archive); err != nil {
+		return errors.Annotatef(err, "copying to local archive file %q", path)
+	}
+	c.Debugf("Downloaded controller backup", map[string]interface{}{"filename": filename, "path": path})
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+)
+
+// ErrControllerUnavailable is wrapped by the errors of the operations
+// failing fast because the controller is consistently failing.
+const ErrControllerUnavailable = errors.ConstError("controller unavailable")
+
+var (
+	// breakerThreshold is the number of consecutive connection failures
+	// after which connections fail fast.
+	breakerThreshold = 3
+	// breakerCooldown is how long connections fail fast before the
+	// controller is tried again.
+	breakerCooldown = time.Minute
+)
+
+// circuitBreaker stops the operations of a client from each waiting out
+// the connection timeout when the controller is consistently failing.
+//
+// After breakerThreshold consecutive connection failures the breaker
+// opens: connections fail fast with an error summarizing the failures.
+// Once breakerCooldown elapsed, a single connection attempt is let
+// through. Its success closes the breaker, its failure opens it again.
+type circuitBreaker struct {
+	clock clock.Clock
+
+	mu           sync.Mutex
+	failures     int
+	firstFailure time.Time
+	lastErr      error
+	openUntil    time.Time
+	// probing is set while the attempt let through after the cooldown
+	// is in flight.
+	probing bool
+}
+
+func newCircuitBreaker(clk clock.Clock) *circuitBreaker {
+	return &circuitBreaker{clock: clk}
+}
+
+// allow returns an error wrapping ErrControllerUnavailable if the
+// breaker is open, nil if a connection can be attempted. The outcome of
+// the attempt must then be recorded.
+func (b *circuitBreaker) allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < breakerThreshold {
+		return nil
+	}
+	if b.probing || b.clock.Now().Before(b.openUntil) {
+		return fmt.Errorf("%w: %d consecutive connection failures since %s, failing fast until %s, last error: %v",
+			ErrControllerUnavailable, b.failures, b.firstFailure.Format(time.RFC3339),
+			b.openUntil.Format(time.RFC3339), b.lastErr)
+	}
+	b.probing = true
+	return nil
+}
+
+// record records the outcome of a connection attempt. Failures because
+// the operation was cancelled are not the controller's.
+func (b *circuitBreaker) record(err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	switch {
+	case err == nil:
+		b.failures = 0
+		b.lastErr = nil
+	case errors.Is(err, context.Canceled):
+	default:
+		now := b.clock.Now()
+		if b.failures == 0 {
+			b.firstFailure = now
+		}
+		b.failures++
+		b.lastErr = err
+		if b.failures >= breakerThreshold {
+			b.openUntil = now.Add(breakerCooldown)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+type BreakerSuite struct {
+	suite.Suite
+
+	clock   *testclock.Clock
+	breaker *circuitBreaker
+}
+
+func (s *BreakerSuite) SetupTest() {
+	s.clock = testclock.NewClock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.breaker = newCircuitBreaker(s.clock)
+}
+
+func (s *BreakerSuite) fail(times int) {
+	for i := 0; i < times; i++ {
+		s.Require().NoError(s.breaker.allow())
+		s.breaker.record(errors.New("dial tcp 10.0.0.1:17070: i/o timeout"))
+	}
+}
+
+func (s *BreakerSuite) TestOpensAfterThreshold() {
+	s.fail(breakerThreshold)
+
+	err := s.breaker.allow()
+	s.Require().Error(err)
+	s.Assert().True(errors.Is(err, ErrControllerUnavailable))
+	s.Assert().ErrorContains(err, "3 consecutive connection failures since 2024-06-01T12:00:00Z")
+	s.Assert().ErrorContains(err, "last error: dial tcp 10.0.0.1:17070: i/o timeout")
+}
+
+func (s *BreakerSuite) TestSuccessResetsFailures() {
+	s.fail(breakerThreshold - 1)
+	s.Require().NoError(s.breaker.allow())
+	s.breaker.record(nil)
+
+	s.fail(breakerThreshold - 1)
+	s.Assert().NoError(s.breaker.allow())
+}
+
+func (s *BreakerSuite) TestCancelledAttemptsNotCounted() {
+	s.fail(breakerThreshold - 1)
+	s.Require().NoError(s.breaker.allow())
+	s.breaker.record(errors.Annotate(context.Canceled, "connecting to controller"))
+
+	s.Assert().NoError(s.breaker.allow())
+}
+
+func (s *BreakerSuite) TestProbeAfterCooldown() {
+	s.fail(breakerThreshold)
+	s.clock.Advance(breakerCooldown)
+
+	// A single attempt is let through.
+	s.Require().NoError(s.breaker.allow())
+	s.Assert().Error(s.breaker.allow())
+
+	// Its failure opens the breaker again.
+	s.breaker.record(errors.New("connection refused"))
+	s.Assert().ErrorContains(s.breaker.allow(), "4 consecutive connection failures")
+
+	// Its success closes it.
+	s.clock.Advance(breakerCooldown)
+	s.Require().NoError(s.breaker.allow())
+	s.breaker.record(nil)
+	s.Assert().NoError(s.breaker.allow())
+}
+
+func (s *BreakerSuite) TestNilBreaker() {
+	var breaker *circuitBreaker
+	s.Assert().NoError(breaker.allow())
+	breaker.record(errors.New("boom"))
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestBreakerSuite(t *testing.T) {
+	suite.Run(t, new(BreakerSuite))
+}
@@ -0,0 +1,788 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/charm/v12"
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/client/annotations"
+	apiapplication "github.com/juju/juju/api/client/application"
+	apiclient "github.com/juju/juju/api/client/client"
+	apimachinemanager "github.com/juju/juju/api/client/machinemanager"
+	"github.com/juju/juju/api/client/modelconfig"
+	"github.com/juju/juju/charmhub/transport"
+	appbundle "github.com/juju/juju/cmd/juju/application/bundle"
+	corebase "github.com/juju/juju/core/base"
+	bundlechanges "github.com/juju/juju/core/bundle/changes"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/devices"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/rpc/params"
+	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/names/v5"
+	"github.com/juju/retry"
+	"gopkg.in/yaml.v2"
+)
+
+type bundlesClient struct {
+	SharedClient
+}
+
+type DiffBundleInput struct {
+	ModelName string
+	// Bundle is the bundle definition in YAML.
+	Bundle             string
+	IncludeAnnotations bool
+}
+
+// BundleValueDiff holds the differing bundle and model values of a
+// single attribute, rendered as strings.
+type BundleValueDiff struct {
+	Bundle string
+	Model  string
+}
+
+// BundleEntityDiff describes the differences of an application or
+// machine between the bundle and the model. Missing is "bundle" or
+// "model" when the entity only exists on the other side. Changes is
+// keyed by attribute, e.g. "charm", "options.port" or "annotations.foo".
+type BundleEntityDiff struct {
+	Missing string
+	Changes map[string]BundleValueDiff
+}
+
+type DiffBundleResponse struct {
+	// Diff is the YAML document as printed by `juju diff-bundle`.
+	Diff                 string
+	Applications         map[string]BundleEntityDiff
+	Machines             map[string]BundleEntityDiff
+	RelationsBundleOnly  []string
+	RelationsModelOnly   []string
+	BundleAndModelInSync bool
+}
+
+type DeployBundleInput struct {
+	ModelName string
+	// BundleName is the name of the Charmhub bundle deployed from
+	// Channel, the default channel if empty.
+	BundleName string
+	Channel    string
+	// Bundle is the bundle definition in YAML deployed instead. The
+	// paths of its local charms are relative to the working directory.
+	Bundle string
+	// Overlays are the overlays in YAML applied to the bundle, in
+	// order.
+	Overlays []string
+}
+
+type DeployBundleResponse struct {
+	// Applications and Machines are the names of the applications and
+	// the ids of the machines created, in order of creation.
+	Applications []string
+	Machines     []string
+	// Integrations are the integrations created, rendered as their two
+	// endpoints separated by a space, e.g. "wordpress:db mysql:db".
+	Integrations []string
+}
+
+func newBundlesClient(sc SharedClient) *bundlesClient {
+	return &bundlesClient{
+		SharedClient: sc,
+	}
+}
+
+// DiffBundle compares a bundle definition against the current state of
+// a model, equivalent to `juju diff-bundle`.
+func (c *bundlesClient) DiffBundle(ctx context.Context, input DiffBundleInput) (*DiffBundleResponse, error) {
+	bundle, err := charm.ReadBundleData(strings.NewReader(input.Bundle))
+	if err != nil {
+		return nil, errors.Annotate(err, "reading bundle")
+	}
+
+	conn, err := c.GetConnection(ctx, &input.ModelName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	status, err := apiclient.NewClient(conn, c.JujuLogger()).Status(nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting model status")
+	}
+	extractor := &bundleModelExtractor{
+		application: apiapplication.NewClient(conn),
+		annotations: annotations.NewClient(conn),
+		modelConfig: modelconfig.NewClient(conn),
+	}
+	model, err := appbundle.BuildModelRepresentation(status, extractor, true, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading model")
+	}
+
+	diff, err := bundlechanges.BuildDiff(bundlechanges.DiffConfig{
+		Bundle:             bundle,
+		Model:              model,
+		IncludeAnnotations: input.IncludeAnnotations,
+		Logger:             bundleLogger{c.SharedClient},
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "comparing bundle and model")
+	}
+
+	out, err := yaml.Marshal(diff)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	response := &DiffBundleResponse{
+		Diff:                 string(out),
+		Applications:         make(map[string]BundleEntityDiff, len(diff.Applications)),
+		Machines:             make(map[string]BundleEntityDiff, len(diff.Machines)),
+		BundleAndModelInSync: diff.Empty(),
+	}
+	for name, app := range diff.Applications {
+		response.Applications[name] = flattenApplicationDiff(app)
+	}
+	for id, machine := range diff.Machines {
+		response.Machines[id] = flattenMachineDiff(machine)
+	}
+	if diff.Relations != nil {
+		response.RelationsBundleOnly = flattenRelations(diff.Relations.BundleAdditions)
+		response.RelationsModelOnly = flattenRelations(diff.Relations.ModelAdditions)
+	}
+	return response, nil
+}
+
+// DeployBundle deploys a bundle and its overlays to a model, applying
+// the changes computed against the model the way `juju deploy` does.
+// The applications of the bundle must not exist in the model. When the
+// deploy fails, the response holds what was created with the error.
+func (c *bundlesClient) DeployBundle(ctx context.Context, input DeployBundleInput) (*DeployBundleResponse, error) {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	bundle, err := c.readBundle(ctx, conn, input)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := apiclient.NewClient(conn, c.JujuLogger()).Status(nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting model status")
+	}
+	for name := range bundle.Applications {
+		if _, ok := status.Applications[name]; ok {
+			return nil, errors.AlreadyExistsf("application %q of the bundle in model %q", name, input.ModelName)
+		}
+	}
+	extractor := &bundleModelExtractor{
+		application: apiapplication.NewClient(conn),
+		annotations: annotations.NewClient(conn),
+		modelConfig: modelconfig.NewClient(conn),
+	}
+	current, err := appbundle.BuildModelRepresentation(status, extractor, true, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading model")
+	}
+	changes, err := bundlechanges.FromData(bundlechanges.ChangesConfig{
+		Bundle: bundle,
+		Model:  current,
+		Logger: bundleLogger{c.SharedClient},
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "computing the changes of the bundle")
+	}
+
+	deployer := &bundleDeployer{
+		SharedClient: c.SharedClient,
+		conn:         conn,
+		modelName:    input.ModelName,
+		bundle:       bundle,
+		applications: newApplicationClient(c.SharedClient),
+		charms:       make(map[string]bundlechanges.AddCharmParams),
+		results:      make(map[string]string),
+		units:        make(map[string]string),
+	}
+	for _, change := range changes {
+		c.Tracef("applying bundle change", map[string]interface{}{"id": change.Id(), "description": change.Description()})
+		if err := deployer.apply(ctx, change); err != nil {
+			return &deployer.response, errors.Annotatef(err, "applying %s", strings.Join(change.Description(), ", "))
+		}
+	}
+	return &deployer.response, nil
+}
+
+// readBundle reads the bundle of input, from Charmhub if named, merged
+// with its overlays, and verifies it.
+func (c *bundlesClient) readBundle(ctx context.Context, conn api.Connection, input DeployBundleInput) (*charm.BundleData, error) {
+	bundleYAML := input.Bundle
+	if input.BundleName != "" {
+		url, err := modelCharmhubURL(conn)
+		if err != nil {
+			return nil, err
+		}
+		info, err := charmhubInfo(ctx, url, input.BundleName, input.Channel)
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading bundle %q", input.BundleName)
+		}
+		if info.Type != transport.BundleType {
+			return nil, fmt.Errorf("%q is a %s, not a bundle", input.BundleName, info.Type)
+		}
+		bundleYAML = info.DefaultRelease.Revision.BundleYAML
+	}
+	var sources []charm.BundleDataSource
+	for i, data := range append([]string{bundleYAML}, input.Overlays...) {
+		source, err := charm.StreamBundleDataSource(strings.NewReader(data), ".")
+		if err != nil && i == 0 {
+			return nil, errors.Annotate(err, "reading bundle")
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "reading overlay %d", i)
+		}
+		sources = append(sources, source)
+	}
+	bundle, err := charm.ReadAndMergeBundleData(sources...)
+	if err != nil {
+		return nil, errors.Annotate(err, "merging the overlays of the bundle")
+	}
+
+	verifyConstraints := func(s string) error {
+		_, err := constraints.Parse(s)
+		return err
+	}
+	verifyStorage := func(s string) error {
+		_, err := jujustorage.ParseConstraints(s)
+		return err
+	}
+	verifyDevices := func(s string) error {
+		_, err := devices.ParseConstraints(s)
+		return err
+	}
+	if err := bundle.VerifyLocal(".", verifyConstraints, verifyStorage, verifyDevices); err != nil {
+		return nil, errors.Annotate(err, "verifying bundle")
+	}
+	return bundle, nil
+}
+
+// bundleDeployer applies the changes of a bundle to a model. results
+// holds the entity created by each change, keyed by change id, which
+// resolves the placeholders of the changes requiring it, e.g.
+// "$deploy-1".
+type bundleDeployer struct {
+	SharedClient
+
+	conn         api.Connection
+	modelName    string
+	bundle       *charm.BundleData
+	applications *applicationsClient
+
+	// charms holds the charms of the bundle by change id, deployed
+	// with their applications.
+	charms  map[string]bundlechanges.AddCharmParams
+	results map[string]string
+	// units holds the machines of the units added, known once the
+	// units are assigned.
+	units map[string]string
+
+	response DeployBundleResponse
+}
+
+func (d *bundleDeployer) apply(ctx context.Context, change bundlechanges.Change) error {
+	switch change := change.(type) {
+	case *bundlechanges.AddCharmChange:
+		d.charms[change.Id()] = change.Params
+		d.results[change.Id()] = change.Params.Charm
+		return nil
+	case *bundlechanges.AddApplicationChange:
+		return d.addApplication(ctx, change)
+	case *bundlechanges.AddMachineChange:
+		return d.addMachine(ctx, change)
+	case *bundlechanges.AddUnitChange:
+		return d.addUnit(ctx, change)
+	case *bundlechanges.AddRelationChange:
+		return d.addRelation(change)
+	case *bundlechanges.ExposeChange:
+		return d.expose(change)
+	case *bundlechanges.ScaleChange:
+		return d.scale(change)
+	case *bundlechanges.SetAnnotationsChange:
+		return d.setAnnotations(ctx, change)
+	default:
+		return errors.NotSupportedf("bundle change %q", change.Method())
+	}
+}
+
+func (d *bundleDeployer) addApplication(ctx context.Context, change *bundlechanges.AddApplicationChange) error {
+	p := change.Params
+	ch, ok := d.charms[strings.TrimPrefix(p.Charm, "$")]
+	if !ok {
+		return errors.NotFoundf("charm %s", p.Charm)
+	}
+
+	input := &CreateApplicationInput{
+		ApplicationName:  p.Application,
+		ModelName:        d.modelName,
+		CharmChannel:     p.Channel,
+		CharmBase:        p.Base,
+		CharmRevision:    UnspecifiedRevision,
+		Units:            p.NumUnits,
+		EndpointBindings: p.EndpointBindings,
+	}
+	if isLocalCharmPath(ch.Charm) {
+		input.CharmPath = ch.Charm
+	} else {
+		charmURL, err := resolveCharmURL(ch.Charm)
+		if err != nil {
+			return err
+		}
+		input.CharmName = charmURL.Name
+	}
+	if input.CharmChannel == "" {
+		input.CharmChannel = ch.Channel
+	}
+	if input.CharmBase == "" {
+		input.CharmBase = ch.Base
+	}
+	if ch.Revision != nil {
+		input.CharmRevision = *ch.Revision
+	}
+	if spec := d.bundle.Applications[p.Application]; spec != nil {
+		input.Trust = spec.RequiresTrust
+	}
+
+	var err error
+	if input.Constraints, err = constraints.Parse(p.Constraints); err != nil {
+		return err
+	}
+	if len(p.Options) > 0 {
+		input.Config = make(map[string]string, len(p.Options))
+		for key, value := range p.Options {
+			input.Config[key] = fmt.Sprint(value)
+		}
+	}
+	if len(p.Storage) > 0 {
+		input.StorageConstraints = make(map[string]jujustorage.Constraints, len(p.Storage))
+		for label, directive := range p.Storage {
+			if input.StorageConstraints[label], err = jujustorage.ParseConstraints(directive); err != nil {
+				return err
+			}
+		}
+	}
+	if len(p.Devices) > 0 {
+		input.Devices = make(map[string]devices.Constraints, len(p.Devices))
+		for name, directive := range p.Devices {
+			if input.Devices[name], err = devices.ParseConstraints(directive); err != nil {
+				return err
+			}
+		}
+	}
+	if len(p.Resources)+len(p.LocalResources) > 0 {
+		input.Resources = make(map[string]string, len(p.Resources)+len(p.LocalResources))
+		for name, revision := range p.Resources {
+			input.Resources[name] = strconv.Itoa(revision)
+		}
+		for name, path := range p.LocalResources {
+			input.Resources[name] = path
+		}
+	}
+
+	response, err := d.applications.CreateApplication(ctx, input)
+	if response != nil {
+		d.results[change.Id()] = response.AppName
+		d.response.Applications = append(d.response.Applications, response.AppName)
+	}
+	return err
+}
+
+// isLocalCharmPath reports whether the charm of a bundle is a local
+// charm, its path being made absolute when the bundle is read.
+func isLocalCharmPath(name string) bool {
+	return strings.HasPrefix(name, ".") || filepath.IsAbs(name)
+}
+
+func (d *bundleDeployer) addMachine(ctx context.Context, change *bundlechanges.AddMachineChange) error {
+	p := change.Params
+	cons, err := constraints.Parse(p.Constraints)
+	if err != nil {
+		return err
+	}
+	machineParams := params.AddMachineParams{
+		Constraints: cons,
+		Jobs:        []model.MachineJob{model.JobHostUnits},
+	}
+	if p.Base != "" {
+		base, err := corebase.ParseBaseFromString(p.Base)
+		if err != nil {
+			return err
+		}
+		machineParams.Base = &params.Base{Name: base.OS, Channel: base.Channel.String()}
+	}
+	if p.ContainerType != "" {
+		if machineParams.ContainerType, err = instance.ParseContainerType(p.ContainerType); err != nil {
+			return err
+		}
+		if p.ParentId != "" {
+			parent, err := d.resolveMachine(ctx, p.ParentId)
+			if err != nil {
+				return err
+			}
+			// Containers are never nested when deploying.
+			if names.IsContainerMachine(parent) {
+				parent = names.NewMachineTag(parent).Parent().Id()
+			}
+			machineParams.ParentId = parent
+		}
+	}
+
+	results, err := apimachinemanager.NewClient(d.conn).AddMachines([]params.AddMachineParams{machineParams})
+	if err != nil {
+		return err
+	}
+	if results[0].Error != nil {
+		return results[0].Error
+	}
+	d.results[change.Id()] = results[0].Machine
+	d.response.Machines = append(d.response.Machines, results[0].Machine)
+	return nil
+}
+
+func (d *bundleDeployer) addUnit(ctx context.Context, change *bundlechanges.AddUnitChange) error {
+	p := change.Params
+	appName, err := d.resolve(p.Application)
+	if err != nil {
+		return err
+	}
+
+	var placements []*instance.Placement
+	var machine string
+	if p.To != "" {
+		// The placement may be of a container, e.g. lxd:$addMachines-1.
+		container, to := "", p.To
+		if i := strings.Index(to, ":"); i >= 0 {
+			container, to = to[:i], to[i+1:]
+		}
+		if machine, err = d.resolveMachine(ctx, to); err != nil {
+			return err
+		}
+		directive := machine
+		if container != "" {
+			directive = container + ":" + machine
+		}
+		placement, err := instance.ParsePlacement(directive)
+		if err != nil {
+			return err
+		}
+		placements = append(placements, placement)
+	}
+
+	units, err := apiapplication.NewClient(d.conn).AddUnits(apiapplication.AddUnitsParams{
+		ApplicationName: appName,
+		NumUnits:        1,
+		Placement:       placements,
+	})
+	if err != nil {
+		return err
+	}
+	if machine == "" {
+		// The machine of the unit is known once it is assigned, it is
+		// only waited for if a later change requires it.
+		d.results[change.Id()] = units[0]
+		return nil
+	}
+	d.results[change.Id()] = machine
+	d.units[units[0]] = machine
+	return nil
+}
+
+func (d *bundleDeployer) addRelation(change *bundlechanges.AddRelationChange) error {
+	endpoints := make([]string, 2)
+	for i, endpoint := range []string{change.Params.Endpoint1, change.Params.Endpoint2} {
+		appName, relation, _ := strings.Cut(endpoint, ":")
+		appName, err := d.resolve(appName)
+		if err != nil {
+			return err
+		}
+		endpoints[i] = appName
+		if relation != "" {
+			endpoints[i] += ":" + relation
+		}
+	}
+	_, err := apiapplication.NewClient(d.conn).AddRelation(endpoints, nil)
+	if err != nil && !params.IsCodeAlreadyExists(err) {
+		return err
+	}
+	d.response.Integrations = append(d.response.Integrations, strings.Join(endpoints, " "))
+	return nil
+}
+
+func (d *bundleDeployer) expose(change *bundlechanges.ExposeChange) error {
+	appName, err := d.resolve(change.Params.Application)
+	if err != nil {
+		return err
+	}
+	var exposedEndpoints map[string]params.ExposedEndpoint
+	if len(change.Params.ExposedEndpoints) > 0 {
+		exposedEndpoints = make(map[string]params.ExposedEndpoint, len(change.Params.ExposedEndpoints))
+		for endpoint, exposed := range change.Params.ExposedEndpoints {
+			exposedEndpoints[endpoint] = params.ExposedEndpoint{
+				ExposeToSpaces: exposed.ExposeToSpaces,
+				ExposeToCIDRs:  exposed.ExposeToCIDRs,
+			}
+		}
+	}
+	return apiapplication.NewClient(d.conn).Expose(appName, exposedEndpoints)
+}
+
+func (d *bundleDeployer) scale(change *bundlechanges.ScaleChange) error {
+	appName, err := d.resolve(change.Params.Application)
+	if err != nil {
+		return err
+	}
+	result, err := apiapplication.NewClient(d.conn).ScaleApplication(apiapplication.ScaleApplicationParams{
+		ApplicationName: appName,
+		Scale:           change.Params.Scale,
+	})
+	if err != nil {
+		return err
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+func (d *bundleDeployer) setAnnotations(ctx context.Context, change *bundlechanges.SetAnnotationsChange) error {
+	var tag string
+	switch change.Params.EntityType {
+	case bundlechanges.ApplicationType:
+		appName, err := d.resolve(change.Params.Id)
+		if err != nil {
+			return err
+		}
+		tag = names.NewApplicationTag(appName).String()
+	case bundlechanges.MachineType:
+		machine, err := d.resolveMachine(ctx, change.Params.Id)
+		if err != nil {
+			return err
+		}
+		tag = names.NewMachineTag(machine).String()
+	default:
+		return errors.NotSupportedf("annotations of %s", change.Params.EntityType)
+	}
+	return setEntityAnnotations(d.conn, tag, change.Params.Annotations)
+}
+
+// resolve returns the entity created for the placeholder, e.g.
+// "$deploy-1". Values which are not placeholders are existing entities
+// of the model, returned as is.
+func (d *bundleDeployer) resolve(placeholder string) (string, error) {
+	if !strings.HasPrefix(placeholder, "$") {
+		return placeholder, nil
+	}
+	result, ok := d.results[placeholder[1:]]
+	if !ok {
+		return "", errors.NotFoundf("result of change %s", placeholder)
+	}
+	return result, nil
+}
+
+// resolveMachine returns the machine of the placeholder, waiting for
+// the assignment of the unit if it is one.
+func (d *bundleDeployer) resolveMachine(ctx context.Context, placeholder string) (string, error) {
+	machineOrUnit, err := d.resolve(placeholder)
+	if err != nil {
+		return "", err
+	}
+	if !names.IsValidUnit(machineOrUnit) {
+		return machineOrUnit, nil
+	}
+	if machine, ok := d.units[machineOrUnit]; ok {
+		return machine, nil
+	}
+
+	client := apiclient.NewClient(d.conn, d.JujuLogger())
+	err = retry.Call(retry.CallArgs{
+		Func: func() error {
+			status, err := client.Status(nil)
+			if err != nil {
+				return err
+			}
+			for _, app := range status.Applications {
+				for name, unit := range app.Units {
+					if unit.Machine != "" {
+						d.units[name] = unit.Machine
+					}
+				}
+			}
+			if _, ok := d.units[machineOrUnit]; !ok {
+				return errUnitUnassigned
+			}
+			return nil
+		},
+		IsFatalError: func(err error) bool {
+			return !errors.Is(err, errUnitUnassigned)
+		},
+		Delay:       unitAssignmentPollDelay,
+		MaxDuration: unitAssignmentTimeout,
+		Clock:       clock.WallClock,
+		Stop:        ctx.Done(),
+	})
+	if retry.IsDurationExceeded(err) {
+		return "", errors.Timeoutf("waiting for the machine of unit %q", machineOrUnit)
+	} else if err != nil {
+		return "", err
+	}
+	return d.units[machineOrUnit], nil
+}
+
+var errUnitUnassigned = errors.New("unit not assigned to a machine")
+
+const (
+	unitAssignmentPollDelay = time.Second
+	unitAssignmentTimeout   = 5 * time.Minute
+)
+
+func flattenApplicationDiff(diff *bundlechanges.ApplicationDiff) BundleEntityDiff {
+	changes := make(map[string]BundleValueDiff)
+	addStringDiff(changes, "charm", diff.Charm)
+	addStringDiff(changes, "series", diff.Series)
+	addStringDiff(changes, "channel", diff.Channel)
+	addIntDiff(changes, "revision", diff.Revision)
+	addStringDiff(changes, "placement", diff.Placement)
+	addIntDiff(changes, "num_units", diff.NumUnits)
+	addIntDiff(changes, "scale", diff.Scale)
+	if diff.Expose != nil {
+		changes["expose"] = BundleValueDiff{
+			Bundle: fmt.Sprint(diff.Expose.Bundle),
+			Model:  fmt.Sprint(diff.Expose.Model),
+		}
+	}
+	for endpoint, d := range diff.ExposedEndpoints {
+		changes["exposed_endpoints."+endpoint] = BundleValueDiff{
+			Bundle: formatExposedEndpoint(d.Bundle),
+			Model:  formatExposedEndpoint(d.Model),
+		}
+	}
+	for key, d := range diff.Options {
+		changes["options."+key] = BundleValueDiff{
+			Bundle: formatOptionValue(d.Bundle),
+			Model:  formatOptionValue(d.Model),
+		}
+	}
+	for key, d := range diff.Annotations {
+		d := d
+		addStringDiff(changes, "annotations."+key, &d)
+	}
+	addStringDiff(changes, "constraints", diff.Constraints)
+	return BundleEntityDiff{
+		Missing: string(diff.Missing),
+		Changes: changes,
+	}
+}
+
+func flattenMachineDiff(diff *bundlechanges.MachineDiff) BundleEntityDiff {
+	changes := make(map[string]BundleValueDiff)
+	addStringDiff(changes, "series", diff.Series)
+	for key, d := range diff.Annotations {
+		d := d
+		addStringDiff(changes, "annotations."+key, &d)
+	}
+	return BundleEntityDiff{
+		Missing: string(diff.Missing),
+		Changes: changes,
+	}
+}
+
+// flattenRelations renders each relation as its two endpoints
+// separated by a space, e.g. "wordpress:db mysql:db".
+func flattenRelations(relations [][]string) []string {
+	result := make([]string, len(relations))
+	for i, relation := range relations {
+		result[i] = strings.Join(relation, " ")
+	}
+	sort.Strings(result)
+	return result
+}
+
+func addStringDiff(changes map[string]BundleValueDiff, key string, diff *bundlechanges.StringDiff) {
+	if diff == nil {
+		return
+	}
+	changes[key] = BundleValueDiff{Bundle: diff.Bundle, Model: diff.Model}
+}
+
+func addIntDiff(changes map[string]BundleValueDiff, key string, diff *bundlechanges.IntDiff) {
+	if diff == nil {
+		return
+	}
+	changes[key] = BundleValueDiff{Bundle: fmt.Sprint(diff.Bundle), Model: fmt.Sprint(diff.Model)}
+}
+
+func formatOptionValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+func formatExposedEndpoint(entry *bundlechanges.ExposedEndpointDiffEntry) string {
+	if entry == nil {
+		return ""
+	}
+	var parts []string
+	if len(entry.ExposeToSpaces) > 0 {
+		parts = append(parts, "spaces="+strings.Join(entry.ExposeToSpaces, ","))
+	}
+	if len(entry.ExposeToCIDRs) > 0 {
+		parts = append(parts, "cidrs="+strings.Join(entry.ExposeToCIDRs, ","))
+	}
+	return strings.Join(parts, " ")
+}
+
+// bundleModelExtractor implements the ModelExtractor required to build
+// the model representation used when diffing a bundle.
+type bundleModelExtractor struct {
+	application *apiapplication.Client
+	annotations *annotations.Client
+	modelConfig *modelconfig.Client
+}
+
+func (e *bundleModelExtractor) GetAnnotations(tags []string) ([]params.AnnotationsGetResult, error) {
+	return e.annotations.Get(tags)
+}
+
+func (e *bundleModelExtractor) GetConstraints(applications ...string) ([]constraints.Value, error) {
+	return e.application.GetConstraints(applications...)
+}
+
+func (e *bundleModelExtractor) GetConfig(branchName string, applications ...string) ([]map[string]interface{}, error) {
+	return e.application.GetConfig(branchName, applications...)
+}
+
+func (e *bundleModelExtractor) Sequences() (map[string]int, error) {
+	return e.modelConfig.Sequences()
+}
+
+// bundleLogger translates the logger required by bundlechanges into
+// the client trace logging.
+type bundleLogger struct {
+	sc SharedClient
+}
+
+func (l bundleLogger) Tracef(msg string, args ...interface{}) {
+	l.sc.Tracef(fmt.Sprintf(msg, args...))
+}
@@ -0,0 +1,142 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"context"
+	"testing"
+
+	bundlechanges "github.com/juju/juju/core/bundle/changes"
+	"github.com/stretchr/testify/suite"
+)
+
+type BundleSuite struct {
+	suite.Suite
+}
+
+func (s *BundleSuite) TestFlattenApplicationDiff() {
+	diff := &bundlechanges.ApplicationDiff{
+		Charm:    &bundlechanges.StringDiff{Bundle: "ch:postgresql", Model: "ch:mysql"},
+		NumUnits: &bundlechanges.IntDiff{Bundle: 3, Model: 1},
+		Expose:   &bundlechanges.BoolDiff{Bundle: true, Model: false},
+		Options: map[string]bundlechanges.OptionDiff{
+			"port": {Bundle: 5432, Model: nil},
+		},
+		Annotations: map[string]bundlechanges.StringDiff{
+			"owner": {Bundle: "team-a", Model: "team-b"},
+		},
+		ExposedEndpoints: map[string]bundlechanges.ExposedEndpointDiff{
+			"db": {Bundle: &bundlechanges.ExposedEndpointDiffEntry{ExposeToCIDRs: []string{"10.0.0.0/24"}}},
+		},
+	}
+
+	result := flattenApplicationDiff(diff)
+	s.Assert().Equal("", result.Missing)
+	s.Assert().Equal(map[string]BundleValueDiff{
+		"charm":                {Bundle: "ch:postgresql", Model: "ch:mysql"},
+		"num_units":            {Bundle: "3", Model: "1"},
+		"expose":               {Bundle: "true", Model: "false"},
+		"options.port":         {Bundle: "5432", Model: ""},
+		"annotations.owner":    {Bundle: "team-a", Model: "team-b"},
+		"exposed_endpoints.db": {Bundle: "cidrs=10.0.0.0/24", Model: ""},
+	}, result.Changes)
+}
+
+func (s *BundleSuite) TestFlattenMachineDiffMissing() {
+	result := flattenMachineDiff(&bundlechanges.MachineDiff{Missing: bundlechanges.ModelSide})
+	s.Assert().Equal("model", result.Missing)
+	s.Assert().Empty(result.Changes)
+}
+
+func (s *BundleSuite) TestFlattenRelations() {
+	result := flattenRelations([][]string{
+		{"wordpress:db", "mysql:db"},
+		{"haproxy:reverseproxy", "wordpress:website"},
+	})
+	s.Assert().Equal([]string{
+		"haproxy:reverseproxy wordpress:website",
+		"wordpress:db mysql:db",
+	}, result)
+}
+
+func (s *BundleSuite) TestReadBundleWithOverlays() {
+	client := &bundlesClient{}
+	bundle, err := client.readBundle(context.Background(), nil, DeployBundleInput{
+		Bundle: `
+applications:
+  wordpress:
+    charm: wordpress
+    num_units: 1
+  mysql:
+    charm: mysql
+    channel: 8.0/stable
+    num_units: 1
+relations:
+- - wordpress:db
+  - mysql:db
+`,
+		Overlays: []string{`
+applications:
+  wordpress:
+    num_units: 3
+    options:
+      blog-title: terraform
+`},
+	})
+	s.Require().NoError(err)
+	s.Assert().Len(bundle.Applications, 2)
+	s.Assert().Equal(3, bundle.Applications["wordpress"].NumUnits)
+	s.Assert().Equal(map[string]interface{}{"blog-title": "terraform"}, bundle.Applications["wordpress"].Options)
+	s.Assert().Equal("8.0/stable", bundle.Applications["mysql"].Channel)
+	s.Assert().Equal([][]string{{"wordpress:db", "mysql:db"}}, bundle.Relations)
+}
+
+func (s *BundleSuite) TestReadBundleInvalid() {
+	client := &bundlesClient{}
+	_, err := client.readBundle(context.Background(), nil, DeployBundleInput{
+		Bundle: `
+applications:
+  wordpress:
+    charm: wordpress
+    constraints: bad-constraint=1
+`,
+	})
+	s.Assert().ErrorContains(err, "verifying bundle")
+}
+
+func (s *BundleSuite) TestBundleDeployerResolve() {
+	deployer := &bundleDeployer{
+		results: map[string]string{"deploy-1": "wordpress", "addMachines-2": "3"},
+		units:   map[string]string{"mysql/0": "4"},
+	}
+
+	appName, err := deployer.resolve("$deploy-1")
+	s.Require().NoError(err)
+	s.Assert().Equal("wordpress", appName)
+	appName, err = deployer.resolve("mysql")
+	s.Require().NoError(err)
+	s.Assert().Equal("mysql", appName)
+	_, err = deployer.resolve("$deploy-7")
+	s.Assert().ErrorContains(err, "result of change $deploy-7 not found")
+
+	machine, err := deployer.resolveMachine(context.Background(), "$addMachines-2")
+	s.Require().NoError(err)
+	s.Assert().Equal("3", machine)
+	machine, err = deployer.resolveMachine(context.Background(), "mysql/0")
+	s.Require().NoError(err)
+	s.Assert().Equal("4", machine)
+}
+
+func (s *BundleSuite) TestIsLocalCharmPath() {
+	s.Assert().True(isLocalCharmPath("./charms/wordpress"))
+	s.Assert().True(isLocalCharmPath("/srv/charms/wordpress"))
+	s.Assert().False(isLocalCharmPath("wordpress"))
+	s.Assert().False(isLocalCharmPath("ch:wordpress"))
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestBundleSuite(t *testing.T) {
+	suite.Run(t, new(BundleSuite))
+}
@@ -0,0 +1,298 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/charm/v12"
+	"github.com/juju/errors"
+	"github.com/juju/juju/api"
+	apicharms "github.com/juju/juju/api/client/charms"
+	apimodelconfig "github.com/juju/juju/api/client/modelconfig"
+	apicommoncharm "github.com/juju/juju/api/common/charm"
+	"github.com/juju/juju/charmhub"
+	"github.com/juju/juju/charmhub/transport"
+	"github.com/juju/juju/cmd/juju/application/utils"
+	corebase "github.com/juju/juju/core/base"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/loggo"
+)
+
+type charmsClient struct {
+	SharedClient
+}
+
+type ResolveCharmInput struct {
+	// ModelName is the model used to query Charmhub, its constraints
+	// provide the default architecture.
+	ModelName    string
+	Name         string
+	Channel      string
+	Base         string
+	Architecture string
+}
+
+type ResolveCharmResponse struct {
+	Revision       int
+	Channel        string
+	Base           string
+	Architecture   string
+	SupportedBases []string
+}
+
+type ListCharmResourcesResponse struct {
+	// Revision and Channel are the revision and the channel of the
+	// charm resolved.
+	Revision int
+	Channel  string
+	// Resources are sorted by name.
+	Resources []CharmResource
+}
+
+// CharmResource is a resource declared by a charm.
+type CharmResource struct {
+	Name        string
+	Type        string
+	Path        string
+	Description string
+	// Revision is the revision of the resource published with the
+	// revision of the charm.
+	Revision    int
+	Fingerprint string
+	Size        int64
+}
+
+func newCharmsClient(sc SharedClient) *charmsClient {
+	return &charmsClient{
+		SharedClient: sc,
+	}
+}
+
+// ResolveCharm resolves a Charmhub charm name, channel and base to the
+// revision currently published.
+func (c *charmsClient) ResolveCharm(ctx context.Context, input ResolveCharmInput) (*ResolveCharmResponse, error) {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	_, resolvedOrigin, supportedBases, err := c.resolve(conn, input)
+	if err != nil {
+		return nil, err
+	}
+
+	bases := make([]string, len(supportedBases))
+	for i, b := range supportedBases {
+		bases[i] = b.String()
+	}
+	resolvedBase := resolvedOrigin.Base
+	if resolvedBase.Empty() {
+		// The base of input was validated when resolving.
+		resolvedBase, _ = corebase.ParseBaseFromString(input.Base)
+	}
+	return &ResolveCharmResponse{
+		Revision:       *resolvedOrigin.Revision,
+		Channel:        resolvedOrigin.CharmChannel().String(),
+		Base:           resolvedBase.String(),
+		Architecture:   resolvedOrigin.Architecture,
+		SupportedBases: bases,
+	}, nil
+}
+
+// ListCharmResources lists the resources declared by the Charmhub charm
+// resolved by input, with the revisions published with it.
+func (c *charmsClient) ListCharmResources(ctx context.Context, input ResolveCharmInput) (*ListCharmResourcesResponse, error) {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	charmURL, resolvedOrigin, _, err := c.resolve(conn, input)
+	if err != nil {
+		return nil, err
+	}
+	charmURL = charmURL.WithRevision(*resolvedOrigin.Revision)
+	resources, err := apicharms.NewClient(conn).ListCharmResources(charmURL.String(), resolvedOrigin)
+	if err != nil {
+		return nil, errors.Annotatef(err, "listing resources of charm %q", input.Name)
+	}
+
+	response := &ListCharmResourcesResponse{
+		Revision:  *resolvedOrigin.Revision,
+		Channel:   resolvedOrigin.CharmChannel().String(),
+		Resources: make([]CharmResource, len(resources)),
+	}
+	for i, r := range resources {
+		response.Resources[i] = CharmResource{
+			Name:        r.Name,
+			Type:        r.Type.String(),
+			Path:        r.Path,
+			Description: r.Description,
+			Revision:    r.Revision,
+			Fingerprint: r.Fingerprint.String(),
+			Size:        r.Size,
+		}
+	}
+	sort.Slice(response.Resources, func(i, j int) bool {
+		return response.Resources[i].Name < response.Resources[j].Name
+	})
+	return response, nil
+}
+
+// CharmConfigInput identifies the charm to read the config options of.
+type CharmConfigInput struct {
+	ModelName string
+	CharmName string
+	Channel   string
+	// CharmPath is the path of a local charm archive, read instead
+	// of Charmhub.
+	CharmPath string
+}
+
+// CharmConfig returns the config options declared by the charm, read
+// from the Charmhub server of the model or the local charm archive.
+// The options are those of the release of the charm in the channel,
+// the default release if none.
+func (c *charmsClient) CharmConfig(ctx context.Context, input *CharmConfigInput) (*charm.Config, error) {
+	if input.CharmPath != "" {
+		ch, err := readLocalCharm(input.CharmPath, input.CharmName)
+		if err != nil {
+			return nil, err
+		}
+		return ch.Config(), nil
+	}
+
+	conn, err := c.GetConnection(ctx, &input.ModelName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	url, err := modelCharmhubURL(conn)
+	if err != nil {
+		return nil, err
+	}
+	c.Tracef("CharmConfig", map[string]interface{}{"charm": input.CharmName, "channel": input.Channel, "charmhub-url": url})
+	return charmhubCharmConfig(ctx, url, input.CharmName, input.Channel)
+}
+
+// resolve resolves the charm of input to the charm URL and origin of
+// the revision currently published.
+func (c *charmsClient) resolve(conn api.Connection, input ResolveCharmInput) (*charm.URL, apicommoncharm.Origin, []corebase.Base, error) {
+	channel, err := parseCharmChannel(input.Channel)
+	if err != nil {
+		return nil, apicommoncharm.Origin{}, nil, err
+	}
+	var base corebase.Base
+	if input.Base != "" {
+		base, err = corebase.ParseBaseFromString(input.Base)
+		if err != nil {
+			return nil, apicommoncharm.Origin{}, nil, errors.Annotatef(err, "parsing base %q", input.Base)
+		}
+	}
+	charmURL, err := resolveCharmURL(input.Name)
+	if err != nil {
+		return nil, apicommoncharm.Origin{}, nil, err
+	}
+	if charmURL.Revision != UnspecifiedRevision {
+		return nil, apicommoncharm.Origin{}, nil, fmt.Errorf("cannot specify revision in a charm name")
+	}
+
+	modelCons, err := apimodelconfig.NewClient(conn).GetModelConstraints()
+	if err != nil {
+		return nil, apicommoncharm.Origin{}, nil, err
+	}
+	var cons constraints.Value
+	if input.Architecture != "" {
+		cons.Arch = &input.Architecture
+	}
+	platform := utils.MakePlatform(cons, base, modelCons)
+	origin, err := utils.MakeOrigin(charm.CharmHub, UnspecifiedRevision, channel, platform)
+	if err != nil {
+		return nil, apicommoncharm.Origin{}, nil, err
+	}
+
+	resolvedURL, resolvedOrigin, supportedBases, err := resolveCharm(apicharms.NewClient(conn), charmURL, origin)
+	if err != nil {
+		return nil, apicommoncharm.Origin{}, nil, errors.Annotatef(err, "resolving charm %q", input.Name)
+	}
+	if resolvedOrigin.Type == "bundle" {
+		return nil, apicommoncharm.Origin{}, nil, errors.NotSupportedf("resolving bundles")
+	}
+	if resolvedOrigin.Revision == nil {
+		return nil, apicommoncharm.Origin{}, nil, errors.NotFoundf("revision of charm %q in channel %q", input.Name, channel)
+	}
+	c.Tracef("resolveCharm returned", map[string]interface{}{"resolvedOrigin": resolvedOrigin, "supportedBases": supportedBases})
+	return resolvedURL, resolvedOrigin, supportedBases, nil
+}
+
+// parseCharmChannel parses the channel of a charm to resolve. Without
+// channel, the zero channel is returned, which Charmhub resolves to the
+// default channel of the charm.
+func parseCharmChannel(channel string) (charm.Channel, error) {
+	if channel == "" {
+		return charm.Channel{}, nil
+	}
+	parsed, err := charm.ParseChannelNormalize(channel)
+	if err != nil {
+		return charm.Channel{}, errors.Annotatef(err, "parsing channel %q", channel)
+	}
+	return parsed, nil
+}
+
+// modelCharmhubURL returns the URL of the Charmhub server of the model.
+func modelCharmhubURL(conn api.Connection) (string, error) {
+	attrs, err := apimodelconfig.NewClient(conn).ModelGet()
+	if err != nil {
+		return "", err
+	}
+	modelConfig, err := config.New(config.NoDefaults, attrs)
+	if err != nil {
+		return "", err
+	}
+	url, _ := modelConfig.CharmHubURL()
+	return url, nil
+}
+
+// charmhubInfo returns the information of the charm or bundle read from
+// the Charmhub server at url, the default release being the one of the
+// channel if set.
+func charmhubInfo(ctx context.Context, url, name, channel string) (transport.InfoResponse, error) {
+	client, err := charmhub.NewClient(charmhub.Config{
+		URL:    url,
+		Logger: loggo.GetLogger("terraform-provider-juju.charmhub"),
+	})
+	if err != nil {
+		return transport.InfoResponse{}, err
+	}
+	var options []charmhub.InfoOption
+	if channel != "" {
+		options = append(options, charmhub.WithInfoChannel(channel))
+	}
+	return client.Info(ctx, name, options...)
+}
+
+// charmhubCharmConfig returns the config options of the charm read from
+// the Charmhub server at url.
+func charmhubCharmConfig(ctx context.Context, url, charmName, channel string) (*charm.Config, error) {
+	info, err := charmhubInfo(ctx, url, charmName, channel)
+	if err != nil {
+		return nil, err
+	}
+	if info.Type != transport.CharmType {
+		return nil, fmt.Errorf("%q is a %s, not a charm", charmName, info.Type)
+	}
+	configYAML := info.DefaultRelease.Revision.ConfigYAML
+	if configYAML == "" {
+		return charm.NewConfig(), nil
+	}
+	return charm.ReadConfig(strings.NewReader(configYAML))
+}
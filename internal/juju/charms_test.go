@@ -0,0 +1,103 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/juju/charm/v12"
+	"github.com/stretchr/testify/suite"
+)
+
+type CharmsSuite struct {
+	suite.Suite
+
+	server   *httptest.Server
+	channels []string
+}
+
+func (s *CharmsSuite) SetupTest() {
+	s.channels = nil
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/charms/info/postgresql" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		s.channels = append(s.channels, r.URL.Query().Get("channel"))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"type": "charm",
+			"name": "postgresql",
+			"id":   "postgresql-id",
+			"default-release": map[string]interface{}{
+				"revision": map[string]interface{}{
+					"config-yaml": `
+options:
+  plugin_citext_enable:
+    type: boolean
+    default: false
+  durability_synchronous_commit:
+    type: string
+    default: "on"
+  experimental_max_connections:
+    type: int
+`,
+				},
+			},
+		})
+	}))
+	s.T().Cleanup(s.server.Close)
+}
+
+func (s *CharmsSuite) TestCharmhubCharmConfig() {
+	cfg, err := charmhubCharmConfig(context.Background(), s.server.URL, "postgresql", "14/stable")
+	s.Require().NoError(err)
+	s.Assert().Equal([]string{"14/stable"}, s.channels)
+	s.Require().Len(cfg.Options, 3)
+	s.Assert().Equal("boolean", cfg.Options["plugin_citext_enable"].Type)
+
+	settings, err := cfg.ParseSettingsStrings(map[string]string{
+		"plugin_citext_enable":         "true",
+		"experimental_max_connections": "100",
+	})
+	s.Require().NoError(err)
+	s.Assert().Equal(true, settings["plugin_citext_enable"])
+	s.Assert().Equal(int64(100), settings["experimental_max_connections"])
+
+	_, err = cfg.ParseSettingsStrings(map[string]string{"max_connections": "100"})
+	s.Assert().ErrorContains(err, `unknown option "max_connections"`)
+}
+
+func (s *CharmsSuite) TestCharmhubCharmConfigNotFound() {
+	_, err := charmhubCharmConfig(context.Background(), s.server.URL, "mysql", "")
+	s.Assert().Error(err)
+}
+
+func (s *CharmsSuite) TestParseCharmChannel() {
+	// Without channel, Charmhub resolves the default channel.
+	channel, err := parseCharmChannel("")
+	s.Require().NoError(err)
+	s.Assert().Equal(charm.Channel{}, channel)
+
+	channel, err = parseCharmChannel("14/edge")
+	s.Require().NoError(err)
+	s.Assert().Equal("14/edge", channel.String())
+
+	channel, err = parseCharmChannel("stable")
+	s.Require().NoError(err)
+	s.Assert().Equal("stable", channel.String())
+
+	_, err = parseCharmChannel("14/unknown/branch/extra")
+	s.Assert().ErrorContains(err, `parsing channel "14/unknown/branch/extra"`)
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestCharmsSuite(t *testing.T) {
+	suite.Run(t, new(CharmsSuite))
+}
@@ -6,16 +6,23 @@ package juju
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/client/modelmanager"
 	"github.com/juju/juju/api/connector"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/utils/v3"
+	"github.com/juju/version/v2"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -37,21 +44,55 @@ type ControllerConfiguration struct {
 	CACert              string
 	ClientID            string
 	ClientSecret        string
+	// TransientRetryTimeout is how long calls failing while the
+	// controller or model is upgrading or migrating are retried.
+	// They are not retried when zero.
+	TransientRetryTimeout time.Duration
+	// ExperimentalFeatures are the experimental features of the
+	// provider enabled, see Client.ExperimentalFeatureEnabled.
+	ExperimentalFeatures []string
+	// KeepStateWhenUnreachable is set when the reads of resources
+	// against an unreachable controller keep their prior state rather
+	// than failing, see Client.KeepStateWhenUnreachable.
+	KeepStateWhenUnreachable bool
+	// Tracing configures the export of traces, disabled by default.
+	Tracing TracingConfig
 }
 
 type Client struct {
-	Applications applicationsClient
-	Machines     machinesClient
-	Credentials  credentialsClient
-	Integrations integrationsClient
-	Models       modelsClient
-	Offers       offersClient
-	SSHKeys      sshKeysClient
-	Users        usersClient
-	Secrets      secretsClient
+	Annotations  AnnotationsClient
+	Applications ApplicationsClient
+	Backups      BackupsClient
+	Bundles      BundlesClient
+	Charms       CharmsClient
+	Machines     MachinesClient
+	Credentials  CredentialsClient
+	Exec         ExecClient
+	Integrations IntegrationsClient
+	Models       ModelsClient
+	Offers       OffersClient
+	SSHKeys      SSHKeysClient
+	Status       StatusClient
+	Users        UsersClient
+	Secrets      SecretsClient
+
+	// controller is the value of the LogFieldController field.
+	controller string
+	// experimentalFeatures are the experimental features enabled.
+	experimentalFeatures []string
+
+	shared *sharedClient
+}
+
+// ExperimentalFeatureEnabled reports whether the experimental feature
+// of the provider is enabled.
+func (c *Client) ExperimentalFeatureEnabled(feature string) bool {
+	return slices.Contains(c.experimentalFeatures, feature)
 }
 
 type jujuModel struct {
+	name      string
+	owner     string
 	uuid      string
 	modelType model.ModelType
 }
@@ -60,12 +101,52 @@ func (j jujuModel) String() string {
 	return fmt.Sprintf("uuid(%s) type(%s)", j.uuid, j.modelType.String())
 }
 
+// qualifiedName returns the name of the model qualified by its owner.
+func (j jujuModel) qualifiedName() string {
+	return j.owner + "/" + j.name
+}
+
+// splitModelName splits a model name optionally qualified by the name
+// of its owner, as in owner/name, into its parts. owner is empty if
+// not qualified.
+func splitModelName(modelName string) (owner, name string) {
+	if i := strings.Index(modelName, "/"); i >= 0 {
+		return modelName[:i], modelName[i+1:]
+	}
+	return "", modelName
+}
+
 type sharedClient struct {
 	controllerConfig ControllerConfiguration
 
+	// modelUUIDcache holds the models known to the client, keyed by
+	// UUID.
 	modelUUIDcache map[string]jujuModel
 	modelUUIDmu    sync.Mutex
 
+	// connections holds the open connections, keyed by model UUID.
+	connections *connectionPool
+
+	// statuses shares the status of the models between the reads of
+	// a refresh.
+	statuses *statusCoalescer
+
+	// breaker fails connections fast when the controller is
+	// consistently failing.
+	breaker *circuitBreaker
+
+	// stats counts the work of the client, see Client.Stats.
+	stats clientStats
+
+	// tracer records the spans of the operations, nil when tracing is
+	// disabled.
+	tracer *tracer
+
+	// controllerVersion is the version of the controller, known once
+	// connected.
+	controllerVersion   version.Number
+	controllerVersionMu sync.Mutex
+
 	// subCtx is the context created with the new tflog subsystem for applications.
 	subCtx context.Context
 }
@@ -80,37 +161,92 @@ func NewClient(ctx context.Context, config ControllerConfiguration) (*Client, er
 	sc := &sharedClient{
 		controllerConfig: config,
 		modelUUIDcache:   make(map[string]jujuModel),
-		subCtx:           tflog.NewSubsystem(ctx, LogJujuClient),
+		connections:      newConnectionPool(),
+		statuses:         newStatusCoalescer(),
+		breaker:          newCircuitBreaker(clock.WallClock),
 	}
+	sc.subCtx = tflog.SubsystemSetField(tflog.NewSubsystem(ctx, LogJujuClient), LogJujuClient,
+		LogFieldController, controllerField(config))
+	sc.subCtx = redactSubsystemLogs(sc.subCtx, LogJujuClient, config)
+	sc.tracer = newTracer(config.Tracing, sc.Warnf)
 
 	return &Client{
-		Applications: *newApplicationClient(sc),
-		Credentials:  *newCredentialsClient(sc),
-		Integrations: *newIntegrationsClient(sc),
-		Machines:     *newMachinesClient(sc),
-		Models:       *newModelsClient(sc),
-		Offers:       *newOffersClient(sc),
-		SSHKeys:      *newSSHKeysClient(sc),
-		Users:        *newUsersClient(sc),
-		Secrets:      *newSecretsClient(sc),
+		Annotations:  newAnnotationsClient(sc),
+		Applications: newApplicationClient(sc),
+		Backups:      newBackupsClient(sc),
+		Bundles:      newBundlesClient(sc),
+		Charms:       newCharmsClient(sc),
+		Credentials:  newCredentialsClient(sc),
+		Exec:         newExecClient(sc),
+		Integrations: newIntegrationsClient(sc),
+		Machines:     newMachinesClient(sc),
+		Models:       newModelsClient(sc),
+		Offers:       newOffersClient(sc),
+		SSHKeys:      newSSHKeysClient(sc),
+		Status:       newStatusClient(sc),
+		Users:        newUsersClient(sc),
+		Secrets:      newSecretsClient(sc),
+		controller:   controllerField(config),
+
+		experimentalFeatures: config.ExperimentalFeatures,
+		shared:               sc,
 	}, nil
 }
 
+// KeepStateWhenUnreachable reports whether the reads of resources
+// against an unreachable controller keep their prior state, with a
+// warning, rather than failing. c may be nil, before the provider is
+// configured.
+func (c *Client) KeepStateWhenUnreachable() bool {
+	return c != nil && c.shared != nil && c.shared.controllerConfig.KeepStateWhenUnreachable
+}
+
+// ControllerVersion returns the version of the controller, false if
+// it is not known yet. The provider connects to the controller when
+// configured, so it is known to resources and data sources. c may be
+// nil, before the provider is configured.
+func (c *Client) ControllerVersion() (version.Number, bool) {
+	if c == nil || c.shared == nil {
+		return version.Number{}, false
+	}
+	c.shared.controllerVersionMu.Lock()
+	defer c.shared.controllerVersionMu.Unlock()
+	return c.shared.controllerVersion, c.shared.controllerVersion != version.Zero
+}
+
 // GetConnection returns a juju connection for use creating juju
-// api clients given the provided model name.
-func (sc *sharedClient) GetConnection(modelName *string) (api.Connection, error) {
+// api clients given the provided model name. Connections are shared
+// between concurrent operations, closing the returned connection
+// releases it to the pool.
+func (sc *sharedClient) GetConnection(ctx context.Context, modelName *string) (api.Connection, error) {
 	var modelUUID string
 	if modelName != nil {
 		var err error
-		modelUUID, err = sc.ModelUUID(*modelName)
+		modelUUID, err = sc.ModelUUID(ctx, *modelName)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	if conn := sc.connections.get(modelUUID); conn != nil {
+		sc.stats.connectionsReused.Add(1)
+		return sc.wrapConnection(ctx, conn), nil
+	}
+
+	if err := sc.breaker.allow(); err != nil {
+		sc.stats.failedFast.Add(1)
+		return nil, err
+	}
+
+	// The span covers dialing the controller and logging in, which the
+	// connector does at once.
+	_, span := sc.tracer.start(ctx, "juju.Connect", trace.SpanKindClient, map[string]string{
+		SpanAttributeController: controllerField(sc.controllerConfig),
+		SpanAttributeModelUUID:  modelUUID,
+	})
+
 	dialOptions := func(do *api.DialOpts) {
-		//this is set as a const above, in case we need to use it elsewhere to manage connection timings
-		do.Timeout = connectionTimeout
+		do.Timeout = dialTimeout(ctx)
 		//default is 2 seconds, as we are changing the overall timeout it makes sense to reduce this as well
 		do.RetryDelay = 1 * time.Second
 	}
@@ -125,45 +261,161 @@ func (sc *sharedClient) GetConnection(modelName *string) (api.Connection, error)
 		ModelUUID:           modelUUID,
 	}, dialOptions)
 	if err != nil {
+		sc.breaker.record(err)
+		span.End(err)
 		return nil, err
 	}
 
-	conn, err := connr.Connect()
+	var conn api.Connection
+	err = sc.retryTransientErrors(ctx, func() error {
+		var err error
+		sc.stats.dials.Add(1)
+		conn, err = connectWithContext(ctx, connr)
+		if err != nil {
+			sc.stats.dialFailures.Add(1)
+		}
+		return err
+	})
+	sc.breaker.record(err)
+	span.End(err)
 	if err != nil {
 		sc.Errorf(err, "connection not established")
 		return nil, err
 	}
-	return conn, nil
+	sc.Tracef("connection established", modelFields(modelUUID))
+	if serverVersion, ok := conn.ServerVersion(); ok {
+		sc.controllerVersionMu.Lock()
+		sc.controllerVersion = serverVersion
+		sc.controllerVersionMu.Unlock()
+	}
+	return sc.wrapConnection(ctx, sc.connections.put(modelUUID, conn)), nil
+}
+
+// dialTimeout returns how long dialing the controller is retried: the
+// time left to the deadline of ctx, e.g. the timeout of the operation
+// of a resource, or connectionTimeout without one.
+func dialTimeout(ctx context.Context) time.Duration {
+	return timeoutFromContext(ctx, connectionTimeout)
+}
+
+// wrapConnection wraps a connection for the operation of ctx.
+func (sc *sharedClient) wrapConnection(ctx context.Context, conn api.Connection) api.Connection {
+	return sc.withTracing(ctx, sc.withTransientRetry(ctx, conn))
+}
+
+// withTransientRetry wraps the connection to retry the API calls failing
+// while the controller or model is upgrading or migrating.
+func (sc *sharedClient) withTransientRetry(ctx context.Context, conn api.Connection) api.Connection {
+	if sc.controllerConfig.TransientRetryTimeout <= 0 {
+		return conn
+	}
+	return &transientRetryConnection{Connection: conn, ctx: ctx, sc: sc}
 }
 
-func (sc *sharedClient) ModelUUID(modelName string) (string, error) {
+// connectWithContext establishes the connection, returning early when
+// the context is done. The API client does not accept a context, so a
+// connection established after that is closed in the background.
+func connectWithContext(ctx context.Context, connr connector.Connector) (api.Connection, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, errors.Annotate(err, "connecting to controller")
+	}
+
+	type result struct {
+		conn api.Connection
+		err  error
+	}
+	resultCh := make(chan result, 1)
+	go func() {
+		conn, err := connr.Connect()
+		resultCh <- result{conn: conn, err: err}
+	}()
+
+	select {
+	case res := <-resultCh:
+		return res.conn, res.err
+	case <-ctx.Done():
+		go func() {
+			if res := <-resultCh; res.err == nil {
+				_ = res.conn.Close()
+			}
+		}()
+		return nil, errors.Annotate(ctx.Err(), "connecting to controller")
+	}
+}
+
+// ModelUUID returns the UUID of the model identified by modelName,
+// which is either the name of the model, optionally qualified by the
+// name of its owner as in owner/name, or the UUID of the model.
+// Referencing a model by UUID keeps working if it is renamed.
+func (sc *sharedClient) ModelUUID(ctx context.Context, modelName string) (string, error) {
 	sc.modelUUIDmu.Lock()
 	defer sc.modelUUIDmu.Unlock()
 	dataMap := make(map[string]interface{})
 	// How to tell if logging level is Trace?
-	for k, v := range sc.modelUUIDcache {
-		dataMap[k] = v.String()
+	for _, v := range sc.modelUUIDcache {
+		dataMap[v.qualifiedName()] = v.String()
 	}
 	sc.Tracef(fmt.Sprintf("ModelUUID cache looking for %q", modelName), dataMap)
-	if modelWithName, ok := sc.modelUUIDcache[modelName]; ok {
-		sc.Tracef(fmt.Sprintf("Found uuid for %q in cache", modelName))
+	modelWithName, ok, err := sc.cachedModel(modelName)
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		sc.stats.modelCacheHits.Add(1)
+		sc.Tracef(fmt.Sprintf("Found uuid for %q in cache", modelName), modelFields(modelWithName.uuid))
 		return modelWithName.uuid, nil
 	}
-	if err := sc.fillModelCache(); err != nil {
+	sc.stats.modelCacheMisses.Add(1)
+	if err := sc.fillModelCache(ctx); err != nil {
 		return "", err
 	}
-	if modelWithName, ok := sc.modelUUIDcache[modelName]; ok {
-		sc.Tracef(fmt.Sprintf("Found uuid for %q in cache on 2nd attempt", modelName))
+	modelWithName, ok, err = sc.cachedModel(modelName)
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		sc.Tracef(fmt.Sprintf("Found uuid for %q in cache on 2nd attempt", modelName), modelFields(modelWithName.uuid))
 		return modelWithName.uuid, nil
 	}
 	return "", errors.NotFoundf("model %q", modelName)
 }
 
+// cachedModel returns the cached model identified by its UUID or its
+// name, optionally qualified by the name of its owner. A NotValid error
+// is returned if an unqualified name matches the models of several
+// owners. Callers are expected to hold the modelUUIDmu lock.
+func (sc *sharedClient) cachedModel(modelName string) (jujuModel, bool, error) {
+	if m, ok := sc.modelUUIDcache[modelName]; ok && utils.IsValidUUIDString(modelName) {
+		return m, true, nil
+	}
+	owner, name := splitModelName(modelName)
+	var matches []jujuModel
+	for _, m := range sc.modelUUIDcache {
+		if m.name == name && (owner == "" || m.owner == owner) {
+			matches = append(matches, m)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return jujuModel{}, false, nil
+	case 1:
+		return matches[0], true, nil
+	}
+	qualifiedNames := make([]string, len(matches))
+	for i, m := range matches {
+		qualifiedNames[i] = m.qualifiedName()
+	}
+	sort.Strings(qualifiedNames)
+	return jujuModel{}, false, errors.NewNotValid(nil, fmt.Sprintf(
+		"model name %q is ambiguous, it matches the models %s: qualify it with the owner of the model, e.g. %q",
+		modelName, strings.Join(qualifiedNames, ", "), qualifiedNames[0]))
+}
+
 // fillModelCache checks with the juju controller for all
 // models and puts the relevant data in the model info cache.
 // Callers are expected to hold the modelUUIDmu lock.
-func (sc *sharedClient) fillModelCache() error {
-	conn, err := sc.GetConnection(nil)
+func (sc *sharedClient) fillModelCache(ctx context.Context) error {
+	conn, err := sc.GetConnection(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -177,20 +429,30 @@ func (sc *sharedClient) fillModelCache() error {
 	if err != nil {
 		return err
 	}
+	// Replace the cache so that the former names of renamed models
+	// are forgotten.
+	cache := make(map[string]jujuModel, len(modelSummaries))
 	for _, modelSummary := range modelSummaries {
 		modelWithName := jujuModel{
+			name:      modelSummary.Name,
+			owner:     modelSummary.Owner,
 			uuid:      modelSummary.UUID,
 			modelType: modelSummary.Type,
 		}
-		sc.modelUUIDcache[modelSummary.Name] = modelWithName
+		cache[modelSummary.UUID] = modelWithName
 	}
+	sc.modelUUIDcache = cache
 	return nil
 }
 
-func (sc *sharedClient) ModelType(modelName string) (model.ModelType, error) {
+func (sc *sharedClient) ModelType(_ context.Context, modelName string) (model.ModelType, error) {
 	sc.modelUUIDmu.Lock()
 	defer sc.modelUUIDmu.Unlock()
-	if modelWithName, ok := sc.modelUUIDcache[modelName]; ok {
+	modelWithName, ok, err := sc.cachedModel(modelName)
+	if err != nil {
+		return model.ModelType(""), err
+	}
+	if ok {
 		return modelWithName.modelType, nil
 	}
 
@@ -199,22 +461,20 @@ func (sc *sharedClient) ModelType(modelName string) (model.ModelType, error) {
 
 func (sc *sharedClient) RemoveModel(modelUUID string) {
 	sc.modelUUIDmu.Lock()
-	var modelName string
-	for k, v := range sc.modelUUIDcache {
-		if v.uuid == modelUUID {
-			modelName = k
-			break
-		}
-	}
-	if modelName != "" {
-		delete(sc.modelUUIDcache, modelName)
-	}
+	delete(sc.modelUUIDcache, modelUUID)
 	sc.modelUUIDmu.Unlock()
+	sc.connections.evict(modelUUID)
+	sc.statuses.forget(modelUUID)
 }
 
+// AddModel adds the model to the cache. modelName is qualified by the
+// name of the owner of the model, as in owner/name.
 func (sc *sharedClient) AddModel(modelName, modelUUID string, modelType model.ModelType) {
+	owner, name := splitModelName(modelName)
 	sc.modelUUIDmu.Lock()
-	sc.modelUUIDcache[modelName] = jujuModel{
+	sc.modelUUIDcache[modelUUID] = jujuModel{
+		name:      name,
+		owner:     owner,
 		uuid:      modelUUID,
 		modelType: modelType,
 	}
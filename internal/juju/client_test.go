@@ -0,0 +1,243 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/utils/v3"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type ClientSuite struct {
+	JujuSuite
+}
+
+// blockingConnector returns its connection once release is closed.
+type blockingConnector struct {
+	conn    api.Connection
+	started chan struct{}
+	release chan struct{}
+}
+
+func (c blockingConnector) Connect(...api.DialOption) (api.Connection, error) {
+	if c.started != nil {
+		close(c.started)
+	}
+	<-c.release
+	return c.conn, nil
+}
+
+func (s *ClientSuite) TestConnectWithContext() {
+	ctlr := s.setupMocks(s.T())
+	defer ctlr.Finish()
+
+	release := make(chan struct{})
+	close(release)
+	conn, err := connectWithContext(context.Background(), blockingConnector{conn: s.mockConnection, release: release})
+	s.Require().NoError(err)
+	s.Assert().Equal(s.mockConnection, conn)
+}
+
+func (s *ClientSuite) TestConnectWithContextCancelled() {
+	ctlr := gomock.NewController(s.T())
+	defer ctlr.Finish()
+
+	closed := make(chan struct{})
+	mockConnection := NewMockConnection(ctlr)
+	mockConnection.EXPECT().Close().DoAndReturn(func() error {
+		close(closed)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	release := make(chan struct{})
+	go func() {
+		<-started
+		cancel()
+	}()
+	_, err := connectWithContext(ctx, blockingConnector{conn: mockConnection, started: started, release: release})
+	s.Require().ErrorIs(err, context.Canceled)
+
+	// The connection established after cancellation is closed.
+	close(release)
+	<-closed
+}
+
+func (s *ClientSuite) TestDialTimeout() {
+	s.Assert().Equal(connectionTimeout, dialTimeout(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	timeout := dialTimeout(ctx)
+	s.Assert().Greater(timeout, time.Duration(0))
+	s.Assert().LessOrEqual(timeout, time.Second)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+	timeout = dialTimeout(ctx)
+	s.Assert().Greater(timeout, 29*time.Minute)
+	s.Assert().LessOrEqual(timeout, 30*time.Minute)
+}
+
+func (s *ClientSuite) TestLogFields() {
+	s.Assert().Equal("10.0.0.1:17070,10.0.0.2:17070", controllerField(ControllerConfiguration{
+		ControllerAddresses: []string{"10.0.0.1:17070", "10.0.0.2:17070"},
+	}))
+	s.Assert().Equal(map[string]interface{}{
+		LogFieldModelUUID: "model-uuid",
+		"application":     "test",
+	}, modelFields("model-uuid", map[string]interface{}{"application": "test"}))
+}
+
+func (s *ClientSuite) TestModelUUIDByNameOrUUID() {
+	uuid := "c9b3a1f4-0f5f-4a8e-8c4e-2a4c7b6d5e3f"
+	sc := &sharedClient{
+		modelUUIDcache: map[string]jujuModel{},
+		subCtx:         context.Background(),
+	}
+	sc.AddModel("admin/test", uuid, model.IAAS)
+
+	for _, modelName := range []string{"test", "admin/test", uuid} {
+		modelUUID, err := sc.ModelUUID(context.Background(), modelName)
+		s.Require().NoError(err)
+		s.Assert().Equal(uuid, modelUUID)
+
+		modelType, err := sc.ModelType(context.Background(), modelName)
+		s.Require().NoError(err)
+		s.Assert().Equal(model.IAAS, modelType)
+	}
+}
+
+func (s *ClientSuite) TestModelUUIDAmbiguous() {
+	sc := &sharedClient{
+		modelUUIDcache: map[string]jujuModel{},
+		subCtx:         context.Background(),
+	}
+	sc.AddModel("admin/test", "c9b3a1f4-0f5f-4a8e-8c4e-2a4c7b6d5e3f", model.IAAS)
+	sc.AddModel("bob/test", "0d5e2f7a-3c1b-4e9d-a8f6-7b2c4d1e9f30", model.CAAS)
+
+	_, err := sc.ModelUUID(context.Background(), "test")
+	s.Require().Error(err)
+	s.Assert().True(errors.Is(err, errors.NotValid))
+	s.Assert().Contains(err.Error(), "admin/test, bob/test")
+
+	modelUUID, err := sc.ModelUUID(context.Background(), "bob/test")
+	s.Require().NoError(err)
+	s.Assert().Equal("0d5e2f7a-3c1b-4e9d-a8f6-7b2c4d1e9f30", modelUUID)
+}
+
+// fakeSummaryWatcher returns its batches of abstracts, then fails.
+type fakeSummaryWatcher struct {
+	batches [][]params.ModelAbstract
+	stopped bool
+}
+
+func (w *fakeSummaryWatcher) Next() ([]params.ModelAbstract, error) {
+	if len(w.batches) == 0 {
+		return nil, errors.New("watcher stopped")
+	}
+	batch := w.batches[0]
+	w.batches = w.batches[1:]
+	return batch, nil
+}
+
+func (w *fakeSummaryWatcher) Stop() error {
+	w.stopped = true
+	return nil
+}
+
+func (s *ClientSuite) TestWatchModels() {
+	ctlr := s.setupMocks(s.T())
+	defer ctlr.Finish()
+
+	sc := &sharedClient{
+		modelUUIDcache: map[string]jujuModel{},
+		connections:    newConnectionPool(),
+		statuses:       newStatusCoalescer(),
+		subCtx:         context.Background(),
+	}
+	sc.AddModel("admin/test", "c9b3a1f4-0f5f-4a8e-8c4e-2a4c7b6d5e3f", model.IAAS)
+	sc.AddModel("admin/other", "0d5e2f7a-3c1b-4e9d-a8f6-7b2c4d1e9f30", model.CAAS)
+
+	watcher := &fakeSummaryWatcher{batches: [][]params.ModelAbstract{{
+		{UUID: "c9b3a1f4-0f5f-4a8e-8c4e-2a4c7b6d5e3f", Name: "renamed"},
+		{UUID: "7a1c9e3b-5d2f-4b8a-9e6c-1f3d5b7a9c2e", Name: "new"},
+	}, {
+		{UUID: "0d5e2f7a-3c1b-4e9d-a8f6-7b2c4d1e9f30", Removed: true},
+	}}}
+	sc.watchModels(s.mockConnection, watcher)
+	s.Assert().True(watcher.stopped)
+
+	modelUUID, err := sc.ModelUUID(context.Background(), "admin/renamed")
+	s.Require().NoError(err)
+	s.Assert().Equal("c9b3a1f4-0f5f-4a8e-8c4e-2a4c7b6d5e3f", modelUUID)
+	s.Assert().Len(sc.modelUUIDcache, 1)
+}
+
+func (s *ClientSuite) TestConcurrentModelCache() {
+	uuid := "c9b3a1f4-0f5f-4a8e-8c4e-2a4c7b6d5e3f"
+	sc := &sharedClient{
+		modelUUIDcache: map[string]jujuModel{},
+		connections:    newConnectionPool(),
+		statuses:       newStatusCoalescer(),
+		subCtx:         context.Background(),
+	}
+	sc.AddModel("admin/test", uuid, model.IAAS)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			otherUUID := utils.MustNewUUID().String()
+			sc.AddModel(fmt.Sprintf("admin/other-%d", i), otherUUID, model.CAAS)
+
+			modelUUID, err := sc.ModelUUID(context.Background(), "admin/test")
+			s.Assert().NoError(err)
+			s.Assert().Equal(uuid, modelUUID)
+
+			sc.applyModelAbstracts([]params.ModelAbstract{{UUID: otherUUID, Name: fmt.Sprintf("renamed-%d", i)}})
+			modelType, err := sc.ModelType(context.Background(), otherUUID)
+			s.Assert().NoError(err)
+			s.Assert().Equal(model.CAAS, modelType)
+			sc.RemoveModel(otherUUID)
+		}(i)
+	}
+	wg.Wait()
+
+	s.Assert().Len(sc.modelUUIDcache, 1)
+	client := &Client{shared: sc}
+	s.Assert().Equal(ClientStats{ModelCacheHits: 10}, client.Stats())
+}
+
+func (s *ClientSuite) TestStatsNotConfigured() {
+	var client *Client
+	s.Assert().Equal(ClientStats{}, client.Stats())
+}
+
+func (s *ClientSuite) TestStatsFields() {
+	fields := ClientStats{ModelCacheHits: 3, Dials: 2, Retries: 1}.Fields()
+	s.Assert().Len(fields, 7)
+	s.Assert().Equal(int64(3), fields["model_cache_hits"])
+	s.Assert().Equal(int64(2), fields["dials"])
+	s.Assert().Equal(int64(1), fields["retries"])
+	s.Assert().Equal(int64(0), fields["dial_failures"])
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestClientSuite(t *testing.T) {
+	suite.Run(t, new(ClientSuite))
+}
@@ -0,0 +1,162 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/juju/api"
+)
+
+// connectionIdleTimeout is how long an unused connection is kept open
+// in the pool before being closed.
+var connectionIdleTimeout = 30 * time.Second
+
+// connectionPool keeps one connection per model, shared by the
+// operations running concurrently on the model. Connections are
+// reference counted and closed once they have not been used for
+// connectionIdleTimeout.
+type connectionPool struct {
+	mu    sync.Mutex
+	conns map[string]*pooledConnection
+}
+
+func newConnectionPool() *connectionPool {
+	return &connectionPool{
+		conns: make(map[string]*pooledConnection),
+	}
+}
+
+// pooledConnection is a connection held by the pool. It is not closed
+// before every user has released it.
+type pooledConnection struct {
+	api.Connection
+
+	pool      *connectionPool
+	modelUUID string
+	refs      int
+	idleTimer *time.Timer
+	// evicted connections are closed once released instead of
+	// being kept for later use.
+	evicted bool
+}
+
+// connectionRef is the connection handed to a user of the pool. Closing
+// it releases the reference rather than the connection.
+type connectionRef struct {
+	*pooledConnection
+
+	once sync.Once
+}
+
+// Close releases the reference to the pooled connection. It is safe to
+// call it more than once.
+func (r *connectionRef) Close() error {
+	r.once.Do(func() {
+		r.pool.release(r.pooledConnection)
+	})
+	return nil
+}
+
+// get returns a connection to the model from the pool, or nil if there
+// is none which can be used.
+func (p *connectionPool) get(modelUUID string) api.Connection {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pc, ok := p.conns[modelUUID]
+	if !ok {
+		return nil
+	}
+	if isBroken(pc.Connection) {
+		p.removeLocked(pc)
+		return nil
+	}
+	return p.acquireLocked(pc)
+}
+
+// put adds a new connection to the pool and returns it. If another
+// connection to the model was added concurrently, conn is closed and
+// the pooled one is returned instead.
+func (p *connectionPool) put(modelUUID string, conn api.Connection) api.Connection {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pc, ok := p.conns[modelUUID]; ok && !isBroken(pc.Connection) {
+		_ = conn.Close()
+		return p.acquireLocked(pc)
+	} else if ok {
+		p.removeLocked(pc)
+	}
+	pc := &pooledConnection{
+		Connection: conn,
+		pool:       p,
+		modelUUID:  modelUUID,
+	}
+	p.conns[modelUUID] = pc
+	return p.acquireLocked(pc)
+}
+
+// evict removes the connection to the model from the pool, e.g. when
+// the model is destroyed. The connection is closed once released.
+func (p *connectionPool) evict(modelUUID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pc, ok := p.conns[modelUUID]; ok {
+		p.removeLocked(pc)
+	}
+}
+
+func (p *connectionPool) acquireLocked(pc *pooledConnection) api.Connection {
+	pc.refs++
+	if pc.idleTimer != nil {
+		pc.idleTimer.Stop()
+		pc.idleTimer = nil
+	}
+	return &connectionRef{pooledConnection: pc}
+}
+
+func (p *connectionPool) release(pc *pooledConnection) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pc.refs--
+	if pc.refs > 0 {
+		return
+	}
+	if pc.evicted {
+		_ = pc.Connection.Close()
+		return
+	}
+	pc.idleTimer = time.AfterFunc(connectionIdleTimeout, func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if pc.refs == 0 && p.conns[pc.modelUUID] == pc {
+			p.removeLocked(pc)
+		}
+	})
+}
+
+// removeLocked removes the connection from the pool, closing it if it
+// is not in use. Callers are expected to hold the mu lock.
+func (p *connectionPool) removeLocked(pc *pooledConnection) {
+	if p.conns[pc.modelUUID] == pc {
+		delete(p.conns, pc.modelUUID)
+	}
+	pc.evicted = true
+	if pc.idleTimer != nil {
+		pc.idleTimer.Stop()
+		pc.idleTimer = nil
+	}
+	if pc.refs == 0 {
+		_ = pc.Connection.Close()
+	}
+}
+
+func isBroken(conn api.Connection) bool {
+	select {
+	case <-conn.Broken():
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type ConnectionPoolSuite struct {
+	suite.Suite
+
+	ctlr *gomock.Controller
+}
+
+func (s *ConnectionPoolSuite) SetupTest() {
+	s.ctlr = gomock.NewController(s.T())
+}
+
+func (s *ConnectionPoolSuite) newConnection(broken chan struct{}) *MockConnection {
+	conn := NewMockConnection(s.ctlr)
+	conn.EXPECT().Broken().Return(broken).AnyTimes()
+	return conn
+}
+
+func (s *ConnectionPoolSuite) TestReuse() {
+	pool := newConnectionPool()
+	s.Assert().Nil(pool.get("model-uuid"))
+
+	conn := s.newConnection(make(chan struct{}))
+	first := pool.put("model-uuid", conn)
+	second := pool.get("model-uuid")
+	s.Require().NotNil(second)
+	s.Assert().Nil(pool.get("other-uuid"))
+
+	// The connection is not closed while in use.
+	s.Require().NoError(first.Close())
+	s.Require().NoError(first.Close())
+	s.Require().NoError(second.Close())
+	s.Assert().NotNil(pool.get("model-uuid"))
+}
+
+func (s *ConnectionPoolSuite) TestIdleTimeout() {
+	defer func(timeout time.Duration) { connectionIdleTimeout = timeout }(connectionIdleTimeout)
+	connectionIdleTimeout = time.Millisecond
+
+	closed := make(chan struct{})
+	conn := s.newConnection(make(chan struct{}))
+	conn.EXPECT().Close().DoAndReturn(func() error {
+		close(closed)
+		return nil
+	})
+
+	pool := newConnectionPool()
+	s.Require().NoError(pool.put("model-uuid", conn).Close())
+
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		s.Fail("idle connection not closed")
+	}
+	s.Assert().Nil(pool.get("model-uuid"))
+}
+
+func (s *ConnectionPoolSuite) TestBroken() {
+	broken := make(chan struct{})
+	conn := s.newConnection(broken)
+	conn.EXPECT().Close().Return(nil)
+
+	pool := newConnectionPool()
+	ref := pool.put("model-uuid", conn)
+	close(broken)
+	s.Assert().Nil(pool.get("model-uuid"))
+
+	// The broken connection is closed once released.
+	s.Require().NoError(ref.Close())
+}
+
+func (s *ConnectionPoolSuite) TestConcurrentPut() {
+	conn := s.newConnection(make(chan struct{}))
+	duplicate := s.newConnection(make(chan struct{}))
+	duplicate.EXPECT().Close().Return(nil)
+
+	pool := newConnectionPool()
+	first := pool.put("model-uuid", conn)
+	second := pool.put("model-uuid", duplicate)
+	s.Assert().Equal(first.(*connectionRef).pooledConnection, second.(*connectionRef).pooledConnection)
+}
+
+func (s *ConnectionPoolSuite) TestEvict() {
+	conn := s.newConnection(make(chan struct{}))
+	conn.EXPECT().Close().Return(nil)
+
+	pool := newConnectionPool()
+	ref := pool.put("model-uuid", conn)
+	pool.evict("model-uuid")
+	s.Assert().Nil(pool.get("model-uuid"))
+	s.Require().NoError(ref.Close())
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestConnectionPoolSuite(t *testing.T) {
+	suite.Run(t, new(ConnectionPoolSuite))
+}
@@ -4,12 +4,16 @@
 package juju
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/juju/errors"
 	cloudapi "github.com/juju/juju/api/client/cloud"
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/rpc/params"
 	"github.com/juju/names/v5"
 )
 
@@ -58,6 +62,28 @@ type DestroyCredentialInput struct {
 	Name                 string
 }
 
+type ListCredentialsInput struct {
+	// CloudName filters the credentials by cloud, the credentials of
+	// all the clouds are listed if empty.
+	CloudName string
+}
+
+type ListCredentialsOutput struct {
+	// Credentials are sorted by cloud and name.
+	Credentials []CredentialSummary
+}
+
+// CredentialSummary holds the details of a controller credential listed,
+// without its secrets.
+type CredentialSummary struct {
+	Cloud    string
+	Name     string
+	AuthType string
+	Valid    bool
+	// Models are the names of the models using the credential, sorted.
+	Models []string
+}
+
 func newCredentialsClient(sc SharedClient) *credentialsClient {
 	return &credentialsClient{
 		SharedClient: sc,
@@ -84,8 +110,8 @@ func supportedAuth(cloud jujucloud.Cloud, authTypeReceived string) bool {
 	return false
 }
 
-func (c *credentialsClient) ValidateCredentialForCloud(cloudName, authTypeReceived string) error {
-	conn, err := c.GetConnection(nil)
+func (c *credentialsClient) ValidateCredentialForCloud(ctx context.Context, cloudName, authTypeReceived string) error {
+	conn, err := c.GetConnection(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -106,7 +132,43 @@ func (c *credentialsClient) ValidateCredentialForCloud(cloudName, authTypeReceiv
 	return nil
 }
 
-func (c *credentialsClient) CreateCredential(input CreateCredentialInput) (*CreateCredentialResponse, error) {
+// ValidateCloudRegion validates that the cloud is known by the
+// controller and, if not empty, that the region is one of its regions.
+func (c *credentialsClient) ValidateCloudRegion(ctx context.Context, cloudName, region string) error {
+	conn, err := c.GetConnection(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	cloud, err := cloudapi.NewClient(conn).Cloud(names.NewCloudTag(cloudName))
+	if params.IsCodeNotFound(err) {
+		return errors.NotFoundf("cloud %q", cloudName)
+	} else if err != nil {
+		return err
+	}
+	return validateCloudRegion(cloud, region)
+}
+
+func validateCloudRegion(cloud jujucloud.Cloud, region string) error {
+	if region == "" {
+		return nil
+	}
+	regions := make([]string, len(cloud.Regions))
+	for i, r := range cloud.Regions {
+		if r.Name == region {
+			return nil
+		}
+		regions[i] = r.Name
+	}
+	if len(regions) == 0 {
+		return errors.NewNotValid(nil, fmt.Sprintf("cloud %q has no regions, region %q is not valid", cloud.Name, region))
+	}
+	return errors.NewNotValid(nil, fmt.Sprintf("region %q is not a region of cloud %q, its regions are: %s",
+		region, cloud.Name, strings.Join(regions, ", ")))
+}
+
+func (c *credentialsClient) CreateCredential(ctx context.Context, input CreateCredentialInput) (*CreateCredentialResponse, error) {
 	if !input.ControllerCredential && !input.ClientCredential {
 		// Just in case none of them are set
 		return nil, fmt.Errorf("controller_credential or/and client_credential must be set to true")
@@ -119,11 +181,11 @@ func (c *credentialsClient) CreateCredential(input CreateCredentialInput) (*Crea
 
 	cloudName := input.CloudName
 
-	if err := c.ValidateCredentialForCloud(cloudName, input.AuthType); err != nil {
+	if err := c.ValidateCredentialForCloud(ctx, cloudName, input.AuthType); err != nil {
 		return nil, err
 	}
 
-	conn, err := c.GetConnection(nil)
+	conn, err := c.GetConnection(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -160,13 +222,13 @@ func (c *credentialsClient) CreateCredential(input CreateCredentialInput) (*Crea
 	return &CreateCredentialResponse{CloudCredential: cloudCredential, CloudName: cloudName}, nil
 }
 
-func (c *credentialsClient) ReadCredential(input ReadCredentialInput) (*ReadCredentialResponse, error) {
+func (c *credentialsClient) ReadCredential(ctx context.Context, input ReadCredentialInput) (*ReadCredentialResponse, error) {
 	clientCredential := input.ClientCredential
 	cloudName := input.CloudName
 	controllerCredential := input.ControllerCredential
 	credentialName := input.Name
 
-	conn, err := c.GetConnection(nil)
+	conn, err := c.GetConnection(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -180,7 +242,11 @@ func (c *credentialsClient) ReadCredential(input ReadCredentialInput) (*ReadCred
 		if err != nil {
 			return nil, err
 		}
-		clientCredentialFound = existingCredentials.AuthCredentials[credentialName]
+		var ok bool
+		clientCredentialFound, ok = existingCredentials.AuthCredentials[credentialName]
+		if !ok {
+			return nil, errors.NotFoundf("client credential %q for cloud %q", credentialName, cloudName)
+		}
 	}
 
 	var controllerCredentialFound jujucloud.Credential
@@ -205,6 +271,9 @@ func (c *credentialsClient) ReadCredential(input ReadCredentialInput) (*ReadCred
 				break
 			}
 		}
+		if controllerCredentialFound.Label == "" {
+			return nil, errors.NotFoundf("controller credential %q for cloud %q", credentialName, cloudName)
+		}
 	}
 
 	if controllerCredential && clientCredential {
@@ -230,7 +299,54 @@ func (c *credentialsClient) ReadCredential(input ReadCredentialInput) (*ReadCred
 	return nil, fmt.Errorf("credential %s not found for cloud %s", credentialName, cloudName)
 }
 
-func (c *credentialsClient) UpdateCredential(input UpdateCredentialInput) error {
+// ListCredentials lists the credentials of the user stored on the
+// controller.
+func (c *credentialsClient) ListCredentials(ctx context.Context, input ListCredentialsInput) (ListCredentialsOutput, error) {
+	conn, err := c.GetConnection(ctx, nil)
+	if err != nil {
+		return ListCredentialsOutput{}, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	contents, err := cloudapi.NewClient(conn).CredentialContents("", "", false)
+	if err != nil {
+		return ListCredentialsOutput{}, err
+	}
+
+	output := ListCredentialsOutput{Credentials: make([]CredentialSummary, 0, len(contents))}
+	for _, content := range contents {
+		if content.Error != nil {
+			return ListCredentialsOutput{}, content.Error
+		}
+		credential := content.Result.Content
+		if input.CloudName != "" && credential.Cloud != input.CloudName {
+			continue
+		}
+		summary := CredentialSummary{
+			Cloud:    credential.Cloud,
+			Name:     credential.Name,
+			AuthType: credential.AuthType,
+			// A credential is valid unless known not to be.
+			Valid:  credential.Valid == nil || *credential.Valid,
+			Models: make([]string, 0, len(content.Result.Models)),
+		}
+		for _, m := range content.Result.Models {
+			summary.Models = append(summary.Models, m.Model)
+		}
+		sort.Strings(summary.Models)
+		output.Credentials = append(output.Credentials, summary)
+	}
+	sort.Slice(output.Credentials, func(i, j int) bool {
+		a, b := output.Credentials[i], output.Credentials[j]
+		if a.Cloud != b.Cloud {
+			return a.Cloud < b.Cloud
+		}
+		return a.Name < b.Name
+	})
+	return output, nil
+}
+
+func (c *credentialsClient) UpdateCredential(ctx context.Context, input UpdateCredentialInput) error {
 	if !input.ControllerCredential && !input.ClientCredential {
 		// Just in case none of them are set
 		return fmt.Errorf("controller_credential or/and client_credential must be set to true")
@@ -243,11 +359,11 @@ func (c *credentialsClient) UpdateCredential(input UpdateCredentialInput) error
 
 	cloudName := input.CloudName
 
-	if err := c.ValidateCredentialForCloud(cloudName, input.AuthType); err != nil {
+	if err := c.ValidateCredentialForCloud(ctx, cloudName, input.AuthType); err != nil {
 		return err
 	}
 
-	conn, err := c.GetConnection(nil)
+	conn, err := c.GetConnection(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -310,11 +426,11 @@ func updateClientCredential(cloudName string, credentialName string, cloudCreden
 	return nil
 }
 
-func (c *credentialsClient) DestroyCredential(input DestroyCredentialInput) error {
+func (c *credentialsClient) DestroyCredential(ctx context.Context, input DestroyCredentialInput) error {
 	cloudName := input.CloudName
 	credentialName := input.Name
 
-	conn, err := c.GetConnection(nil)
+	conn, err := c.GetConnection(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,38 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"testing"
+
+	"github.com/juju/errors"
+	jujucloud "github.com/juju/juju/cloud"
+	"github.com/stretchr/testify/suite"
+)
+
+type CredentialsSuite struct {
+	suite.Suite
+}
+
+func (s *CredentialsSuite) TestValidateCloudRegion() {
+	cloud := jujucloud.Cloud{
+		Name:    "aws",
+		Regions: []jujucloud.Region{{Name: "us-east-1"}, {Name: "eu-west-1"}},
+	}
+	s.Assert().NoError(validateCloudRegion(cloud, ""))
+	s.Assert().NoError(validateCloudRegion(cloud, "eu-west-1"))
+
+	err := validateCloudRegion(cloud, "eu-west-9")
+	s.Assert().True(errors.Is(err, errors.NotValid))
+	s.Assert().EqualError(err, `region "eu-west-9" is not a region of cloud "aws", its regions are: us-east-1, eu-west-1`)
+
+	err = validateCloudRegion(jujucloud.Cloud{Name: "manual"}, "default")
+	s.Assert().EqualError(err, `cloud "manual" has no regions, region "default" is not valid`)
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestCredentialsSuite(t *testing.T) {
+	suite.Run(t, new(CredentialsSuite))
+}
@@ -0,0 +1,55 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"time"
+
+	"github.com/juju/juju/rpc/params"
+)
+
+// DestroyOptions are the options shared by the destroy operations of
+// models, applications, machines and integrations.
+//
+// Forced destroys ignore the errors of the removal of an entity, e.g.
+// unreachable agents or failing hooks, and remove its dependents Juju
+// would otherwise refuse to, like the units of a machine. Terraform
+// destroys the dependents it knows of first, the others are removed
+// with the entity. As an entity may then already be removed when it is
+// destroyed, a forced destroy succeeds if the entity is not found.
+type DestroyOptions struct {
+	// Force forces the removal of the entity.
+	Force bool
+	// MaxWait is how long each step of a forced removal waits for the
+	// entity to be removed cleanly before forcing it. Zero uses the Juju
+	// default. Ignored unless Force is set.
+	MaxWait time.Duration
+	// NoWait forces each step of the removal without waiting for the
+	// entity to be removed cleanly, like `--no-wait`. It overrides
+	// MaxWait and is ignored unless Force is set.
+	NoWait bool
+}
+
+// forceArgs returns the force and max wait arguments of the Juju
+// destroy API calls.
+func (o DestroyOptions) forceArgs() (*bool, *time.Duration) {
+	force := o.Force
+	if !force || (o.MaxWait == 0 && !o.NoWait) {
+		return &force, nil
+	}
+	maxWait := o.MaxWait
+	if o.NoWait {
+		maxWait = 0
+	}
+	return &force, &maxWait
+}
+
+// destroyError returns err, unless the destroy is forced and the entity
+// is not found.
+func (o DestroyOptions) destroyError(err error) error {
+	if err == nil || (o.Force && params.IsCodeNotFound(err)) {
+		return nil
+	}
+	return err
+}
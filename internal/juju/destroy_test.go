@@ -0,0 +1,57 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"testing"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/juju/rpc/params"
+	"github.com/stretchr/testify/suite"
+)
+
+type DestroySuite struct {
+	suite.Suite
+}
+
+func (s *DestroySuite) TestForceArgs() {
+	force, maxWait := DestroyOptions{MaxWait: time.Minute}.forceArgs()
+	s.Assert().False(*force)
+	s.Assert().Nil(maxWait)
+
+	force, maxWait = DestroyOptions{Force: true}.forceArgs()
+	s.Assert().True(*force)
+	s.Assert().Nil(maxWait)
+
+	force, maxWait = DestroyOptions{Force: true, MaxWait: time.Minute}.forceArgs()
+	s.Assert().True(*force)
+	s.Require().NotNil(maxWait)
+	s.Assert().Equal(time.Minute, *maxWait)
+
+	force, maxWait = DestroyOptions{Force: true, MaxWait: time.Minute, NoWait: true}.forceArgs()
+	s.Assert().True(*force)
+	s.Require().NotNil(maxWait)
+	s.Assert().Zero(*maxWait)
+
+	force, maxWait = DestroyOptions{NoWait: true}.forceArgs()
+	s.Assert().False(*force)
+	s.Assert().Nil(maxWait)
+}
+
+func (s *DestroySuite) TestDestroyError() {
+	notFound := &params.Error{Code: params.CodeNotFound, Message: `application "mysql" not found`}
+	other := errors.New("boom")
+
+	s.Assert().Equal(notFound, DestroyOptions{}.destroyError(notFound))
+	s.Assert().NoError(DestroyOptions{Force: true}.destroyError(notFound))
+	s.Assert().Equal(other, DestroyOptions{Force: true}.destroyError(other))
+	s.Assert().NoError(DestroyOptions{}.destroyError(nil))
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestDestroySuite(t *testing.T) {
+	suite.Run(t, new(DestroySuite))
+}
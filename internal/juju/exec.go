@@ -0,0 +1,196 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/juju/api/base"
+	apiaction "github.com/juju/juju/api/client/action"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	"github.com/juju/retry"
+)
+
+// execGracePeriod is added to the command timeout when waiting for the
+// tasks, to give the controller time to report timed out tasks.
+const execGracePeriod = 30 * time.Second
+
+// execPollDelay is the delay between two queries of the running tasks.
+var execPollDelay = 2 * time.Second
+
+// errExecPending is returned while waiting for tasks to complete.
+var errExecPending = errors.New("tasks still pending")
+
+type execClient struct {
+	SharedClient
+
+	getActionAPIClient func(base.APICallCloser) ActionAPIClient
+}
+
+type ExecInput struct {
+	ModelName    string
+	Command      string
+	Applications []string
+	Machines     []string
+	Units        []string
+	// Timeout is the maximum time the command is allowed to run on
+	// each target.
+	Timeout time.Duration
+}
+
+type ExecResponse struct {
+	OperationID string
+	Results     []ExecResult
+}
+
+// ExecResult is the outcome of the command on a single unit or machine.
+type ExecResult struct {
+	// Target is the unit name or machine id the command ran on.
+	Target string
+	Status string
+	// ExitCode is -1 when the command did not report an exit code,
+	// e.g. when it timed out.
+	ExitCode int
+	Stdout   string
+	Stderr   string
+	Message  string
+}
+
+func newExecClient(sc SharedClient) *execClient {
+	return &execClient{
+		SharedClient: sc,
+		getActionAPIClient: func(closer base.APICallCloser) ActionAPIClient {
+			return apiaction.NewClient(closer)
+		},
+	}
+}
+
+// Exec runs a command on the requested applications, machines and units,
+// as `juju exec` does, and waits for every task to complete.
+func (c execClient) Exec(ctx context.Context, input ExecInput) (*ExecResponse, error) {
+	if input.Command == "" {
+		return nil, errors.NotValidf("empty command")
+	}
+	if len(input.Applications)+len(input.Machines)+len(input.Units) == 0 {
+		return nil, errors.NotValidf("exec without applications, machines or units")
+	}
+
+	modelType, err := c.ModelType(ctx, input.ModelName)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := c.GetConnection(ctx, &input.ModelName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	client := c.getActionAPIClient(conn)
+	enqueued, err := client.Run(apiaction.RunParams{
+		Commands:     input.Command,
+		Timeout:      input.Timeout,
+		Applications: input.Applications,
+		Machines:     input.Machines,
+		Units:        input.Units,
+		// On Kubernetes models, run the command in the workload
+		// container rather than in the charm container.
+		WorkloadContext: modelType == model.CAAS,
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "running command")
+	}
+
+	pending := make([]string, 0, len(enqueued.Actions))
+	for _, task := range enqueued.Actions {
+		if task.Error != nil {
+			return nil, errors.Annotate(task.Error, "enqueuing command")
+		}
+		pending = append(pending, task.Action.ID)
+	}
+	c.Tracef("command enqueued", map[string]interface{}{"operation": enqueued.OperationID, "tasks": pending})
+
+	results := make([]ExecResult, 0, len(pending))
+	err = retry.Call(retry.CallArgs{
+		Func: func() error {
+			tasks, err := client.Actions(pending)
+			if err != nil {
+				return err
+			}
+			pending = pending[:0]
+			for _, task := range tasks {
+				if task.Error != nil {
+					return task.Error
+				}
+				switch task.Status {
+				case params.ActionPending, params.ActionRunning:
+					pending = append(pending, task.Action.ID)
+				default:
+					results = append(results, execResultFromAction(task))
+				}
+			}
+			if len(pending) > 0 {
+				return errExecPending
+			}
+			return nil
+		},
+		IsFatalError: func(err error) bool {
+			return !errors.Is(err, errExecPending)
+		},
+		NotifyFunc: func(err error, attempt int) {
+			if attempt%5 == 0 {
+				c.Debugf(fmt.Sprintf("waiting for %d tasks to complete", len(pending)))
+			}
+		},
+		Delay:       execPollDelay,
+		MaxDuration: input.Timeout + execGracePeriod,
+		Clock:       clock.WallClock,
+		Stop:        ctx.Done(),
+	})
+	if retry.IsDurationExceeded(err) {
+		return nil, errors.Timeoutf("waiting for tasks %v", pending)
+	} else if retry.IsRetryStopped(err) {
+		return nil, errors.Annotatef(ctx.Err(), "waiting for tasks %v", pending)
+	} else if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Target < results[j].Target
+	})
+	return &ExecResponse{OperationID: enqueued.OperationID, Results: results}, nil
+}
+
+func execResultFromAction(task apiaction.ActionResult) ExecResult {
+	result := ExecResult{
+		Target:   task.Action.Receiver,
+		Status:   task.Status,
+		ExitCode: -1,
+		Message:  task.Message,
+	}
+	if tag, err := names.ParseTag(task.Action.Receiver); err == nil {
+		result.Target = tag.Id()
+	}
+	if stdout, ok := task.Output["stdout"].(string); ok {
+		result.Stdout = stdout
+	}
+	if stderr, ok := task.Output["stderr"].(string); ok {
+		result.Stderr = stderr
+	}
+	// return-code may come in as a float64 due to serialisation.
+	if code, ok := task.Output["return-code"]; ok && code != nil {
+		if exitCode, err := strconv.Atoi(fmt.Sprintf("%v", code)); err == nil {
+			result.ExitCode = exitCode
+		}
+	}
+	return result
+}
@@ -0,0 +1,105 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/juju/juju/api/base"
+	apiaction "github.com/juju/juju/api/client/action"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/rpc/params"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type ExecSuite struct {
+	JujuSuite
+
+	mockActionClient *MockActionAPIClient
+}
+
+func (s *ExecSuite) SetupTest() {
+	execPollDelay = time.Millisecond
+}
+
+func (s *ExecSuite) setupMocks(t *testing.T) *gomock.Controller {
+	ctlr := s.JujuSuite.setupMocks(t)
+	s.mockActionClient = NewMockActionAPIClient(ctlr)
+	s.mockSharedClient.EXPECT().ModelType(gomock.Any(), s.testModelName).Return(model.IAAS, nil).AnyTimes()
+
+	return ctlr
+}
+
+func (s *ExecSuite) getExecClient() execClient {
+	return execClient{
+		SharedClient: s.mockSharedClient,
+		getActionAPIClient: func(_ base.APICallCloser) ActionAPIClient {
+			return s.mockActionClient
+		},
+	}
+}
+
+func (s *ExecSuite) TestExec() {
+	defer s.setupMocks(s.T()).Finish()
+
+	s.mockActionClient.EXPECT().Run(apiaction.RunParams{
+		Commands:     "hostname",
+		Timeout:      time.Minute,
+		Applications: []string{"ubuntu"},
+	}).Return(apiaction.EnqueuedActions{
+		OperationID: "1",
+		Actions: []apiaction.ActionResult{
+			{Action: &apiaction.Action{ID: "2", Receiver: "unit-ubuntu-1"}},
+			{Action: &apiaction.Action{ID: "3", Receiver: "unit-ubuntu-0"}},
+		},
+	}, nil)
+	gomock.InOrder(
+		s.mockActionClient.EXPECT().Actions([]string{"2", "3"}).Return([]apiaction.ActionResult{
+			{
+				Action: &apiaction.Action{ID: "2", Receiver: "unit-ubuntu-1"},
+				Status: params.ActionFailed,
+				Output: map[string]interface{}{"return-code": float64(1), "stderr": "boom"},
+			},
+			{Action: &apiaction.Action{ID: "3", Receiver: "unit-ubuntu-0"}, Status: params.ActionRunning},
+		}, nil),
+		s.mockActionClient.EXPECT().Actions([]string{"3"}).Return([]apiaction.ActionResult{
+			{
+				Action: &apiaction.Action{ID: "3", Receiver: "unit-ubuntu-0"},
+				Status: params.ActionCompleted,
+				Output: map[string]interface{}{"return-code": float64(0), "stdout": "juju-0\n"},
+			},
+		}, nil),
+	)
+
+	resp, err := s.getExecClient().Exec(context.Background(), ExecInput{
+		ModelName:    s.testModelName,
+		Command:      "hostname",
+		Applications: []string{"ubuntu"},
+		Timeout:      time.Minute,
+	})
+	s.Require().NoError(err)
+	s.Assert().Equal([]ExecResult{
+		{Target: "ubuntu/0", Status: params.ActionCompleted, ExitCode: 0, Stdout: "juju-0\n"},
+		{Target: "ubuntu/1", Status: params.ActionFailed, ExitCode: 1, Stderr: "boom"},
+	}, resp.Results)
+}
+
+func (s *ExecSuite) TestExecNoTargets() {
+	defer s.setupMocks(s.T()).Finish()
+
+	_, err := s.getExecClient().Exec(context.Background(), ExecInput{
+		ModelName: s.testModelName,
+		Command:   "hostname",
+	})
+	s.Assert().ErrorContains(err, "exec without applications, machines or units not valid")
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestExecSuite(t *testing.T) {
+	suite.Run(t, new(ExecSuite))
+}
@@ -19,9 +19,6 @@ import (
 )
 
 const (
-	// IntegrationQueryTick defines the time to wait between ticks
-	// when querying the API
-	IntegrationApiTickWait = time.Second * 5
 	// IntegrationAppAvailableTimeout indicates the time to wait
 	// for applications to be available before integrating them
 	IntegrationAppAvailableTimeout = time.Second * 60
@@ -38,6 +35,11 @@ func (ie *noIntegrationFoundError) Error() string {
 	return fmt.Sprintf("no integrations exist in model %v", ie.ModelUUID)
 }
 
+// Is reports the error to be a NotFound error.
+func (ie *noIntegrationFoundError) Is(target error) bool {
+	return target == errors.NotFound
+}
+
 type integrationsClient struct {
 	SharedClient
 }
@@ -58,6 +60,8 @@ type IntegrationInput struct {
 	Apps      []string
 	Endpoints []string
 	ViaCIDRs  string
+	// Destroy is only used by DestroyIntegration.
+	Destroy DestroyOptions
 }
 
 type CreateIntegrationResponse struct {
@@ -85,8 +89,8 @@ func newIntegrationsClient(sc SharedClient) *integrationsClient {
 	}
 }
 
-func (c integrationsClient) CreateIntegration(input *IntegrationInput) (*CreateIntegrationResponse, error) {
-	conn, err := c.GetConnection(&input.ModelName)
+func (c integrationsClient) CreateIntegration(ctx context.Context, input *IntegrationInput) (*CreateIntegrationResponse, error) {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
 	if err != nil {
 		return nil, err
 	}
@@ -94,13 +98,11 @@ func (c integrationsClient) CreateIntegration(input *IntegrationInput) (*CreateI
 
 	client := apiapplication.NewClient(conn)
 
-	// wait for the apps to be available
-	ctx, cancel := context.WithTimeout(context.Background(), IntegrationAppAvailableTimeout)
-	defer cancel()
-
-	err = WaitForAppsAvailable(ctx, client, input.Apps, IntegrationApiTickWait)
+	// Wait for the apps to be available. Apps only holds the local
+	// applications, consumed offers are integrated by their SAAS name.
+	err = waitForModel(ctx, c.SharedClient, conn, ApplicationsExist(input.Apps...), IntegrationAppAvailableTimeout)
 	if err != nil {
-		return nil, errors.New("the applications were not available to be integrated")
+		return nil, errors.Annotate(err, "the applications were not available to be integrated")
 	}
 
 	listViaCIDRs := splitCommaDelimitedList(input.ViaCIDRs)
@@ -113,7 +115,7 @@ func (c integrationsClient) CreateIntegration(input *IntegrationInput) (*CreateI
 	}
 
 	// integration is created - fetch the status in order to validate
-	status, err := c.getStatus(conn)
+	status, err := c.getStatus(ctx, conn)
 	if err != nil {
 		return nil, err
 	}
@@ -125,14 +127,14 @@ func (c integrationsClient) CreateIntegration(input *IntegrationInput) (*CreateI
 	}, nil
 }
 
-func (c integrationsClient) ReadIntegration(input *IntegrationInput) (*ReadIntegrationResponse, error) {
-	conn, err := c.GetConnection(&input.ModelName)
+func (c integrationsClient) ReadIntegration(ctx context.Context, input *IntegrationInput) (*ReadIntegrationResponse, error) {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = conn.Close() }()
 
-	status, err := c.getStatus(conn)
+	status, err := c.getStatus(ctx, conn)
 	if err != nil {
 		return nil, err
 	}
@@ -186,8 +188,8 @@ func (c integrationsClient) ReadIntegration(input *IntegrationInput) (*ReadInteg
 	}, nil
 }
 
-func (c integrationsClient) UpdateIntegration(input *UpdateIntegrationInput) (*UpdateIntegrationResponse, error) {
-	conn, err := c.GetConnection(&input.ModelName)
+func (c integrationsClient) UpdateIntegration(ctx context.Context, input *UpdateIntegrationInput) (*UpdateIntegrationResponse, error) {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
 	if err != nil {
 		return nil, err
 	}
@@ -224,7 +226,7 @@ func (c integrationsClient) UpdateIntegration(input *UpdateIntegrationInput) (*U
 	//TODO: check deletion success and force?
 
 	//integration is updated - fetch the status in order to validate
-	status, err := c.getStatus(conn)
+	status, err := c.getStatus(ctx, conn)
 	if err != nil {
 		return nil, err
 	}
@@ -236,8 +238,8 @@ func (c integrationsClient) UpdateIntegration(input *UpdateIntegrationInput) (*U
 	}, nil
 }
 
-func (c integrationsClient) DestroyIntegration(input *IntegrationInput) error {
-	conn, err := c.GetConnection(&input.ModelName)
+func (c integrationsClient) DestroyIntegration(ctx context.Context, input *IntegrationInput) error {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
 	if err != nil {
 		return err
 	}
@@ -245,25 +247,24 @@ func (c integrationsClient) DestroyIntegration(input *IntegrationInput) error {
 
 	client := apiapplication.NewClient(conn)
 
-	var force bool = false
-	var timeout time.Duration = 30 * time.Second
+	force, timeout := input.Destroy.forceArgs()
+	if timeout == nil {
+		defaultTimeout := 30 * time.Second
+		timeout = &defaultTimeout
+	}
 
 	err = client.DestroyRelation(
-		&force,
-		&timeout,
+		force,
+		timeout,
 		input.Endpoints...,
 	)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return input.Destroy.destroyError(err)
 }
 
-func (c integrationsClient) getStatus(conn api.Connection) (*params.FullStatus, error) {
+func (c integrationsClient) getStatus(ctx context.Context, conn api.Connection) (*params.FullStatus, error) {
 	client := apiclient.NewClient(conn, c.JujuLogger())
 
-	status, err := client.Status(nil)
+	status, err := readStatus(ctx, c.SharedClient, conn, client, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -4,16 +4,21 @@
 package juju
 
 import (
+	"context"
 	"io"
+	"time"
 
 	"github.com/juju/charm/v12"
 	charmresources "github.com/juju/charm/v12/resource"
 	"github.com/juju/juju/api"
+	apiaction "github.com/juju/juju/api/client/action"
 	apiapplication "github.com/juju/juju/api/client/application"
 	apiclient "github.com/juju/juju/api/client/client"
 	apiresources "github.com/juju/juju/api/client/resources"
+	apisecretbackends "github.com/juju/juju/api/client/secretbackends"
 	apisecrets "github.com/juju/juju/api/client/secrets"
 	apicommoncharm "github.com/juju/juju/api/common/charm"
+	corebase "github.com/juju/juju/core/base"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/resources"
@@ -24,9 +29,9 @@ import (
 
 type SharedClient interface {
 	AddModel(modelName, modelUUID string, modelType model.ModelType)
-	GetConnection(modelName *string) (api.Connection, error)
-	ModelType(modelName string) (model.ModelType, error)
-	ModelUUID(modelName string) (string, error)
+	GetConnection(ctx context.Context, modelName *string) (api.Connection, error)
+	ModelType(ctx context.Context, modelName string) (model.ModelType, error)
+	ModelUUID(ctx context.Context, modelName string) (string, error)
 	RemoveModel(modelUUID string)
 
 	Debugf(msg string, additionalFields ...map[string]interface{})
@@ -37,6 +42,162 @@ type SharedClient interface {
 	JujuLogger() *jujuLoggerShim
 }
 
+// The interfaces below are implemented by the sub-clients of Client, so
+// the provider can be tested against mocks rather than a live controller.
+
+// AnnotationsClient manages the annotations of models, applications and
+// machines.
+type AnnotationsClient interface {
+	GetAnnotations(ctx context.Context, input GetAnnotationsInput) (*GetAnnotationsResponse, error)
+	SetAnnotations(ctx context.Context, input SetAnnotationsInput) error
+}
+
+// ApplicationsClient manages applications and their units, expose
+// settings and resources. CreateApplication returns the response with
+// the error when the application is deployed but a later step fails.
+type ApplicationsClient interface {
+	CreateApplication(ctx context.Context, input *CreateApplicationInput) (*CreateApplicationResponse, error)
+	DestroyApplication(ctx context.Context, input *DestroyApplicationInput) error
+	ExposeApplication(ctx context.Context, input ExposeApplicationInput) error
+	IsExposeManaged(ctx context.Context, modelName, appName string) (bool, error)
+	ListApplications(ctx context.Context, input ListApplicationsInput) (*ListApplicationsResponse, error)
+	ReadApplication(ctx context.Context, input *ReadApplicationInput) (*ReadApplicationResponse, error)
+	ReadApplicationConfig(ctx context.Context, input ReadApplicationConfigInput) (*ReadApplicationConfigResponse, error)
+	ReadApplicationExpose(ctx context.Context, input ReadApplicationExposeInput) (*ReadApplicationExposeResponse, error)
+	ReadApplicationResource(ctx context.Context, input ReadApplicationResourceInput) (*ReadApplicationResourceResponse, error)
+	ReadApplicationWithRetryOnNotFound(ctx context.Context, input *ReadApplicationInput) (*ReadApplicationResponse, error)
+	ReadUnit(ctx context.Context, input ReadUnitInput) (*ReadUnitResponse, error)
+	SetApplicationConfig(ctx context.Context, input SetApplicationConfigInput) error
+	SetApplicationResource(ctx context.Context, input SetApplicationResourceInput) error
+	UnexposeApplication(ctx context.Context, input UnexposeApplicationInput) error
+	UnsetApplicationConfig(ctx context.Context, input UnsetApplicationConfigInput) error
+	UpdateApplication(ctx context.Context, input *UpdateApplicationInput) error
+}
+
+// BackupsClient creates controller backups.
+type BackupsClient interface {
+	CreateBackup(ctx context.Context, input CreateBackupInput) (*CreateBackupResponse, error)
+}
+
+// BundlesClient deploys bundles and compares them with the deployed
+// models.
+type BundlesClient interface {
+	DeployBundle(ctx context.Context, input DeployBundleInput) (*DeployBundleResponse, error)
+	DiffBundle(ctx context.Context, input DiffBundleInput) (*DiffBundleResponse, error)
+}
+
+// CharmsClient resolves charms in CharmHub.
+type CharmsClient interface {
+	CharmConfig(ctx context.Context, input *CharmConfigInput) (*charm.Config, error)
+	ListCharmResources(ctx context.Context, input ResolveCharmInput) (*ListCharmResourcesResponse, error)
+	ResolveCharm(ctx context.Context, input ResolveCharmInput) (*ResolveCharmResponse, error)
+}
+
+// CredentialsClient manages cloud credentials.
+type CredentialsClient interface {
+	CreateCredential(ctx context.Context, input CreateCredentialInput) (*CreateCredentialResponse, error)
+	DestroyCredential(ctx context.Context, input DestroyCredentialInput) error
+	ListCredentials(ctx context.Context, input ListCredentialsInput) (ListCredentialsOutput, error)
+	ReadCredential(ctx context.Context, input ReadCredentialInput) (*ReadCredentialResponse, error)
+	UpdateCredential(ctx context.Context, input UpdateCredentialInput) error
+	ValidateCloudRegion(ctx context.Context, cloudName, region string) error
+	ValidateCredentialForCloud(ctx context.Context, cloudName, authTypeReceived string) error
+}
+
+// ExecClient runs commands on units and machines.
+type ExecClient interface {
+	Exec(ctx context.Context, input ExecInput) (*ExecResponse, error)
+}
+
+// IntegrationsClient manages integrations between applications.
+type IntegrationsClient interface {
+	CreateIntegration(ctx context.Context, input *IntegrationInput) (*CreateIntegrationResponse, error)
+	DestroyIntegration(ctx context.Context, input *IntegrationInput) error
+	ReadIntegration(ctx context.Context, input *IntegrationInput) (*ReadIntegrationResponse, error)
+	UpdateIntegration(ctx context.Context, input *UpdateIntegrationInput) (*UpdateIntegrationResponse, error)
+}
+
+// MachinesClient manages machines.
+type MachinesClient interface {
+	CreateMachine(ctx context.Context, input *CreateMachineInput) (*CreateMachineResponse, error)
+	DestroyMachine(ctx context.Context, input *DestroyMachineInput) error
+	ReadMachine(ctx context.Context, input ReadMachineInput) (ReadMachineResponse, error)
+}
+
+// ModelsClient manages models and the access of users to them.
+// CreateModel waits for the model to be available, and returns the
+// response, including the UUID, with the error when the model is
+// created but the wait or setting its constraints fails.
+type ModelsClient interface {
+	CreateModel(ctx context.Context, input CreateModelInput) (CreateModelResponse, error)
+	DestroyAccessModel(ctx context.Context, input DestroyAccessModelInput) error
+	DestroyModel(ctx context.Context, input DestroyModelInput) error
+	GetConnection(ctx context.Context, modelName *string) (api.Connection, error)
+	GetModelByName(ctx context.Context, name string) (*params.ModelInfo, error)
+	GrantModel(ctx context.Context, input GrantModelInput) error
+	ListModels(ctx context.Context, input ListModelsInput) (ListModelsOutput, error)
+	ListSpaces(ctx context.Context, input ListSpacesInput) (ListSpacesOutput, error)
+	ListStoragePools(ctx context.Context, input ListStoragePoolsInput) (ListStoragePoolsOutput, error)
+	MigrateModel(ctx context.Context, input MigrateModelInput) (string, error)
+	ReadAgentVersions(ctx context.Context, input ReadAgentVersionsInput) (*ReadAgentVersionsResponse, error)
+	ReadModel(ctx context.Context, name string) (*ReadModelResponse, error)
+	ReadModelConfig(ctx context.Context, input ReadModelConfigInput) (ReadModelConfigOutput, error)
+	ReadModelDefaults(ctx context.Context, input ReadModelDefaultsInput) (ReadModelDefaultsOutput, error)
+	ReadModelMigration(ctx context.Context, uuid string) (*ReadModelMigrationResponse, error)
+	UpdateAccessModel(ctx context.Context, input UpdateAccessModelInput) error
+	UpdateModel(ctx context.Context, input UpdateModelInput) error
+	UpdateModelDefaults(ctx context.Context, input UpdateModelDefaultsInput) error
+	WaitForModelMigration(ctx context.Context, uuid string, timeout time.Duration) (*ReadModelMigrationResponse, error)
+}
+
+// OffersClient manages offers and their consumption.
+type OffersClient interface {
+	ConsumeRemoteOffer(ctx context.Context, input *ConsumeRemoteOfferInput) (*ConsumeRemoteOfferResponse, error)
+	CreateOffer(ctx context.Context, input *CreateOfferInput) (*CreateOfferResponse, []error)
+	DestroyOffer(ctx context.Context, input *DestroyOfferInput) error
+	ReadOffer(ctx context.Context, input *ReadOfferInput) (*ReadOfferResponse, error)
+	RemoveRemoteOffer(ctx context.Context, input *RemoveRemoteOfferInput) []error
+}
+
+// SecretsClient manages user secrets and their access.
+type SecretsClient interface {
+	CreateSecret(ctx context.Context, input *CreateSecretInput) (CreateSecretOutput, error)
+	DeleteSecret(ctx context.Context, input *DeleteSecretInput) error
+	ListSecretBackends(ctx context.Context, input *ListSecretBackendsInput) (ListSecretBackendsOutput, error)
+	ReadSecret(ctx context.Context, input *ReadSecretInput) (ReadSecretOutput, error)
+	UpdateAccessSecret(ctx context.Context, input *GrantRevokeAccessSecretInput, op AccessSecretAction) error
+	UpdateSecret(ctx context.Context, input *UpdateSecretInput) error
+}
+
+// SSHKeysClient manages the SSH keys of models.
+type SSHKeysClient interface {
+	CreateSSHKey(ctx context.Context, input *CreateSSHKeyInput) error
+	DeleteSSHKey(ctx context.Context, input *DeleteSSHKeyInput) error
+	ReadSSHKey(ctx context.Context, input *ReadSSHKeyInput) (*ReadSSHKeyOutput, error)
+}
+
+// StatusClient reads the status of models.
+type StatusClient interface {
+	ReadFullStatus(ctx context.Context, input ReadFullStatusInput) (*ReadFullStatusResponse, error)
+	WaitForStatus(ctx context.Context, input WaitForStatusInput) (*WaitForStatusResponse, error)
+	Wait(ctx context.Context, input WaitInput) error
+}
+
+// UsersClient manages users.
+type UsersClient interface {
+	CreateUser(ctx context.Context, input CreateUserInput) (*CreateUserResponse, error)
+	DestroyUser(ctx context.Context, input DestroyUserInput) error
+	ListUsers(ctx context.Context, input ListUsersInput) (ListUsersOutput, error)
+	ModelUserInfo(ctx context.Context, modelName string) (*ReadModelUserResponse, error)
+	ReadUser(ctx context.Context, name string) (*ReadUserResponse, error)
+	UpdateUser(ctx context.Context, input UpdateUserInput) error
+}
+
+type ActionAPIClient interface {
+	Actions(actionIDs []string) ([]apiaction.ActionResult, error)
+	Run(run apiaction.RunParams) (apiaction.EnqueuedActions, error)
+}
+
 type ClientAPIClient interface {
 	Status(args *apiclient.StatusArgs) (*params.FullStatus, error)
 }
@@ -58,6 +219,13 @@ type ApplicationAPIClient interface {
 	SetConfig(branchName, application, configYAML string, config map[string]string) error
 	SetConstraints(application string, constraints constraints.Value) error
 	Unexpose(application string, endpoints []string) error
+	UnsetApplicationConfig(branchName, application string, keys []string) error
+	UpdateApplicationBase(appName string, base corebase.Base, force bool) error
+}
+
+type MachineManagerAPIClient interface {
+	UpgradeSeriesComplete(machineName string) error
+	UpgradeSeriesPrepare(machineName, channel string, force bool) error
 }
 
 type ModelConfigAPIClient interface {
@@ -82,3 +250,7 @@ type SecretAPIClient interface {
 	GrantSecret(uri *secrets.URI, name string, apps []string) ([]error, error)
 	RevokeSecret(uri *secrets.URI, name string, apps []string) ([]error, error)
 }
+
+type SecretBackendsAPIClient interface {
+	ListSecretBackends(names []string, reveal bool) ([]apisecretbackends.SecretBackend, error)
+}
@@ -0,0 +1,102 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Fields set on the log entries of the client and of the provider
+// resources and data sources, so that logs can be filtered and
+// correlated. The resource type and the operation are identified by
+// the tf_resource_type and tf_rpc fields set by the framework.
+const (
+	// LogFieldController is the comma separated addresses of the
+	// controller.
+	LogFieldController = "juju_controller"
+	// LogFieldModelUUID is the UUID of the model an entry relates to.
+	LogFieldModelUUID = "juju_model_uuid"
+)
+
+// RedactedValue replaces sensitive values in logs, as tflog does when
+// masking them.
+const RedactedValue = "***"
+
+// sensitiveLogFields are the keys of the fields whose values are
+// masked in the logs of the client, the resources and the data sources,
+// should they ever be logged.
+var sensitiveLogFields = []string{
+	"password",
+	"client_secret",
+	"secret_value",
+	"credential_attributes",
+	"macaroon",
+	"token",
+}
+
+// NewLogSubsystem returns ctx with the logging subsystem of a resource
+// or data source, including the fields of the Terraform RPC and the
+// controller the client talks to. It is expected to be called from
+// Configure, which is called for each RPC.
+func (c *Client) NewLogSubsystem(ctx context.Context, subsystem string) context.Context {
+	ctx = tflog.NewSubsystem(ctx, subsystem, tflog.WithRootFields())
+	if c.shared != nil {
+		ctx = redactSubsystemLogs(ctx, subsystem, c.shared.controllerConfig)
+	}
+	return tflog.SubsystemSetField(ctx, subsystem, LogFieldController, c.controller)
+}
+
+// RedactLogs returns ctx with the root provider logs redacted, see
+// redactSubsystemLogs.
+func RedactLogs(ctx context.Context, config ControllerConfiguration) context.Context {
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, sensitiveLogFields...)
+	if secrets := controllerSecrets(config); len(secrets) > 0 {
+		ctx = tflog.MaskAllFieldValuesStrings(ctx, secrets...)
+		ctx = tflog.MaskMessageStrings(ctx, secrets...)
+	}
+	return ctx
+}
+
+// redactSubsystemLogs returns ctx with the logs of subsystem redacted:
+// the values of the sensitive fields, and the password and client
+// secret of the controller wherever they appear, are masked.
+func redactSubsystemLogs(ctx context.Context, subsystem string, config ControllerConfiguration) context.Context {
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, subsystem, sensitiveLogFields...)
+	if secrets := controllerSecrets(config); len(secrets) > 0 {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, subsystem, secrets...)
+		ctx = tflog.SubsystemMaskMessageStrings(ctx, subsystem, secrets...)
+	}
+	return ctx
+}
+
+// controllerSecrets returns the credentials of config to mask in logs.
+func controllerSecrets(config ControllerConfiguration) []string {
+	var secrets []string
+	for _, secret := range []string{config.Password, config.ClientSecret} {
+		if secret != "" {
+			secrets = append(secrets, secret)
+		}
+	}
+	return secrets
+}
+
+// controllerField returns the value of the LogFieldController field.
+func controllerField(config ControllerConfiguration) string {
+	return strings.Join(config.ControllerAddresses, ",")
+}
+
+// modelFields returns the fields of an entry relating to the model
+// with the given UUID, merged with additionalFields.
+func modelFields(modelUUID string, additionalFields ...map[string]interface{}) map[string]interface{} {
+	fields := map[string]interface{}{LogFieldModelUUID: modelUUID}
+	for _, f := range additionalFields {
+		for k, v := range f {
+			fields[k] = v
+		}
+	}
+	return fields
+}
@@ -0,0 +1,62 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/suite"
+)
+
+type LoggingSuite struct {
+	suite.Suite
+}
+
+func (s *LoggingSuite) TestRedactSubsystemLogs() {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	ctx = tflog.NewSubsystem(ctx, LogJujuClient)
+	ctx = redactSubsystemLogs(ctx, LogJujuClient, ControllerConfiguration{
+		Password:     "hunter2",
+		ClientSecret: "s3cr3t",
+	})
+
+	tflog.SubsystemDebug(ctx, LogJujuClient, "logging in with hunter2", map[string]interface{}{
+		"password": "anything",
+		"error":    "client secret s3cr3t rejected",
+		"user":     "admin",
+	})
+
+	s.Assert().NotContains(output.String(), "hunter2")
+	s.Assert().NotContains(output.String(), "s3cr3t")
+	s.Assert().NotContains(output.String(), "anything")
+	s.Assert().Contains(output.String(), "admin")
+}
+
+func (s *LoggingSuite) TestRedactLogs() {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	ctx = RedactLogs(ctx, ControllerConfiguration{Password: "hunter2"})
+
+	tflog.Debug(ctx, "logging in with hunter2", map[string]interface{}{"client_secret": "s3cr3t"})
+
+	s.Assert().NotContains(output.String(), "hunter2")
+	s.Assert().NotContains(output.String(), "s3cr3t")
+	s.Assert().Contains(output.String(), RedactedValue)
+}
+
+func (s *LoggingSuite) TestControllerSecrets() {
+	s.Assert().Empty(controllerSecrets(ControllerConfiguration{}))
+	s.Assert().Equal([]string{"s3cr3t"}, controllerSecrets(ControllerConfiguration{ClientSecret: "s3cr3t"}))
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestLoggingSuite(t *testing.T) {
+	suite.Run(t, new(LoggingSuite))
+}
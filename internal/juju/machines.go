@@ -54,9 +54,12 @@ type CreateMachineInput struct {
 }
 
 type CreateMachineResponse struct {
-	ID     string
-	Base   string
-	Series string
+	ID   string
+	Base string
+	// Constraints are the constraints of the machine, as normalized
+	// by Juju, e.g. mem=4096M for mem=4G.
+	Constraints string
+	Series      string
 }
 
 type ReadMachineInput struct {
@@ -69,11 +72,19 @@ type ReadMachineResponse struct {
 	Base        string
 	Constraints string
 	Series      string
+	// InstanceID is the ID of the instance of the machine in the
+	// cloud, empty until the machine is provisioned.
+	InstanceID string
+	// Hostname and IPAddresses are those of the machine, as reported
+	// by its agent once started.
+	Hostname    string
+	IPAddresses []string
 }
 
 type DestroyMachineInput struct {
 	ModelName string
 	ID        string
+	DestroyOptions
 }
 
 func newMachinesClient(sc SharedClient) *machinesClient {
@@ -83,7 +94,7 @@ func newMachinesClient(sc SharedClient) *machinesClient {
 }
 
 func (c machinesClient) CreateMachine(ctx context.Context, input *CreateMachineInput) (*CreateMachineResponse, error) {
-	conn, err := c.GetConnection(&input.ModelName)
+	conn, err := c.GetConnection(ctx, &input.ModelName)
 	if err != nil {
 		return nil, err
 	}
@@ -112,7 +123,7 @@ func (c machinesClient) CreateMachine(ctx context.Context, input *CreateMachineI
 	if placement != "" {
 		machineParams.Placement, err = instance.ParsePlacement(placement)
 		if err == instance.ErrPlacementScopeMissing {
-			modelUUID, err := c.ModelUUID(input.ModelName)
+			modelUUID, err := c.ModelUUID(ctx, input.ModelName)
 			if err != nil {
 				return nil, err
 			}
@@ -178,9 +189,10 @@ func (c machinesClient) CreateMachine(ctx context.Context, input *CreateMachineI
 		ReadMachineInput{ModelName: input.ModelName, ID: machineID})
 
 	return &CreateMachineResponse{
-		ID:     machineID,
-		Base:   readResponse.Base,
-		Series: readResponse.Series,
+		ID:          machineID,
+		Base:        readResponse.Base,
+		Constraints: readResponse.Constraints,
+		Series:      readResponse.Series,
 	}, err
 }
 
@@ -303,9 +315,9 @@ func manualProvision(client manual.ProvisioningClientAPI,
 	}, nil
 }
 
-func (c machinesClient) ReadMachine(input ReadMachineInput) (ReadMachineResponse, error) {
+func (c machinesClient) ReadMachine(ctx context.Context, input ReadMachineInput) (ReadMachineResponse, error) {
 	var response ReadMachineResponse
-	conn, err := c.GetConnection(&input.ModelName)
+	conn, err := c.GetConnection(ctx, &input.ModelName)
 	if err != nil {
 		return response, err
 	}
@@ -313,7 +325,7 @@ func (c machinesClient) ReadMachine(input ReadMachineInput) (ReadMachineResponse
 
 	clientAPIClient := apiclient.NewClient(conn, c.JujuLogger())
 
-	status, err := clientAPIClient.Status(nil)
+	status, err := readStatus(ctx, c.SharedClient, conn, clientAPIClient, nil)
 	if err != nil {
 		return response, err
 	}
@@ -321,14 +333,14 @@ func (c machinesClient) ReadMachine(input ReadMachineInput) (ReadMachineResponse
 	machineIDParts := strings.Split(input.ID, "/")
 	machineStatus, exists := status.Machines[machineIDParts[0]]
 	if !exists {
-		return response, fmt.Errorf("no status returned for machine: %s", input.ID)
+		return response, errors.NotFoundf("machine %s", input.ID)
 	}
 	c.Tracef("ReadMachine:Machine status result", map[string]interface{}{"machineStatus": machineStatus})
 	if len(machineIDParts) > 1 {
 		// check for containers
 		machineStatus, exists = machineStatus.Containers[input.ID]
 		if !exists {
-			return response, fmt.Errorf("no status returned for container in machine: %s", input.ID)
+			return response, errors.NotFoundf("container %s", input.ID)
 		}
 	}
 	response.ID = machineStatus.Id
@@ -337,6 +349,9 @@ func (c machinesClient) ReadMachine(input ReadMachineInput) (ReadMachineResponse
 		return response, err
 	}
 	response.Constraints = machineStatus.Constraints
+	response.InstanceID = string(machineStatus.InstanceId)
+	response.Hostname = machineStatus.Hostname
+	response.IPAddresses = machineStatus.IPAddresses
 	return response, nil
 }
 
@@ -348,7 +363,7 @@ func (c machinesClient) readMachineWithRetryOnNotFound(ctx context.Context, inpu
 	err := retry.Call(retry.CallArgs{
 		Func: func() error {
 			var err error
-			output, err = c.ReadMachine(input)
+			output, err = c.ReadMachine(ctx, input)
 			typedErr := typedError(err)
 			if errors.Is(typedErr, errors.NotFound) {
 				return nil
@@ -373,8 +388,8 @@ func (c machinesClient) readMachineWithRetryOnNotFound(ctx context.Context, inpu
 	return output, err
 }
 
-func (c machinesClient) DestroyMachine(input *DestroyMachineInput) error {
-	conn, err := c.GetConnection(&input.ModelName)
+func (c machinesClient) DestroyMachine(ctx context.Context, input *DestroyMachineInput) error {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
 	if err != nil {
 		return err
 	}
@@ -382,10 +397,13 @@ func (c machinesClient) DestroyMachine(input *DestroyMachineInput) error {
 
 	machineAPIClient := apimachinemanager.NewClient(conn)
 
-	_, err = machineAPIClient.DestroyMachinesWithParams(false, false, false, (*time.Duration)(nil), input.ID)
-
+	force, maxWait := input.forceArgs()
+	results, err := machineAPIClient.DestroyMachinesWithParams(*force, false, false, maxWait, input.ID)
 	if err != nil {
-		return err
+		return input.destroyError(err)
+	}
+	if len(results) == 1 && results[0].Error != nil {
+		return input.destroyError(results[0].Error)
 	}
 
 	return nil
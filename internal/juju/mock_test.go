@@ -1,26 +1,30 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/juju/terraform-provider-juju/internal/juju (interfaces: SharedClient,ClientAPIClient,ApplicationAPIClient,ModelConfigAPIClient,ResourceAPIClient,SecretAPIClient)
+// Source: github.com/juju/terraform-provider-juju/internal/juju (interfaces: SharedClient,ActionAPIClient,ClientAPIClient,ApplicationAPIClient,MachineManagerAPIClient,ModelConfigAPIClient,ResourceAPIClient,SecretAPIClient,SecretBackendsAPIClient)
 //
 // Generated by this command:
 //
-//	mockgen -package juju -destination mock_test.go github.com/juju/terraform-provider-juju/internal/juju SharedClient,ClientAPIClient,ApplicationAPIClient,ModelConfigAPIClient,ResourceAPIClient,SecretAPIClient
+//	mockgen -package juju -destination mock_test.go github.com/juju/terraform-provider-juju/internal/juju SharedClient,ActionAPIClient,ClientAPIClient,ApplicationAPIClient,MachineManagerAPIClient,ModelConfigAPIClient,ResourceAPIClient,SecretAPIClient,SecretBackendsAPIClient
 //
 
 // Package juju is a generated GoMock package.
 package juju
 
 import (
+	context "context"
 	io "io"
 	reflect "reflect"
 
 	charm "github.com/juju/charm/v12"
 	resource "github.com/juju/charm/v12/resource"
 	api "github.com/juju/juju/api"
+	action "github.com/juju/juju/api/client/action"
 	application "github.com/juju/juju/api/client/application"
 	client "github.com/juju/juju/api/client/client"
 	resources "github.com/juju/juju/api/client/resources"
+	secretbackends "github.com/juju/juju/api/client/secretbackends"
 	secrets "github.com/juju/juju/api/client/secrets"
 	charm0 "github.com/juju/juju/api/common/charm"
+	base "github.com/juju/juju/core/base"
 	constraints "github.com/juju/juju/core/constraints"
 	model "github.com/juju/juju/core/model"
 	resources0 "github.com/juju/juju/core/resources"
@@ -95,18 +99,18 @@ func (mr *MockSharedClientMockRecorder) Errorf(arg0, arg1 any) *gomock.Call {
 }
 
 // GetConnection mocks base method.
-func (m *MockSharedClient) GetConnection(arg0 *string) (api.Connection, error) {
+func (m *MockSharedClient) GetConnection(arg0 context.Context, arg1 *string) (api.Connection, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetConnection", arg0)
+	ret := m.ctrl.Call(m, "GetConnection", arg0, arg1)
 	ret0, _ := ret[0].(api.Connection)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetConnection indicates an expected call of GetConnection.
-func (mr *MockSharedClientMockRecorder) GetConnection(arg0 any) *gomock.Call {
+func (mr *MockSharedClientMockRecorder) GetConnection(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnection", reflect.TypeOf((*MockSharedClient)(nil).GetConnection), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnection", reflect.TypeOf((*MockSharedClient)(nil).GetConnection), arg0, arg1)
 }
 
 // JujuLogger mocks base method.
@@ -124,33 +128,33 @@ func (mr *MockSharedClientMockRecorder) JujuLogger() *gomock.Call {
 }
 
 // ModelType mocks base method.
-func (m *MockSharedClient) ModelType(arg0 string) (model.ModelType, error) {
+func (m *MockSharedClient) ModelType(arg0 context.Context, arg1 string) (model.ModelType, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ModelType", arg0)
+	ret := m.ctrl.Call(m, "ModelType", arg0, arg1)
 	ret0, _ := ret[0].(model.ModelType)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ModelType indicates an expected call of ModelType.
-func (mr *MockSharedClientMockRecorder) ModelType(arg0 any) *gomock.Call {
+func (mr *MockSharedClientMockRecorder) ModelType(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModelType", reflect.TypeOf((*MockSharedClient)(nil).ModelType), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModelType", reflect.TypeOf((*MockSharedClient)(nil).ModelType), arg0, arg1)
 }
 
 // ModelUUID mocks base method.
-func (m *MockSharedClient) ModelUUID(arg0 string) (string, error) {
+func (m *MockSharedClient) ModelUUID(arg0 context.Context, arg1 string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ModelUUID", arg0)
+	ret := m.ctrl.Call(m, "ModelUUID", arg0, arg1)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ModelUUID indicates an expected call of ModelUUID.
-func (mr *MockSharedClientMockRecorder) ModelUUID(arg0 any) *gomock.Call {
+func (mr *MockSharedClientMockRecorder) ModelUUID(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModelUUID", reflect.TypeOf((*MockSharedClient)(nil).ModelUUID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModelUUID", reflect.TypeOf((*MockSharedClient)(nil).ModelUUID), arg0, arg1)
 }
 
 // RemoveModel mocks base method.
@@ -199,6 +203,59 @@ func (mr *MockSharedClientMockRecorder) Warnf(arg0 any, arg1 ...any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warnf", reflect.TypeOf((*MockSharedClient)(nil).Warnf), varargs...)
 }
 
+// MockActionAPIClient is a mock of ActionAPIClient interface.
+type MockActionAPIClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockActionAPIClientMockRecorder
+}
+
+// MockActionAPIClientMockRecorder is the mock recorder for MockActionAPIClient.
+type MockActionAPIClientMockRecorder struct {
+	mock *MockActionAPIClient
+}
+
+// NewMockActionAPIClient creates a new mock instance.
+func NewMockActionAPIClient(ctrl *gomock.Controller) *MockActionAPIClient {
+	mock := &MockActionAPIClient{ctrl: ctrl}
+	mock.recorder = &MockActionAPIClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActionAPIClient) EXPECT() *MockActionAPIClientMockRecorder {
+	return m.recorder
+}
+
+// Actions mocks base method.
+func (m *MockActionAPIClient) Actions(arg0 []string) ([]action.ActionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Actions", arg0)
+	ret0, _ := ret[0].([]action.ActionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Actions indicates an expected call of Actions.
+func (mr *MockActionAPIClientMockRecorder) Actions(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Actions", reflect.TypeOf((*MockActionAPIClient)(nil).Actions), arg0)
+}
+
+// Run mocks base method.
+func (m *MockActionAPIClient) Run(arg0 action.RunParams) (action.EnqueuedActions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", arg0)
+	ret0, _ := ret[0].(action.EnqueuedActions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockActionAPIClientMockRecorder) Run(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockActionAPIClient)(nil).Run), arg0)
+}
+
 // MockClientAPIClient is a mock of ClientAPIClient interface.
 type MockClientAPIClient struct {
 	ctrl     *gomock.Controller
@@ -499,6 +556,85 @@ func (mr *MockApplicationAPIClientMockRecorder) Unexpose(arg0, arg1 any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unexpose", reflect.TypeOf((*MockApplicationAPIClient)(nil).Unexpose), arg0, arg1)
 }
 
+// UnsetApplicationConfig mocks base method.
+func (m *MockApplicationAPIClient) UnsetApplicationConfig(arg0, arg1 string, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnsetApplicationConfig", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnsetApplicationConfig indicates an expected call of UnsetApplicationConfig.
+func (mr *MockApplicationAPIClientMockRecorder) UnsetApplicationConfig(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsetApplicationConfig", reflect.TypeOf((*MockApplicationAPIClient)(nil).UnsetApplicationConfig), arg0, arg1, arg2)
+}
+
+// UpdateApplicationBase mocks base method.
+func (m *MockApplicationAPIClient) UpdateApplicationBase(arg0 string, arg1 base.Base, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateApplicationBase", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateApplicationBase indicates an expected call of UpdateApplicationBase.
+func (mr *MockApplicationAPIClientMockRecorder) UpdateApplicationBase(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateApplicationBase", reflect.TypeOf((*MockApplicationAPIClient)(nil).UpdateApplicationBase), arg0, arg1, arg2)
+}
+
+// MockMachineManagerAPIClient is a mock of MachineManagerAPIClient interface.
+type MockMachineManagerAPIClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockMachineManagerAPIClientMockRecorder
+}
+
+// MockMachineManagerAPIClientMockRecorder is the mock recorder for MockMachineManagerAPIClient.
+type MockMachineManagerAPIClientMockRecorder struct {
+	mock *MockMachineManagerAPIClient
+}
+
+// NewMockMachineManagerAPIClient creates a new mock instance.
+func NewMockMachineManagerAPIClient(ctrl *gomock.Controller) *MockMachineManagerAPIClient {
+	mock := &MockMachineManagerAPIClient{ctrl: ctrl}
+	mock.recorder = &MockMachineManagerAPIClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMachineManagerAPIClient) EXPECT() *MockMachineManagerAPIClientMockRecorder {
+	return m.recorder
+}
+
+// UpgradeSeriesComplete mocks base method.
+func (m *MockMachineManagerAPIClient) UpgradeSeriesComplete(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpgradeSeriesComplete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpgradeSeriesComplete indicates an expected call of UpgradeSeriesComplete.
+func (mr *MockMachineManagerAPIClientMockRecorder) UpgradeSeriesComplete(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeSeriesComplete", reflect.TypeOf((*MockMachineManagerAPIClient)(nil).UpgradeSeriesComplete), arg0)
+}
+
+// UpgradeSeriesPrepare mocks base method.
+func (m *MockMachineManagerAPIClient) UpgradeSeriesPrepare(arg0, arg1 string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpgradeSeriesPrepare", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpgradeSeriesPrepare indicates an expected call of UpgradeSeriesPrepare.
+func (mr *MockMachineManagerAPIClientMockRecorder) UpgradeSeriesPrepare(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeSeriesPrepare", reflect.TypeOf((*MockMachineManagerAPIClient)(nil).UpgradeSeriesPrepare), arg0, arg1, arg2)
+}
+
 // MockModelConfigAPIClient is a mock of ModelConfigAPIClient interface.
 type MockModelConfigAPIClient struct {
 	ctrl     *gomock.Controller
@@ -729,3 +865,41 @@ func (mr *MockSecretAPIClientMockRecorder) UpdateSecret(arg0, arg1, arg2, arg3,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecret", reflect.TypeOf((*MockSecretAPIClient)(nil).UpdateSecret), arg0, arg1, arg2, arg3, arg4, arg5)
 }
+
+// MockSecretBackendsAPIClient is a mock of SecretBackendsAPIClient interface.
+type MockSecretBackendsAPIClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecretBackendsAPIClientMockRecorder
+}
+
+// MockSecretBackendsAPIClientMockRecorder is the mock recorder for MockSecretBackendsAPIClient.
+type MockSecretBackendsAPIClientMockRecorder struct {
+	mock *MockSecretBackendsAPIClient
+}
+
+// NewMockSecretBackendsAPIClient creates a new mock instance.
+func NewMockSecretBackendsAPIClient(ctrl *gomock.Controller) *MockSecretBackendsAPIClient {
+	mock := &MockSecretBackendsAPIClient{ctrl: ctrl}
+	mock.recorder = &MockSecretBackendsAPIClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecretBackendsAPIClient) EXPECT() *MockSecretBackendsAPIClientMockRecorder {
+	return m.recorder
+}
+
+// ListSecretBackends mocks base method.
+func (m *MockSecretBackendsAPIClient) ListSecretBackends(arg0 []string, arg1 bool) ([]secretbackends.SecretBackend, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecretBackends", arg0, arg1)
+	ret0, _ := ret[0].([]secretbackends.SecretBackend)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecretBackends indicates an expected call of ListSecretBackends.
+func (mr *MockSecretBackendsAPIClientMockRecorder) ListSecretBackends(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecretBackends", reflect.TypeOf((*MockSecretBackendsAPIClient)(nil).ListSecretBackends), arg0, arg1)
+}
@@ -4,19 +4,45 @@
 package juju
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
+	"github.com/juju/juju/api"
+	apiclient "github.com/juju/juju/api/client/client"
 	"github.com/juju/juju/api/client/modelconfig"
 	"github.com/juju/juju/api/client/modelmanager"
+	"github.com/juju/juju/api/client/modelupgrader"
+	apispaces "github.com/juju/juju/api/client/spaces"
+	apistorage "github.com/juju/juju/api/client/storage"
+	apicontroller "github.com/juju/juju/api/controller/controller"
 	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/names/v5"
+	"github.com/juju/retry"
+	slaapi "github.com/juju/romulus/api/sla"
+	"github.com/juju/version/v2"
+	"gopkg.in/macaroon.v2"
 )
 
 var ModelNotFoundError = &modelNotFoundError{}
 
+const (
+	// defaultModelAvailableTimeout is the time to wait for a model to
+	// be available once created, when its create has no deadline.
+	defaultModelAvailableTimeout = time.Minute * 5
+)
+
+// newModelConnectDelay is the delay between the attempts to connect to
+// a model just created.
+var newModelConnectDelay = time.Second
+
 type modelNotFoundError struct {
 	uuid string
 	name string
@@ -30,6 +56,11 @@ func (me *modelNotFoundError) Error() string {
 	return fmt.Sprintf(toReturn, me.uuid)
 }
 
+// Is reports the error to be a NotFound error.
+func (me *modelNotFoundError) Is(target error) bool {
+	return target == errors.NotFound
+}
+
 type modelsClient struct {
 	SharedClient
 }
@@ -47,6 +78,9 @@ type CreateModelInput struct {
 	Config      map[string]string
 	Credential  string
 	Constraints constraints.Value
+	// SLA is the support level of the model, set after it is
+	// created if not nil.
+	SLA *ModelSLA
 }
 
 type CreateModelResponse struct {
@@ -55,21 +89,48 @@ type CreateModelResponse struct {
 	CloudCredentialName string
 	Type                string
 	UUID                string
+	ControllerUUID      string
 }
 
 type ReadModelResponse struct {
 	ModelInfo        params.ModelInfo
 	ModelConfig      map[string]interface{}
 	ModelConstraints constraints.Value
+	// MachineCount and ApplicationCount are the numbers of machines
+	// and applications in the model, as shown by `juju status`.
+	MachineCount     int
+	ApplicationCount int
 }
 
 type UpdateModelInput struct {
+	// Name is the name or the UUID of the model.
 	Name        string
 	CloudName   string
 	Config      map[string]string
 	Unset       []string
 	Constraints *constraints.Value
 	Credential  string
+	// SLA is the support level of the model, set if not nil.
+	SLA *ModelSLA
+}
+
+// The support levels of a model.
+const (
+	SLAUnsupported = "unsupported"
+	SLAEssential   = "essential"
+	SLAStandard    = "standard"
+	SLAAdvanced    = "advanced"
+)
+
+// ModelSLA is the support level of a model, like `juju sla`.
+type ModelSLA struct {
+	// Level is the support level, one of unsupported, essential,
+	// standard and advanced.
+	Level string
+	// Budget is the maximum spend of the support, authorized with
+	// the commercial support service for the levels other than
+	// unsupported.
+	Budget string
 }
 
 type UpdateAccessModelInput struct {
@@ -82,6 +143,40 @@ type UpdateAccessModelInput struct {
 
 type DestroyModelInput struct {
 	UUID string
+	DestroyOptions
+	// KeepStorage releases the storage of the model from Juju instead
+	// of destroying it, like `juju destroy-model --release-storage`.
+	KeepStorage bool
+	// Timeout is how long the destroy of the model may take before it
+	// fails. If zero, the time left to the deadline of the context, or
+	// 30 minutes without one.
+	Timeout time.Duration
+}
+
+type MigrateModelInput struct {
+	// UUID is the UUID of the model to migrate.
+	UUID string
+	// The target controller, its UUID, the alias of the controller
+	// the model is known under once migrated, its API addresses and
+	// CA certificate, and the credentials of the user the model is
+	// migrated as.
+	TargetControllerUUID  string
+	TargetControllerAlias string
+	TargetAddresses       []string
+	TargetCACert          string
+	TargetUser            string
+	TargetPassword        string
+}
+
+type ReadModelMigrationResponse struct {
+	// Status is the status of the latest migration of the model, as
+	// shown by `juju show-model`, empty if none.
+	Status string
+	Start  *time.Time
+	End    *time.Time
+	// Migrated reports whether the model has left the controller, its
+	// migration being complete.
+	Migrated bool
 }
 
 type DestroyAccessModelInput struct {
@@ -90,15 +185,171 @@ type DestroyAccessModelInput struct {
 	Access    string
 }
 
+type ListModelsInput struct {
+	// Name and Owner filter the models by name and by owner, all the
+	// models the user can see are listed if empty.
+	Name  string
+	Owner string
+}
+
+type ListModelsOutput struct {
+	// Models are sorted by owner and name.
+	Models []ModelSummary
+}
+
+type ReadModelConfigInput struct {
+	// ModelName is the name or the UUID of the model.
+	ModelName string
+}
+
+type ReadModelConfigOutput struct {
+	// Config holds the effective value of every key of the config of
+	// the model.
+	Config map[string]ModelConfigValue
+}
+
+// ModelConfigValue is the effective value of a key of the config of a
+// model.
+type ModelConfigValue struct {
+	// Value is the value in the string form used by the config of the
+	// juju_model resource.
+	Value string
+	// Source is where the value comes from, e.g. default, controller,
+	// region or model.
+	Source string
+}
+
+type ReadModelDefaultsInput struct {
+	// Cloud is the cloud of the defaults.
+	Cloud string
+	// Region is the region of the defaults, the defaults of the cloud
+	// if empty.
+	Region string
+}
+
+type ReadModelDefaultsOutput struct {
+	// Config are the defaults set for the cloud or the region, in the
+	// string form used by the config of the juju_model resource.
+	Config map[string]string
+}
+
+type UpdateModelDefaultsInput struct {
+	Cloud  string
+	Region string
+	// Config are the defaults to set, Unset the keys to reset.
+	Config map[string]string
+	Unset  []string
+}
+
+type ListSpacesInput struct {
+	// ModelName is the name or the UUID of the model.
+	ModelName string
+}
+
+type ListSpacesOutput struct {
+	// Spaces are sorted by name.
+	Spaces []Space
+}
+
+// Space is a network space of a model.
+type Space struct {
+	ID   string
+	Name string
+	// ProviderID is the ID of the space in the cloud, empty if the
+	// cloud does not support spaces.
+	ProviderID string
+	// Subnets are sorted by CIDR.
+	Subnets []Subnet
+}
+
+// Subnet is a subnet of a space.
+type Subnet struct {
+	CIDR              string
+	ProviderID        string
+	ProviderNetworkID string
+	VLANTag           int
+	Zones             []string
+}
+
+type ListStoragePoolsInput struct {
+	// ModelName is the name or the UUID of the model.
+	ModelName string
+}
+
+type ListStoragePoolsOutput struct {
+	// Pools are sorted by name.
+	Pools []StoragePool
+}
+
+// StoragePool is a storage pool of a model.
+type StoragePool struct {
+	Name     string
+	Provider string
+	// Attributes are in the string form of configValueString.
+	Attributes map[string]string
+}
+
+// ModelSummary holds the details of a model listed.
+type ModelSummary struct {
+	Name        string
+	Owner       string
+	UUID        string
+	Type        string
+	Cloud       string
+	CloudRegion string
+	Life        string
+}
+
 func newModelsClient(sc SharedClient) *modelsClient {
 	return &modelsClient{
 		SharedClient: sc,
 	}
 }
 
+// ListModels lists the models the user can see.
+func (c *modelsClient) ListModels(ctx context.Context, input ListModelsInput) (ListModelsOutput, error) {
+	conn, err := c.GetConnection(ctx, nil)
+	if err != nil {
+		return ListModelsOutput{}, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	summaries, err := modelmanager.NewClient(conn).ListModelSummaries(conn.AuthTag().Id(), false)
+	if err != nil {
+		return ListModelsOutput{}, err
+	}
+
+	output := ListModelsOutput{Models: make([]ModelSummary, 0, len(summaries))}
+	for _, summary := range summaries {
+		if summary.Error != nil {
+			return ListModelsOutput{}, summary.Error
+		}
+		if (input.Name != "" && summary.Name != input.Name) || (input.Owner != "" && summary.Owner != input.Owner) {
+			continue
+		}
+		output.Models = append(output.Models, ModelSummary{
+			Name:        summary.Name,
+			Owner:       summary.Owner,
+			UUID:        summary.UUID,
+			Type:        summary.Type.String(),
+			Cloud:       summary.Cloud,
+			CloudRegion: summary.CloudRegion,
+			Life:        string(summary.Life),
+		})
+	}
+	sort.Slice(output.Models, func(i, j int) bool {
+		a, b := output.Models[i], output.Models[j]
+		if a.Owner != b.Owner {
+			return a.Owner < b.Owner
+		}
+		return a.Name < b.Name
+	})
+	return output, nil
+}
+
 // GetModelByName retrieves a model by name
-func (c *modelsClient) GetModelByName(name string) (*params.ModelInfo, error) {
-	conn, err := c.GetConnection(nil)
+func (c *modelsClient) GetModelByName(ctx context.Context, name string) (*params.ModelInfo, error) {
+	conn, err := c.GetConnection(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -106,7 +357,7 @@ func (c *modelsClient) GetModelByName(name string) (*params.ModelInfo, error) {
 
 	client := modelmanager.NewClient(conn)
 
-	modelUUID, err := c.ModelUUID(name)
+	modelUUID, err := c.ModelUUID(ctx, name)
 	if err != nil {
 		return nil, err
 	}
@@ -128,7 +379,7 @@ func (c *modelsClient) GetModelByName(name string) (*params.ModelInfo, error) {
 	return modelInfo, nil
 }
 
-func (c *modelsClient) CreateModel(input CreateModelInput) (CreateModelResponse, error) {
+func (c *modelsClient) CreateModel(ctx context.Context, input CreateModelInput) (CreateModelResponse, error) {
 	resp := CreateModelResponse{}
 
 	modelName := input.Name
@@ -136,7 +387,7 @@ func (c *modelsClient) CreateModel(input CreateModelInput) (CreateModelResponse,
 		return resp, fmt.Errorf("%q is not a valid name: model names may only contain lowercase letters, digits and hyphens", modelName)
 	}
 
-	conn, err := c.GetConnection(nil)
+	conn, err := c.GetConnection(ctx, nil)
 	if err != nil {
 		return resp, err
 	}
@@ -174,40 +425,282 @@ func (c *modelsClient) CreateModel(input CreateModelInput) (CreateModelResponse,
 	resp.CloudCredentialName = names.NewCloudCredentialTag(modelInfo.CloudCredential).Name()
 	resp.Type = modelInfo.Type.String()
 	resp.UUID = modelInfo.UUID
+	resp.ControllerUUID = modelInfo.ControllerUUID
 
 	// Add a model object on the client internal to the provider
-	c.AddModel(modelInfo.Name, modelInfo.UUID, modelInfo.Type)
+	c.AddModel(modelInfo.Owner+"/"+modelInfo.Name, modelInfo.UUID, modelInfo.Type)
 
-	// set constraints when required
-	if input.Constraints.String() == "" {
-		return resp, nil
+	// establish a new connection with the created model, available
+	// once it can be connected to and its status is available, so that
+	// the resources created in it next do not fail.
+	timeout := modelAvailableTimeout(ctx)
+	connModel, err := c.connectNewModel(ctx, modelName, timeout)
+	if err != nil {
+		return resp, errors.Annotatef(err, "connecting to model %q", modelName)
 	}
+	defer func() { _ = connModel.Close() }()
 
-	// we have to set constraints ...
-	// establish a new connection with the created model through the modelconfig api to set constraints
-	connModel, err := c.GetConnection(&modelName)
+	err = waitForModel(ctx, c.SharedClient, connModel, EntityStatus(EntityKindModel, modelName, "available"), timeout)
 	if err != nil {
-		return resp, err
+		return resp, errors.Annotatef(err, "waiting for model %q to be available", modelName)
 	}
-	defer func() { _ = conn.Close() }()
 
 	modelClient := modelconfig.NewClient(connModel)
-	err = modelClient.SetModelConstraints(input.Constraints)
-	if err != nil {
-		return resp, err
+	if input.Constraints.String() != "" {
+		err = modelClient.SetModelConstraints(input.Constraints)
+		if err != nil {
+			return resp, err
+		}
+	}
+
+	if input.SLA != nil {
+		err = c.setModelSLA(ctx, modelClient, modelInfo.UUID, *input.SLA)
+		if err != nil {
+			return resp, err
+		}
 	}
 
 	return resp, nil
 }
 
-func (c *modelsClient) ReadModel(name string) (*ReadModelResponse, error) {
-	modelmanagerConn, err := c.GetConnection(nil)
+// modelAvailableTimeout returns how long to wait for a model to be
+// available once created: the time left to the deadline of ctx, e.g.
+// the create timeout of the resource, or defaultModelAvailableTimeout.
+func modelAvailableTimeout(ctx context.Context) time.Duration {
+	return timeoutFromContext(ctx, defaultModelAvailableTimeout)
+}
+
+// connectNewModel connects to a model just created, retrying until the
+// controller accepts the connection or timeout expires, as the model
+// may not be ready to be connected to yet.
+func (c *modelsClient) connectNewModel(ctx context.Context, modelName string, timeout time.Duration) (api.Connection, error) {
+	var conn api.Connection
+	err := retry.Call(retry.CallArgs{
+		Func: func() error {
+			var err error
+			conn, err = c.GetConnection(ctx, &modelName)
+			return err
+		},
+		NotifyFunc: func(err error, attempt int) {
+			c.Debugf(fmt.Sprintf("connecting to new model %q, attempt %d", modelName, attempt),
+				map[string]interface{}{"err": err.Error()})
+		},
+		MaxDuration: timeout,
+		Delay:       newModelConnectDelay,
+		Clock:       clock.WallClock,
+		Stop:        ctx.Done(),
+	})
+	if retry.IsDurationExceeded(err) || retry.IsRetryStopped(err) {
+		return nil, retry.LastError(err)
+	}
+	return conn, err
+}
+
+// ListSpaces lists the network spaces of a model.
+func (c *modelsClient) ListSpaces(ctx context.Context, input ListSpacesInput) (ListSpacesOutput, error) {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
+	if err != nil {
+		return ListSpacesOutput{}, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	spaces, err := apispaces.NewAPI(conn).ListSpaces()
+	if err != nil {
+		return ListSpacesOutput{}, err
+	}
+
+	output := ListSpacesOutput{Spaces: make([]Space, 0, len(spaces))}
+	for _, s := range spaces {
+		if s.Error != nil {
+			return ListSpacesOutput{}, s.Error
+		}
+		space := Space{ID: s.Id, Name: s.Name, Subnets: make([]Subnet, 0, len(s.Subnets))}
+		for _, subnet := range s.Subnets {
+			if space.ProviderID == "" {
+				space.ProviderID = subnet.ProviderSpaceId
+			}
+			space.Subnets = append(space.Subnets, Subnet{
+				CIDR:              subnet.CIDR,
+				ProviderID:        subnet.ProviderId,
+				ProviderNetworkID: subnet.ProviderNetworkId,
+				VLANTag:           subnet.VLANTag,
+				Zones:             subnet.Zones,
+			})
+		}
+		sort.Slice(space.Subnets, func(i, j int) bool {
+			return space.Subnets[i].CIDR < space.Subnets[j].CIDR
+		})
+		output.Spaces = append(output.Spaces, space)
+	}
+	sort.Slice(output.Spaces, func(i, j int) bool {
+		return output.Spaces[i].Name < output.Spaces[j].Name
+	})
+	return output, nil
+}
+
+// ListStoragePools lists the storage pools of a model, including the
+// default pools of its storage providers.
+func (c *modelsClient) ListStoragePools(ctx context.Context, input ListStoragePoolsInput) (ListStoragePoolsOutput, error) {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
+	if err != nil {
+		return ListStoragePoolsOutput{}, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	pools, err := apistorage.NewClient(conn).ListPools(nil, nil)
+	if err != nil {
+		return ListStoragePoolsOutput{}, err
+	}
+
+	output := ListStoragePoolsOutput{Pools: make([]StoragePool, 0, len(pools))}
+	for _, p := range pools {
+		pool := StoragePool{Name: p.Name, Provider: p.Provider, Attributes: make(map[string]string, len(p.Attrs))}
+		for k, v := range p.Attrs {
+			value, err := configValueString(v)
+			if err != nil {
+				return ListStoragePoolsOutput{}, errors.Annotatef(err, "attribute %q of storage pool %q", k, p.Name)
+			}
+			pool.Attributes[k] = value
+		}
+		output.Pools = append(output.Pools, pool)
+	}
+	sort.Slice(output.Pools, func(i, j int) bool {
+		return output.Pools[i].Name < output.Pools[j].Name
+	})
+	return output, nil
+}
+
+// ReadModelConfig reads the effective config of a model, including the
+// keys not set on the model.
+func (c *modelsClient) ReadModelConfig(ctx context.Context, input ReadModelConfigInput) (ReadModelConfigOutput, error) {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
+	if err != nil {
+		return ReadModelConfigOutput{}, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	values, err := modelconfig.NewClient(conn).ModelGetWithMetadata()
+	if err != nil {
+		return ReadModelConfigOutput{}, err
+	}
+
+	output := ReadModelConfigOutput{Config: make(map[string]ModelConfigValue, len(values))}
+	for k, v := range values {
+		value, err := configValueString(v.Value)
+		if err != nil {
+			return ReadModelConfigOutput{}, errors.Annotatef(err, "model config %q", k)
+		}
+		output.Config[k] = ModelConfigValue{Value: value, Source: v.Source}
+	}
+	return output, nil
+}
+
+// ReadModelDefaults reads the defaults of the config of the models set
+// for a cloud or one of its regions, like `juju model-defaults`.
+func (c *modelsClient) ReadModelDefaults(ctx context.Context, input ReadModelDefaultsInput) (ReadModelDefaultsOutput, error) {
+	conn, err := c.GetConnection(ctx, nil)
+	if err != nil {
+		return ReadModelDefaultsOutput{}, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	defaults, err := modelmanager.NewClient(conn).ModelDefaults(input.Cloud)
+	if err != nil {
+		return ReadModelDefaultsOutput{}, err
+	}
+	config, err := modelDefaultsConfig(defaults, input.Region)
+	if err != nil {
+		return ReadModelDefaultsOutput{}, err
+	}
+	return ReadModelDefaultsOutput{Config: config}, nil
+}
+
+// modelDefaultsConfig returns the defaults set for the cloud, or for
+// the region if not empty, rather than the defaults of Juju.
+func modelDefaultsConfig(defaults config.ModelDefaultAttributes, region string) (map[string]string, error) {
+	values := make(map[string]string)
+	for key, attr := range defaults {
+		value := attr.Controller
+		if region != "" {
+			value = nil
+			for _, r := range attr.Regions {
+				if r.Name == region {
+					value = r.Value
+				}
+			}
+		}
+		if value == nil {
+			continue
+		}
+		s, err := configValueString(value)
+		if err != nil {
+			return nil, errors.Annotatef(err, "model default %q", key)
+		}
+		values[key] = s
+	}
+	return values, nil
+}
+
+// UpdateModelDefaults sets and resets the defaults of the config of
+// the models for a cloud or one of its regions.
+func (c *modelsClient) UpdateModelDefaults(ctx context.Context, input UpdateModelDefaultsInput) error {
+	conn, err := c.GetConnection(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	client := modelmanager.NewClient(conn)
+	if len(input.Config) > 0 {
+		values := make(map[string]interface{}, len(input.Config))
+		for key, value := range input.Config {
+			values[key] = value
+		}
+		if err := client.SetModelDefaults(input.Cloud, input.Region, values); err != nil {
+			return err
+		}
+	}
+	if len(input.Unset) > 0 {
+		if err := client.UnsetModelDefaults(input.Cloud, input.Region, input.Unset...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// configValueString returns the string form of a config value, e.g. of
+// a model or a storage pool, JSON for the values which are not scalars.
+func configValueString(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+}
+
+func (c *modelsClient) ReadModel(ctx context.Context, name string) (*ReadModelResponse, error) {
+	modelmanagerConn, err := c.GetConnection(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = modelmanagerConn.Close() }()
 
-	modelconfigConn, err := c.GetConnection(&name)
+	modelconfigConn, err := c.GetConnection(ctx, &name)
 	if err != nil {
 		return nil, errors.Wrap(err, &modelNotFoundError{uuid: name})
 	}
@@ -248,15 +741,22 @@ func (c *modelsClient) ReadModel(name string) (*ReadModelResponse, error) {
 		return nil, err
 	}
 
+	status, err := apiclient.NewClient(modelconfigConn, c.JujuLogger()).Status(nil)
+	if err != nil {
+		return nil, err
+	}
+
 	return &ReadModelResponse{
 		ModelInfo:        modelInfo,
 		ModelConfig:      modelConfig,
 		ModelConstraints: modelConstraints,
+		MachineCount:     len(status.Machines),
+		ApplicationCount: len(status.Applications),
 	}, nil
 }
 
-func (c *modelsClient) UpdateModel(input UpdateModelInput) error {
-	conn, err := c.GetConnection(&input.Name)
+func (c *modelsClient) UpdateModel(ctx context.Context, input UpdateModelInput) error {
+	conn, err := c.GetConnection(ctx, &input.Name)
 	if err != nil {
 		return err
 	}
@@ -297,11 +797,11 @@ func (c *modelsClient) UpdateModel(input UpdateModelInput) error {
 			return err
 		}
 		// open new connection to get facade versions correctly
-		connModelManager, err := c.GetConnection(nil)
+		connModelManager, err := c.GetConnection(ctx, nil)
 		if err != nil {
 			return err
 		}
-		defer func() { _ = conn.Close() }()
+		defer func() { _ = connModelManager.Close() }()
 		modelUUIDTag, modelOk := conn.ModelTag()
 		if !modelOk {
 			return errors.Errorf("Not connected to model %q", input.Name)
@@ -312,11 +812,60 @@ func (c *modelsClient) UpdateModel(input UpdateModelInput) error {
 		}
 	}
 
+	if input.SLA != nil {
+		modelUUIDTag, modelOk := conn.ModelTag()
+		if !modelOk {
+			return errors.Errorf("Not connected to model %q", input.Name)
+		}
+		if err := c.setModelSLA(ctx, client, modelUUIDTag.Id(), *input.SLA); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setModelSLA sets the support level of the model, like `juju sla`.
+// The levels other than unsupported are authorized with the commercial
+// support service of the controller, its metering URL, for the budget.
+func (c *modelsClient) setModelSLA(ctx context.Context, client *modelconfig.Client, uuid string, sla ModelSLA) error {
+	var owner string
+	var credentials []byte
+	if sla.Level != "" && sla.Level != SLAUnsupported {
+		conn, err := c.GetConnection(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = conn.Close() }()
+
+		controllerConfig, err := apicontroller.NewClient(conn).ControllerConfig()
+		if err != nil {
+			return err
+		}
+		authClient, err := slaapi.NewClient(slaapi.APIRoot(controllerConfig.MeteringURL()))
+		if err != nil {
+			return err
+		}
+		authorization, err := authClient.Authorize(uuid, sla.Level, sla.Budget)
+		if err != nil {
+			return errors.Annotatef(err, "authorizing support level %q", sla.Level)
+		}
+		credentials, err = json.Marshal(macaroon.Slice{authorization.Credentials})
+		if err != nil {
+			return err
+		}
+		owner = authorization.Owner
+	}
+
+	if err := client.SetSLALevel(sla.Level, owner, credentials); err != nil {
+		return err
+	}
+	c.Tracef("SetSLALevel", map[string]interface{}{"model": uuid, "level": sla.Level, "owner": owner})
 	return nil
 }
 
-func (c *modelsClient) DestroyModel(input DestroyModelInput) error {
-	conn, err := c.GetConnection(nil)
+func (c *modelsClient) DestroyModel(ctx context.Context, input DestroyModelInput) error {
+	conn, err := c.GetConnection(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -325,15 +874,25 @@ func (c *modelsClient) DestroyModel(input DestroyModelInput) error {
 	client := modelmanager.NewClient(conn)
 
 	maxWait := 10 * time.Minute
+	// The controller gives up destroying the model after timeout, the
+	// one of the input, else the delete timeout of the resource if set.
 	timeout := 30 * time.Minute
+	if input.Timeout > 0 {
+		timeout = input.Timeout
+	} else if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
 
 	tag := names.NewModelTag(input.UUID)
 
-	destroyStorage := true
-	forceDestroy := false
+	destroyStorage := !input.KeepStorage
+	forceDestroy, forceMaxWait := input.forceArgs()
+	if forceMaxWait != nil {
+		maxWait = *forceMaxWait
+	}
 
-	err = client.DestroyModel(tag, &destroyStorage, &forceDestroy, &maxWait, &timeout)
-	if err != nil {
+	err = client.DestroyModel(tag, &destroyStorage, forceDestroy, &maxWait, &timeout)
+	if err = input.destroyError(err); err != nil {
 		return err
 	}
 
@@ -341,8 +900,106 @@ func (c *modelsClient) DestroyModel(input DestroyModelInput) error {
 	return nil
 }
 
-func (c *modelsClient) GrantModel(input GrantModelInput) error {
-	conn, err := c.GetConnection(nil)
+// MigrateModel initiates the migration of the model to the target
+// controller, like `juju migrate`, and returns the ID of the migration.
+// The migration carries on asynchronously, see ReadModelMigration.
+func (c *modelsClient) MigrateModel(ctx context.Context, input MigrateModelInput) (string, error) {
+	conn, err := c.GetConnection(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = conn.Close() }()
+
+	migrationID, err := apicontroller.NewClient(conn).InitiateMigration(apicontroller.MigrationSpec{
+		ModelUUID:             input.UUID,
+		TargetControllerUUID:  input.TargetControllerUUID,
+		TargetControllerAlias: input.TargetControllerAlias,
+		TargetAddrs:           input.TargetAddresses,
+		TargetCACert:          input.TargetCACert,
+		TargetUser:            input.TargetUser,
+		TargetPassword:        input.TargetPassword,
+	})
+	if err != nil {
+		return "", errors.Annotatef(err, "migrating model %q", input.UUID)
+	}
+	c.Tracef("MigrateModel", map[string]interface{}{"model": input.UUID, "migration": migrationID})
+	return migrationID, nil
+}
+
+// ReadModelMigration reads the status of the latest migration of the
+// model. A model which is not found, or redirected to another
+// controller, is migrated.
+func (c *modelsClient) ReadModelMigration(ctx context.Context, uuid string) (*ReadModelMigrationResponse, error) {
+	conn, err := c.GetConnection(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	results, err := modelmanager.NewClient(conn).ModelInfo([]names.ModelTag{names.NewModelTag(uuid)})
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != 1 {
+		return nil, fmt.Errorf("expected one model info result, got %d", len(results))
+	}
+	if err := results[0].Error; err != nil {
+		if params.IsCodeNotFound(err) || params.IsRedirect(err) {
+			c.RemoveModel(uuid)
+			return &ReadModelMigrationResponse{Migrated: true}, nil
+		}
+		return nil, err
+	}
+
+	response := &ReadModelMigrationResponse{}
+	if migration := results[0].Result.Migration; migration != nil {
+		response.Status = migration.Status
+		response.Start = migration.Start
+		response.End = migration.End
+	}
+	return response, nil
+}
+
+// WaitForModelMigration waits for the latest migration of the model to
+// end, the model being migrated or the migration aborted, for at most
+// timeout, and returns its status.
+func (c *modelsClient) WaitForModelMigration(ctx context.Context, uuid string, timeout time.Duration) (*ReadModelMigrationResponse, error) {
+	var response *ReadModelMigrationResponse
+	errMigrating := errors.New("model migrating")
+	err := retry.Call(retry.CallArgs{
+		Func: func() error {
+			var err error
+			response, err = c.ReadModelMigration(ctx, uuid)
+			if err != nil {
+				return err
+			}
+			if !response.Migrated && response.End == nil {
+				return errMigrating
+			}
+			return nil
+		},
+		IsFatalError: func(err error) bool {
+			return !errors.Is(err, errMigrating)
+		},
+		NotifyFunc: func(err error, attempt int) {
+			if attempt%4 == 0 {
+				c.Debugf(fmt.Sprintf("waiting for the migration of model %q", uuid),
+					map[string]interface{}{"status": response.Status})
+			}
+		},
+		MaxDuration: timeout,
+		Delay:       5 * time.Second,
+		Clock:       clock.WallClock,
+		Stop:        ctx.Done(),
+	})
+	if retry.IsRetryStopped(err) || retry.IsDurationExceeded(err) {
+		return response, errors.Errorf("timed out waiting for the migration of model %q, status: %s", uuid, response.Status)
+	}
+	return response, err
+}
+
+func (c *modelsClient) GrantModel(ctx context.Context, input GrantModelInput) error {
+	conn, err := c.GetConnection(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -350,7 +1007,7 @@ func (c *modelsClient) GrantModel(input GrantModelInput) error {
 
 	client := modelmanager.NewClient(conn)
 
-	modelUUID, err := c.ModelUUID(input.ModelName)
+	modelUUID, err := c.ModelUUID(ctx, input.ModelName)
 	if err != nil {
 		return err
 	}
@@ -366,16 +1023,16 @@ func (c *modelsClient) GrantModel(input GrantModelInput) error {
 // Note we do a revoke against `read` to remove the user from the model access
 // If a user has had `write`, then removing that access would decrease their
 // access to `read` and the user will remain part of the model access.
-func (c *modelsClient) UpdateAccessModel(input UpdateAccessModelInput) error {
+func (c *modelsClient) UpdateAccessModel(ctx context.Context, input UpdateAccessModelInput) error {
 	model := input.ModelName
 	access := input.OldAccess
 
-	uuid, err := c.ModelUUID(model)
+	uuid, err := c.ModelUUID(ctx, model)
 	if err != nil {
 		return err
 	}
 
-	conn, err := c.GetConnection(nil)
+	conn, err := c.GetConnection(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -403,8 +1060,8 @@ func (c *modelsClient) UpdateAccessModel(input UpdateAccessModelInput) error {
 // Note we do a revoke against `read` to remove the user from the model access
 // If a user has had `write`, then removing that access would decrease their
 // access to `read` and the user will remain part of the model access.
-func (c *modelsClient) DestroyAccessModel(input DestroyAccessModelInput) error {
-	conn, err := c.GetConnection(nil)
+func (c *modelsClient) DestroyAccessModel(ctx context.Context, input DestroyAccessModelInput) error {
+	conn, err := c.GetConnection(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -412,7 +1069,7 @@ func (c *modelsClient) DestroyAccessModel(input DestroyAccessModelInput) error {
 
 	client := modelmanager.NewClient(conn)
 
-	uuid, err := c.ModelUUID(input.ModelName)
+	uuid, err := c.ModelUUID(ctx, input.ModelName)
 	if err != nil {
 		return err
 	}
@@ -426,3 +1083,82 @@ func (c *modelsClient) DestroyAccessModel(input DestroyAccessModelInput) error {
 
 	return nil
 }
+
+type ReadAgentVersionsInput struct {
+	ModelName string
+	// AgentStream is the simplestreams stream used to find upgrade
+	// targets. The model configuration is used when empty.
+	AgentStream string
+}
+
+type ReadAgentVersionsResponse struct {
+	ControllerVersion string
+	ModelVersion      string
+	// ModelUpgradeTarget is the version the model would be upgraded to
+	// by `juju upgrade-model`, empty when the model is up to date.
+	ModelUpgradeTarget string
+	// ControllerUpgradeTarget is the version the controller would be
+	// upgraded to by `juju upgrade-controller`, empty when the controller
+	// is up to date or the user may not upgrade it.
+	ControllerUpgradeTarget string
+}
+
+// ReadAgentVersions returns the agent versions of the controller and of
+// a model, along with the versions they can be upgraded to. Upgrade
+// targets are found with a dry run of the upgrade.
+func (c *modelsClient) ReadAgentVersions(ctx context.Context, input ReadAgentVersionsInput) (*ReadAgentVersionsResponse, error) {
+	modelInfo, err := c.GetModelByName(ctx, input.ModelName)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := c.GetConnection(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	response := &ReadAgentVersionsResponse{}
+	if controllerVersion, ok := conn.ServerVersion(); ok {
+		response.ControllerVersion = controllerVersion.String()
+	}
+	if modelInfo.AgentVersion != nil {
+		response.ModelVersion = modelInfo.AgentVersion.String()
+	}
+
+	client := modelupgrader.NewClient(conn)
+	response.ModelUpgradeTarget, err = c.upgradeTarget(client, modelInfo.UUID, input.AgentStream)
+	if err != nil {
+		return nil, errors.Annotatef(err, "finding upgrade target of model %q", input.ModelName)
+	}
+
+	controllerModelUUID, err := c.ModelUUID(ctx, ControllerModelName)
+	if errors.Is(err, errors.NotFound) {
+		// The controller model is only visible to controller admins.
+		return response, nil
+	} else if err != nil {
+		return nil, err
+	}
+	response.ControllerUpgradeTarget, err = c.upgradeTarget(client, controllerModelUUID, input.AgentStream)
+	if err != nil {
+		return nil, errors.Annotate(err, "finding upgrade target of controller")
+	}
+	return response, nil
+}
+
+// upgradeTarget returns the version chosen by the controller for an
+// upgrade of the model, or an empty string if no upgrade is available.
+func (c *modelsClient) upgradeTarget(client *modelupgrader.Client, modelUUID, stream string) (string, error) {
+	target, err := client.UpgradeModel(modelUUID, version.Zero, stream, false, true)
+	switch {
+	case err == nil:
+		return target.String(), nil
+	case errors.Is(err, errors.NotFound), errors.Is(err, errors.AlreadyExists):
+		return "", nil
+	case params.IsCodeUnauthorized(err):
+		c.Debugf("Not authorized to check upgrades", modelFields(modelUUID))
+		return "", nil
+	default:
+		return "", err
+	}
+}
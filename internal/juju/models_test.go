@@ -0,0 +1,129 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type ModelsSuite struct {
+	JujuSuite
+}
+
+func (s *ModelsSuite) TestModelDefaultsConfig() {
+	defaults := config.ModelDefaultAttributes{
+		"image-stream": {Default: "released", Controller: "daily"},
+		"automatically-retry-hooks": {
+			Default: true,
+			Regions: []config.RegionDefaultValue{{Name: "us-east-1", Value: false}},
+		},
+		"logging-config": {Default: "<root>=INFO"},
+	}
+
+	values, err := modelDefaultsConfig(defaults, "")
+	s.Require().NoError(err)
+	s.Assert().Equal(map[string]string{"image-stream": "daily"}, values)
+
+	values, err = modelDefaultsConfig(defaults, "us-east-1")
+	s.Require().NoError(err)
+	s.Assert().Equal(map[string]string{"automatically-retry-hooks": "false"}, values)
+
+	values, err = modelDefaultsConfig(defaults, "eu-west-1")
+	s.Require().NoError(err)
+	s.Assert().Empty(values)
+}
+
+func (s *ModelsSuite) TestCreateModelWaitsForAvailable() {
+	defer s.setupMocks(s.T()).Finish()
+	defer func(delay time.Duration) { newModelConnectDelay = delay }(newModelConnectDelay)
+	newModelConnectDelay = time.Millisecond
+
+	modelName := "development"
+	uuid := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	s.mockSharedClient.EXPECT().GetConnection(gomock.Any(), nil).Return(s.mockConnection, nil)
+	s.mockConnection.EXPECT().AuthTag().Return(names.NewUserTag("admin")).AnyTimes()
+	s.mockConnection.EXPECT().BestFacadeVersion(gomock.Any()).Return(1).AnyTimes()
+	s.mockConnection.EXPECT().APICall("ModelManager", 1, "", "CreateModel", gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ string, _ int, _, _ string, _, response interface{}) error {
+			*response.(*params.ModelInfo) = params.ModelInfo{
+				Name:               modelName,
+				UUID:               uuid,
+				Type:               "iaas",
+				CloudTag:           "cloud-lxd",
+				OwnerTag:           "user-admin",
+				CloudCredentialTag: "cloudcred-lxd_admin_default",
+			}
+			return nil
+		})
+	s.mockSharedClient.EXPECT().AddModel("admin/"+modelName, uuid, model.IAAS)
+
+	// The new model is not ready to be connected to at first.
+	notReady := s.mockSharedClient.EXPECT().GetConnection(gomock.Any(), &modelName).
+		Return(nil, errors.New("model not ready"))
+	s.mockSharedClient.EXPECT().GetConnection(gomock.Any(), &modelName).
+		Return(s.mockConnection, nil).After(notReady)
+
+	// Then it is busy before being available.
+	modelDelta := func(current status.Status) params.Delta {
+		return params.Delta{Entity: &params.ModelUpdate{
+			Name:   modelName,
+			Status: params.StatusInfo{Current: current},
+		}}
+	}
+	batches := [][]params.Delta{{modelDelta(status.Busy)}, {modelDelta(status.Available)}}
+	stopped := make(chan struct{})
+	s.mockConnection.EXPECT().APICall("Client", 1, "", "WatchAll", nil, gomock.Any()).DoAndReturn(
+		func(_ string, _ int, _, _ string, _, response interface{}) error {
+			*response.(*params.AllWatcherId) = params.AllWatcherId{AllWatcherId: "1"}
+			return nil
+		})
+	s.mockConnection.EXPECT().APICall("AllWatcher", 1, "1", "Next", nil, gomock.Any()).DoAndReturn(
+		func(_ string, _ int, _, _ string, _, response interface{}) error {
+			if len(batches) == 0 {
+				<-stopped
+				return errors.New("watcher stopped")
+			}
+			*response.(*params.AllWatcherNextResults) = params.AllWatcherNextResults{Deltas: batches[0]}
+			batches = batches[1:]
+			return nil
+		}).MinTimes(2)
+	s.mockConnection.EXPECT().APICall("AllWatcher", 1, "1", "Stop", nil, nil).DoAndReturn(
+		func(_ string, _ int, _, _ string, _, _ interface{}) error {
+			close(stopped)
+			return nil
+		})
+
+	client := newModelsClient(s.mockSharedClient)
+	resp, err := client.CreateModel(context.Background(), CreateModelInput{Name: modelName, CloudName: "lxd"})
+	s.Require().NoError(err)
+	s.Assert().Equal(uuid, resp.UUID)
+	s.Assert().Empty(batches)
+}
+
+func (s *ModelsSuite) TestModelAvailableTimeout() {
+	s.Assert().Equal(defaultModelAvailableTimeout, modelAvailableTimeout(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+	timeout := modelAvailableTimeout(ctx)
+	s.Assert().Greater(timeout, 29*time.Minute)
+	s.Assert().LessOrEqual(timeout, 30*time.Minute)
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestModelsSuite(t *testing.T) {
+	suite.Run(t, new(ModelsSuite))
+}
@@ -0,0 +1,92 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"context"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/controller/controller"
+	"github.com/juju/juju/rpc/params"
+)
+
+// WarmModelCache fills the model cache with the models the user can
+// see, with a single call to the controller, rather than on the first
+// lookup of each model missing from the cache.
+//
+// If watch is true, the cache is then kept up to date with a watcher of
+// the model summaries, which follows renamed and removed models while
+// the provider runs. New models are added on the first lookup missing
+// them, as the summaries lack their owner and type. The watcher stops
+// when its connection fails, the cache is then filled on misses again.
+func (c *Client) WarmModelCache(ctx context.Context, watch bool) error {
+	sc := c.shared
+	sc.modelUUIDmu.Lock()
+	err := sc.fillModelCache(ctx)
+	sc.modelUUIDmu.Unlock()
+	if err != nil || !watch {
+		return err
+	}
+
+	// The watcher outlives the request configuring the provider.
+	conn, err := sc.GetConnection(context.WithoutCancel(ctx), nil)
+	if err != nil {
+		return err
+	}
+	watcher, err := controller.NewClient(conn).WatchModelSummaries()
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	go sc.watchModels(conn, watcher)
+	return nil
+}
+
+// summaryWatcher is the part of controller.SummaryWatcher the model
+// watcher uses.
+type summaryWatcher interface {
+	Next() ([]params.ModelAbstract, error)
+	Stop() error
+}
+
+// watchModels applies the changes reported by watcher to the model
+// cache until it fails. conn, the connection of the watcher, is held
+// until then.
+func (sc *sharedClient) watchModels(conn api.Connection, watcher summaryWatcher) {
+	defer func() { _ = conn.Close() }()
+	defer func() { _ = watcher.Stop() }()
+	for {
+		abstracts, err := watcher.Next()
+		if err != nil {
+			sc.Debugf("Model watcher stopped, the model cache is filled on misses", map[string]interface{}{"error": err.Error()})
+			return
+		}
+		sc.applyModelAbstracts(abstracts)
+	}
+}
+
+// applyModelAbstracts updates the names of the cached models and
+// removes the removed ones.
+func (sc *sharedClient) applyModelAbstracts(abstracts []params.ModelAbstract) {
+	var removed []string
+	sc.modelUUIDmu.Lock()
+	for _, abstract := range abstracts {
+		m, ok := sc.modelUUIDcache[abstract.UUID]
+		switch {
+		case !ok:
+		case abstract.Removed:
+			removed = append(removed, abstract.UUID)
+		case abstract.Name != "" && abstract.Name != m.name:
+			sc.Tracef("Model renamed", modelFields(abstract.UUID, map[string]interface{}{"from": m.name, "to": abstract.Name}))
+			m.name = abstract.Name
+			sc.modelUUIDcache[abstract.UUID] = m
+		}
+	}
+	sc.modelUUIDmu.Unlock()
+
+	for _, modelUUID := range removed {
+		sc.Tracef("Model removed", modelFields(modelUUID))
+		sc.RemoveModel(modelUUID)
+	}
+}
@@ -5,12 +5,11 @@ package juju
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 	"time"
 
-	"github.com/juju/juju/api/client/application"
+	jujuerrors "github.com/juju/errors"
 	apiapplication "github.com/juju/juju/api/client/application"
 	"github.com/juju/juju/api/client/applicationoffers"
 	apiclient "github.com/juju/juju/api/client/client"
@@ -23,9 +22,6 @@ const (
 	// OfferAppAvailableTimeout is the time to wait for an app to be available
 	// before creating an offer.
 	OfferAppAvailableTimeout = time.Second * 60
-	// OfferApiTickWait is the time to wait between consecutive requests
-	// to the API
-	OfferApiTickWait = time.Second * 5
 )
 
 type offersClient struct {
@@ -81,10 +77,10 @@ func newOffersClient(sc SharedClient) *offersClient {
 	}
 }
 
-func (c offersClient) CreateOffer(input *CreateOfferInput) (*CreateOfferResponse, []error) {
+func (c offersClient) CreateOffer(ctx context.Context, input *CreateOfferInput) (*CreateOfferResponse, []error) {
 	var errs []error
 
-	conn, err := c.GetConnection(nil)
+	conn, err := c.GetConnection(ctx, nil)
 	if err != nil {
 		return nil, append(errs, err)
 	}
@@ -98,23 +94,19 @@ func (c offersClient) CreateOffer(input *CreateOfferInput) (*CreateOfferResponse
 	}
 
 	// connect to the corresponding model
-	modelConn, err := c.GetConnection(&input.ModelName)
+	modelConn, err := c.GetConnection(ctx, &input.ModelName)
 	if err != nil {
 		return nil, append(errs, err)
 	}
 	defer func() { _ = modelConn.Close() }()
-	applicationClient := application.NewClient(modelConn)
 
 	// wait for the app to be available
-	ctx, cancel := context.WithTimeout(context.Background(), OfferAppAvailableTimeout)
-	defer cancel()
-
-	err = WaitForAppsAvailable(ctx, applicationClient, []string{input.ApplicationName}, OfferApiTickWait)
+	err = waitForModel(ctx, c.SharedClient, modelConn, ApplicationsExist(input.ApplicationName), OfferAppAvailableTimeout)
 	if err != nil {
-		return nil, append(errs, errors.New("the application was not available to be offered"))
+		return nil, append(errs, fmt.Errorf("the application was not available to be offered: %w", err))
 	}
 
-	modelUUID, err := c.ModelUUID(input.ModelName)
+	modelUUID, err := c.ModelUUID(ctx, input.ModelName)
 	if err != nil {
 		return nil, append(errs, err)
 	}
@@ -153,8 +145,8 @@ func (c offersClient) CreateOffer(input *CreateOfferInput) (*CreateOfferResponse
 	return &resp, nil
 }
 
-func (c offersClient) ReadOffer(input *ReadOfferInput) (*ReadOfferResponse, error) {
-	conn, err := c.GetConnection(nil)
+func (c offersClient) ReadOffer(ctx context.Context, input *ReadOfferInput) (*ReadOfferResponse, error) {
+	conn, err := c.GetConnection(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -162,7 +154,10 @@ func (c offersClient) ReadOffer(input *ReadOfferInput) (*ReadOfferResponse, erro
 
 	client := applicationoffers.NewClient(conn)
 	result, err := client.ApplicationOffer(input.OfferURL)
-	if err != nil {
+	if err != nil && strings.Contains(err.Error(), "expected to find one result for url") {
+		// The offer is not found.
+		return nil, jujuerrors.WithType(err, jujuerrors.NotFound)
+	} else if err != nil {
 		return nil, err
 	}
 
@@ -183,8 +178,8 @@ func (c offersClient) ReadOffer(input *ReadOfferInput) (*ReadOfferResponse, erro
 	return &response, nil
 }
 
-func (c offersClient) DestroyOffer(input *DestroyOfferInput) error {
-	conn, err := c.GetConnection(nil)
+func (c offersClient) DestroyOffer(ctx context.Context, input *DestroyOfferInput) error {
+	conn, err := c.GetConnection(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -207,7 +202,11 @@ func (c offersClient) DestroyOffer(input *DestroyOfferInput) error {
 				forceDestroy = true
 				break
 			}
-			time.Sleep(10 * time.Second)
+			select {
+			case <-time.After(10 * time.Second):
+			case <-ctx.Done():
+				return fmt.Errorf("waiting for offer %q connections to be removed: %w", input.OfferURL, ctx.Err())
+			}
 			offer, err = client.ApplicationOffer(input.OfferURL)
 			if err != nil {
 				return err
@@ -251,13 +250,13 @@ func parseModelFromURL(url string) (result string, success bool) {
 }
 
 // This function allows the integration resource to consume the offers managed by the offer resource
-func (c offersClient) ConsumeRemoteOffer(input *ConsumeRemoteOfferInput) (*ConsumeRemoteOfferResponse, error) {
-	modelConn, err := c.GetConnection(&input.ModelName)
+func (c offersClient) ConsumeRemoteOffer(ctx context.Context, input *ConsumeRemoteOfferInput) (*ConsumeRemoteOfferResponse, error) {
+	modelConn, err := c.GetConnection(ctx, &input.ModelName)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = modelConn.Close() }()
-	conn, err := c.GetConnection(nil)
+	conn, err := c.GetConnection(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -332,9 +331,9 @@ func (c offersClient) ConsumeRemoteOffer(input *ConsumeRemoteOfferInput) (*Consu
 }
 
 // This function allows the integration resource to destroy the offers managed by the offer resource
-func (c offersClient) RemoveRemoteOffer(input *RemoveRemoteOfferInput) []error {
+func (c offersClient) RemoveRemoteOffer(ctx context.Context, input *RemoveRemoteOfferInput) []error {
 	var errors []error
-	conn, err := c.GetConnection(&input.ModelName)
+	conn, err := c.GetConnection(ctx, &input.ModelName)
 	if err != nil {
 		errors = append(errors, err)
 		return errors
@@ -4,6 +4,7 @@
 package juju
 
 import (
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -11,6 +12,7 @@ import (
 
 	jujuerrors "github.com/juju/errors"
 	"github.com/juju/juju/api"
+	apisecretbackends "github.com/juju/juju/api/client/secretbackends"
 	apisecrets "github.com/juju/juju/api/client/secrets"
 	coresecrets "github.com/juju/juju/core/secrets"
 )
@@ -29,10 +31,16 @@ func (se *secretNotFoundError) Error() string {
 	}
 }
 
+// Is reports the error to be a NotFound error.
+func (se *secretNotFoundError) Is(target error) bool {
+	return target == jujuerrors.NotFound
+}
+
 type secretsClient struct {
 	SharedClient
 
-	getSecretAPIClient func(connection api.Connection) SecretAPIClient
+	getSecretAPIClient         func(connection api.Connection) SecretAPIClient
+	getSecretBackendsAPIClient func(connection api.Connection) SecretBackendsAPIClient
 }
 
 type AccessSecretAction int
@@ -88,6 +96,26 @@ type GrantRevokeAccessSecretInput struct {
 	Applications []string
 }
 
+type ListSecretBackendsInput struct {
+	// Names filters the backends, all backends are listed if empty.
+	Names []string
+}
+
+type ListSecretBackendsOutput struct {
+	Backends []SecretBackend
+}
+
+// SecretBackend holds the details of a secret backend.
+type SecretBackend struct {
+	ID                  string
+	Name                string
+	BackendType         string
+	Status              string
+	Message             string
+	NumSecrets          int
+	TokenRotateInterval string
+}
+
 type MultiError struct {
 	Errors []error
 }
@@ -107,12 +135,15 @@ func newSecretsClient(sc SharedClient) *secretsClient {
 		getSecretAPIClient: func(connection api.Connection) SecretAPIClient {
 			return apisecrets.NewClient(connection)
 		},
+		getSecretBackendsAPIClient: func(connection api.Connection) SecretBackendsAPIClient {
+			return apisecretbackends.NewClient(connection)
+		},
 	}
 }
 
 // CreateSecret creates a new secret.
-func (c *secretsClient) CreateSecret(input *CreateSecretInput) (CreateSecretOutput, error) {
-	conn, err := c.GetConnection(&input.ModelName)
+func (c *secretsClient) CreateSecret(ctx context.Context, input *CreateSecretInput) (CreateSecretOutput, error) {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
 	if err != nil {
 		return CreateSecretOutput{}, err
 	}
@@ -140,8 +171,8 @@ func (c *secretsClient) CreateSecret(input *CreateSecretInput) (CreateSecretOutp
 }
 
 // ReadSecret reads a secret.
-func (c *secretsClient) ReadSecret(input *ReadSecretInput) (ReadSecretOutput, error) {
-	conn, err := c.GetConnection(&input.ModelName)
+func (c *secretsClient) ReadSecret(ctx context.Context, input *ReadSecretInput) (ReadSecretOutput, error) {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
 	if err != nil {
 		return ReadSecretOutput{}, err
 	}
@@ -194,8 +225,8 @@ func (c *secretsClient) ReadSecret(input *ReadSecretInput) (ReadSecretOutput, er
 }
 
 // UpdateSecret updates a secret.
-func (c *secretsClient) UpdateSecret(input *UpdateSecretInput) error {
-	conn, err := c.GetConnection(&input.ModelName)
+func (c *secretsClient) UpdateSecret(ctx context.Context, input *UpdateSecretInput) error {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
 	if err != nil {
 		return err
 	}
@@ -255,8 +286,8 @@ func (c *secretsClient) UpdateSecret(input *UpdateSecretInput) error {
 }
 
 // DeleteSecret deletes a secret.
-func (c *secretsClient) DeleteSecret(input *DeleteSecretInput) error {
-	conn, err := c.GetConnection(&input.ModelName)
+func (c *secretsClient) DeleteSecret(ctx context.Context, input *DeleteSecretInput) error {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
 	if err != nil {
 		return err
 	}
@@ -276,8 +307,8 @@ func (c *secretsClient) DeleteSecret(input *DeleteSecretInput) error {
 }
 
 // UpdateAccessSecret updates access to a secret.
-func (c *secretsClient) UpdateAccessSecret(input *GrantRevokeAccessSecretInput, op AccessSecretAction) error {
-	conn, err := c.GetConnection(&input.ModelName)
+func (c *secretsClient) UpdateAccessSecret(ctx context.Context, input *GrantRevokeAccessSecretInput, op AccessSecretAction) error {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
 	if err != nil {
 		return err
 	}
@@ -320,3 +351,39 @@ func getApplicationsFromAccessInfo(accessInfo []coresecrets.AccessInfo) []string
 	}
 	return applications
 }
+
+// ListSecretBackends lists the secret backends of the controller. The
+// configuration of the backends is not revealed.
+func (c *secretsClient) ListSecretBackends(ctx context.Context, input *ListSecretBackendsInput) (ListSecretBackendsOutput, error) {
+	conn, err := c.GetConnection(ctx, nil)
+	if err != nil {
+		return ListSecretBackendsOutput{}, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	backends, err := c.getSecretBackendsAPIClient(conn).ListSecretBackends(input.Names, false)
+	if err != nil {
+		return ListSecretBackendsOutput{}, typedError(err)
+	}
+
+	output := ListSecretBackendsOutput{Backends: make([]SecretBackend, 0, len(backends))}
+	for _, backend := range backends {
+		if backend.Error != nil {
+			return ListSecretBackendsOutput{}, typedError(backend.Error)
+		}
+		var rotateInterval string
+		if backend.TokenRotateInterval != nil {
+			rotateInterval = backend.TokenRotateInterval.String()
+		}
+		output.Backends = append(output.Backends, SecretBackend{
+			ID:                  backend.ID,
+			Name:                backend.Name,
+			BackendType:         backend.BackendType,
+			Status:              backend.Status.String(),
+			Message:             backend.Message,
+			NumSecrets:          backend.NumSecrets,
+			TokenRotateInterval: rotateInterval,
+		})
+	}
+	return output, nil
+}
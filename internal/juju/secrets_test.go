@@ -4,13 +4,17 @@
 package juju
 
 import (
+	"context"
 	"encoding/base64"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/juju/juju/api"
+	apisecretbackends "github.com/juju/juju/api/client/secretbackends"
 	apisecrets "github.com/juju/juju/api/client/secrets"
 	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/core/status"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
 )
@@ -21,7 +25,8 @@ type SecretSuite struct {
 
 	testModelName string
 
-	mockSecretClient *MockSecretAPIClient
+	mockSecretClient         *MockSecretAPIClient
+	mockSecretBackendsClient *MockSecretBackendsAPIClient
 }
 
 func (s *SecretSuite) SetupTest() {}
@@ -31,6 +36,7 @@ func (s *SecretSuite) setupMocks(t *testing.T) *gomock.Controller {
 
 	ctlr := s.JujuSuite.setupMocks(t)
 	s.mockSecretClient = NewMockSecretAPIClient(ctlr)
+	s.mockSecretBackendsClient = NewMockSecretBackendsAPIClient(ctlr)
 
 	return ctlr
 }
@@ -41,6 +47,9 @@ func (s *SecretSuite) getSecretsClient() secretsClient {
 		getSecretAPIClient: func(connection api.Connection) SecretAPIClient {
 			return s.mockSecretClient
 		},
+		getSecretBackendsAPIClient: func(connection api.Connection) SecretBackendsAPIClient {
+			return s.mockSecretBackendsClient
+		},
 	}
 }
 
@@ -59,7 +68,7 @@ func (s *SecretSuite) TestCreateSecret() {
 	).Return(secretURI.ID, nil).AnyTimes()
 
 	client := s.getSecretsClient()
-	output, err := client.CreateSecret(&CreateSecretInput{
+	output, err := client.CreateSecret(context.Background(), &CreateSecretInput{
 		ModelName: s.testModelName,
 		Name:      "test-secret",
 		Value:     decodedValue,
@@ -85,7 +94,7 @@ func (s *SecretSuite) TestCreateSecretError() {
 	).Return("", errBoom).AnyTimes()
 
 	client := s.getSecretsClient()
-	output, err := client.CreateSecret(&CreateSecretInput{
+	output, err := client.CreateSecret(context.Background(), &CreateSecretInput{
 		ModelName: s.testModelName,
 		Name:      "test-secret",
 		Value:     decodedValue,
@@ -131,7 +140,7 @@ func (s *SecretSuite) TestReadSecret() {
 	}, nil).AnyTimes()
 
 	client := s.getSecretsClient()
-	output, err := client.ReadSecret(&ReadSecretInput{
+	output, err := client.ReadSecret(context.Background(), &ReadSecretInput{
 		SecretId:  secretId,
 		ModelName: s.testModelName,
 		Name:      &secretName,
@@ -163,7 +172,7 @@ func (s *SecretSuite) TestReadSecretError() {
 	}, nil).AnyTimes()
 
 	client := s.getSecretsClient()
-	output, err := client.ReadSecret(&ReadSecretInput{
+	output, err := client.ReadSecret(context.Background(), &ReadSecretInput{
 		SecretId:  secretId,
 		ModelName: s.testModelName,
 	})
@@ -193,7 +202,7 @@ func (s *SecretSuite) TestUpdateSecretWithRenaming() {
 	).Return(nil).AnyTimes()
 
 	client := s.getSecretsClient()
-	err = client.UpdateSecret(&UpdateSecretInput{
+	err = client.UpdateSecret(context.Background(), &UpdateSecretInput{
 		SecretId:  secretId,
 		ModelName: s.testModelName,
 		Name:      &newSecretName,
@@ -222,7 +231,7 @@ func (s *SecretSuite) TestUpdateSecretWithRenaming() {
 	}, nil).Times(1)
 
 	// read secret and check if value is updated
-	output, err := client.ReadSecret(&ReadSecretInput{
+	output, err := client.ReadSecret(context.Background(), &ReadSecretInput{
 		SecretId:  secretId,
 		ModelName: s.testModelName,
 	})
@@ -250,7 +259,7 @@ func (s *SecretSuite) TestUpdateSecret() {
 	).Return(nil).AnyTimes()
 
 	client := s.getSecretsClient()
-	err = client.UpdateSecret(&UpdateSecretInput{
+	err = client.UpdateSecret(context.Background(), &UpdateSecretInput{
 		SecretId:  secretId,
 		ModelName: s.testModelName,
 		Value:     &decodedValue,
@@ -279,7 +288,7 @@ func (s *SecretSuite) TestUpdateSecret() {
 	}, nil).Times(1)
 
 	// read secret and check if secret info is updated
-	output, err := client.ReadSecret(&ReadSecretInput{
+	output, err := client.ReadSecret(context.Background(), &ReadSecretInput{
 		SecretId:  secretId,
 		ModelName: s.testModelName,
 	})
@@ -300,7 +309,7 @@ func (s *SecretSuite) TestDeleteSecret() {
 	s.mockSecretClient.EXPECT().RemoveSecret(secretURI, "", nil).Return(nil).AnyTimes()
 
 	client := s.getSecretsClient()
-	err = client.DeleteSecret(&DeleteSecretInput{
+	err = client.DeleteSecret(context.Background(), &DeleteSecretInput{
 		SecretId:  secretId,
 		ModelName: s.testModelName,
 	})
@@ -321,14 +330,14 @@ func (s *SecretSuite) TestUpdateAccessSecret() {
 	s.mockSecretClient.EXPECT().RevokeSecret(secretURI, "", applications).Return([]error{nil}, nil).AnyTimes()
 
 	client := s.getSecretsClient()
-	err = client.UpdateAccessSecret(&GrantRevokeAccessSecretInput{
+	err = client.UpdateAccessSecret(context.Background(), &GrantRevokeAccessSecretInput{
 		SecretId:     secretId,
 		ModelName:    s.testModelName,
 		Applications: applications,
 	}, GrantAccess)
 	s.Require().NoError(err)
 
-	err = client.UpdateAccessSecret(&GrantRevokeAccessSecretInput{
+	err = client.UpdateAccessSecret(context.Background(), &GrantRevokeAccessSecretInput{
 		SecretId:     secretId,
 		ModelName:    s.testModelName,
 		Applications: applications,
@@ -336,6 +345,46 @@ func (s *SecretSuite) TestUpdateAccessSecret() {
 	s.Require().NoError(err)
 }
 
+func (s *SecretSuite) TestListSecretBackends() {
+	ctlr := s.setupMocks(s.T())
+	defer ctlr.Finish()
+
+	s.mockSharedClient.EXPECT().GetConnection(gomock.Any(), nil).Return(s.mockConnection, nil)
+	rotateInterval := time.Hour
+	s.mockSecretBackendsClient.EXPECT().ListSecretBackends([]string{}, false).Return([]apisecretbackends.SecretBackend{{
+		ID:          "1",
+		Name:        "internal",
+		BackendType: "controller",
+		Status:      status.Active,
+		NumSecrets:  3,
+	}, {
+		ID:                  "2",
+		Name:                "myvault",
+		BackendType:         "vault",
+		TokenRotateInterval: &rotateInterval,
+		Status:              status.Error,
+		Message:             "sealed",
+	}}, nil)
+
+	client := s.getSecretsClient()
+	output, err := client.ListSecretBackends(context.Background(), &ListSecretBackendsInput{Names: []string{}})
+	s.Require().NoError(err)
+	s.Assert().Equal([]SecretBackend{{
+		ID:          "1",
+		Name:        "internal",
+		BackendType: "controller",
+		Status:      "active",
+		NumSecrets:  3,
+	}, {
+		ID:                  "2",
+		Name:                "myvault",
+		BackendType:         "vault",
+		Status:              "error",
+		Message:             "sealed",
+		TokenRotateInterval: "1h0m0s",
+	}}, output.Backends)
+}
+
 // In order for 'go test' to run this suite, we need to create
 // a normal test function and pass our suite to suite.Run
 func TestUserSecretSuite(t *testing.T) {
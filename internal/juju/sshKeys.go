@@ -4,8 +4,10 @@
 package juju
 
 import (
+	"context"
 	"fmt"
 
+	jujuerrors "github.com/juju/errors"
 	"github.com/juju/juju/api/client/keymanager"
 	"github.com/juju/utils/v3/ssh"
 
@@ -42,8 +44,8 @@ func newSSHKeysClient(sc SharedClient) *sshKeysClient {
 	}
 }
 
-func (c *sshKeysClient) CreateSSHKey(input *CreateSSHKeyInput) error {
-	conn, err := c.GetConnection(&input.ModelName)
+func (c *sshKeysClient) CreateSSHKey(ctx context.Context, input *CreateSSHKeyInput) error {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
 	if err != nil {
 		return err
 	}
@@ -74,8 +76,8 @@ func (c *sshKeysClient) CreateSSHKey(input *CreateSSHKeyInput) error {
 	return nil
 }
 
-func (c *sshKeysClient) ReadSSHKey(input *ReadSSHKeyInput) (*ReadSSHKeyOutput, error) {
-	conn, err := c.GetConnection(&input.ModelName)
+func (c *sshKeysClient) ReadSSHKey(ctx context.Context, input *ReadSSHKeyInput) (*ReadSSHKeyOutput, error) {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
 	if err != nil {
 		return nil, err
 	}
@@ -101,11 +103,11 @@ func (c *sshKeysClient) ReadSSHKey(input *ReadSSHKeyInput) (*ReadSSHKeyOutput, e
 		}
 	}
 
-	return nil, fmt.Errorf("no ssh key found for %s", input.KeyIdentifier)
+	return nil, jujuerrors.NotFoundf("ssh key %s", input.KeyIdentifier)
 }
 
-func (c *sshKeysClient) DeleteSSHKey(input *DeleteSSHKeyInput) error {
-	conn, err := c.GetConnection(&input.ModelName)
+func (c *sshKeysClient) DeleteSSHKey(ctx context.Context, input *DeleteSSHKeyInput) error {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,78 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"sync/atomic"
+)
+
+// ClientStats are counters of the work of a client since it was
+// created, showing how often the caches of the client save calls to the
+// controller.
+type ClientStats struct {
+	// ModelCacheHits is the number of models found in the model cache.
+	ModelCacheHits int64
+	// ModelCacheMisses is the number of models missing from the model
+	// cache, each listing the models of the controller.
+	ModelCacheMisses int64
+	// ConnectionsReused is the number of connections taken from the
+	// connection pool rather than dialed.
+	ConnectionsReused int64
+	// Dials is the number of attempts to connect to the controller,
+	// including the failed ones and the retries.
+	Dials int64
+	// DialFailures is the number of attempts to connect which failed.
+	DialFailures int64
+	// FailedFast is the number of connections failed without dialing
+	// while the controller was consistently failing.
+	FailedFast int64
+	// Retries is the number of connections and calls retried after a
+	// transient error.
+	Retries int64
+}
+
+// clientStats holds the counters of ClientStats, updated concurrently.
+type clientStats struct {
+	modelCacheHits    atomic.Int64
+	modelCacheMisses  atomic.Int64
+	connectionsReused atomic.Int64
+	dials             atomic.Int64
+	dialFailures      atomic.Int64
+	failedFast        atomic.Int64
+	retries           atomic.Int64
+}
+
+func (s *clientStats) snapshot() ClientStats {
+	return ClientStats{
+		ModelCacheHits:    s.modelCacheHits.Load(),
+		ModelCacheMisses:  s.modelCacheMisses.Load(),
+		ConnectionsReused: s.connectionsReused.Load(),
+		Dials:             s.dials.Load(),
+		DialFailures:      s.dialFailures.Load(),
+		FailedFast:        s.failedFast.Load(),
+		Retries:           s.retries.Load(),
+	}
+}
+
+// Stats returns the counters of the client. c may be nil, before the
+// provider is configured, in which case they are all zero.
+func (c *Client) Stats() ClientStats {
+	if c == nil || c.shared == nil {
+		return ClientStats{}
+	}
+	return c.shared.stats.snapshot()
+}
+
+// Fields returns the counters as the fields of a log line.
+func (s ClientStats) Fields() map[string]interface{} {
+	return map[string]interface{}{
+		"model_cache_hits":   s.ModelCacheHits,
+		"model_cache_misses": s.ModelCacheMisses,
+		"connections_reused": s.ConnectionsReused,
+		"dials":              s.Dials,
+		"dial_failures":      s.DialFailures,
+		"failed_fast":        s.FailedFast,
+		"retries":            s.Retries,
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/juju/errors"
+	apiclient "github.com/juju/juju/api/client/client"
+	"github.com/juju/juju/rpc/params"
+)
+
+// Entity kinds which can be waited on with WaitForStatus.
+const (
+	EntityKindApplication = "application"
+	EntityKindUnit        = "unit"
+	EntityKindMachine     = "machine"
+	EntityKindModel       = "model"
+)
+
+type statusClient struct {
+	SharedClient
+}
+
+type WaitForStatusInput struct {
+	ModelName string
+	// Kind is one of the EntityKind constants.
+	Kind string
+	// Name identifies the entity, e.g. an application name, unit name or
+	// machine id. Ignored when waiting on a model.
+	Name string
+	// Status is the list of acceptable statuses, the wait ends when the
+	// entity reaches any of them.
+	Status  []string
+	Timeout time.Duration
+}
+
+type WaitInput struct {
+	ModelName string
+	Condition WaitCondition
+	Timeout   time.Duration
+}
+
+type WaitForStatusResponse struct {
+	Status  string
+	Message string
+}
+
+type ReadFullStatusInput struct {
+	ModelName string
+	// Patterns filters the status as `juju status <pattern>...` does.
+	Patterns []string
+}
+
+type ReadFullStatusResponse struct {
+	// JSON is the status document as returned by the FullStatus API.
+	JSON string
+	// Applications, Units and Machines map the name of each entity to
+	// its status. Workload statuses are used for applications and
+	// units, agent statuses for machines.
+	Applications map[string]string
+	Units        map[string]string
+	Machines     map[string]string
+}
+
+// entityStatus holds the status of an entity as reported in an
+// AllWatcher delta.
+type entityStatus struct {
+	status  string
+	message string
+	removed bool
+}
+
+func newStatusClient(sc SharedClient) *statusClient {
+	return &statusClient{
+		SharedClient: sc,
+	}
+}
+
+// WaitForStatus blocks until the entity described by the input reaches
+// one of the requested statuses, the timeout expires or the context is
+// done.
+func (c *statusClient) WaitForStatus(ctx context.Context, input WaitForStatusInput) (*WaitForStatusResponse, error) {
+	if len(input.Status) == 0 {
+		return nil, errors.NotValidf("empty status list")
+	}
+	conn, err := c.GetConnection(ctx, &input.ModelName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	// Keep the last status seen, to return it.
+	var last entityStatus
+	condition := EntityStatus(input.Kind, input.Name, input.Status...)
+	check := condition.Check
+	condition.Check = func(m *ModelState) (bool, string, error) {
+		if delta, ok := m.entity(input.Kind, input.Name); ok {
+			last, _ = matchEntityStatus(delta, input.Kind, input.Name)
+		}
+		return check(m)
+	}
+	if err := waitForModel(ctx, c, conn, condition, input.Timeout); err != nil {
+		return nil, err
+	}
+	return &WaitForStatusResponse{
+		Status:  last.status,
+		Message: last.message,
+	}, nil
+}
+
+// Wait blocks until the condition of the input is met by the model,
+// the timeout expires or the context is done.
+func (c *statusClient) Wait(ctx context.Context, input WaitInput) error {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+	return waitForModel(ctx, c, conn, input.Condition, input.Timeout)
+}
+
+// ReadFullStatus returns the complete status document of a model.
+func (c *statusClient) ReadFullStatus(ctx context.Context, input ReadFullStatusInput) (*ReadFullStatusResponse, error) {
+	conn, err := c.GetConnection(ctx, &input.ModelName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	client := apiclient.NewClient(conn, c.JujuLogger())
+	status, err := client.Status(&apiclient.StatusArgs{
+		Patterns:       input.Patterns,
+		IncludeStorage: true,
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "reading status")
+	}
+	return fullStatusResponse(status)
+}
+
+func fullStatusResponse(status *params.FullStatus) (*ReadFullStatusResponse, error) {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return nil, errors.Annotate(err, "formatting status")
+	}
+
+	response := &ReadFullStatusResponse{
+		JSON:         string(data),
+		Applications: make(map[string]string),
+		Units:        make(map[string]string),
+		Machines:     make(map[string]string),
+	}
+	for name, app := range status.Applications {
+		response.Applications[name] = app.Status.Status
+		for unitName, unit := range app.Units {
+			response.Units[unitName] = unit.WorkloadStatus.Status
+			for subName, sub := range unit.Subordinates {
+				response.Units[subName] = sub.WorkloadStatus.Status
+			}
+		}
+	}
+	for id, machine := range status.Machines {
+		response.Machines[id] = machine.AgentStatus.Status
+		for containerID, container := range machine.Containers {
+			response.Machines[containerID] = container.AgentStatus.Status
+		}
+	}
+	return response, nil
+}
+
+// matchEntityStatus returns the status of the entity of the given kind
+// and name if the delta refers to it. For applications and units the
+// workload status is used, for machines the agent status.
+func matchEntityStatus(delta params.Delta, kind, name string) (entityStatus, bool) {
+	var info params.StatusInfo
+	switch entity := delta.Entity.(type) {
+	case *params.ApplicationInfo:
+		if kind != EntityKindApplication || entity.Name != name {
+			return entityStatus{}, false
+		}
+		info = entity.Status
+	case *params.UnitInfo:
+		if kind != EntityKindUnit || entity.Name != name {
+			return entityStatus{}, false
+		}
+		info = entity.WorkloadStatus
+	case *params.MachineInfo:
+		if kind != EntityKindMachine || entity.Id != name {
+			return entityStatus{}, false
+		}
+		info = entity.AgentStatus
+	case *params.ModelUpdate:
+		if kind != EntityKindModel {
+			return entityStatus{}, false
+		}
+		info = entity.Status
+	default:
+		return entityStatus{}, false
+	}
+	return entityStatus{
+		status:  info.Current.String(),
+		message: info.Message,
+		removed: delta.Removed,
+	}, true
+}
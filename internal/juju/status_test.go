@@ -0,0 +1,122 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/rpc/params"
+	"github.com/stretchr/testify/suite"
+)
+
+type StatusSuite struct {
+	suite.Suite
+}
+
+func (s *StatusSuite) TestMatchEntityStatusApplication() {
+	delta := params.Delta{Entity: &params.ApplicationInfo{
+		Name:   "postgresql",
+		Status: params.StatusInfo{Current: status.Active, Message: "ready"},
+	}}
+
+	current, ok := matchEntityStatus(delta, EntityKindApplication, "postgresql")
+	s.Require().True(ok)
+	s.Assert().Equal("active", current.status)
+	s.Assert().Equal("ready", current.message)
+	s.Assert().False(current.removed)
+
+	_, ok = matchEntityStatus(delta, EntityKindApplication, "mysql")
+	s.Assert().False(ok)
+	_, ok = matchEntityStatus(delta, EntityKindUnit, "postgresql")
+	s.Assert().False(ok)
+}
+
+func (s *StatusSuite) TestMatchEntityStatusUnit() {
+	delta := params.Delta{Entity: &params.UnitInfo{
+		Name:           "postgresql/0",
+		WorkloadStatus: params.StatusInfo{Current: status.Blocked, Message: "needs relation"},
+		AgentStatus:    params.StatusInfo{Current: status.Idle},
+	}}
+
+	current, ok := matchEntityStatus(delta, EntityKindUnit, "postgresql/0")
+	s.Require().True(ok)
+	s.Assert().Equal("blocked", current.status)
+	s.Assert().Equal("needs relation", current.message)
+}
+
+func (s *StatusSuite) TestMatchEntityStatusMachine() {
+	delta := params.Delta{Entity: &params.MachineInfo{
+		Id:          "0/lxd/1",
+		AgentStatus: params.StatusInfo{Current: status.Started},
+	}}
+
+	current, ok := matchEntityStatus(delta, EntityKindMachine, "0/lxd/1")
+	s.Require().True(ok)
+	s.Assert().Equal("started", current.status)
+
+	_, ok = matchEntityStatus(delta, EntityKindMachine, "0")
+	s.Assert().False(ok)
+}
+
+func (s *StatusSuite) TestMatchEntityStatusModelRemoved() {
+	delta := params.Delta{
+		Removed: true,
+		Entity: &params.ModelUpdate{
+			Name:   "development",
+			Status: params.StatusInfo{Current: status.Available},
+		},
+	}
+
+	current, ok := matchEntityStatus(delta, EntityKindModel, "")
+	s.Require().True(ok)
+	s.Assert().Equal("available", current.status)
+	s.Assert().True(current.removed)
+}
+
+func (s *StatusSuite) TestMatchEntityStatusIgnoresOtherEntities() {
+	delta := params.Delta{Entity: &params.RelationInfo{Key: "a:db b:db"}}
+
+	_, ok := matchEntityStatus(delta, EntityKindApplication, "a")
+	s.Assert().False(ok)
+}
+
+func (s *StatusSuite) TestFullStatusResponse() {
+	status := &params.FullStatus{
+		Model: params.ModelStatusInfo{Name: "test"},
+		Applications: map[string]params.ApplicationStatus{"wordpress": {
+			Status: params.DetailedStatus{Status: "active"},
+			Units: map[string]params.UnitStatus{"wordpress/0": {
+				WorkloadStatus: params.DetailedStatus{Status: "active"},
+				Subordinates: map[string]params.UnitStatus{"telegraf/0": {
+					WorkloadStatus: params.DetailedStatus{Status: "blocked"},
+				}},
+			}},
+		}},
+		Machines: map[string]params.MachineStatus{"0": {
+			AgentStatus: params.DetailedStatus{Status: "started"},
+			Containers: map[string]params.MachineStatus{"0/lxd/0": {
+				AgentStatus: params.DetailedStatus{Status: "pending"},
+			}},
+		}},
+	}
+
+	resp, err := fullStatusResponse(status)
+	s.Require().NoError(err)
+	s.Assert().Equal(map[string]string{"wordpress": "active"}, resp.Applications)
+	s.Assert().Equal(map[string]string{"wordpress/0": "active", "telegraf/0": "blocked"}, resp.Units)
+	s.Assert().Equal(map[string]string{"0": "started", "0/lxd/0": "pending"}, resp.Machines)
+
+	var decoded params.FullStatus
+	s.Require().NoError(json.Unmarshal([]byte(resp.JSON), &decoded))
+	s.Assert().Equal("test", decoded.Model.Name)
+	s.Assert().Contains(decoded.Applications, "wordpress")
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestStatusSuite(t *testing.T) {
+	suite.Run(t, new(StatusSuite))
+}
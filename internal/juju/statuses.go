@@ -0,0 +1,148 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/juju/api"
+	apiclient "github.com/juju/juju/api/client/client"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+)
+
+// statusShareTTL is how long the status of a model fetched during a
+// refresh is shared with the following reads.
+var statusShareTTL = 30 * time.Second
+
+type refreshKey struct{}
+
+// WithRefresh returns a context marking the calls made with it as part
+// of the refresh of the Terraform state. The reads of a refresh share
+// the full status of each model, fetched once, rather than each
+// requesting the status of the entity read.
+func WithRefresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, refreshKey{}, true)
+}
+
+func isRefresh(ctx context.Context) bool {
+	refresh, _ := ctx.Value(refreshKey{}).(bool)
+	return refresh
+}
+
+// statusSharer is implemented by the shared clients able to share the
+// status of a model between reads.
+type statusSharer interface {
+	sharedStatus(conn api.Connection, client ClientAPIClient) (*params.FullStatus, error)
+}
+
+// readStatus returns the status of the model of conn filtered by args.
+// During a refresh the full status of the model, including storage, is
+// returned instead and must not be modified. The status is read again
+// with args if reading the full status fails, so errors are those of
+// the filtered status.
+func readStatus(ctx context.Context, sc SharedClient, conn api.Connection, client ClientAPIClient, args *apiclient.StatusArgs) (*params.FullStatus, error) {
+	if sharer, ok := sc.(statusSharer); ok && isRefresh(ctx) {
+		status, err := sharer.sharedStatus(conn, client)
+		if err == nil {
+			return status, nil
+		}
+		sc.Debugf("reading shared status", map[string]interface{}{"error": err.Error()})
+	}
+	return client.Status(args)
+}
+
+// statusCoalescer fetches the status of each model once for all the
+// concurrent reads, and shares it for statusShareTTL.
+type statusCoalescer struct {
+	mu       sync.Mutex
+	statuses map[string]*sharedStatus
+}
+
+// sharedStatus is the status of a model. done is closed once fetched.
+type sharedStatus struct {
+	done    chan struct{}
+	fetched time.Time
+	status  *params.FullStatus
+	err     error
+}
+
+func newStatusCoalescer() *statusCoalescer {
+	return &statusCoalescer{statuses: make(map[string]*sharedStatus)}
+}
+
+// get returns the status of the model, calling fetch unless it has
+// been fetched recently or is being fetched. Errors are not shared
+// beyond the reads waiting on the fetch.
+func (c *statusCoalescer) get(modelUUID string, fetch func() (*params.FullStatus, error)) (*params.FullStatus, error) {
+	c.mu.Lock()
+	s, ok := c.statuses[modelUUID]
+	if ok {
+		select {
+		case <-s.done:
+			if s.err != nil || time.Since(s.fetched) > statusShareTTL {
+				ok = false
+			}
+		default:
+		}
+	}
+	if ok {
+		c.mu.Unlock()
+		<-s.done
+		return s.status, s.err
+	}
+	s = &sharedStatus{done: make(chan struct{})}
+	c.statuses[modelUUID] = s
+	c.mu.Unlock()
+
+	s.status, s.err = fetch()
+	s.fetched = time.Now()
+	close(s.done)
+	return s.status, s.err
+}
+
+// forget drops the status of the model.
+func (c *statusCoalescer) forget(modelUUID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.statuses, modelUUID)
+}
+
+// sharedStatus implements statusSharer.
+func (sc *sharedClient) sharedStatus(conn api.Connection, client ClientAPIClient) (*params.FullStatus, error) {
+	modelTag, ok := conn.ModelTag()
+	if !ok {
+		return client.Status(&apiclient.StatusArgs{IncludeStorage: true})
+	}
+	return sc.statuses.get(modelTag.Id(), func() (*params.FullStatus, error) {
+		sc.Tracef("fetching shared status", modelFields(modelTag.Id()))
+		return client.Status(&apiclient.StatusArgs{IncludeStorage: true})
+	})
+}
+
+// applicationStorage returns the storage of the status attached to, or
+// owned by, the units of the application.
+func applicationStorage(status *params.FullStatus, appName string) []params.StorageDetails {
+	isApplicationUnit := func(tag string) bool {
+		unitTag, err := names.ParseUnitTag(tag)
+		if err != nil {
+			return false
+		}
+		return strings.HasPrefix(unitTag.Id(), appName+"/")
+	}
+	var storage []params.StorageDetails
+	for _, s := range status.Storage {
+		owned := isApplicationUnit(s.OwnerTag) || s.OwnerTag == names.NewApplicationTag(appName).String()
+		for unit := range s.Attachments {
+			owned = owned || isApplicationUnit(unit)
+		}
+		if owned {
+			storage = append(storage, s)
+		}
+	}
+	return storage
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/juju/errors"
+	apiclient "github.com/juju/juju/api/client/client"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type StatusesSuite struct {
+	suite.Suite
+
+	ctlr *gomock.Controller
+}
+
+func (s *StatusesSuite) SetupTest() {
+	s.ctlr = gomock.NewController(s.T())
+}
+
+func (s *StatusesSuite) TestCoalescerConcurrentReads() {
+	coalescer := newStatusCoalescer()
+	release := make(chan struct{})
+	calls := 0
+	fetch := func() (*params.FullStatus, error) {
+		calls++
+		<-release
+		return &params.FullStatus{}, nil
+	}
+
+	var wg sync.WaitGroup
+	statuses := make([]*params.FullStatus, 5)
+	for i := range statuses {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			statuses[i], _ = coalescer.get("model-uuid", fetch)
+		}(i)
+	}
+	// Let the reads wait on the fetch in progress before completing it.
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	s.Assert().Equal(1, calls)
+	for _, status := range statuses {
+		s.Assert().Same(statuses[0], status)
+	}
+}
+
+func (s *StatusesSuite) TestCoalescerExpiry() {
+	defer func(ttl time.Duration) { statusShareTTL = ttl }(statusShareTTL)
+	statusShareTTL = time.Millisecond
+
+	coalescer := newStatusCoalescer()
+	calls := 0
+	fetch := func() (*params.FullStatus, error) {
+		calls++
+		return &params.FullStatus{}, nil
+	}
+	_, _ = coalescer.get("model-uuid", fetch)
+	time.Sleep(5 * time.Millisecond)
+	_, _ = coalescer.get("model-uuid", fetch)
+	s.Assert().Equal(2, calls)
+}
+
+func (s *StatusesSuite) TestCoalescerErrorNotShared() {
+	coalescer := newStatusCoalescer()
+	_, err := coalescer.get("model-uuid", func() (*params.FullStatus, error) {
+		return nil, errors.New("boom")
+	})
+	s.Require().Error(err)
+
+	status, err := coalescer.get("model-uuid", func() (*params.FullStatus, error) {
+		return &params.FullStatus{}, nil
+	})
+	s.Require().NoError(err)
+	s.Assert().NotNil(status)
+}
+
+func (s *StatusesSuite) TestReadStatus() {
+	sc := &sharedClient{statuses: newStatusCoalescer(), subCtx: context.Background()}
+	conn := NewMockConnection(s.ctlr)
+	conn.EXPECT().ModelTag().Return(names.NewModelTag("c9b3a1f4-0f5f-4a8e-8c4e-2a4c7b6d5e3f"), true).AnyTimes()
+	client := NewMockClientAPIClient(s.ctlr)
+	args := &apiclient.StatusArgs{Patterns: []string{"app"}}
+
+	// Outside of a refresh the filtered status is read every time.
+	client.EXPECT().Status(args).Return(&params.FullStatus{}, nil).Times(2)
+	_, err := readStatus(context.Background(), sc, conn, client, args)
+	s.Require().NoError(err)
+	_, err = readStatus(context.Background(), sc, conn, client, args)
+	s.Require().NoError(err)
+
+	// During a refresh the full status is read once.
+	client.EXPECT().Status(&apiclient.StatusArgs{IncludeStorage: true}).Return(&params.FullStatus{}, nil)
+	ctx := WithRefresh(context.Background())
+	first, err := readStatus(ctx, sc, conn, client, args)
+	s.Require().NoError(err)
+	second, err := readStatus(ctx, sc, conn, client, nil)
+	s.Require().NoError(err)
+	s.Assert().Same(first, second)
+}
+
+func (s *StatusesSuite) TestApplicationStorage() {
+	status := &params.FullStatus{
+		Storage: []params.StorageDetails{
+			{StorageTag: "storage-files-0", OwnerTag: "unit-app-0"},
+			{StorageTag: "storage-files-1", OwnerTag: "unit-other-0"},
+			{StorageTag: "storage-files-2", Attachments: map[string]params.StorageAttachmentDetails{"unit-app-1": {}}},
+			{StorageTag: "storage-files-3", OwnerTag: "unit-application-0"},
+		},
+	}
+	storage := applicationStorage(status, "app")
+	s.Require().Len(storage, 2)
+	s.Assert().Equal("storage-files-0", storage[0].StorageTag)
+	s.Assert().Equal("storage-files-2", storage[1].StorageTag)
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestStatusesSuite(t *testing.T) {
+	suite.Run(t, new(StatusesSuite))
+}
@@ -0,0 +1,206 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/juju/juju/api"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracingConfig configures the export of the traces of the operations
+// of the provider, so that the time spent connecting to the controller,
+// logging in and calling its facades can be seen per resource
+// operation.
+type TracingConfig struct {
+	// Endpoint is the URL the traces are posted to with OTLP over HTTP,
+	// e.g. http://localhost:4318/v1/traces. Traces are not recorded
+	// when empty.
+	Endpoint string
+	// Headers are set on the export requests, e.g. for authentication.
+	Headers map[string]string
+}
+
+// Attributes of the spans.
+const (
+	SpanAttributeResourceType = "tf.resource_type"
+	SpanAttributeOperation    = "tf.operation"
+	SpanAttributeModelUUID    = "juju.model_uuid"
+	SpanAttributeController   = "juju.controller"
+	SpanAttributeFacade       = "rpc.service"
+	SpanAttributeFacadeMethod = "rpc.method"
+	SpanAttributeFacadeVer    = "juju.facade_version"
+)
+
+const (
+	tracingServiceName = "terraform-provider-juju"
+	tracingScopeName   = "github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// traceExportTimeout is how long the export of a batch of spans may
+// take.
+var traceExportTimeout = 5 * time.Second
+
+// tracerProviders are the tracer providers of the clients, flushed
+// and shut down by ShutdownTracing.
+var tracerProviders struct {
+	mu        sync.Mutex
+	providers []*sdktrace.TracerProvider
+}
+
+// ShutdownTracing exports the spans not exported yet by the clients
+// and stops their exporters. It is called once the provider is shut
+// down, no span is recorded afterwards.
+func ShutdownTracing(ctx context.Context) error {
+	tracerProviders.mu.Lock()
+	providers := tracerProviders.providers
+	tracerProviders.providers = nil
+	tracerProviders.mu.Unlock()
+
+	var err error
+	for _, tp := range providers {
+		err = errors.Join(err, tp.Shutdown(ctx))
+	}
+	return err
+}
+
+// tracer records the spans of the client. They are exported in batches
+// in the background by the OpenTelemetry SDK.
+type tracer struct {
+	tracer trace.Tracer
+}
+
+// newTracer returns the tracer configured by config, nil when tracing
+// is disabled. The failures to export spans, which do not fail the
+// operations traced, are logged with warnf.
+func newTracer(config TracingConfig, warnf func(string, ...map[string]interface{})) *tracer {
+	if config.Endpoint == "" {
+		return nil
+	}
+	exporter, err := otlptracehttp.New(context.Background(),
+		otlptracehttp.WithEndpointURL(config.Endpoint),
+		otlptracehttp.WithHeaders(config.Headers),
+		otlptracehttp.WithTimeout(traceExportTimeout),
+	)
+	if err != nil {
+		warnf("unable to export traces", map[string]interface{}{"error": err.Error()})
+		return nil
+	}
+	return newTracerWithExporter(&loggingExporter{SpanExporter: exporter, warnf: warnf})
+}
+
+// newTracerWithExporter returns a tracer exporting the spans with
+// exporter, registered to be shut down by ShutdownTracing.
+func newTracerWithExporter(exporter sdktrace.SpanExporter) *tracer {
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(sdkresource.NewSchemaless(
+			attribute.String("service.name", tracingServiceName),
+		)),
+	)
+	tracerProviders.mu.Lock()
+	tracerProviders.providers = append(tracerProviders.providers, tp)
+	tracerProviders.mu.Unlock()
+	return &tracer{tracer: tp.Tracer(tracingScopeName)}
+}
+
+// loggingExporter logs the failures to export spans rather than
+// reporting them to the global error handler of OpenTelemetry.
+type loggingExporter struct {
+	sdktrace.SpanExporter
+
+	warnf func(msg string, additionalFields ...map[string]interface{})
+}
+
+// ExportSpans implements sdktrace.SpanExporter.
+func (e *loggingExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if err := e.SpanExporter.ExportSpans(ctx, spans); err != nil {
+		e.warnf("unable to export traces", map[string]interface{}{"error": err.Error()})
+	}
+	return nil
+}
+
+// Span is an operation traced, started by Client.StartSpan. A nil Span
+// is valid, and not recorded.
+type Span struct {
+	span trace.Span
+}
+
+// start starts a span, child of the span of ctx if any, returning ctx
+// with the span. t may be nil, when tracing is disabled.
+func (t *tracer) start(ctx context.Context, name string, kind trace.SpanKind, attributes map[string]string) (context.Context, *Span) {
+	if t == nil {
+		return ctx, nil
+	}
+	attrs := make([]attribute.KeyValue, 0, len(attributes))
+	for k, v := range attributes {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+	ctx, span := t.tracer.Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attrs...))
+	return ctx, &Span{span: span}
+}
+
+// End ends the span, failed with err if not nil. The span is exported
+// with the next batch.
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+	if err != nil {
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	s.span.End()
+}
+
+// StartSpan starts a span named name, child of the span of ctx if any,
+// returning ctx with the span. The connections and the facade calls
+// made with ctx are recorded as children of the span. The span must be
+// ended. c may be nil, before the provider is configured, in which case
+// the span is not recorded, as when tracing is disabled.
+func (c *Client) StartSpan(ctx context.Context, name string, attributes map[string]string) (context.Context, *Span) {
+	if c == nil || c.shared == nil {
+		return ctx, nil
+	}
+	return c.shared.tracer.start(ctx, name, trace.SpanKindInternal, attributes)
+}
+
+// withTracing wraps the connection to record its facade calls as spans
+// of the operation of ctx.
+func (sc *sharedClient) withTracing(ctx context.Context, conn api.Connection) api.Connection {
+	if sc.tracer == nil {
+		return conn
+	}
+	return &tracingConnection{Connection: conn, ctx: ctx, tracer: sc.tracer}
+}
+
+// tracingConnection records the API calls as spans, so that every API
+// client created with it does.
+type tracingConnection struct {
+	api.Connection
+
+	ctx    context.Context
+	tracer *tracer
+}
+
+// APICall implements base.APICaller.
+func (c *tracingConnection) APICall(objType string, version int, id, request string, args, response interface{}) error {
+	_, span := c.tracer.start(c.ctx, objType+"."+request, trace.SpanKindClient, map[string]string{
+		SpanAttributeFacade:       objType,
+		SpanAttributeFacadeMethod: request,
+		SpanAttributeFacadeVer:    strconv.Itoa(version),
+	})
+	err := c.Connection.APICall(objType, version, id, request, args, response)
+	span.End(err)
+	return err
+}
@@ -0,0 +1,179 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/juju/errors"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/proto"
+)
+
+type TracingSuite struct {
+	JujuSuite
+
+	server   *httptest.Server
+	requests chan *coltracepb.ExportTraceServiceRequest
+}
+
+func (s *TracingSuite) SetupTest() {
+	s.requests = make(chan *coltracepb.ExportTraceServiceRequest, 10)
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" || r.URL.Path != "/v1/traces" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var req coltracepb.ExportTraceServiceRequest
+		if err := proto.Unmarshal(body, &req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.requests <- &req
+	}))
+	s.T().Cleanup(s.server.Close)
+}
+
+func (s *TracingSuite) TearDownTest() {
+	s.Require().NoError(ShutdownTracing(context.Background()))
+}
+
+// recordingExporter records the spans exported.
+type recordingExporter struct {
+	mu    sync.Mutex
+	spans []sdktrace.ReadOnlySpan
+}
+
+func (e *recordingExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (e *recordingExporter) Shutdown(context.Context) error { return nil }
+
+func (s *TracingSuite) TestTracingDisabled() {
+	s.Assert().Nil(newTracer(TracingConfig{}, nil))
+
+	var c *Client
+	ctx, span := c.StartSpan(context.Background(), "juju_model.Create", nil)
+	s.Assert().Nil(span)
+	s.Assert().False(trace.SpanFromContext(ctx).SpanContext().IsValid())
+	span.End(nil)
+}
+
+func (s *TracingSuite) TestRecordSpans() {
+	exporter := &recordingExporter{}
+	t := newTracerWithExporter(exporter)
+
+	ctx, root := t.start(context.Background(), "juju_application.Create", trace.SpanKindInternal,
+		map[string]string{SpanAttributeResourceType: "juju_application"})
+	_, connect := t.start(ctx, "juju.Connect", trace.SpanKindClient, nil)
+	connect.End(nil)
+	_, call := t.start(ctx, "Application.Deploy", trace.SpanKindClient, nil)
+	call.End(errors.New("boom"))
+	root.End(nil)
+	root.End(nil)
+
+	// The spans are exported in batches, at the latest on shutdown.
+	s.Require().NoError(ShutdownTracing(context.Background()))
+	spans := exporter.spans
+	s.Require().Len(spans, 3)
+
+	s.Assert().Equal("juju.Connect", spans[0].Name())
+	s.Assert().Equal("Application.Deploy", spans[1].Name())
+	s.Assert().Equal(sdktrace.Status{Code: codes.Error, Description: "boom"}, spans[1].Status())
+	s.Assert().Equal("juju_application.Create", spans[2].Name())
+	s.Assert().False(spans[2].Parent().IsValid())
+	s.Assert().Equal([]attribute.KeyValue{attribute.String(SpanAttributeResourceType, "juju_application")},
+		spans[2].Attributes())
+	s.Assert().Contains(spans[2].Resource().Attributes(), attribute.String("service.name", tracingServiceName))
+	for _, span := range spans[:2] {
+		s.Assert().Equal(spans[2].SpanContext().TraceID(), span.SpanContext().TraceID())
+		s.Assert().Equal(spans[2].SpanContext().SpanID(), span.Parent().SpanID())
+		s.Assert().Equal(trace.SpanKindClient, span.SpanKind())
+	}
+}
+
+func (s *TracingSuite) TestExportTrace() {
+	var warnings []string
+	t := newTracer(TracingConfig{
+		Endpoint: s.server.URL + "/v1/traces",
+		Headers:  map[string]string{"Authorization": "Bearer token"},
+	}, func(msg string, _ ...map[string]interface{}) { warnings = append(warnings, msg) })
+	s.Require().NotNil(t)
+
+	_, span := t.start(context.Background(), "juju_model.Read", trace.SpanKindInternal, nil)
+	span.End(nil)
+
+	s.Require().NoError(ShutdownTracing(context.Background()))
+	s.Require().Empty(warnings)
+	s.Require().Len(s.requests, 1)
+	req := <-s.requests
+	s.Require().Len(req.ResourceSpans, 1)
+	s.Require().Len(req.ResourceSpans[0].ScopeSpans, 1)
+	s.Assert().Equal(tracingScopeName, req.ResourceSpans[0].ScopeSpans[0].Scope.Name)
+	spans := req.ResourceSpans[0].ScopeSpans[0].Spans
+	s.Require().Len(spans, 1)
+	s.Assert().Equal("juju_model.Read", spans[0].Name)
+}
+
+func (s *TracingSuite) TestExportFailureLogged() {
+	var warnings []string
+	t := newTracer(TracingConfig{Endpoint: s.server.URL + "/v1/traces"},
+		func(msg string, _ ...map[string]interface{}) { warnings = append(warnings, msg) })
+
+	_, span := t.start(context.Background(), "juju_model.Read", trace.SpanKindInternal, nil)
+	span.End(nil)
+
+	s.Require().NoError(ShutdownTracing(context.Background()))
+	s.Assert().Equal([]string{"unable to export traces"}, warnings)
+	s.Assert().Len(s.requests, 0)
+}
+
+func (s *TracingSuite) TestTracingConnection() {
+	defer s.setupMocks(s.T()).Finish()
+	exporter := &recordingExporter{}
+	t := newTracerWithExporter(exporter)
+
+	s.mockConnection.EXPECT().APICall("Client", 7, "", "FullStatus", gomock.Any(), gomock.Any()).Return(nil)
+
+	ctx, root := t.start(context.Background(), "data.juju_full_status.Read", trace.SpanKindInternal, nil)
+	conn := &tracingConnection{Connection: s.mockConnection, ctx: ctx, tracer: t}
+	s.Require().NoError(conn.APICall("Client", 7, "", "FullStatus", nil, nil))
+	root.End(nil)
+
+	s.Require().NoError(ShutdownTracing(context.Background()))
+	spans := exporter.spans
+	s.Require().Len(spans, 2)
+	s.Assert().Equal("Client.FullStatus", spans[0].Name())
+	s.Assert().ElementsMatch([]attribute.KeyValue{
+		attribute.String(SpanAttributeFacadeVer, "7"),
+		attribute.String(SpanAttributeFacadeMethod, "FullStatus"),
+		attribute.String(SpanAttributeFacade, "Client"),
+	}, spans[0].Attributes())
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestTracingSuite(t *testing.T) {
+	suite.Run(t, new(TracingSuite))
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/retry"
+)
+
+// DefaultTransientRetryTimeout is how long calls failing while the
+// controller or model is upgrading or migrating are retried, unless set
+// in the controller configuration.
+const DefaultTransientRetryTimeout = 10 * time.Minute
+
+var (
+	// transientRetryDelay is the delay before the first retry, it
+	// doubles for each retry up to transientRetryMaxDelay.
+	transientRetryDelay    = 5 * time.Second
+	transientRetryMaxDelay = 30 * time.Second
+)
+
+// isTransientError reports whether err is returned because the
+// controller or model is upgrading or migrating, in which case the call
+// is expected to succeed once done.
+func isTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch params.ErrCode(err) {
+	case params.CodeUpgradeInProgress, params.CodeMigrationInProgress, params.CodeTryAgain:
+		return true
+	}
+	if errors.Is(err, params.UpgradeInProgressError) || errors.Is(err, params.MigrationInProgressError) {
+		return true
+	}
+	// Errors returned by the login are not always typed.
+	msg := err.Error()
+	return strings.Contains(msg, params.CodeUpgradeInProgress) || strings.Contains(msg, params.CodeMigrationInProgress)
+}
+
+// retryTransientErrors calls f until it does not return a transient
+// error, timeout elapses or ctx is done. The last error is returned in
+// the last two cases. notify is called before each retry.
+func retryTransientErrors(ctx context.Context, timeout time.Duration, notify func(err error), f func() error) error {
+	if timeout <= 0 {
+		return f()
+	}
+	err := retry.Call(retry.CallArgs{
+		Func: f,
+		IsFatalError: func(err error) bool {
+			return !isTransientError(err)
+		},
+		NotifyFunc: func(err error, _ int) {
+			notify(err)
+		},
+		BackoffFunc: retry.DoubleDelay,
+		Delay:       transientRetryDelay,
+		MaxDelay:    transientRetryMaxDelay,
+		MaxDuration: timeout,
+		Clock:       clock.WallClock,
+		Stop:        ctx.Done(),
+	})
+	if retry.IsDurationExceeded(err) || retry.IsRetryStopped(err) {
+		return retry.LastError(err)
+	}
+	return err
+}
+
+// retryTransientErrors retries f on transient errors for the duration
+// set in the controller configuration.
+func (sc *sharedClient) retryTransientErrors(ctx context.Context, f func() error) error {
+	return retryTransientErrors(ctx, sc.controllerConfig.TransientRetryTimeout, func(err error) {
+		sc.stats.retries.Add(1)
+		sc.Warnf("retrying after transient error", map[string]interface{}{"error": err.Error()})
+	}, f)
+}
+
+// transientRetryConnection retries the API calls failing while the
+// controller or model is upgrading or migrating, so that every API
+// client created with it does.
+type transientRetryConnection struct {
+	api.Connection
+
+	ctx context.Context
+	sc  *sharedClient
+}
+
+// APICall implements base.APICaller.
+func (c *transientRetryConnection) APICall(objType string, version int, id, request string, args, response interface{}) error {
+	return c.sc.retryTransientErrors(c.ctx, func() error {
+		return c.Connection.APICall(objType, version, id, request, args, response)
+	})
+}
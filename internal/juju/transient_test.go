@@ -0,0 +1,104 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/juju/rpc/params"
+	"github.com/stretchr/testify/suite"
+)
+
+type TransientSuite struct {
+	suite.Suite
+}
+
+func (s *TransientSuite) SetupTest() {
+	delay, maxDelay := transientRetryDelay, transientRetryMaxDelay
+	transientRetryDelay, transientRetryMaxDelay = time.Millisecond, time.Millisecond
+	s.T().Cleanup(func() {
+		transientRetryDelay, transientRetryMaxDelay = delay, maxDelay
+	})
+}
+
+func (s *TransientSuite) TestIsTransientError() {
+	s.Assert().True(isTransientError(&params.Error{Code: params.CodeUpgradeInProgress}))
+	s.Assert().True(isTransientError(&params.Error{Code: params.CodeMigrationInProgress}))
+	s.Assert().True(isTransientError(&params.Error{Code: params.CodeTryAgain}))
+	s.Assert().True(isTransientError(errors.Annotate(params.UpgradeInProgressError, "login")))
+	s.Assert().True(isTransientError(errors.New("cannot deploy: model migration in progress")))
+
+	s.Assert().False(isTransientError(nil))
+	s.Assert().False(isTransientError(&params.Error{Code: params.CodeNotFound}))
+	s.Assert().False(isTransientError(errors.New("boom")))
+}
+
+func (s *TransientSuite) TestRetryTransientErrors() {
+	calls, notified := 0, 0
+	err := retryTransientErrors(context.Background(), time.Minute, func(error) { notified++ }, func() error {
+		calls++
+		if calls < 3 {
+			return &params.Error{Code: params.CodeUpgradeInProgress}
+		}
+		return nil
+	})
+	s.Require().NoError(err)
+	s.Assert().Equal(3, calls)
+	s.Assert().Equal(2, notified)
+}
+
+func (s *TransientSuite) TestRetryTransientErrorsFatal() {
+	calls := 0
+	err := retryTransientErrors(context.Background(), time.Minute, func(error) {}, func() error {
+		calls++
+		return errors.NotFoundf("application %q", "test")
+	})
+	s.Require().Error(err)
+	s.Assert().True(errors.Is(err, errors.NotFound))
+	s.Assert().Equal(1, calls)
+}
+
+func (s *TransientSuite) TestRetryTransientErrorsTimeout() {
+	err := retryTransientErrors(context.Background(), 10*time.Millisecond, func(error) {}, func() error {
+		return &params.Error{Code: params.CodeMigrationInProgress}
+	})
+	s.Require().Error(err)
+	s.Assert().True(isTransientError(err))
+}
+
+func (s *TransientSuite) TestRetryTransientErrorsDisabled() {
+	calls := 0
+	err := retryTransientErrors(context.Background(), 0, func(error) {}, func() error {
+		calls++
+		return &params.Error{Code: params.CodeUpgradeInProgress}
+	})
+	s.Require().Error(err)
+	s.Assert().Equal(1, calls)
+}
+
+func (s *TransientSuite) TestRetriesCounted() {
+	sc := &sharedClient{
+		controllerConfig: ControllerConfiguration{TransientRetryTimeout: time.Minute},
+		subCtx:           context.Background(),
+	}
+	calls := 0
+	err := sc.retryTransientErrors(context.Background(), func() error {
+		calls++
+		if calls < 3 {
+			return &params.Error{Code: params.CodeTryAgain}
+		}
+		return nil
+	})
+	s.Require().NoError(err)
+	s.Assert().Equal(int64(2), sc.stats.snapshot().Retries)
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestTransientSuite(t *testing.T) {
+	suite.Run(t, new(TransientSuite))
+}
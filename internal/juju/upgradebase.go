@@ -0,0 +1,241 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/juju/api"
+	corebase "github.com/juju/juju/core/base"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/retry"
+)
+
+// defaultUpgradeMachineTimeout bounds each step of the upgrade of a
+// machine when the update of the application has no deadline.
+const defaultUpgradeMachineTimeout = time.Hour
+
+// upgradeMachineCommand upgrades the operating system of a machine to
+// its next release without prompting, then reboots it, a minute later
+// so that the command returns first. It is the manual step of
+// `juju upgrade-machine`, between prepare and complete.
+const upgradeMachineCommand = "DEBIAN_FRONTEND=noninteractive do-release-upgrade -f DistUpgradeViewNonInteractive && shutdown -r +1"
+
+// bootIDCommand prints the ID of the current boot of a machine, which
+// changes when it reboots.
+const bootIDCommand = "cat /proc/sys/kernel/random/boot_id"
+
+// upgradeSeriesMessagePrefix prefixes the instance status messages of
+// the machines being upgraded, followed by the status of the upgrade,
+// e.g. `series upgrade prepare completed: waiting for completion command`.
+const upgradeSeriesMessagePrefix = "series upgrade "
+
+// upgradeMachineRebootDelay is the delay between two checks of the
+// reboot of a machine upgraded.
+var upgradeMachineRebootDelay = 30 * time.Second
+
+// errMachineNotRebooted is returned while waiting for a machine to
+// reboot.
+var errMachineNotRebooted = errors.New("machine not rebooted yet")
+
+// upgradeApplicationBase sets the base of the application, like `juju
+// set-application-base`, then upgrades in place the operating system of
+// the machines of its units, one at a time, like `juju upgrade-machine`.
+// The machines already running the base are skipped, so an upgrade
+// failed part way is resumed by applying again.
+func (c applicationsClient) upgradeApplicationBase(
+	ctx context.Context, conn api.Connection, input *UpdateApplicationInput,
+	appStatus params.ApplicationStatus, machines map[string]params.MachineStatus,
+) error {
+	var base corebase.Base
+	var err error
+	if input.Base != "" {
+		base, err = corebase.ParseBaseFromString(input.Base)
+	} else {
+		base, err = corebase.GetBaseFromSeries(input.Series)
+	}
+	if err != nil {
+		return err
+	}
+	c.Tracef("upgradeApplicationBase", map[string]interface{}{"application": input.AppName, "base": base.String()})
+	if err := c.getApplicationAPIClient(conn).UpdateApplicationBase(input.AppName, base, false); err != nil {
+		return err
+	}
+
+	for _, machine := range applicationMachines(appStatus) {
+		machineStatus, found := findMachineStatus(machines, machine)
+		if !found {
+			return errors.NotFoundf("machine %q", machine)
+		}
+		current, err := corebase.ParseBase(machineStatus.Base.Name, machineStatus.Base.Channel)
+		if err == nil && current.IsCompatible(base) {
+			continue
+		}
+		if err := c.upgradeMachineBase(ctx, conn, input.ModelName, machine, base); err != nil {
+			return errors.Annotatef(err, "upgrading machine %q to %q", machine, base.DisplayString())
+		}
+	}
+	return nil
+}
+
+// upgradeMachineBase upgrades the operating system of the machine to
+// base in place: the units of the machine are prepared, the machine is
+// upgraded and rebooted, then the upgrade is completed, once the units
+// have run their series upgrade hooks.
+func (c applicationsClient) upgradeMachineBase(ctx context.Context, conn api.Connection, modelName, machine string, base corebase.Base) error {
+	timeout := timeoutFromContext(ctx, defaultUpgradeMachineTimeout)
+	bootID, err := c.execOnMachine(ctx, modelName, machine, bootIDCommand, timeout)
+	if err != nil {
+		return errors.Annotate(err, "reading boot ID")
+	}
+
+	machineManagerAPIClient := c.getMachineManagerAPIClient(conn)
+	c.Tracef("preparing machine upgrade", map[string]interface{}{"machine": machine, "base": base.String()})
+	if err := machineManagerAPIClient.UpgradeSeriesPrepare(machine, base.Channel.String(), false); err != nil {
+		return errors.Annotate(err, "preparing upgrade")
+	}
+	err = waitForModel(ctx, c.SharedClient, conn, machineUpgradeStatus(machine, model.UpgradeSeriesPrepareCompleted, ""), timeout)
+	if err != nil {
+		return err
+	}
+
+	c.Tracef("upgrading machine", map[string]interface{}{"machine": machine})
+	if _, err := c.execOnMachine(ctx, modelName, machine, upgradeMachineCommand, timeout); err != nil {
+		return errors.Annotate(err, "upgrading operating system")
+	}
+	if err := c.waitForMachineReboot(ctx, modelName, machine, bootID, timeout); err != nil {
+		return err
+	}
+
+	c.Tracef("completing machine upgrade", map[string]interface{}{"machine": machine})
+	if err := machineManagerAPIClient.UpgradeSeriesComplete(machine); err != nil {
+		return errors.Annotate(err, "completing upgrade")
+	}
+	return waitForModel(ctx, c.SharedClient, conn, machineUpgradeStatus(machine, model.UpgradeSeriesCompleted, "success"), timeout)
+}
+
+// waitForMachineReboot blocks until the boot ID of the machine differs
+// from bootID. The commands sent while the machine reboots run once its
+// agent is started again.
+func (c applicationsClient) waitForMachineReboot(ctx context.Context, modelName, machine, bootID string, timeout time.Duration) error {
+	err := retry.Call(retry.CallArgs{
+		Func: func() error {
+			current, err := c.execOnMachine(ctx, modelName, machine, bootIDCommand, timeout)
+			if err != nil {
+				return err
+			}
+			if current == bootID {
+				return errMachineNotRebooted
+			}
+			return nil
+		},
+		IsFatalError: func(err error) bool {
+			return !errors.Is(err, errMachineNotRebooted)
+		},
+		NotifyFunc: func(err error, attempt int) {
+			c.Debugf(fmt.Sprintf("waiting for machine %q to reboot, attempt %d", machine, attempt))
+		},
+		MaxDuration: timeout,
+		Delay:       upgradeMachineRebootDelay,
+		Clock:       clock.WallClock,
+		Stop:        ctx.Done(),
+	})
+	if retry.IsDurationExceeded(err) {
+		return errors.Timeoutf("waiting for machine %q to reboot", machine)
+	} else if retry.IsRetryStopped(err) {
+		return errors.Annotatef(ctx.Err(), "waiting for machine %q to reboot", machine)
+	}
+	return err
+}
+
+// execOnMachine runs the command on the machine, like `juju exec
+// --machine`, returning its output. The command must succeed.
+func (c applicationsClient) execOnMachine(ctx context.Context, modelName, machine, command string, timeout time.Duration) (string, error) {
+	exec := execClient{SharedClient: c.SharedClient, getActionAPIClient: c.getActionAPIClient}
+	resp, err := exec.Exec(ctx, ExecInput{
+		ModelName: modelName,
+		Command:   command,
+		Machines:  []string{machine},
+		Timeout:   timeout,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(resp.Results) != 1 {
+		return "", errors.Errorf("expected 1 result of %q, got %d", command, len(resp.Results))
+	}
+	result := resp.Results[0]
+	if result.Status != params.ActionCompleted || result.ExitCode != 0 {
+		return "", errors.Errorf("running %q: %s, exit code %d: %s", command, result.Status, result.ExitCode,
+			strings.TrimSpace(result.Message+" "+result.Stderr))
+	}
+	return strings.TrimSpace(result.Stdout), nil
+}
+
+// machineUpgradeStatus is met when the upgrade of the machine reaches
+// status, with the given message if not empty, as reported in the
+// instance status of the machine. The wait fails if the upgrade fails.
+func machineUpgradeStatus(machine string, status model.UpgradeSeriesStatus, message string) WaitCondition {
+	expected := upgradeSeriesMessagePrefix + string(status)
+	if message != "" {
+		expected += ": " + message
+	}
+	failed := upgradeSeriesMessagePrefix + string(model.UpgradeSeriesError)
+	return WaitCondition{
+		Description: fmt.Sprintf("upgrade of machine %q to reach %q", machine, expected),
+		Check: func(m *ModelState) (bool, string, error) {
+			delta, ok := m.entity(EntityKindMachine, machine)
+			if !ok {
+				return false, "not found yet", nil
+			}
+			info, ok := delta.Entity.(*params.MachineInfo)
+			if !ok || delta.Removed {
+				return false, "", errors.NotFoundf("machine %q", machine)
+			}
+			current := info.InstanceStatus.Message
+			if strings.HasPrefix(current, failed) {
+				return false, "", errors.Errorf("upgrade of machine %q failed: %s", machine, current)
+			}
+			return strings.HasPrefix(current, expected), fmt.Sprintf("instance status %q", current), nil
+		},
+	}
+}
+
+// applicationMachines returns the machines of the units of the
+// application, sorted. Subordinate applications have none.
+func applicationMachines(appStatus params.ApplicationStatus) []string {
+	machines := make(map[string]bool)
+	for _, unit := range appStatus.Units {
+		if unit.Machine != "" {
+			machines[unit.Machine] = true
+		}
+	}
+	sorted := make([]string, 0, len(machines))
+	for machine := range machines {
+		sorted = append(sorted, machine)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// findMachineStatus returns the status of the machine, containers
+// included.
+func findMachineStatus(machines map[string]params.MachineStatus, id string) (params.MachineStatus, bool) {
+	for machineID, machine := range machines {
+		if machineID == id {
+			return machine, true
+		}
+		if container, found := findMachineStatus(machine.Containers, id); found {
+			return container, true
+		}
+	}
+	return params.MachineStatus{}, false
+}
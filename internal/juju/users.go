@@ -4,8 +4,12 @@
 package juju
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/juju/api/client/usermanager"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/names/v5"
@@ -50,14 +54,35 @@ type DestroyUserInput struct {
 	Name string
 }
 
+type ListUsersInput struct {
+	// IncludeDisabled lists the disabled users too.
+	IncludeDisabled bool
+}
+
+type ListUsersOutput struct {
+	// Users are sorted by name.
+	Users []UserSummary
+}
+
+// UserSummary holds the details of a user listed.
+type UserSummary struct {
+	Name        string
+	DisplayName string
+	Access      string
+	Disabled    bool
+	// LastConnection is the time of the last connection of the user,
+	// in RFC 3339 format, empty if the user never connected.
+	LastConnection string
+}
+
 func newUsersClient(sc SharedClient) *usersClient {
 	return &usersClient{
 		SharedClient: sc,
 	}
 }
 
-func (c *usersClient) CreateUser(input CreateUserInput) (*CreateUserResponse, error) {
-	conn, err := c.GetConnection(nil)
+func (c *usersClient) CreateUser(ctx context.Context, input CreateUserInput) (*CreateUserResponse, error) {
+	conn, err := c.GetConnection(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -73,8 +98,8 @@ func (c *usersClient) CreateUser(input CreateUserInput) (*CreateUserResponse, er
 	return &CreateUserResponse{UserTag: userTag, Secret: userSecret}, nil
 }
 
-func (c *usersClient) ReadUser(name string) (*ReadUserResponse, error) {
-	usermanagerConn, err := c.GetConnection(nil)
+func (c *usersClient) ReadUser(ctx context.Context, name string) (*ReadUserResponse, error) {
+	usermanagerConn, err := c.GetConnection(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -91,7 +116,7 @@ func (c *usersClient) ReadUser(name string) (*ReadUserResponse, error) {
 		return nil, fmt.Errorf("more than one user returned for user name: %s", name)
 	}
 	if len(users) < 1 {
-		return nil, fmt.Errorf("no user returned for user name: %s", name)
+		return nil, errors.UserNotFoundf("%s", name)
 	}
 
 	userInfo := users[0]
@@ -101,15 +126,15 @@ func (c *usersClient) ReadUser(name string) (*ReadUserResponse, error) {
 	}, nil
 }
 
-func (c *usersClient) ModelUserInfo(modelName string) (*ReadModelUserResponse, error) {
-	usermanagerConn, err := c.GetConnection(nil)
+func (c *usersClient) ModelUserInfo(ctx context.Context, modelName string) (*ReadModelUserResponse, error) {
+	usermanagerConn, err := c.GetConnection(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = usermanagerConn.Close() }()
 	usermanagerClient := usermanager.NewClient(usermanagerConn)
 
-	uuid, err := c.ModelUUID(modelName)
+	uuid, err := c.ModelUUID(ctx, modelName)
 	if err != nil {
 		return nil, err
 	}
@@ -128,8 +153,8 @@ func (c *usersClient) ModelUserInfo(modelName string) (*ReadModelUserResponse, e
 	}, nil
 }
 
-func (c *usersClient) UpdateUser(input UpdateUserInput) error {
-	conn, err := c.GetConnection(nil)
+func (c *usersClient) UpdateUser(ctx context.Context, input UpdateUserInput) error {
+	conn, err := c.GetConnection(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -147,8 +172,8 @@ func (c *usersClient) UpdateUser(input UpdateUserInput) error {
 	return nil
 }
 
-func (c *usersClient) DestroyUser(input DestroyUserInput) error {
-	conn, err := c.GetConnection(nil)
+func (c *usersClient) DestroyUser(ctx context.Context, input DestroyUserInput) error {
+	conn, err := c.GetConnection(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -163,3 +188,35 @@ func (c *usersClient) DestroyUser(input DestroyUserInput) error {
 
 	return nil
 }
+
+// ListUsers lists the users of the controller.
+func (c *usersClient) ListUsers(ctx context.Context, input ListUsersInput) (ListUsersOutput, error) {
+	conn, err := c.GetConnection(ctx, nil)
+	if err != nil {
+		return ListUsersOutput{}, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	users, err := usermanager.NewClient(conn).UserInfo(nil, usermanager.IncludeDisabled(input.IncludeDisabled))
+	if err != nil {
+		return ListUsersOutput{}, err
+	}
+
+	output := ListUsersOutput{Users: make([]UserSummary, 0, len(users))}
+	for _, user := range users {
+		summary := UserSummary{
+			Name:        user.Username,
+			DisplayName: user.DisplayName,
+			Access:      user.Access,
+			Disabled:    user.Disabled,
+		}
+		if user.LastConnection != nil {
+			summary.LastConnection = user.LastConnection.UTC().Format(time.RFC3339)
+		}
+		output.Users = append(output.Users, summary)
+	}
+	sort.Slice(output.Users, func(i, j int) bool {
+		return output.Users[i].Name < output.Users[j].Name
+	})
+	return output, nil
+}
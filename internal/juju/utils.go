@@ -6,16 +6,11 @@ package juju
 import (
 	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
 	"os/exec"
 	"strings"
 	"sync"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	apiapplication "github.com/juju/juju/api/client/application"
-	"github.com/juju/names/v5"
 )
 
 // controllerConfig is a representation of the output
@@ -109,48 +104,12 @@ func populateControllerConfig() {
 	localProviderConfig["JUJU_USERNAME"] = controllerConfig.Account.User
 	localProviderConfig["JUJU_PASSWORD"] = controllerConfig.Account.Password
 
-	tflog.Debug(context.TODO(), "local provider controllerConfig was set", map[string]interface{}{"localProviderConfig": fmt.Sprintf("%#v", localProviderConfig)})
-}
-
-// WaitForAppAvailable blocks the execution flow and waits until all the
-// application names can be queried before the context is done. The
-// tickTime param indicates the frequency used to query the API.
-func WaitForAppsAvailable(ctx context.Context, client *apiapplication.Client, appsName []string, tickTime time.Duration) error {
-	if len(appsName) == 0 {
-		return nil
-	}
-	// build app tags for these apps
-	tags := make([]names.ApplicationTag, len(appsName))
-	for i, n := range appsName {
-		tags[i] = names.NewApplicationTag(n)
-	}
-
-	tick := time.NewTicker(tickTime)
-	for {
-		select {
-		case <-tick.C:
-			returned, err := client.ApplicationsInfo(tags)
-			// if there is no error and we get as many app infos as
-			// requested apps, we can assume the apps are available
-			if err != nil {
-				return err
-			}
-			totalAvailable := 0
-			for _, entry := range returned {
-				// there's no info available yet
-				if entry.Result == nil {
-					continue
-				}
-				totalAvailable++
-			}
-			// All the entries were available
-			if totalAvailable == len(appsName) {
-				return nil
-			}
-		case <-ctx.Done():
-			return errors.New("the context was done waiting for apps")
-		}
-	}
+	// The password is not logged.
+	tflog.Debug(context.TODO(), "local provider controllerConfig was set", map[string]interface{}{
+		"controller_addresses": localProviderConfig["JUJU_CONTROLLER_ADDRESSES"],
+		"username":             localProviderConfig["JUJU_USERNAME"],
+		"agent_version":        localProviderConfig["JUJU_AGENT_VERSION"],
+	})
 }
 
 // ProcessErrorResults processes the results of a secret operation.
@@ -0,0 +1,312 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/juju/api"
+	apiclient "github.com/juju/juju/api/client/client"
+	"github.com/juju/juju/rpc/params"
+)
+
+// waitProgressInterval is how often the progress of a wait is logged.
+const waitProgressInterval = 30 * time.Second
+
+// ModelState is the state of a model as reported by the AllWatcher,
+// against which the conditions of waits are checked. It holds the
+// last delta of each entity, removed entities included.
+type ModelState struct {
+	deltas map[string]params.Delta
+}
+
+func newModelState() *ModelState {
+	return &ModelState{deltas: make(map[string]params.Delta)}
+}
+
+func entityKey(kind, id string) string {
+	return kind + "/" + id
+}
+
+func (m *ModelState) apply(deltas []params.Delta) {
+	for _, delta := range deltas {
+		id := delta.Entity.EntityId()
+		m.deltas[entityKey(id.Kind, id.Id)] = delta
+	}
+}
+
+// entity returns the last delta of the entity of the given kind and ID.
+// The model is looked up by kind only.
+func (m *ModelState) entity(kind, id string) (params.Delta, bool) {
+	if kind == EntityKindModel {
+		for _, delta := range m.deltas {
+			if delta.Entity.EntityId().Kind == EntityKindModel {
+				return delta, true
+			}
+		}
+		return params.Delta{}, false
+	}
+	delta, ok := m.deltas[entityKey(kind, id)]
+	return delta, ok
+}
+
+// Units returns the units of the application, in name order.
+func (m *ModelState) Units(application string) []*params.UnitInfo {
+	var units []*params.UnitInfo
+	for _, delta := range m.deltas {
+		if unit, ok := delta.Entity.(*params.UnitInfo); ok && !delta.Removed && unit.Application == application {
+			units = append(units, unit)
+		}
+	}
+	sort.Slice(units, func(i, j int) bool { return units[i].Name < units[j].Name })
+	return units
+}
+
+// Relations returns the relations of the model.
+func (m *ModelState) Relations() []*params.RelationInfo {
+	var relations []*params.RelationInfo
+	for _, delta := range m.deltas {
+		if relation, ok := delta.Entity.(*params.RelationInfo); ok && !delta.Removed {
+			relations = append(relations, relation)
+		}
+	}
+	return relations
+}
+
+// WaitCondition is a condition on the state of a model to wait for.
+type WaitCondition struct {
+	// Description describes what is waited for in logs and errors,
+	// e.g. `application "postgresql" to reach status "active"`.
+	Description string
+	// Check reports whether the condition is met. progress describes
+	// how far the model is from meeting it, e.g. the current status of
+	// an application. It is logged, and reported if the wait times out.
+	// An error ends the wait.
+	Check func(m *ModelState) (met bool, progress string, err error)
+}
+
+// EntityStatus is met when the application, unit, machine or model
+// reaches one of statuses. For applications and units the workload
+// status is used, for machines the agent status. The wait fails if
+// the entity is removed.
+func EntityStatus(kind, name string, statuses ...string) WaitCondition {
+	description := fmt.Sprintf("%s %q to reach status %q", kind, name, statuses)
+	if kind == EntityKindModel {
+		description = fmt.Sprintf("model to reach status %q", statuses)
+	}
+	return WaitCondition{
+		Description: description,
+		Check: func(m *ModelState) (bool, string, error) {
+			delta, ok := m.entity(kind, name)
+			if !ok {
+				return false, "not found yet", nil
+			}
+			current, _ := matchEntityStatus(delta, kind, name)
+			if current.removed {
+				return false, "", errors.NotFoundf("%s %q", kind, name)
+			}
+			progress := fmt.Sprintf("status %q", current.status)
+			return slices.Contains(statuses, current.status), progress, nil
+		},
+	}
+}
+
+// ApplicationsExist is met once all the applications exist.
+func ApplicationsExist(applications ...string) WaitCondition {
+	return WaitCondition{
+		Description: fmt.Sprintf("applications %q to exist", applications),
+		Check: func(m *ModelState) (bool, string, error) {
+			var missing []string
+			for _, name := range applications {
+				if delta, ok := m.entity(EntityKindApplication, name); !ok || delta.Removed {
+					missing = append(missing, name)
+				}
+			}
+			return len(missing) == 0, fmt.Sprintf("missing %q", missing), nil
+		},
+	}
+}
+
+// UnitsStatus is met when the application has at least one unit and
+// the workload status of all of them is one of statuses.
+func UnitsStatus(application string, statuses ...string) WaitCondition {
+	return unitsStatus(fmt.Sprintf("units of application %q to reach status %q", application, statuses),
+		application, func(unit *params.UnitInfo) string { return unit.WorkloadStatus.Current.String() }, statuses)
+}
+
+// UnitsAgentStatus is met when the application has at least one unit
+// and the agent status of all of them is one of statuses, e.g. idle
+// once the hooks are run.
+func UnitsAgentStatus(application string, statuses ...string) WaitCondition {
+	return unitsStatus(fmt.Sprintf("units of application %q to reach agent status %q", application, statuses),
+		application, func(unit *params.UnitInfo) string { return unit.AgentStatus.Current.String() }, statuses)
+}
+
+func unitsStatus(description, application string, current func(*params.UnitInfo) string, statuses []string) WaitCondition {
+	return WaitCondition{
+		Description: description,
+		Check: func(m *ModelState) (bool, string, error) {
+			units := m.Units(application)
+			if len(units) == 0 {
+				return false, "no units yet", nil
+			}
+			var pending []string
+			for _, unit := range units {
+				if status := current(unit); !slices.Contains(statuses, status) {
+					pending = append(pending, fmt.Sprintf("%s is %q", unit.Name, status))
+				}
+			}
+			return len(pending) == 0, strings.Join(pending, ", "), nil
+		},
+	}
+}
+
+// UnitsAtMost is met when the application has at most count units,
+// e.g. once the units scaled down are removed.
+func UnitsAtMost(application string, count int) WaitCondition {
+	return WaitCondition{
+		Description: fmt.Sprintf("application %q to have at most %d units", application, count),
+		Check: func(m *ModelState) (bool, string, error) {
+			units := m.Units(application)
+			return len(units) <= count, fmt.Sprintf("%d units", len(units)), nil
+		},
+	}
+}
+
+// AllConditions is met when all the conditions are met at once.
+func AllConditions(conditions ...WaitCondition) WaitCondition {
+	descriptions := make([]string, 0, len(conditions))
+	for _, condition := range conditions {
+		descriptions = append(descriptions, condition.Description)
+	}
+	return WaitCondition{
+		Description: strings.Join(descriptions, " and "),
+		Check: func(m *ModelState) (bool, string, error) {
+			var progress []string
+			allMet := true
+			for _, condition := range conditions {
+				met, current, err := condition.Check(m)
+				if err != nil {
+					return false, "", err
+				}
+				if !met {
+					allMet = false
+					progress = append(progress, current)
+				}
+			}
+			return allMet, strings.Join(progress, "; "), nil
+		},
+	}
+}
+
+// RelationExists is met once a relation between the endpoints exists,
+// each endpoint being formatted as <application>:<endpoint>.
+func RelationExists(endpoints ...string) WaitCondition {
+	return WaitCondition{
+		Description: fmt.Sprintf("relation between %q to exist", endpoints),
+		Check: func(m *ModelState) (bool, string, error) {
+			for _, relation := range m.Relations() {
+				related := make([]string, 0, len(relation.Endpoints))
+				for _, endpoint := range relation.Endpoints {
+					related = append(related, endpoint.ApplicationName+":"+endpoint.Relation.Name)
+				}
+				if !slices.ContainsFunc(endpoints, func(e string) bool { return !slices.Contains(related, e) }) {
+					return true, fmt.Sprintf("relation %q", relation.Key), nil
+				}
+			}
+			return false, "no relation yet", nil
+		},
+	}
+}
+
+// timeoutFromContext returns the time left to the deadline of ctx, e.g.
+// the timeout of the operation of a resource, or fallback without one.
+func timeoutFromContext(ctx context.Context, fallback time.Duration) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		return time.Until(deadline)
+	}
+	return fallback
+}
+
+// allWatcher is the part of api.AllWatcher waits use.
+type allWatcher interface {
+	Next() ([]params.Delta, error)
+	Stop() error
+}
+
+// waitForModel blocks until condition is met by the model of conn, the
+// timeout expires or ctx is done.
+func waitForModel(ctx context.Context, sc SharedClient, conn api.Connection, condition WaitCondition, timeout time.Duration) error {
+	watcher, err := apiclient.NewClient(conn, sc.JujuLogger()).WatchAll()
+	if err != nil {
+		return errors.Annotate(err, "watching model")
+	}
+	defer func() { _ = watcher.Stop() }()
+	return waitFor(ctx, sc, watcher, condition, timeout)
+}
+
+// waitFor blocks until condition is met by the model watched by
+// watcher, the timeout expires or ctx is done. The AllWatcher returns
+// the current state of the model first, so a condition already met
+// returns immediately, then the changes are pushed by the controller.
+// The progress is logged at trace level when it changes, and at debug
+// level every waitProgressInterval.
+func waitFor(ctx context.Context, sc SharedClient, watcher allWatcher, condition WaitCondition, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	deltasCh := make(chan []params.Delta)
+	errCh := make(chan error, 1)
+	go func() {
+		for {
+			deltas, err := watcher.Next()
+			if err != nil {
+				errCh <- err
+				return
+			}
+			select {
+			case deltasCh <- deltas:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	state := newModelState()
+	progress := "nothing seen yet"
+	ticker := time.NewTicker(waitProgressInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case deltas := <-deltasCh:
+			state.apply(deltas)
+			met, current, err := condition.Check(state)
+			if err != nil {
+				return err
+			}
+			if current != progress {
+				progress = current
+				sc.Tracef(fmt.Sprintf("waiting for %s: %s", condition.Description, progress))
+			}
+			if met {
+				return nil
+			}
+		case <-ticker.C:
+			sc.Debugf(fmt.Sprintf("still waiting for %s: %s", condition.Description, progress))
+		case err := <-errCh:
+			return errors.Annotate(err, "watching model")
+		case <-ctx.Done():
+			if ctx.Err() != context.DeadlineExceeded {
+				return ctx.Err()
+			}
+			return errors.Timeoutf("waiting for %s, last seen %s", condition.Description, progress)
+		}
+	}
+}
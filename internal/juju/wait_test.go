@@ -0,0 +1,247 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/rpc/params"
+	"github.com/stretchr/testify/suite"
+)
+
+type WaitSuite struct {
+	JujuSuite
+}
+
+// fakeAllWatcher returns its batches of deltas in order, then blocks
+// until it is stopped.
+type fakeAllWatcher struct {
+	batches [][]params.Delta
+	stopped chan struct{}
+}
+
+func newFakeAllWatcher(batches ...[]params.Delta) *fakeAllWatcher {
+	return &fakeAllWatcher{batches: batches, stopped: make(chan struct{})}
+}
+
+func (w *fakeAllWatcher) Next() ([]params.Delta, error) {
+	if len(w.batches) == 0 {
+		<-w.stopped
+		return nil, errors.New("watcher stopped")
+	}
+	batch := w.batches[0]
+	w.batches = w.batches[1:]
+	return batch, nil
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	close(w.stopped)
+	return nil
+}
+
+func applicationDelta(name string, current status.Status) params.Delta {
+	return params.Delta{Entity: &params.ApplicationInfo{
+		Name:   name,
+		Status: params.StatusInfo{Current: current},
+	}}
+}
+
+func unitDelta(name, application string, current status.Status) params.Delta {
+	return params.Delta{Entity: &params.UnitInfo{
+		Name:           name,
+		Application:    application,
+		WorkloadStatus: params.StatusInfo{Current: current},
+	}}
+}
+
+func (s *WaitSuite) wait(watcher *fakeAllWatcher, condition WaitCondition, timeout time.Duration) error {
+	defer func() { _ = watcher.Stop() }()
+	return waitFor(context.Background(), s.mockSharedClient, watcher, condition, timeout)
+}
+
+func (s *WaitSuite) TestWaitForEntityStatus() {
+	defer s.setupMocks(s.T()).Finish()
+
+	watcher := newFakeAllWatcher(
+		[]params.Delta{applicationDelta("postgresql", status.Waiting)},
+		[]params.Delta{applicationDelta("postgresql", status.Active)},
+	)
+	err := s.wait(watcher, EntityStatus(EntityKindApplication, "postgresql", "active"), time.Minute)
+	s.Require().NoError(err)
+}
+
+func (s *WaitSuite) TestWaitForModelAvailable() {
+	defer s.setupMocks(s.T()).Finish()
+
+	modelDelta := func(current status.Status) params.Delta {
+		return params.Delta{Entity: &params.ModelUpdate{
+			Name:   "development",
+			Status: params.StatusInfo{Current: current},
+		}}
+	}
+	watcher := newFakeAllWatcher(
+		[]params.Delta{modelDelta(status.Busy)},
+		[]params.Delta{modelDelta(status.Available)},
+	)
+	err := s.wait(watcher, EntityStatus(EntityKindModel, "development", "available"), time.Minute)
+	s.Require().NoError(err)
+}
+
+func (s *WaitSuite) TestWaitForEntityStatusRemoved() {
+	defer s.setupMocks(s.T()).Finish()
+
+	removed := applicationDelta("postgresql", status.Waiting)
+	removed.Removed = true
+	watcher := newFakeAllWatcher(
+		[]params.Delta{applicationDelta("postgresql", status.Waiting)},
+		[]params.Delta{removed},
+	)
+	err := s.wait(watcher, EntityStatus(EntityKindApplication, "postgresql", "active"), time.Minute)
+	s.Require().Error(err)
+	s.Assert().True(errors.Is(err, errors.NotFound), "unexpected error %v", err)
+}
+
+func (s *WaitSuite) TestWaitForTimeout() {
+	defer s.setupMocks(s.T()).Finish()
+
+	watcher := newFakeAllWatcher(
+		[]params.Delta{applicationDelta("postgresql", status.Blocked)},
+	)
+	err := s.wait(watcher, EntityStatus(EntityKindApplication, "postgresql", "active"), 10*time.Millisecond)
+	s.Require().Error(err)
+	s.Assert().True(errors.Is(err, errors.Timeout), "unexpected error %v", err)
+	s.Assert().ErrorContains(err, `last seen status "blocked"`)
+}
+
+func (s *WaitSuite) TestWaitForContextCancelled() {
+	defer s.setupMocks(s.T()).Finish()
+
+	watcher := newFakeAllWatcher()
+	defer func() { _ = watcher.Stop() }()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := waitFor(ctx, s.mockSharedClient, watcher, ApplicationsExist("postgresql"), time.Minute)
+	s.Require().ErrorIs(err, context.Canceled)
+}
+
+func (s *WaitSuite) TestApplicationsExist() {
+	state := newModelState()
+	state.apply([]params.Delta{applicationDelta("postgresql", status.Active)})
+
+	met, progress, err := ApplicationsExist("postgresql", "mysql").Check(state)
+	s.Require().NoError(err)
+	s.Assert().False(met)
+	s.Assert().Equal(`missing ["mysql"]`, progress)
+
+	state.apply([]params.Delta{applicationDelta("mysql", status.Waiting)})
+	met, _, err = ApplicationsExist("postgresql", "mysql").Check(state)
+	s.Require().NoError(err)
+	s.Assert().True(met)
+}
+
+func (s *WaitSuite) TestUnitsStatus() {
+	state := newModelState()
+	condition := UnitsStatus("postgresql", "active")
+
+	met, progress, err := condition.Check(state)
+	s.Require().NoError(err)
+	s.Assert().False(met)
+	s.Assert().Equal("no units yet", progress)
+
+	state.apply([]params.Delta{
+		unitDelta("postgresql/0", "postgresql", status.Active),
+		unitDelta("postgresql/1", "postgresql", status.Maintenance),
+		unitDelta("mysql/0", "mysql", status.Blocked),
+	})
+	met, progress, err = condition.Check(state)
+	s.Require().NoError(err)
+	s.Assert().False(met)
+	s.Assert().Equal(`postgresql/1 is "maintenance"`, progress)
+
+	state.apply([]params.Delta{unitDelta("postgresql/1", "postgresql", status.Active)})
+	met, _, err = condition.Check(state)
+	s.Require().NoError(err)
+	s.Assert().True(met)
+}
+
+func (s *WaitSuite) TestUnitsAtMost() {
+	state := newModelState()
+	state.apply([]params.Delta{
+		unitDelta("postgresql/0", "postgresql", status.Active),
+		unitDelta("postgresql/1", "postgresql", status.Active),
+		unitDelta("mysql/0", "mysql", status.Active),
+	})
+	condition := UnitsAtMost("postgresql", 1)
+
+	met, progress, err := condition.Check(state)
+	s.Require().NoError(err)
+	s.Assert().False(met)
+	s.Assert().Equal("2 units", progress)
+
+	state.apply([]params.Delta{{Removed: true, Entity: &params.UnitInfo{Name: "postgresql/1", Application: "postgresql"}}})
+	met, _, err = condition.Check(state)
+	s.Require().NoError(err)
+	s.Assert().True(met)
+}
+
+func (s *WaitSuite) TestUnitsAgentStatus() {
+	state := newModelState()
+	condition := AllConditions(UnitsStatus("postgresql", "active"), UnitsAgentStatus("postgresql", "idle"))
+
+	unit := func(agent status.Status) params.Delta {
+		return params.Delta{Entity: &params.UnitInfo{
+			Name:           "postgresql/0",
+			Application:    "postgresql",
+			WorkloadStatus: params.StatusInfo{Current: status.Active},
+			AgentStatus:    params.StatusInfo{Current: agent},
+		}}
+	}
+	state.apply([]params.Delta{unit(status.Executing)})
+	met, progress, err := condition.Check(state)
+	s.Require().NoError(err)
+	s.Assert().False(met)
+	s.Assert().Equal(`postgresql/0 is "executing"`, progress)
+
+	state.apply([]params.Delta{unit(status.Idle)})
+	met, _, err = condition.Check(state)
+	s.Require().NoError(err)
+	s.Assert().True(met)
+}
+
+func (s *WaitSuite) TestRelationExists() {
+	state := newModelState()
+	condition := RelationExists("wordpress:db", "mysql:db")
+
+	state.apply([]params.Delta{{Entity: &params.RelationInfo{
+		Key: "wordpress:cache memcached:cache",
+		Endpoints: []params.Endpoint{
+			{ApplicationName: "wordpress", Relation: params.CharmRelation{Name: "cache"}},
+			{ApplicationName: "memcached", Relation: params.CharmRelation{Name: "cache"}},
+		},
+	}}})
+	met, _, err := condition.Check(state)
+	s.Require().NoError(err)
+	s.Assert().False(met)
+
+	state.apply([]params.Delta{{Entity: &params.RelationInfo{
+		Key: "wordpress:db mysql:db",
+		Endpoints: []params.Endpoint{
+			{ApplicationName: "wordpress", Relation: params.CharmRelation{Name: "db"}},
+			{ApplicationName: "mysql", Relation: params.CharmRelation{Name: "db"}},
+		},
+	}}})
+	met, _, err = condition.Check(state)
+	s.Require().NoError(err)
+	s.Assert().True(met)
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestWaitSuite(t *testing.T) {
+	suite.Run(t, new(WaitSuite))
+}
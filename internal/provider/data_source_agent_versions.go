@@ -0,0 +1,165 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &agentVersionsDataSource{}
+
+func NewAgentVersionsDataSource() datasource.DataSourceWithConfigure {
+	return &agentVersionsDataSource{}
+}
+
+type agentVersionsDataSource struct {
+	client *juju.Client
+
+	// context for the logging subsystem.
+	subCtx context.Context
+}
+
+type agentVersionsDataSourceModel struct {
+	Model                   types.String `tfsdk:"model"`
+	AgentStream             types.String `tfsdk:"agent_stream"`
+	ControllerVersion       types.String `tfsdk:"controller_version"`
+	ModelVersion            types.String `tfsdk:"model_version"`
+	ModelUpgradeTarget      types.String `tfsdk:"model_upgrade_target"`
+	ControllerUpgradeTarget types.String `tfsdk:"controller_upgrade_target"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+// Metadata returns the full data source name as used in terraform plans.
+func (d *agentVersionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_agent_versions"
+}
+
+func (d *agentVersionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A data source with the agent versions of the controller and of a model, along with the " +
+			"versions they can be upgraded to.",
+		Attributes: map[string]schema.Attribute{
+			"model": schema.StringAttribute{
+				Description: "The name of the model.",
+				Required:    true,
+			},
+			"agent_stream": schema.StringAttribute{
+				Description: "The agent stream used to find upgrade targets. Defaults to the model configuration.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("released", "proposed", "testing", "devel"),
+				},
+			},
+			"controller_version": schema.StringAttribute{
+				Description: "The agent version of the controller.",
+				Computed:    true,
+			},
+			"model_version": schema.StringAttribute{
+				Description: "The agent version of the model.",
+				Computed:    true,
+			},
+			"model_upgrade_target": schema.StringAttribute{
+				Description: "The version the model would be upgraded to by `juju upgrade-model`. Empty when " +
+					"the model is up to date.",
+				Computed: true,
+			},
+			"controller_upgrade_target": schema.StringAttribute{
+				Description: "The version the controller would be upgraded to by `juju upgrade-controller`. " +
+					"Empty when the controller is up to date or the user is not a controller admin.",
+				Computed: true,
+			},
+			// ID required by the testing framework
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Configure enables provider-level data or clients to be set in the
+// provider-defined DataSource type. It is separately executed for each
+// ReadDataSource RPC.
+func (d *agentVersionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	d.subCtx = d.client.NewLogSubsystem(ctx, LogDataSourceAgentVersions)
+}
+
+// Read is called when the provider must read data source values in
+// order to update state. Config values should be read from the
+// ReadRequest and new state values set on the ReadResponse.
+func (d *agentVersionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, d.client, "data.juju_agent_versions", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if d.client == nil {
+		addDSClientNotConfiguredError(&resp.Diagnostics, "agent_versions")
+		return
+	}
+
+	var data agentVersionsDataSourceModel
+
+	// Read Terraform configuration data into the model.
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	modelName := data.Model.ValueString()
+	d.trace(fmt.Sprintf("reading agent versions of model %q", modelName))
+
+	response, err := d.client.Models.ReadAgentVersions(ctx, juju.ReadAgentVersionsInput{
+		ModelName:   modelName,
+		AgentStream: data.AgentStream.ValueString(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to read agent versions of model %q", modelName)
+		return
+	}
+
+	data.ControllerVersion = types.StringValue(response.ControllerVersion)
+	data.ModelVersion = types.StringValue(response.ModelVersion)
+	data.ModelUpgradeTarget = types.StringValue(response.ModelUpgradeTarget)
+	data.ControllerUpgradeTarget = types.StringValue(response.ControllerUpgradeTarget)
+	data.ID = types.StringValue(modelName)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *agentVersionsDataSource) trace(msg string, additionalFields ...map[string]interface{}) {
+	if d.subCtx == nil {
+		return
+	}
+
+	//SubsystemTrace(subCtx, "datasource-agent-versions", "hello, world", map[string]interface{}{"foo": 123})
+	// Output:
+	// {"@level":"trace","@message":"hello, world","@module":"juju.datasource-agent-versions","foo":123}
+	tflog.SubsystemTrace(d.subCtx, LogDataSourceAgentVersions, msg, additionalFields...)
+}
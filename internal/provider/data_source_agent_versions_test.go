@@ -0,0 +1,43 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_DataSourceAgentVersions(t *testing.T) {
+	modelName := acctest.RandomWithPrefix("tf-datasource-agent-versions-test-model")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAgentVersions(modelName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.juju_agent_versions.this", "model", modelName),
+					resource.TestMatchResourceAttr("data.juju_agent_versions.this", "controller_version", regexp.MustCompile(`^\d+\.\d+`)),
+					resource.TestMatchResourceAttr("data.juju_agent_versions.this", "model_version", regexp.MustCompile(`^\d+\.\d+`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAgentVersions(modelName string) string {
+	return fmt.Sprintf(`
+resource "juju_model" "model" {
+  name = %q
+}
+
+data "juju_agent_versions" "this" {
+  model = juju_model.model.name
+}`, modelName)
+}
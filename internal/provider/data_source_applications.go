@@ -0,0 +1,188 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSourceWithConfigure = &applicationsDataSource{}
+
+func NewApplicationsDataSource() datasource.DataSourceWithConfigure {
+	return &applicationsDataSource{}
+}
+
+type applicationsDataSource struct {
+	client *juju.Client
+
+	// context for the logging subsystem.
+	subCtx context.Context
+}
+
+type applicationsDataSourceModel struct {
+	Model        types.String                        `tfsdk:"model"`
+	Applications []applicationSummaryDataSourceModel `tfsdk:"applications"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+type applicationSummaryDataSourceModel struct {
+	Name        types.String `tfsdk:"name"`
+	Charm       types.String `tfsdk:"charm"`
+	Channel     types.String `tfsdk:"channel"`
+	Revision    types.Int64  `tfsdk:"revision"`
+	Units       types.Int64  `tfsdk:"units"`
+	Exposed     types.Bool   `tfsdk:"exposed"`
+	Subordinate types.Bool   `tfsdk:"subordinate"`
+	Status      types.String `tfsdk:"status"`
+}
+
+// Metadata returns the full data source name as used in terraform plans.
+func (d *applicationsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_applications"
+}
+
+// Schema returns the schema for the applications data source.
+func (d *applicationsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A data source listing the applications of a model.",
+		Attributes: map[string]schema.Attribute{
+			"model": schema.StringAttribute{
+				Description: "The name of the model.",
+				Required:    true,
+			},
+			"applications": schema.ListNestedAttribute{
+				Description: "The applications of the model, sorted by name. Applications consumed from " +
+					"offers are not listed.",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The name of the application.",
+							Computed:    true,
+						},
+						"charm": schema.StringAttribute{
+							Description: "The name of the charm of the application.",
+							Computed:    true,
+						},
+						"channel": schema.StringAttribute{
+							Description: "The channel of the charm. Empty for local charms.",
+							Computed:    true,
+						},
+						"revision": schema.Int64Attribute{
+							Description: "The revision of the charm.",
+							Computed:    true,
+						},
+						"units": schema.Int64Attribute{
+							Description: "The number of units of the application, its scale in Kubernetes models.",
+							Computed:    true,
+						},
+						"exposed": schema.BoolAttribute{
+							Description: "Whether the application is exposed.",
+							Computed:    true,
+						},
+						"subordinate": schema.BoolAttribute{
+							Description: "Whether the application is subordinate to other applications.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "The status of the application, e.g. `active` or `blocked`.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			// ID required by the testing framework
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Configure enables provider-level data or clients to be set in the
+// provider-defined DataSource type. It is separately executed for each
+// ReadDataSource RPC.
+func (d *applicationsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	d.subCtx = d.client.NewLogSubsystem(ctx, LogDataSourceApplications)
+}
+
+// Read is called when the provider must read data source values in
+// order to update state. Config values should be read from the
+// ReadRequest and new state values set on the ReadResponse.
+func (d *applicationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, d.client, "data.juju_applications", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if d.client == nil {
+		addDSClientNotConfiguredError(&resp.Diagnostics, "applications")
+		return
+	}
+
+	var data applicationsDataSourceModel
+
+	// Read Terraform configuration data into the model.
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	modelName := data.Model.ValueString()
+	response, err := d.client.Applications.ListApplications(ctx, juju.ListApplicationsInput{ModelName: modelName})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to list applications of model %q", modelName)
+		return
+	}
+	d.trace("listed applications", map[string]interface{}{"applications": response.Applications})
+
+	data.Applications = make([]applicationSummaryDataSourceModel, len(response.Applications))
+	for i, app := range response.Applications {
+		data.Applications[i] = applicationSummaryDataSourceModel{
+			Name:        types.StringValue(app.Name),
+			Charm:       types.StringValue(app.Charm),
+			Channel:     types.StringValue(app.Channel),
+			Revision:    types.Int64Value(int64(app.Revision)),
+			Units:       types.Int64Value(int64(app.Units)),
+			Exposed:     types.BoolValue(app.Exposed),
+			Subordinate: types.BoolValue(app.Subordinate),
+			Status:      types.StringValue(app.Status),
+		}
+	}
+	data.ID = types.StringValue(modelName)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *applicationsDataSource) trace(msg string, additionalFields ...map[string]interface{}) {
+	if d.subCtx == nil {
+		return
+	}
+
+	tflog.SubsystemTrace(d.subCtx, LogDataSourceApplications, msg, additionalFields...)
+}
@@ -0,0 +1,58 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_DataSourceApplications(t *testing.T) {
+	if testingCloud != LXDCloudTesting {
+		t.Skip(t.Name() + " only runs with LXD")
+	}
+	modelName := acctest.RandomWithPrefix("tf-datasource-applications-test-model")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceApplications(modelName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.juju_applications.this", "applications.#", "1"),
+					resource.TestCheckResourceAttr("data.juju_applications.this", "applications.0.name", "test-app"),
+					resource.TestCheckResourceAttr("data.juju_applications.this", "applications.0.charm", "ubuntu"),
+					resource.TestCheckResourceAttr("data.juju_applications.this", "applications.0.units", "1"),
+					resource.TestCheckResourceAttr("data.juju_applications.this", "applications.0.exposed", "false"),
+					resource.TestCheckResourceAttr("data.juju_applications.this", "applications.0.subordinate", "false"),
+					resource.TestCheckResourceAttrSet("data.juju_applications.this", "applications.0.revision"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceApplications(modelName string) string {
+	return fmt.Sprintf(`
+resource "juju_model" "model" {
+  name = %q
+}
+
+resource "juju_application" "app" {
+  model = juju_model.model.name
+  name  = "test-app"
+
+  charm {
+    name = "ubuntu"
+  }
+}
+
+data "juju_applications" "this" {
+  model = juju_application.app.model
+}`, modelName)
+}
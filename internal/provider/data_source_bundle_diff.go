@@ -0,0 +1,240 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &bundleDiffDataSource{}
+
+func NewBundleDiffDataSource() datasource.DataSourceWithConfigure {
+	return &bundleDiffDataSource{}
+}
+
+type bundleDiffDataSource struct {
+	client *juju.Client
+
+	// context for the logging subsystem.
+	subCtx context.Context
+}
+
+type bundleDiffDataSourceModel struct {
+	Model               types.String                     `tfsdk:"model"`
+	Bundle              types.String                     `tfsdk:"bundle"`
+	IncludeAnnotations  types.Bool                       `tfsdk:"include_annotations"`
+	Diff                types.String                     `tfsdk:"diff"`
+	InSync              types.Bool                       `tfsdk:"in_sync"`
+	Applications        map[string]bundleEntityDiffModel `tfsdk:"applications"`
+	Machines            map[string]bundleEntityDiffModel `tfsdk:"machines"`
+	RelationsBundleOnly []string                         `tfsdk:"relations_bundle_only"`
+	RelationsModelOnly  []string                         `tfsdk:"relations_model_only"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+type bundleEntityDiffModel struct {
+	Missing types.String                    `tfsdk:"missing"`
+	Changes map[string]bundleValueDiffModel `tfsdk:"changes"`
+}
+
+type bundleValueDiffModel struct {
+	Bundle types.String `tfsdk:"bundle"`
+	Model  types.String `tfsdk:"model"`
+}
+
+// Metadata returns the full data source name as used in terraform plans.
+func (d *bundleDiffDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bundle_diff"
+}
+
+func (d *bundleDiffDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	entityDiff := schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"missing": schema.StringAttribute{
+				Description: "Set to `bundle` or `model` when the entity only exists on the other side.",
+				Computed:    true,
+			},
+			"changes": schema.MapNestedAttribute{
+				Description: "The differing values, keyed by attribute, e.g. `charm`, `num_units` or `options.<key>`.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"bundle": schema.StringAttribute{
+							Description: "The value in the bundle.",
+							Computed:    true,
+						},
+						"model": schema.StringAttribute{
+							Description: "The value in the model.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+	resp.Schema = schema.Schema{
+		Description: "A data source comparing a bundle against the live model, equivalent to `juju diff-bundle`. " +
+			"Useful to detect drift and to migrate deployments from bundles to Terraform.",
+		Attributes: map[string]schema.Attribute{
+			"model": schema.StringAttribute{
+				Description: "The name of the model.",
+				Required:    true,
+			},
+			"bundle": schema.StringAttribute{
+				Description: "The bundle definition in YAML, e.g. `file(\"bundle.yaml\")`.",
+				Required:    true,
+			},
+			"include_annotations": schema.BoolAttribute{
+				Description: "Include annotations in the comparison. Defaults to false.",
+				Optional:    true,
+			},
+			"diff": schema.StringAttribute{
+				Description: "The differences as a YAML document, as printed by `juju diff-bundle`.",
+				Computed:    true,
+			},
+			"in_sync": schema.BoolAttribute{
+				Description: "True when no differences were found.",
+				Computed:    true,
+			},
+			"applications": schema.MapNestedAttribute{
+				Description:  "The differences per application name.",
+				Computed:     true,
+				NestedObject: entityDiff,
+			},
+			"machines": schema.MapNestedAttribute{
+				Description:  "The differences per machine id.",
+				Computed:     true,
+				NestedObject: entityDiff,
+			},
+			"relations_bundle_only": schema.ListAttribute{
+				Description: "Relations only found in the bundle, as space separated endpoints.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"relations_model_only": schema.ListAttribute{
+				Description: "Relations only found in the model, as space separated endpoints.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			// ID required by the testing framework
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Configure enables provider-level data or clients to be set in the
+// provider-defined DataSource type. It is separately executed for each
+// ReadDataSource RPC.
+func (d *bundleDiffDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	d.subCtx = d.client.NewLogSubsystem(ctx, LogDataSourceBundleDiff)
+}
+
+// Read is called when the provider must read data source values in
+// order to update state. Config values should be read from the
+// ReadRequest and new state values set on the ReadResponse.
+func (d *bundleDiffDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, d.client, "data.juju_bundle_diff", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if d.client == nil {
+		addDSClientNotConfiguredError(&resp.Diagnostics, "bundle_diff")
+		return
+	}
+
+	var data bundleDiffDataSourceModel
+
+	// Read Terraform configuration data into the model.
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	modelName := data.Model.ValueString()
+	d.trace(fmt.Sprintf("comparing bundle with model %q", modelName))
+
+	response, err := d.client.Bundles.DiffBundle(ctx, juju.DiffBundleInput{
+		ModelName:          modelName,
+		Bundle:             data.Bundle.ValueString(),
+		IncludeAnnotations: data.IncludeAnnotations.ValueBool(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to compare bundle with model %q", modelName)
+		return
+	}
+
+	data.Diff = types.StringValue(response.Diff)
+	data.InSync = types.BoolValue(response.BundleAndModelInSync)
+	data.Applications = newBundleEntityDiffModels(response.Applications)
+	data.Machines = newBundleEntityDiffModels(response.Machines)
+	data.RelationsBundleOnly = emptyIfNil(response.RelationsBundleOnly)
+	data.RelationsModelOnly = emptyIfNil(response.RelationsModelOnly)
+	data.ID = types.StringValue(modelName)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *bundleDiffDataSource) trace(msg string, additionalFields ...map[string]interface{}) {
+	if d.subCtx == nil {
+		return
+	}
+
+	//SubsystemTrace(subCtx, "datasource-bundle-diff", "hello, world", map[string]interface{}{"foo": 123})
+	// Output:
+	// {"@level":"trace","@message":"hello, world","@module":"juju.datasource-bundle-diff","foo":123}
+	tflog.SubsystemTrace(d.subCtx, LogDataSourceBundleDiff, msg, additionalFields...)
+}
+
+func newBundleEntityDiffModels(diffs map[string]juju.BundleEntityDiff) map[string]bundleEntityDiffModel {
+	result := make(map[string]bundleEntityDiffModel, len(diffs))
+	for name, diff := range diffs {
+		changes := make(map[string]bundleValueDiffModel, len(diff.Changes))
+		for key, change := range diff.Changes {
+			changes[key] = bundleValueDiffModel{
+				Bundle: types.StringValue(change.Bundle),
+				Model:  types.StringValue(change.Model),
+			}
+		}
+		result[name] = bundleEntityDiffModel{
+			Missing: types.StringValue(diff.Missing),
+			Changes: changes,
+		}
+	}
+	return result
+}
+
+// emptyIfNil avoids a null list in state when there are no entries.
+func emptyIfNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_DataSourceBundleDiff(t *testing.T) {
+	if testingCloud != LXDCloudTesting {
+		t.Skip(t.Name() + " only runs with LXD")
+	}
+	modelName := acctest.RandomWithPrefix("tf-datasource-bundle-diff-test-model")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceBundleDiff(modelName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.juju_bundle_diff.this", "in_sync", "false"),
+					resource.TestCheckResourceAttr("data.juju_bundle_diff.this", "applications.ubuntu.changes.num_units.bundle", "2"),
+					resource.TestCheckResourceAttr("data.juju_bundle_diff.this", "applications.ubuntu.changes.num_units.model", "1"),
+					resource.TestCheckResourceAttr("data.juju_bundle_diff.this", "applications.ntp.missing", "model"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceBundleDiff(modelName string) string {
+	return fmt.Sprintf(`
+resource "juju_model" "model" {
+  name = %q
+}
+
+resource "juju_application" "ubuntu" {
+  model = juju_model.model.name
+  name  = "ubuntu"
+
+  charm {
+    name = "ubuntu"
+    base = "ubuntu@22.04"
+  }
+
+  units = 1
+}
+
+data "juju_bundle_diff" "this" {
+  model  = juju_model.model.name
+  bundle = <<-EOT
+    applications:
+      ubuntu:
+        charm: ubuntu
+        base: ubuntu@22.04/stable
+        num_units: 2
+      ntp:
+        charm: ntp
+  EOT
+
+  depends_on = [juju_application.ubuntu]
+}`, modelName)
+}
@@ -0,0 +1,238 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSourceWithConfigure = &charmResourcesDataSource{}
+
+func NewCharmResourcesDataSource() datasource.DataSourceWithConfigure {
+	return &charmResourcesDataSource{}
+}
+
+type charmResourcesDataSource struct {
+	client *juju.Client
+
+	// context for the logging subsystem.
+	subCtx context.Context
+}
+
+type charmResourcesDataSourceModel struct {
+	Model        types.String                   `tfsdk:"model"`
+	Name         types.String                   `tfsdk:"name"`
+	Channel      types.String                   `tfsdk:"channel"`
+	Base         types.String                   `tfsdk:"base"`
+	Architecture types.String                   `tfsdk:"architecture"`
+	Revision     types.Int64                    `tfsdk:"revision"`
+	Resources    []charmResourceDataSourceModel `tfsdk:"resources"`
+	Revisions    map[string]types.String        `tfsdk:"revisions"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+type charmResourceDataSourceModel struct {
+	Name        types.String `tfsdk:"name"`
+	Type        types.String `tfsdk:"type"`
+	Path        types.String `tfsdk:"path"`
+	Description types.String `tfsdk:"description"`
+	Revision    types.Int64  `tfsdk:"revision"`
+	Fingerprint types.String `tfsdk:"fingerprint"`
+	Size        types.Int64  `tfsdk:"size"`
+}
+
+// Metadata returns the full data source name as used in terraform plans.
+func (d *charmResourcesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_charm_resources"
+}
+
+// Schema returns the schema for the charm resources data source.
+func (d *charmResourcesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A data source listing the resources declared by a Charmhub charm, with the revisions " +
+			"published with the revision of the charm currently in a channel. Use it to pin the resources " +
+			"of an application.",
+		Attributes: map[string]schema.Attribute{
+			"model": schema.StringAttribute{
+				Description: "The name of the model used to query Charmhub. The model constraints provide " +
+					"the default architecture.",
+				Required: true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the charm.",
+				Required:    true,
+			},
+			"channel": schema.StringAttribute{
+				Description: "The channel to resolve. Specified as \\<track>/\\<risk>/\\<branch>. Defaults to the " +
+					"default channel of the charm, the resolved channel is returned.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					StringIsChannelValidator{},
+				},
+			},
+			"base": schema.StringAttribute{
+				Description: "The operating system to resolve for. E.g. ubuntu@22.04. Defaults to the base " +
+					"chosen by Charmhub.",
+				Optional: true,
+				Validators: []validator.String{
+					stringIsBaseValidator{},
+				},
+			},
+			"architecture": schema.StringAttribute{
+				Description: "The architecture to resolve for. Defaults to the model constraints.",
+				Optional:    true,
+			},
+			"revision": schema.Int64Attribute{
+				Description: "The revision of the charm currently published in the channel.",
+				Computed:    true,
+			},
+			"resources": schema.ListNestedAttribute{
+				Description: "The resources declared by the charm, sorted by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The name of the resource.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The type of the resource, `file` or `oci-image`.",
+							Computed:    true,
+						},
+						"path": schema.StringAttribute{
+							Description: "The file name of the resource, for `file` resources.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "The description of the resource.",
+							Computed:    true,
+						},
+						"revision": schema.Int64Attribute{
+							Description: "The revision of the resource published with the revision of the charm.",
+							Computed:    true,
+						},
+						"fingerprint": schema.StringAttribute{
+							Description: "The SHA-384 checksum of the resource.",
+							Computed:    true,
+						},
+						"size": schema.Int64Attribute{
+							Description: "The size of the resource, in bytes.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"revisions": schema.MapAttribute{
+				Description: "The revisions of the resources by name, in the form of the resources of the " +
+					"juju_application resource.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			// ID required by the testing framework
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Configure enables provider-level data or clients to be set in the
+// provider-defined DataSource type. It is separately executed for each
+// ReadDataSource RPC.
+func (d *charmResourcesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	d.subCtx = d.client.NewLogSubsystem(ctx, LogDataSourceCharmResources)
+}
+
+// Read is called when the provider must read data source values in
+// order to update state. Config values should be read from the
+// ReadRequest and new state values set on the ReadResponse.
+func (d *charmResourcesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, d.client, "data.juju_charm_resources", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if d.client == nil {
+		addDSClientNotConfiguredError(&resp.Diagnostics, "charm_resources")
+		return
+	}
+
+	var data charmResourcesDataSourceModel
+
+	// Read Terraform configuration data into the model.
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	charmName := data.Name.ValueString()
+	d.trace(fmt.Sprintf("listing resources of charm %q in channel %q", charmName, data.Channel.ValueString()))
+
+	response, err := d.client.Charms.ListCharmResources(ctx, juju.ResolveCharmInput{
+		ModelName:    data.Model.ValueString(),
+		Name:         charmName,
+		Channel:      data.Channel.ValueString(),
+		Base:         data.Base.ValueString(),
+		Architecture: data.Architecture.ValueString(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to list resources of charm %q", charmName)
+		return
+	}
+
+	data.Channel = types.StringValue(response.Channel)
+	data.Revision = types.Int64Value(int64(response.Revision))
+	data.Resources = make([]charmResourceDataSourceModel, len(response.Resources))
+	data.Revisions = make(map[string]types.String, len(response.Resources))
+	for i, r := range response.Resources {
+		data.Resources[i] = charmResourceDataSourceModel{
+			Name:        types.StringValue(r.Name),
+			Type:        types.StringValue(r.Type),
+			Path:        types.StringValue(r.Path),
+			Description: types.StringValue(r.Description),
+			Revision:    types.Int64Value(int64(r.Revision)),
+			Fingerprint: types.StringValue(r.Fingerprint),
+			Size:        types.Int64Value(r.Size),
+		}
+		data.Revisions[r.Name] = types.StringValue(strconv.Itoa(r.Revision))
+	}
+	data.ID = types.StringValue(fmt.Sprintf("%s:%s:%d", charmName, response.Channel, response.Revision))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *charmResourcesDataSource) trace(msg string, additionalFields ...map[string]interface{}) {
+	if d.subCtx == nil {
+		return
+	}
+
+	tflog.SubsystemTrace(d.subCtx, LogDataSourceCharmResources, msg, additionalFields...)
+}
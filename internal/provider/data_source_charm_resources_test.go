@@ -0,0 +1,65 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_DataSourceCharmResources(t *testing.T) {
+	if testingCloud != MicroK8sTesting {
+		t.Skip(t.Name() + " only runs with Microk8s")
+	}
+	modelName := acctest.RandomWithPrefix("tf-datasource-charm-resources-test-model")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceCharmResources(modelName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.juju_charm_resources.this", "channel", "latest/stable"),
+					resource.TestCheckResourceAttrSet("data.juju_charm_resources.this", "revision"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.juju_charm_resources.this", "resources.*", map[string]string{
+						"name": "coredns-image",
+						"type": "oci-image",
+					}),
+					resource.TestCheckResourceAttrPair(
+						"data.juju_charm_resources.this", "revisions.coredns-image",
+						"juju_application.this", "resources.coredns-image"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceCharmResources(modelName string) string {
+	return fmt.Sprintf(`
+resource "juju_model" "model" {
+  name = %q
+}
+
+data "juju_charm_resources" "this" {
+  model   = juju_model.model.name
+  name    = "coredns"
+  channel = "latest/stable"
+}
+
+resource "juju_application" "this" {
+  model = juju_model.model.name
+
+  charm {
+    name     = data.juju_charm_resources.this.name
+    channel  = data.juju_charm_resources.this.channel
+    revision = data.juju_charm_resources.this.revision
+  }
+  trust     = true
+  resources = data.juju_charm_resources.this.revisions
+}`, modelName)
+}
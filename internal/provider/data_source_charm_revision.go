@@ -0,0 +1,187 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &charmRevisionDataSource{}
+
+func NewCharmRevisionDataSource() datasource.DataSourceWithConfigure {
+	return &charmRevisionDataSource{}
+}
+
+type charmRevisionDataSource struct {
+	client *juju.Client
+
+	// context for the logging subsystem.
+	subCtx context.Context
+}
+
+type charmRevisionDataSourceModel struct {
+	Model          types.String `tfsdk:"model"`
+	Name           types.String `tfsdk:"name"`
+	Channel        types.String `tfsdk:"channel"`
+	Base           types.String `tfsdk:"base"`
+	Architecture   types.String `tfsdk:"architecture"`
+	Revision       types.Int64  `tfsdk:"revision"`
+	SupportedBases types.List   `tfsdk:"supported_bases"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+// Metadata returns the full data source name as used in terraform plans.
+func (d *charmRevisionDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_charm_revision"
+}
+
+func (d *charmRevisionDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A data source resolving a Charmhub charm, channel and base to the revision currently " +
+			"published. Use it to pin the revision of an application while tracking a channel deliberately.",
+		Attributes: map[string]schema.Attribute{
+			"model": schema.StringAttribute{
+				Description: "The name of the model used to query Charmhub. The model constraints provide " +
+					"the default architecture.",
+				Required: true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the charm.",
+				Required:    true,
+			},
+			"channel": schema.StringAttribute{
+				Description: "The channel to resolve. Specified as \\<track>/\\<risk>/\\<branch>. Defaults to the " +
+					"default channel of the charm, the resolved channel is returned.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					StringIsChannelValidator{},
+				},
+			},
+			"base": schema.StringAttribute{
+				Description: "The operating system to resolve for. E.g. ubuntu@22.04. Defaults to the base " +
+					"chosen by Charmhub, the resolved base is returned.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringIsBaseValidator{},
+				},
+			},
+			"architecture": schema.StringAttribute{
+				Description: "The architecture to resolve for. Defaults to the model constraints.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"revision": schema.Int64Attribute{
+				Description: "The revision currently published in the channel.",
+				Computed:    true,
+			},
+			"supported_bases": schema.ListAttribute{
+				Description: "The bases supported by the revision.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			// ID required by the testing framework
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Configure enables provider-level data or clients to be set in the
+// provider-defined DataSource type. It is separately executed for each
+// ReadDataSource RPC.
+func (d *charmRevisionDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	d.subCtx = d.client.NewLogSubsystem(ctx, LogDataSourceCharmRevision)
+}
+
+// Read is called when the provider must read data source values in
+// order to update state. Config values should be read from the
+// ReadRequest and new state values set on the ReadResponse.
+func (d *charmRevisionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, d.client, "data.juju_charm_revision", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if d.client == nil {
+		addDSClientNotConfiguredError(&resp.Diagnostics, "charm_revision")
+		return
+	}
+
+	var data charmRevisionDataSourceModel
+
+	// Read Terraform configuration data into the model.
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	charmName := data.Name.ValueString()
+	d.trace(fmt.Sprintf("resolving charm %q in channel %q", charmName, data.Channel.ValueString()))
+
+	response, err := d.client.Charms.ResolveCharm(ctx, juju.ResolveCharmInput{
+		ModelName:    data.Model.ValueString(),
+		Name:         charmName,
+		Channel:      data.Channel.ValueString(),
+		Base:         data.Base.ValueString(),
+		Architecture: data.Architecture.ValueString(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to resolve charm %q", charmName)
+		return
+	}
+
+	supportedBases, diags := types.ListValueFrom(ctx, types.StringType, response.SupportedBases)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Channel = types.StringValue(response.Channel)
+	data.Base = types.StringValue(response.Base)
+	data.Architecture = types.StringValue(response.Architecture)
+	data.Revision = types.Int64Value(int64(response.Revision))
+	data.SupportedBases = supportedBases
+	data.ID = types.StringValue(fmt.Sprintf("%s:%s:%d", charmName, response.Channel, response.Revision))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *charmRevisionDataSource) trace(msg string, additionalFields ...map[string]interface{}) {
+	if d.subCtx == nil {
+		return
+	}
+
+	//SubsystemTrace(subCtx, "datasource-charm-revision", "hello, world", map[string]interface{}{"foo": 123})
+	// Output:
+	// {"@level":"trace","@message":"hello, world","@module":"juju.datasource-charm-revision","foo":123}
+	tflog.SubsystemTrace(d.subCtx, LogDataSourceCharmRevision, msg, additionalFields...)
+}
@@ -0,0 +1,59 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_DataSourceCharmRevision(t *testing.T) {
+	modelName := acctest.RandomWithPrefix("tf-datasource-charm-revision-test-model")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceCharmRevision(modelName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.juju_charm_revision.this", "channel", "latest/stable"),
+					resource.TestCheckResourceAttr("data.juju_charm_revision.this", "base", "ubuntu@22.04"),
+					resource.TestCheckResourceAttrSet("data.juju_charm_revision.this", "revision"),
+					resource.TestCheckResourceAttrPair(
+						"data.juju_charm_revision.this", "revision",
+						"juju_application.this", "charm.0.revision"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceCharmRevision(modelName string) string {
+	return fmt.Sprintf(`
+resource "juju_model" "model" {
+  name = %q
+}
+
+data "juju_charm_revision" "this" {
+  model   = juju_model.model.name
+  name    = "ubuntu"
+  channel = "latest/stable"
+  base    = "ubuntu@22.04"
+}
+
+resource "juju_application" "this" {
+  model = juju_model.model.name
+
+  charm {
+    name     = data.juju_charm_revision.this.name
+    channel  = data.juju_charm_revision.this.channel
+    revision = data.juju_charm_revision.this.revision
+    base     = data.juju_charm_revision.this.base
+  }
+}`, modelName)
+}
@@ -0,0 +1,177 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSourceWithConfigure = &credentialsDataSource{}
+
+func NewCredentialsDataSource() datasource.DataSourceWithConfigure {
+	return &credentialsDataSource{}
+}
+
+type credentialsDataSource struct {
+	client *juju.Client
+
+	// context for the logging subsystem.
+	subCtx context.Context
+}
+
+type credentialsDataSourceModel struct {
+	Cloud       types.String                       `tfsdk:"cloud"`
+	Credentials []credentialSummaryDataSourceModel `tfsdk:"credentials"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+type credentialSummaryDataSourceModel struct {
+	Cloud    types.String `tfsdk:"cloud"`
+	Name     types.String `tfsdk:"name"`
+	AuthType types.String `tfsdk:"auth_type"`
+	Valid    types.Bool   `tfsdk:"valid"`
+	Models   types.List   `tfsdk:"models"`
+}
+
+// Metadata returns the full data source name as used in terraform plans.
+func (d *credentialsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_credentials"
+}
+
+// Schema returns the schema for the credentials data source.
+func (d *credentialsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A data source listing the credentials of the user stored on the controller, " +
+			"without their attributes.",
+		Attributes: map[string]schema.Attribute{
+			"cloud": schema.StringAttribute{
+				Description: "Only list the credentials of this cloud.",
+				Optional:    true,
+			},
+			"credentials": schema.ListNestedAttribute{
+				Description: "The credentials, sorted by cloud and name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"cloud": schema.StringAttribute{
+							Description: "The cloud of the credential.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the credential.",
+							Computed:    true,
+						},
+						"auth_type": schema.StringAttribute{
+							Description: "The authentication type of the credential.",
+							Computed:    true,
+						},
+						"valid": schema.BoolAttribute{
+							Description: "Whether the credential is valid.",
+							Computed:    true,
+						},
+						"models": schema.ListAttribute{
+							Description: "The names of the models using the credential, sorted.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			// ID required by the testing framework
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Configure enables provider-level data or clients to be set in the
+// provider-defined DataSource type. It is separately executed for each
+// ReadDataSource RPC.
+func (d *credentialsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	d.subCtx = d.client.NewLogSubsystem(ctx, LogDataSourceCredentials)
+}
+
+// Read is called when the provider must read data source values in
+// order to update state. Config values should be read from the
+// ReadRequest and new state values set on the ReadResponse.
+func (d *credentialsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, d.client, "data.juju_credentials", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if d.client == nil {
+		addDSClientNotConfiguredError(&resp.Diagnostics, "credentials")
+		return
+	}
+
+	var data credentialsDataSourceModel
+
+	// Read Terraform configuration data into the model.
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	output, err := d.client.Credentials.ListCredentials(ctx, juju.ListCredentialsInput{
+		CloudName: data.Cloud.ValueString(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to list credentials")
+		return
+	}
+	d.trace("listed credentials", map[string]interface{}{"credentials": output.Credentials})
+
+	data.Credentials = make([]credentialSummaryDataSourceModel, len(output.Credentials))
+	for i, c := range output.Credentials {
+		models, diags := types.ListValueFrom(ctx, types.StringType, c.Models)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.Credentials[i] = credentialSummaryDataSourceModel{
+			Cloud:    types.StringValue(c.Cloud),
+			Name:     types.StringValue(c.Name),
+			AuthType: types.StringValue(c.AuthType),
+			Valid:    types.BoolValue(c.Valid),
+			Models:   models,
+		}
+	}
+	data.ID = types.StringValue("credentials")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *credentialsDataSource) trace(msg string, additionalFields ...map[string]interface{}) {
+	if d.subCtx == nil {
+		return
+	}
+
+	tflog.SubsystemTrace(d.subCtx, LogDataSourceCredentials, msg, additionalFields...)
+}
@@ -0,0 +1,178 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &fullStatusDataSource{}
+
+func NewFullStatusDataSource() datasource.DataSourceWithConfigure {
+	return &fullStatusDataSource{}
+}
+
+type fullStatusDataSource struct {
+	client *juju.Client
+
+	// context for the logging subsystem.
+	subCtx context.Context
+}
+
+type fullStatusDataSourceModel struct {
+	Model        types.String `tfsdk:"model"`
+	Patterns     types.List   `tfsdk:"patterns"`
+	JSON         types.String `tfsdk:"json"`
+	Applications types.Map    `tfsdk:"applications"`
+	Units        types.Map    `tfsdk:"units"`
+	Machines     types.Map    `tfsdk:"machines"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+// Metadata returns the full data source name as used in terraform plans.
+func (d *fullStatusDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_full_status"
+}
+
+func (d *fullStatusDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A data source with the complete status of a model, as returned by the FullStatus API. " +
+			"Use `jsondecode` on the `json` attribute to build arbitrary conditions and reports.",
+		Attributes: map[string]schema.Attribute{
+			"model": schema.StringAttribute{
+				Description: "The name of the model.",
+				Required:    true,
+			},
+			"patterns": schema.ListAttribute{
+				Description: "Filter the status by application, unit or machine, as `juju status <pattern>` does.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"json": schema.StringAttribute{
+				Description: "The status document encoded as JSON.",
+				Computed:    true,
+			},
+			"applications": schema.MapAttribute{
+				Description: "The workload status of each application.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"units": schema.MapAttribute{
+				Description: "The workload status of each unit, including subordinate units.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"machines": schema.MapAttribute{
+				Description: "The agent status of each machine, including containers.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			// ID required by the testing framework
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Configure enables provider-level data or clients to be set in the
+// provider-defined DataSource type. It is separately executed for each
+// ReadDataSource RPC.
+func (d *fullStatusDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	d.subCtx = d.client.NewLogSubsystem(ctx, LogDataSourceFullStatus)
+}
+
+// Read is called when the provider must read data source values in
+// order to update state. Config values should be read from the
+// ReadRequest and new state values set on the ReadResponse.
+func (d *fullStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, d.client, "data.juju_full_status", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if d.client == nil {
+		addDSClientNotConfiguredError(&resp.Diagnostics, "full_status")
+		return
+	}
+
+	var data fullStatusDataSourceModel
+
+	// Read Terraform configuration data into the model.
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var patterns []string
+	resp.Diagnostics.Append(data.Patterns.ElementsAs(ctx, &patterns, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	modelName := data.Model.ValueString()
+	d.trace(fmt.Sprintf("reading status of model %q", modelName), map[string]interface{}{"patterns": patterns})
+
+	response, err := d.client.Status.ReadFullStatus(ctx, juju.ReadFullStatusInput{
+		ModelName: modelName,
+		Patterns:  patterns,
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to read status of model %q", modelName)
+		return
+	}
+
+	applications, diags := types.MapValueFrom(ctx, types.StringType, response.Applications)
+	resp.Diagnostics.Append(diags...)
+	units, diags := types.MapValueFrom(ctx, types.StringType, response.Units)
+	resp.Diagnostics.Append(diags...)
+	machines, diags := types.MapValueFrom(ctx, types.StringType, response.Machines)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.JSON = types.StringValue(response.JSON)
+	data.Applications = applications
+	data.Units = units
+	data.Machines = machines
+	data.ID = types.StringValue(modelName)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *fullStatusDataSource) trace(msg string, additionalFields ...map[string]interface{}) {
+	if d.subCtx == nil {
+		return
+	}
+
+	//SubsystemTrace(subCtx, "datasource-full-status", "hello, world", map[string]interface{}{"foo": 123})
+	// Output:
+	// {"@level":"trace","@message":"hello, world","@module":"juju.datasource-full-status","foo":123}
+	tflog.SubsystemTrace(d.subCtx, LogDataSourceFullStatus, msg, additionalFields...)
+}
@@ -0,0 +1,56 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_DataSourceFullStatus(t *testing.T) {
+	if testingCloud != LXDCloudTesting {
+		t.Skip(t.Name() + " only runs with LXD")
+	}
+	modelName := acctest.RandomWithPrefix("tf-datasource-full-status-test-model")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceFullStatus(modelName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.juju_full_status.this", "model", modelName),
+					resource.TestCheckResourceAttrSet("data.juju_full_status.this", "applications.test-app"),
+					resource.TestCheckResourceAttrSet("data.juju_full_status.this", "units.test-app/0"),
+					resource.TestCheckResourceAttrSet("data.juju_full_status.this", "json"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceFullStatus(modelName string) string {
+	return fmt.Sprintf(`
+resource "juju_model" "model" {
+  name = %q
+}
+
+resource "juju_application" "app" {
+  model = juju_model.model.name
+  name  = "test-app"
+
+  charm {
+    name = "ubuntu"
+  }
+}
+
+data "juju_full_status" "this" {
+  model    = juju_model.model.name
+  patterns = [juju_application.app.name]
+}`, modelName)
+}
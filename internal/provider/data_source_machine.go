@@ -80,13 +80,16 @@ func (d *machineDataSource) Configure(ctx context.Context, req datasource.Config
 	}
 
 	d.client = client
-	d.subCtx = tflog.NewSubsystem(ctx, LogDataSourceMachine)
+	d.subCtx = d.client.NewLogSubsystem(ctx, LogDataSourceMachine)
 }
 
 // Read is called when the provider must read data source values in
 // order to update state. Config values should be read from the
 // ReadRequest and new state values set on the ReadResponse.
 func (d *machineDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, d.client, "data.juju_machine", "Read", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if d.client == nil {
 		addDSClientNotConfiguredError(&resp.Diagnostics, "machine")
@@ -109,13 +112,13 @@ func (d *machineDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	d.trace(fmt.Sprintf("reading juju machine %q data source", machine_id))
 
 	// Verify the machine exists in the model provided
-	if _, err := d.client.Machines.ReadMachine(
+	if _, err := d.client.Machines.ReadMachine(ctx,
 		juju.ReadMachineInput{
 			ModelName: data.Model.ValueString(),
 			ID:        machine_id,
 		},
 	); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read machine %q, got error: %s", machine_id, err))
+		addClientError(&resp.Diagnostics, err, "Unable to read machine %q", machine_id)
 		return
 	}
 
@@ -81,13 +81,16 @@ func (d *modelDataSource) Configure(ctx context.Context, req datasource.Configur
 	}
 
 	d.client = client
-	d.subCtx = tflog.NewSubsystem(ctx, LogDataSourceModel)
+	d.subCtx = d.client.NewLogSubsystem(ctx, LogDataSourceModel)
 }
 
 // Read is called when the provider must read data source values in
 // order to update state. Config values should be read from the
 // ReadRequest and new state values set on the ReadResponse.
 func (d *modelDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, d.client, "data.juju_model", "Read", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if d.client == nil {
 		addDSClientNotConfiguredError(&resp.Diagnostics, "model")
@@ -103,9 +106,9 @@ func (d *modelDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 
 	// Get current juju model data source values.
-	model, err := d.client.Models.GetModelByName(data.Name.ValueString())
+	model, err := d.client.Models.GetModelByName(ctx, data.Name.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read model, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to read model")
 		return
 	}
 	d.trace(fmt.Sprintf("read juju model %q data source", data.Name))
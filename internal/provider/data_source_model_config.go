@@ -0,0 +1,154 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSourceWithConfigure = &modelConfigDataSource{}
+
+func NewModelConfigDataSource() datasource.DataSourceWithConfigure {
+	return &modelConfigDataSource{}
+}
+
+type modelConfigDataSource struct {
+	client *juju.Client
+
+	// context for the logging subsystem.
+	subCtx context.Context
+}
+
+type modelConfigDataSourceModel struct {
+	Model  types.String                           `tfsdk:"model"`
+	Config map[string]modelConfigValueSourceModel `tfsdk:"config"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+type modelConfigValueSourceModel struct {
+	Value  types.String `tfsdk:"value"`
+	Source types.String `tfsdk:"source"`
+}
+
+// Metadata returns the full data source name as used in terraform plans.
+func (d *modelConfigDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_model_config"
+}
+
+// Schema returns the schema for the model config data source.
+func (d *modelConfigDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A data source representing the effective configuration of a Juju Model, " +
+			"including the keys not set on the model.",
+		Attributes: map[string]schema.Attribute{
+			"model": schema.StringAttribute{
+				Description: "The name of the model.",
+				Required:    true,
+			},
+			"config": schema.MapNestedAttribute{
+				Description: "The effective value of every key of the configuration of the model.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.StringAttribute{
+							Description: "The value, as set in the config of the juju_model resource. " +
+								"Values which are not strings, numbers or booleans are JSON encoded.",
+							Computed: true,
+						},
+						"source": schema.StringAttribute{
+							Description: "Where the value comes from: `default`, `controller`, `region` or `model`.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			// ID required by the testing framework
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Configure enables provider-level data or clients to be set in the
+// provider-defined DataSource type. It is separately executed for each
+// ReadDataSource RPC.
+func (d *modelConfigDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	d.subCtx = d.client.NewLogSubsystem(ctx, LogDataSourceModelConfig)
+}
+
+// Read is called when the provider must read data source values in
+// order to update state. Config values should be read from the
+// ReadRequest and new state values set on the ReadResponse.
+func (d *modelConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, d.client, "data.juju_model_config", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if d.client == nil {
+		addDSClientNotConfiguredError(&resp.Diagnostics, "model config")
+		return
+	}
+
+	var data modelConfigDataSourceModel
+
+	// Read Terraform configuration data into the model.
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	output, err := d.client.Models.ReadModelConfig(ctx, juju.ReadModelConfigInput{
+		ModelName: data.Model.ValueString(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to read config of model %q", data.Model.ValueString())
+		return
+	}
+	d.trace(fmt.Sprintf("read config of model %q", data.Model.ValueString()), map[string]interface{}{"keys": len(output.Config)})
+
+	data.Config = make(map[string]modelConfigValueSourceModel, len(output.Config))
+	for k, v := range output.Config {
+		data.Config[k] = modelConfigValueSourceModel{
+			Value:  types.StringValue(v.Value),
+			Source: types.StringValue(v.Source),
+		}
+	}
+	data.ID = types.StringValue(data.Model.ValueString())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *modelConfigDataSource) trace(msg string, additionalFields ...map[string]interface{}) {
+	if d.subCtx == nil {
+		return
+	}
+
+	tflog.SubsystemTrace(d.subCtx, LogDataSourceModelConfig, msg, additionalFields...)
+}
@@ -0,0 +1,47 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_DataSourceModelConfig(t *testing.T) {
+	modelName := acctest.RandomWithPrefix("tf-datasource-model-config-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceModelConfig(modelName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.juju_model_config.test-model", "config.logging-config.value", "<root>=INFO"),
+					resource.TestCheckResourceAttr("data.juju_model_config.test-model", "config.logging-config.source", "model"),
+					resource.TestCheckResourceAttr("data.juju_model_config.test-model", "config.name.value", modelName),
+					resource.TestCheckResourceAttrSet("data.juju_model_config.test-model", "config.default-base.source"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceModelConfig(modelName string) string {
+	return fmt.Sprintf(`
+resource "juju_model" "test-model" {
+  name = %q
+
+  config = {
+    logging-config = "<root>=INFO"
+  }
+}
+
+data "juju_model_config" "test-model" {
+  model = juju_model.test-model.name
+}`, modelName)
+}
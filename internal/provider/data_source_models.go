@@ -0,0 +1,188 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSourceWithConfigure = &modelsDataSource{}
+
+func NewModelsDataSource() datasource.DataSourceWithConfigure {
+	return &modelsDataSource{}
+}
+
+type modelsDataSource struct {
+	client *juju.Client
+
+	// context for the logging subsystem.
+	subCtx context.Context
+}
+
+type modelsDataSourceModel struct {
+	Name   types.String                  `tfsdk:"name"`
+	Owner  types.String                  `tfsdk:"owner"`
+	Models []modelSummaryDataSourceModel `tfsdk:"models"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+type modelSummaryDataSourceModel struct {
+	Name        types.String `tfsdk:"name"`
+	Owner       types.String `tfsdk:"owner"`
+	UUID        types.String `tfsdk:"uuid"`
+	Type        types.String `tfsdk:"type"`
+	Cloud       types.String `tfsdk:"cloud"`
+	CloudRegion types.String `tfsdk:"cloud_region"`
+	Life        types.String `tfsdk:"life"`
+}
+
+// Metadata returns the full data source name as used in terraform plans.
+func (d *modelsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_models"
+}
+
+// Schema returns the schema for the models data source.
+func (d *modelsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A data source listing the models the user can see.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "Only list the models with this name, e.g. the models of the same name of several owners.",
+				Optional:    true,
+			},
+			"owner": schema.StringAttribute{
+				Description: "Only list the models of this owner.",
+				Optional:    true,
+			},
+			"models": schema.ListNestedAttribute{
+				Description: "The models, sorted by owner and name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The name of the model.",
+							Computed:    true,
+						},
+						"owner": schema.StringAttribute{
+							Description: "The owner of the model.",
+							Computed:    true,
+						},
+						"uuid": schema.StringAttribute{
+							Description: "The UUID of the model.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The type of the model, `iaas` or `caas`.",
+							Computed:    true,
+						},
+						"cloud": schema.StringAttribute{
+							Description: "The cloud of the model.",
+							Computed:    true,
+						},
+						"cloud_region": schema.StringAttribute{
+							Description: "The cloud region of the model.",
+							Computed:    true,
+						},
+						"life": schema.StringAttribute{
+							Description: "The life of the model, `alive`, `dying` or `dead`.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			// ID required by the testing framework
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Configure enables provider-level data or clients to be set in the
+// provider-defined DataSource type. It is separately executed for each
+// ReadDataSource RPC.
+func (d *modelsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	d.subCtx = d.client.NewLogSubsystem(ctx, LogDataSourceModels)
+}
+
+// Read is called when the provider must read data source values in
+// order to update state. Config values should be read from the
+// ReadRequest and new state values set on the ReadResponse.
+func (d *modelsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, d.client, "data.juju_models", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if d.client == nil {
+		addDSClientNotConfiguredError(&resp.Diagnostics, "models")
+		return
+	}
+
+	var data modelsDataSourceModel
+
+	// Read Terraform configuration data into the model.
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	output, err := d.client.Models.ListModels(ctx, juju.ListModelsInput{
+		Name:  data.Name.ValueString(),
+		Owner: data.Owner.ValueString(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to list models")
+		return
+	}
+	d.trace("listed models", map[string]interface{}{"models": output.Models})
+
+	data.Models = make([]modelSummaryDataSourceModel, len(output.Models))
+	for i, m := range output.Models {
+		data.Models[i] = modelSummaryDataSourceModel{
+			Name:        types.StringValue(m.Name),
+			Owner:       types.StringValue(m.Owner),
+			UUID:        types.StringValue(m.UUID),
+			Type:        types.StringValue(m.Type),
+			Cloud:       types.StringValue(m.Cloud),
+			CloudRegion: types.StringValue(m.CloudRegion),
+			Life:        types.StringValue(m.Life),
+		}
+	}
+	data.ID = types.StringValue("models")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *modelsDataSource) trace(msg string, additionalFields ...map[string]interface{}) {
+	if d.subCtx == nil {
+		return
+	}
+
+	tflog.SubsystemTrace(d.subCtx, LogDataSourceModels, msg, additionalFields...)
+}
@@ -0,0 +1,46 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_DataSourceModels(t *testing.T) {
+	modelName := acctest.RandomWithPrefix("tf-datasource-models-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceModels(modelName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.juju_models.test-model", "models.#", "1"),
+					resource.TestCheckResourceAttr("data.juju_models.test-model", "models.0.name", modelName),
+					resource.TestCheckResourceAttrPair("data.juju_models.test-model", "models.0.uuid", "juju_model.test-model", "uuid"),
+					resource.TestCheckResourceAttr("data.juju_models.test-model", "models.0.type", "iaas"),
+					resource.TestCheckResourceAttr("data.juju_models.test-model", "models.0.life", "alive"),
+					resource.TestCheckResourceAttrSet("data.juju_models.test-model", "models.0.owner"),
+					resource.TestCheckResourceAttrSet("data.juju_models.test-model", "models.0.cloud"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceModels(modelName string) string {
+	return fmt.Sprintf(`
+resource "juju_model" "test-model" {
+	name = %q
+}
+
+data "juju_models" "test-model" {
+	name = juju_model.test-model.name
+}`, modelName)
+}
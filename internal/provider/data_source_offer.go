@@ -95,10 +95,13 @@ func (d *offerDataSource) Configure(ctx context.Context, req datasource.Configur
 	}
 
 	d.client = client
-	d.subCtx = tflog.NewSubsystem(ctx, LogDataSourceOffer)
+	d.subCtx = d.client.NewLogSubsystem(ctx, LogDataSourceOffer)
 }
 
 func (d *offerDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, d.client, "data.juju_offer", "Read", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if d.client == nil {
 		addDSClientNotConfiguredError(&resp.Diagnostics, "offer")
@@ -114,11 +117,11 @@ func (d *offerDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 
 	// Get current juju machine data source values .
-	offer, err := d.client.Offers.ReadOffer(&juju.ReadOfferInput{
+	offer, err := d.client.Offers.ReadOffer(ctx, &juju.ReadOfferInput{
 		OfferURL: data.OfferURL.ValueString(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read offer, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to read offer")
 		return
 	}
 	d.trace(fmt.Sprintf("read juju offer %q data source", data.OfferName))
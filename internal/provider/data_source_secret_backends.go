@@ -0,0 +1,190 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &secretBackendsDataSource{}
+
+func NewSecretBackendsDataSource() datasource.DataSourceWithConfigure {
+	return &secretBackendsDataSource{}
+}
+
+type secretBackendsDataSource struct {
+	client *juju.Client
+
+	// context for the logging subsystem.
+	subCtx context.Context
+}
+
+type secretBackendsDataSourceModel struct {
+	Names    types.List                     `tfsdk:"names"`
+	Backends []secretBackendDataSourceModel `tfsdk:"backends"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+type secretBackendDataSourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	Name                types.String `tfsdk:"name"`
+	Type                types.String `tfsdk:"type"`
+	Status              types.String `tfsdk:"status"`
+	Message             types.String `tfsdk:"message"`
+	NumSecrets          types.Int64  `tfsdk:"num_secrets"`
+	TokenRotateInterval types.String `tfsdk:"token_rotate_interval"`
+}
+
+// Metadata returns the full data source name as used in terraform plans.
+func (d *secretBackendsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_secret_backends"
+}
+
+func (d *secretBackendsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A data source listing the secret backends of the controller.",
+		Attributes: map[string]schema.Attribute{
+			"names": schema.ListAttribute{
+				Description: "Only list the backends with these names. All backends are listed if not set.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"backends": schema.ListNestedAttribute{
+				Description: "The secret backends.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The ID of the backend.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the backend.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The type of the backend, e.g. `controller`, `kubernetes` or `vault`.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "The status of the backend.",
+							Computed:    true,
+						},
+						"message": schema.StringAttribute{
+							Description: "The message of the backend status.",
+							Computed:    true,
+						},
+						"num_secrets": schema.Int64Attribute{
+							Description: "The number of secrets stored in the backend.",
+							Computed:    true,
+						},
+						"token_rotate_interval": schema.StringAttribute{
+							Description: "How often the backend access token is rotated. Empty when not rotated.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			// ID required by the testing framework
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Configure enables provider-level data or clients to be set in the
+// provider-defined DataSource type. It is separately executed for each
+// ReadDataSource RPC.
+func (d *secretBackendsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	checkMinJujuVersion(client, "juju_secret_backends", &resp.Diagnostics)
+	d.subCtx = d.client.NewLogSubsystem(ctx, LogDataSourceSecretBackends)
+}
+
+// Read is called when the provider must read data source values in
+// order to update state. Config values should be read from the
+// ReadRequest and new state values set on the ReadResponse.
+func (d *secretBackendsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, d.client, "data.juju_secret_backends", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if d.client == nil {
+		addDSClientNotConfiguredError(&resp.Diagnostics, "secret_backends")
+		return
+	}
+
+	var data secretBackendsDataSourceModel
+
+	// Read Terraform configuration data into the model.
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var names []string
+	resp.Diagnostics.Append(data.Names.ElementsAs(ctx, &names, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	output, err := d.client.Secrets.ListSecretBackends(ctx, &juju.ListSecretBackendsInput{Names: names})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to list secret backends")
+		return
+	}
+	d.trace("listed secret backends", map[string]interface{}{"backends": output.Backends})
+
+	data.Backends = make([]secretBackendDataSourceModel, len(output.Backends))
+	for i, backend := range output.Backends {
+		data.Backends[i] = secretBackendDataSourceModel{
+			ID:                  types.StringValue(backend.ID),
+			Name:                types.StringValue(backend.Name),
+			Type:                types.StringValue(backend.BackendType),
+			Status:              types.StringValue(backend.Status),
+			Message:             types.StringValue(backend.Message),
+			NumSecrets:          types.Int64Value(int64(backend.NumSecrets)),
+			TokenRotateInterval: types.StringValue(backend.TokenRotateInterval),
+		}
+	}
+	data.ID = types.StringValue("secret-backends")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *secretBackendsDataSource) trace(msg string, additionalFields ...map[string]interface{}) {
+	if d.subCtx == nil {
+		return
+	}
+
+	//SubsystemTrace(subCtx, "datasource-secret-backends", "hello, world", map[string]interface{}{"foo": 123})
+	// Output:
+	// {"@level":"trace","@message":"hello, world","@module":"juju.datasource-secret-backends","foo":123}
+	tflog.SubsystemTrace(d.subCtx, LogDataSourceSecretBackends, msg, additionalFields...)
+}
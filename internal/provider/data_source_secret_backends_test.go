@@ -0,0 +1,34 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_DataSourceSecretBackends(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSecretBackends(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.juju_secret_backends.this", "backends.#", "1"),
+					resource.TestCheckResourceAttr("data.juju_secret_backends.this", "backends.0.name", "internal"),
+					resource.TestCheckResourceAttr("data.juju_secret_backends.this", "backends.0.type", "controller"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceSecretBackends() string {
+	return `
+data "juju_secret_backends" "this" {
+  names = ["internal"]
+}`
+}
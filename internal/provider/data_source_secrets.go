@@ -85,13 +85,17 @@ func (d *secretDataSource) Configure(ctx context.Context, req datasource.Configu
 	}
 
 	d.client = client
-	d.subCtx = tflog.NewSubsystem(ctx, LogDataSourceSecret)
+	checkMinJujuVersion(client, "juju_secret", &resp.Diagnostics)
+	d.subCtx = d.client.NewLogSubsystem(ctx, LogDataSourceSecret)
 }
 
 // Read is called when the provider must read data source values in
 // order to update state. Config values should be read from the
 // ReadRequest and new state values set on the ReadResponse.
 func (d *secretDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, d.client, "data.juju_secret", "Read", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if d.client == nil {
 		addDSClientNotConfiguredError(&resp.Diagnostics, "secret")
@@ -115,9 +119,9 @@ func (d *secretDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		readSecretInput.SecretId = data.SecretId.ValueString()
 	}
 
-	readSecretOutput, err := d.client.Secrets.ReadSecret(&readSecretInput)
+	readSecretOutput, err := d.client.Secrets.ReadSecret(ctx, &readSecretInput)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read secret, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to read secret")
 		return
 	}
 	d.trace(fmt.Sprintf("read secret data source %q", data.SecretId))
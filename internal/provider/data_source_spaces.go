@@ -0,0 +1,212 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSourceWithConfigure = &spacesDataSource{}
+
+func NewSpacesDataSource() datasource.DataSourceWithConfigure {
+	return &spacesDataSource{}
+}
+
+type spacesDataSource struct {
+	client *juju.Client
+
+	// context for the logging subsystem.
+	subCtx context.Context
+}
+
+type spacesDataSourceModel struct {
+	Model  types.String           `tfsdk:"model"`
+	Spaces []spaceDataSourceModel `tfsdk:"spaces"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+type spaceDataSourceModel struct {
+	ID         types.String            `tfsdk:"id"`
+	Name       types.String            `tfsdk:"name"`
+	ProviderID types.String            `tfsdk:"provider_id"`
+	Subnets    []subnetDataSourceModel `tfsdk:"subnets"`
+}
+
+type subnetDataSourceModel struct {
+	CIDR              types.String `tfsdk:"cidr"`
+	ProviderID        types.String `tfsdk:"provider_id"`
+	ProviderNetworkID types.String `tfsdk:"provider_network_id"`
+	VLANTag           types.Int64  `tfsdk:"vlan_tag"`
+	Zones             types.List   `tfsdk:"zones"`
+}
+
+// Metadata returns the full data source name as used in terraform plans.
+func (d *spacesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_spaces"
+}
+
+// Schema returns the schema for the spaces data source.
+func (d *spacesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A data source listing the network spaces of a model and their subnets.",
+		Attributes: map[string]schema.Attribute{
+			"model": schema.StringAttribute{
+				Description: "The name of the model.",
+				Required:    true,
+			},
+			"spaces": schema.ListNestedAttribute{
+				Description: "The spaces, sorted by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The ID of the space.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the space.",
+							Computed:    true,
+						},
+						"provider_id": schema.StringAttribute{
+							Description: "The ID of the space in the cloud. Empty if the cloud does not support spaces.",
+							Computed:    true,
+						},
+						"subnets": schema.ListNestedAttribute{
+							Description: "The subnets of the space, sorted by CIDR.",
+							Computed:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"cidr": schema.StringAttribute{
+										Description: "The CIDR of the subnet.",
+										Computed:    true,
+									},
+									"provider_id": schema.StringAttribute{
+										Description: "The ID of the subnet in the cloud.",
+										Computed:    true,
+									},
+									"provider_network_id": schema.StringAttribute{
+										Description: "The ID of the network of the subnet in the cloud.",
+										Computed:    true,
+									},
+									"vlan_tag": schema.Int64Attribute{
+										Description: "The VLAN tag of the subnet, 0 for a normal network.",
+										Computed:    true,
+									},
+									"zones": schema.ListAttribute{
+										Description: "The availability zones of the subnet.",
+										ElementType: types.StringType,
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			// ID required by the testing framework
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Configure enables provider-level data or clients to be set in the
+// provider-defined DataSource type. It is separately executed for each
+// ReadDataSource RPC.
+func (d *spacesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	d.subCtx = d.client.NewLogSubsystem(ctx, LogDataSourceSpaces)
+}
+
+// Read is called when the provider must read data source values in
+// order to update state. Config values should be read from the
+// ReadRequest and new state values set on the ReadResponse.
+func (d *spacesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, d.client, "data.juju_spaces", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if d.client == nil {
+		addDSClientNotConfiguredError(&resp.Diagnostics, "spaces")
+		return
+	}
+
+	var data spacesDataSourceModel
+
+	// Read Terraform configuration data into the model.
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	output, err := d.client.Models.ListSpaces(ctx, juju.ListSpacesInput{
+		ModelName: data.Model.ValueString(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to list spaces of model %q", data.Model.ValueString())
+		return
+	}
+	d.trace(fmt.Sprintf("listed spaces of model %q", data.Model.ValueString()), map[string]interface{}{"spaces": output.Spaces})
+
+	data.Spaces = make([]spaceDataSourceModel, len(output.Spaces))
+	for i, s := range output.Spaces {
+		space := spaceDataSourceModel{
+			ID:         types.StringValue(s.ID),
+			Name:       types.StringValue(s.Name),
+			ProviderID: types.StringValue(s.ProviderID),
+			Subnets:    make([]subnetDataSourceModel, len(s.Subnets)),
+		}
+		for j, subnet := range s.Subnets {
+			zones, diags := types.ListValueFrom(ctx, types.StringType, subnet.Zones)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			space.Subnets[j] = subnetDataSourceModel{
+				CIDR:              types.StringValue(subnet.CIDR),
+				ProviderID:        types.StringValue(subnet.ProviderID),
+				ProviderNetworkID: types.StringValue(subnet.ProviderNetworkID),
+				VLANTag:           types.Int64Value(int64(subnet.VLANTag)),
+				Zones:             zones,
+			}
+		}
+		data.Spaces[i] = space
+	}
+	data.ID = types.StringValue(data.Model.ValueString())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *spacesDataSource) trace(msg string, additionalFields ...map[string]interface{}) {
+	if d.subCtx == nil {
+		return
+	}
+
+	tflog.SubsystemTrace(d.subCtx, LogDataSourceSpaces, msg, additionalFields...)
+}
@@ -0,0 +1,182 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSourceWithConfigure = &storagePoolsDataSource{}
+
+func NewStoragePoolsDataSource() datasource.DataSourceWithConfigure {
+	return &storagePoolsDataSource{}
+}
+
+type storagePoolsDataSource struct {
+	client *juju.Client
+
+	// context for the logging subsystem.
+	subCtx context.Context
+}
+
+type storagePoolsDataSourceModel struct {
+	Model types.String                 `tfsdk:"model"`
+	Pools []storagePoolDataSourceModel `tfsdk:"pools"`
+	Names types.List                   `tfsdk:"names"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+type storagePoolDataSourceModel struct {
+	Name       types.String `tfsdk:"name"`
+	Provider   types.String `tfsdk:"provider"`
+	Attributes types.Map    `tfsdk:"attributes"`
+}
+
+// Metadata returns the full data source name as used in terraform plans.
+func (d *storagePoolsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_storage_pools"
+}
+
+// Schema returns the schema for the storage pools data source.
+func (d *storagePoolsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A data source listing the storage pools of a model, including the default pools " +
+			"of its storage providers.",
+		Attributes: map[string]schema.Attribute{
+			"model": schema.StringAttribute{
+				Description: "The name of the model.",
+				Required:    true,
+			},
+			"pools": schema.ListNestedAttribute{
+				Description: "The storage pools, sorted by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The name of the pool.",
+							Computed:    true,
+						},
+						"provider": schema.StringAttribute{
+							Description: "The storage provider of the pool, e.g. `lxd`, `ebs` or `kubernetes`.",
+							Computed:    true,
+						},
+						"attributes": schema.MapAttribute{
+							Description: "The configuration attributes of the pool. Values which are not strings, " +
+								"numbers or booleans are JSON encoded.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"names": schema.ListAttribute{
+				Description: "The names of the pools, sorted, e.g. to check that the pool of a storage " +
+					"directive exists.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			// ID required by the testing framework
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Configure enables provider-level data or clients to be set in the
+// provider-defined DataSource type. It is separately executed for each
+// ReadDataSource RPC.
+func (d *storagePoolsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	d.subCtx = d.client.NewLogSubsystem(ctx, LogDataSourceStoragePools)
+}
+
+// Read is called when the provider must read data source values in
+// order to update state. Config values should be read from the
+// ReadRequest and new state values set on the ReadResponse.
+func (d *storagePoolsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, d.client, "data.juju_storage_pools", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if d.client == nil {
+		addDSClientNotConfiguredError(&resp.Diagnostics, "storage pools")
+		return
+	}
+
+	var data storagePoolsDataSourceModel
+
+	// Read Terraform configuration data into the model.
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	output, err := d.client.Models.ListStoragePools(ctx, juju.ListStoragePoolsInput{
+		ModelName: data.Model.ValueString(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to list storage pools of model %q", data.Model.ValueString())
+		return
+	}
+	d.trace(fmt.Sprintf("listed storage pools of model %q", data.Model.ValueString()), map[string]interface{}{"pools": output.Pools})
+
+	data.Pools = make([]storagePoolDataSourceModel, len(output.Pools))
+	names := make([]string, len(output.Pools))
+	for i, p := range output.Pools {
+		attributes, diags := types.MapValueFrom(ctx, types.StringType, p.Attributes)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.Pools[i] = storagePoolDataSourceModel{
+			Name:       types.StringValue(p.Name),
+			Provider:   types.StringValue(p.Provider),
+			Attributes: attributes,
+		}
+		names[i] = p.Name
+	}
+	var diags diag.Diagnostics
+	data.Names, diags = types.ListValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = types.StringValue(data.Model.ValueString())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *storagePoolsDataSource) trace(msg string, additionalFields ...map[string]interface{}) {
+	if d.subCtx == nil {
+		return
+	}
+
+	tflog.SubsystemTrace(d.subCtx, LogDataSourceStoragePools, msg, additionalFields...)
+}
@@ -0,0 +1,197 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &unitDataSource{}
+
+func NewUnitDataSource() datasource.DataSourceWithConfigure {
+	return &unitDataSource{}
+}
+
+type unitDataSource struct {
+	client *juju.Client
+
+	// context for the logging subsystem.
+	subCtx context.Context
+}
+
+type unitDataSourceModel struct {
+	Model           types.String `tfsdk:"model"`
+	Name            types.String `tfsdk:"name"`
+	Machine         types.String `tfsdk:"machine"`
+	PublicAddress   types.String `tfsdk:"public_address"`
+	PrivateAddress  types.String `tfsdk:"private_address"`
+	OpenedPorts     types.List   `tfsdk:"opened_ports"`
+	WorkloadStatus  types.String `tfsdk:"workload_status"`
+	WorkloadMessage types.String `tfsdk:"workload_message"`
+	AgentStatus     types.String `tfsdk:"agent_status"`
+	Leader          types.Bool   `tfsdk:"leader"`
+	Principal       types.String `tfsdk:"principal"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+// Metadata returns the full data source name as used in terraform plans.
+func (d *unitDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_unit"
+}
+
+func (d *unitDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A data source representing a unit of a Juju application.",
+		Attributes: map[string]schema.Attribute{
+			"model": schema.StringAttribute{
+				Description: "The name of the model where the unit is deployed.",
+				Required:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the unit, e.g. `mysql/0`.",
+				Required:    true,
+			},
+			"machine": schema.StringAttribute{
+				Description: "The machine the unit is running on. Subordinate units report the machine of their " +
+					"principal. Empty for units of Kubernetes models.",
+				Computed: true,
+			},
+			"public_address": schema.StringAttribute{
+				Description: "The public address of the unit.",
+				Computed:    true,
+			},
+			"private_address": schema.StringAttribute{
+				Description: "The private address of the unit.",
+				Computed:    true,
+			},
+			"opened_ports": schema.ListAttribute{
+				Description: "The ports opened by the unit, e.g. `80/tcp`.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"workload_status": schema.StringAttribute{
+				Description: "The workload status of the unit.",
+				Computed:    true,
+			},
+			"workload_message": schema.StringAttribute{
+				Description: "The message of the workload status.",
+				Computed:    true,
+			},
+			"agent_status": schema.StringAttribute{
+				Description: "The status of the unit agent.",
+				Computed:    true,
+			},
+			"leader": schema.BoolAttribute{
+				Description: "Whether the unit is the leader of its application.",
+				Computed:    true,
+			},
+			"principal": schema.StringAttribute{
+				Description: "The principal unit of a subordinate unit. Empty for principal units.",
+				Computed:    true,
+			},
+			// ID required by the testing framework
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Configure enables provider-level data or clients to be set in the
+// provider-defined DataSource type. It is separately executed for each
+// ReadDataSource RPC.
+func (d *unitDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	d.subCtx = d.client.NewLogSubsystem(ctx, LogDataSourceUnit)
+}
+
+// Read is called when the provider must read data source values in
+// order to update state. Config values should be read from the
+// ReadRequest and new state values set on the ReadResponse.
+func (d *unitDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, d.client, "data.juju_unit", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if d.client == nil {
+		addDSClientNotConfiguredError(&resp.Diagnostics, "unit")
+		return
+	}
+
+	var data unitDataSourceModel
+
+	// Read Terraform configuration data into the model.
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	modelName := data.Model.ValueString()
+	unitName := data.Name.ValueString()
+	d.trace(fmt.Sprintf("reading unit %q of model %q", unitName, modelName))
+
+	response, err := d.client.Applications.ReadUnit(ctx, juju.ReadUnitInput{
+		ModelName: modelName,
+		UnitName:  unitName,
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to read unit %q", unitName)
+		return
+	}
+	d.trace(fmt.Sprintf("read unit %q", unitName), map[string]interface{}{"response": response})
+
+	openedPorts, diags := types.ListValueFrom(ctx, types.StringType, response.OpenedPorts)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Machine = types.StringValue(response.Machine)
+	data.PublicAddress = types.StringValue(response.PublicAddress)
+	data.PrivateAddress = types.StringValue(response.PrivateAddress)
+	data.OpenedPorts = openedPorts
+	data.WorkloadStatus = types.StringValue(response.WorkloadStatus)
+	data.WorkloadMessage = types.StringValue(response.WorkloadMessage)
+	data.AgentStatus = types.StringValue(response.AgentStatus)
+	data.Leader = types.BoolValue(response.Leader)
+	data.Principal = types.StringValue(response.Principal)
+	data.ID = types.StringValue(fmt.Sprintf("%s:%s", modelName, unitName))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *unitDataSource) trace(msg string, additionalFields ...map[string]interface{}) {
+	if d.subCtx == nil {
+		return
+	}
+
+	//SubsystemTrace(subCtx, "datasource-unit", "hello, world", map[string]interface{}{"foo": 123})
+	// Output:
+	// {"@level":"trace","@message":"hello, world","@module":"juju.datasource-unit","foo":123}
+	tflog.SubsystemTrace(d.subCtx, LogDataSourceUnit, msg, additionalFields...)
+}
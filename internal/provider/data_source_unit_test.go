@@ -0,0 +1,57 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_DataSourceUnit(t *testing.T) {
+	if testingCloud != LXDCloudTesting {
+		t.Skip(t.Name() + " only runs with LXD")
+	}
+	modelName := acctest.RandomWithPrefix("tf-datasource-unit-test-model")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceUnit(modelName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.juju_unit.this", "model", modelName),
+					resource.TestCheckResourceAttr("data.juju_unit.this", "name", "test-app/0"),
+					resource.TestCheckResourceAttr("data.juju_unit.this", "leader", "true"),
+					resource.TestCheckResourceAttr("data.juju_unit.this", "principal", ""),
+					resource.TestCheckResourceAttrSet("data.juju_unit.this", "machine"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceUnit(modelName string) string {
+	return fmt.Sprintf(`
+resource "juju_model" "model" {
+  name = %q
+}
+
+resource "juju_application" "app" {
+  model = juju_model.model.name
+  name  = "test-app"
+
+  charm {
+    name = "ubuntu"
+  }
+}
+
+data "juju_unit" "this" {
+  model = juju_model.model.name
+  name  = "${juju_application.app.name}/0"
+}`, modelName)
+}
@@ -0,0 +1,170 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSourceWithConfigure = &usersDataSource{}
+
+func NewUsersDataSource() datasource.DataSourceWithConfigure {
+	return &usersDataSource{}
+}
+
+type usersDataSource struct {
+	client *juju.Client
+
+	// context for the logging subsystem.
+	subCtx context.Context
+}
+
+type usersDataSourceModel struct {
+	IncludeDisabled types.Bool                   `tfsdk:"include_disabled"`
+	Users           []userSummaryDataSourceModel `tfsdk:"users"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+type userSummaryDataSourceModel struct {
+	Name           types.String `tfsdk:"name"`
+	DisplayName    types.String `tfsdk:"display_name"`
+	Access         types.String `tfsdk:"access"`
+	Disabled       types.Bool   `tfsdk:"disabled"`
+	LastConnection types.String `tfsdk:"last_connection"`
+}
+
+// Metadata returns the full data source name as used in terraform plans.
+func (d *usersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+// Schema returns the schema for the users data source.
+func (d *usersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A data source listing the users of the controller.",
+		Attributes: map[string]schema.Attribute{
+			"include_disabled": schema.BoolAttribute{
+				Description: "List the disabled users too. Defaults to false.",
+				Optional:    true,
+			},
+			"users": schema.ListNestedAttribute{
+				Description: "The users, sorted by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The name of the user.",
+							Computed:    true,
+						},
+						"display_name": schema.StringAttribute{
+							Description: "The display name of the user.",
+							Computed:    true,
+						},
+						"access": schema.StringAttribute{
+							Description: "The access level of the user to the controller, e.g. `login` or `superuser`.",
+							Computed:    true,
+						},
+						"disabled": schema.BoolAttribute{
+							Description: "Whether the user is disabled.",
+							Computed:    true,
+						},
+						"last_connection": schema.StringAttribute{
+							Description: "The time of the last connection of the user, in RFC 3339 format. Empty if the user never connected.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			// ID required by the testing framework
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Configure enables provider-level data or clients to be set in the
+// provider-defined DataSource type. It is separately executed for each
+// ReadDataSource RPC.
+func (d *usersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	d.subCtx = d.client.NewLogSubsystem(ctx, LogDataSourceUsers)
+}
+
+// Read is called when the provider must read data source values in
+// order to update state. Config values should be read from the
+// ReadRequest and new state values set on the ReadResponse.
+func (d *usersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, d.client, "data.juju_users", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if d.client == nil {
+		addDSClientNotConfiguredError(&resp.Diagnostics, "users")
+		return
+	}
+
+	var data usersDataSourceModel
+
+	// Read Terraform configuration data into the model.
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	output, err := d.client.Users.ListUsers(ctx, juju.ListUsersInput{
+		IncludeDisabled: data.IncludeDisabled.ValueBool(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to list users")
+		return
+	}
+	d.trace("listed users", map[string]interface{}{"users": output.Users})
+
+	data.Users = make([]userSummaryDataSourceModel, len(output.Users))
+	for i, u := range output.Users {
+		data.Users[i] = userSummaryDataSourceModel{
+			Name:           types.StringValue(u.Name),
+			DisplayName:    types.StringValue(u.DisplayName),
+			Access:         types.StringValue(u.Access),
+			Disabled:       types.BoolValue(u.Disabled),
+			LastConnection: types.StringValue(u.LastConnection),
+		}
+	}
+	data.ID = types.StringValue("users")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *usersDataSource) trace(msg string, additionalFields ...map[string]interface{}) {
+	if d.subCtx == nil {
+		return
+	}
+
+	tflog.SubsystemTrace(d.subCtx, LogDataSourceUsers, msg, additionalFields...)
+}
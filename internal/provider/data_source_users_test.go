@@ -0,0 +1,47 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_DataSourceUsers(t *testing.T) {
+	userName := acctest.RandomWithPrefix("tfuser")
+	userPassword := acctest.RandomWithPrefix("tf-test-user")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceUsers(userName, userPassword),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.juju_users.all", "users.*", map[string]string{
+						"name":            userName,
+						"access":          "login",
+						"disabled":        "false",
+						"last_connection": "",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceUsers(userName, userPassword string) string {
+	return fmt.Sprintf(`
+resource "juju_user" "user" {
+  name = %q
+  password = %q
+}
+
+data "juju_users" "all" {
+  depends_on = [juju_user.user]
+}`, userName, userPassword)
+}
@@ -0,0 +1,245 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// defaultWaitForTimeout is used when no timeout is configured.
+const defaultWaitForTimeout = 10 * time.Minute
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &waitForDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &waitForDataSource{}
+
+func NewWaitForDataSource() datasource.DataSourceWithConfigure {
+	return &waitForDataSource{}
+}
+
+type waitForDataSource struct {
+	client *juju.Client
+
+	// context for the logging subsystem.
+	subCtx context.Context
+}
+
+type waitForDataSourceModel struct {
+	Model         types.String `tfsdk:"model"`
+	Type          types.String `tfsdk:"type"`
+	Name          types.String `tfsdk:"name"`
+	Status        types.List   `tfsdk:"status"`
+	Timeout       types.String `tfsdk:"timeout"`
+	CurrentStatus types.String `tfsdk:"current_status"`
+	Message       types.String `tfsdk:"message"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+// Metadata returns the full data source name as used in terraform plans.
+func (d *waitForDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_wait_for"
+}
+
+func (d *waitForDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A data source which blocks until a Juju application, unit, machine or model reaches one of " +
+			"the given statuses. Use it with `depends_on` to sequence multi-stage rollouts.",
+		Attributes: map[string]schema.Attribute{
+			"model": schema.StringAttribute{
+				Description: "The name of the model.",
+				Required:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "The kind of entity to wait for. One of `application`, `unit`, `machine` or `model`.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						juju.EntityKindApplication,
+						juju.EntityKindUnit,
+						juju.EntityKindMachine,
+						juju.EntityKindModel,
+					),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the entity: an application name, a unit name such as `postgresql/0` or " +
+					"a machine id. Not used when waiting for a model.",
+				Optional: true,
+			},
+			"status": schema.ListAttribute{
+				Description: "The statuses to wait for, the wait ends when the entity reaches any of them. " +
+					"The workload status is used for applications and units, the agent status for machines.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"timeout": schema.StringAttribute{
+				Description: "How long to wait, as a duration string such as `30s` or `5m`. Defaults to `10m`.",
+				Optional:    true,
+			},
+			"current_status": schema.StringAttribute{
+				Description: "The status reached by the entity.",
+				Computed:    true,
+			},
+			"message": schema.StringAttribute{
+				Description: "The status message of the entity.",
+				Computed:    true,
+			},
+			// ID required by the testing framework
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ConfigValidators requires a name for every entity kind but models.
+func (d *waitForDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		waitForNameValidator{},
+	}
+}
+
+// Configure enables provider-level data or clients to be set in the
+// provider-defined DataSource type. It is separately executed for each
+// ReadDataSource RPC.
+func (d *waitForDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	d.subCtx = d.client.NewLogSubsystem(ctx, LogDataSourceWaitFor)
+}
+
+// Read is called when the provider must read data source values in
+// order to update state. Config values should be read from the
+// ReadRequest and new state values set on the ReadResponse.
+func (d *waitForDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, d.client, "data.juju_wait_for", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if d.client == nil {
+		addDSClientNotConfiguredError(&resp.Diagnostics, "wait_for")
+		return
+	}
+
+	var data waitForDataSourceModel
+
+	// Read Terraform configuration data into the model.
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var statuses []string
+	resp.Diagnostics.Append(data.Status.ElementsAs(ctx, &statuses, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout := defaultWaitForTimeout
+	if !data.Timeout.IsNull() {
+		var err error
+		timeout, err = time.ParseDuration(data.Timeout.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("timeout"), "Invalid Attribute Value",
+				fmt.Sprintf("Unable to parse timeout %q, got error: %s", data.Timeout.ValueString(), err))
+			return
+		}
+	}
+
+	kind := data.Type.ValueString()
+	name := data.Name.ValueString()
+	d.trace(fmt.Sprintf("waiting for %s %q to reach status %q", kind, name, statuses))
+
+	response, err := d.client.Status.WaitForStatus(ctx, juju.WaitForStatusInput{
+		ModelName: data.Model.ValueString(),
+		Kind:      kind,
+		Name:      name,
+		Status:    statuses,
+		Timeout:   timeout,
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to wait for %s %q", kind, name)
+		return
+	}
+
+	data.CurrentStatus = types.StringValue(response.Status)
+	data.Message = types.StringValue(response.Message)
+	data.ID = types.StringValue(newWaitForID(data.Model.ValueString(), kind, name))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *waitForDataSource) trace(msg string, additionalFields ...map[string]interface{}) {
+	if d.subCtx == nil {
+		return
+	}
+
+	//SubsystemTrace(subCtx, "datasource-wait-for", "hello, world", map[string]interface{}{"foo": 123})
+	// Output:
+	// {"@level":"trace","@message":"hello, world","@module":"juju.datasource-wait-for","foo":123}
+	tflog.SubsystemTrace(d.subCtx, LogDataSourceWaitFor, msg, additionalFields...)
+}
+
+func newWaitForID(model, kind, name string) string {
+	if kind == juju.EntityKindModel {
+		return fmt.Sprintf("%s:%s", model, kind)
+	}
+	return fmt.Sprintf("%s:%s:%s", model, kind, name)
+}
+
+// waitForNameValidator ensures name is set unless waiting for a model.
+type waitForNameValidator struct{}
+
+func (v waitForNameValidator) Description(_ context.Context) string {
+	return "name must be set unless type is model"
+}
+
+func (v waitForNameValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v waitForNameValidator) ValidateDataSource(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data waitForDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.Type.IsUnknown() || data.Name.IsUnknown() {
+		return
+	}
+	if data.Type.ValueString() != juju.EntityKindModel && data.Name.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("name"), "Missing Attribute Configuration",
+			fmt.Sprintf("name must be set when waiting for a %s.", data.Type.ValueString()))
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_DataSourceWaitFor(t *testing.T) {
+	if testingCloud != LXDCloudTesting {
+		t.Skip(t.Name() + " only runs with LXD")
+	}
+	modelName := acctest.RandomWithPrefix("tf-datasource-wait-for-test-model")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceWaitFor(modelName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.juju_wait_for.app", "current_status", "active"),
+					resource.TestCheckResourceAttr("data.juju_wait_for.machine", "current_status", "started"),
+					resource.TestCheckResourceAttr("data.juju_wait_for.model", "current_status", "available"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceWaitFor(modelName string) string {
+	return fmt.Sprintf(`
+resource "juju_model" "model" {
+  name = %q
+}
+
+resource "juju_application" "app" {
+  model = juju_model.model.name
+  name  = "app"
+
+  charm {
+    name = "ubuntu"
+  }
+
+  units = 1
+}
+
+data "juju_wait_for" "app" {
+  model  = juju_model.model.name
+  type   = "application"
+  name   = juju_application.app.name
+  status = ["active"]
+}
+
+data "juju_wait_for" "machine" {
+  model  = juju_model.model.name
+  type   = "machine"
+  name   = "0"
+  status = ["started"]
+
+  depends_on = [juju_application.app]
+}
+
+data "juju_wait_for" "model" {
+  model   = juju_model.model.name
+  type    = "model"
+  status  = ["available"]
+  timeout = "1m"
+}`, modelName)
+}
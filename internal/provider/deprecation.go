@@ -0,0 +1,170 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// renamedAttribute describes a top level attribute of a resource which
+// has been renamed. Both attributes are kept in the schema until the
+// next major version:
+//
+//   - the deprecated attribute sets DeprecationMessage to the value of
+//     deprecationMessage, so Terraform warns when it is configured,
+//   - both attributes are Optional and Computed, conflict with each
+//     other and use the plan modifier returned by the copy functions
+//     below, so the value configured in either is planned for both,
+//   - the state upgrader of the schema version introducing the rename
+//     calls upgradeState, so existing state has both set.
+//
+// The resource only reads the new attribute.
+type renamedAttribute struct {
+	// from is the deprecated name of the attribute.
+	from string
+	// to is the name replacing it.
+	to string
+	// convert converts a value of the deprecated attribute into a
+	// value of the new one, when their formats differ, e.g. a series
+	// into a base. Values are copied as is when nil.
+	convert func(value string) (string, error)
+}
+
+// deprecationMessage returns the DeprecationMessage of the deprecated
+// attribute.
+func (a renamedAttribute) deprecationMessage() string {
+	return fmt.Sprintf("Configure %s instead. This attribute will be removed in the next major version of the provider.", a.to)
+}
+
+// upgradeState copies the value of the deprecated attribute into the
+// new one in the raw state, to be used with rawStateUpgrader.
+func (a renamedAttribute) upgradeState(state map[string]interface{}) error {
+	value, ok := state[a.from]
+	if !ok || value == nil {
+		return nil
+	}
+	if current, ok := state[a.to]; ok && current != nil {
+		return nil
+	}
+	if a.convert != nil {
+		from, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%q is not a string", a.from)
+		}
+		to, err := a.convert(from)
+		if err != nil {
+			return fmt.Errorf("converting %q: %w", a.from, err)
+		}
+		value = to
+	}
+	state[a.to] = value
+	return nil
+}
+
+// upgradeRenamedAttributes returns a function upgrading the raw state
+// for every renamed attribute.
+func upgradeRenamedAttributes(renamed ...renamedAttribute) func(state map[string]interface{}) error {
+	return func(state map[string]interface{}) error {
+		for _, a := range renamed {
+			if err := a.upgradeState(state); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// copyStringFrom returns a plan modifier planning the value configured
+// for the attribute at other when the attribute is not configured.
+func copyStringFrom(other path.Path) planmodifier.String {
+	return copyFromModifier{other: other}
+}
+
+// convertStringFrom is copyStringFrom for renamed attributes whose
+// format changed: the value configured at other is converted with
+// convert.
+func convertStringFrom(other path.Path, convert func(value string) (string, error)) planmodifier.String {
+	return copyFromModifier{other: other, convert: convert}
+}
+
+// copyInt64From is the Int64 equivalent of copyStringFrom.
+func copyInt64From(other path.Path) planmodifier.Int64 {
+	return copyFromModifier{other: other}
+}
+
+// copyBoolFrom is the Bool equivalent of copyStringFrom.
+func copyBoolFrom(other path.Path) planmodifier.Bool {
+	return copyFromModifier{other: other}
+}
+
+type copyFromModifier struct {
+	other   path.Path
+	convert func(value string) (string, error)
+}
+
+// Description returns a plain text description of the modifier's behavior.
+func (m copyFromModifier) Description(_ context.Context) string {
+	return fmt.Sprintf("Defaults to the value configured for %s.", m.other)
+}
+
+// MarkdownDescription returns a markdown formatted description of the
+// modifier's behavior.
+func (m copyFromModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// PlanModifyString implements planmodifier.String.
+func (m copyFromModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	var other types.String
+	if !m.otherValue(ctx, req.ConfigValue, req.Config, &other, &resp.Diagnostics) {
+		return
+	}
+	if m.convert == nil || other.IsUnknown() {
+		resp.PlanValue = other
+		return
+	}
+	value, err := m.convert(other.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(m.other, "Invalid Attribute Value", err.Error())
+		return
+	}
+	resp.PlanValue = types.StringValue(value)
+}
+
+// PlanModifyInt64 implements planmodifier.Int64.
+func (m copyFromModifier) PlanModifyInt64(ctx context.Context, req planmodifier.Int64Request, resp *planmodifier.Int64Response) {
+	var other types.Int64
+	if m.otherValue(ctx, req.ConfigValue, req.Config, &other, &resp.Diagnostics) {
+		resp.PlanValue = other
+	}
+}
+
+// PlanModifyBool implements planmodifier.Bool.
+func (m copyFromModifier) PlanModifyBool(ctx context.Context, req planmodifier.BoolRequest, resp *planmodifier.BoolResponse) {
+	var other types.Bool
+	if m.otherValue(ctx, req.ConfigValue, req.Config, &other, &resp.Diagnostics) {
+		resp.PlanValue = other
+	}
+}
+
+// otherValue reads the configured value of the other attribute into
+// target, and reports whether it must be planned for this attribute.
+func (m copyFromModifier) otherValue(ctx context.Context, value attr.Value, config tfsdk.Config, target attr.Value, diags *diag.Diagnostics) bool {
+	if !value.IsNull() {
+		return false
+	}
+	diags.Append(config.GetAttribute(ctx, m.other, target)...)
+	if diags.HasError() {
+		return false
+	}
+	return !target.IsNull()
+}
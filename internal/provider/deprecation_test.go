@@ -0,0 +1,148 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRenamedAttribute = renamedAttribute{from: "old_name", to: "new_name"}
+
+func TestRenamedAttributeUpgradeState(t *testing.T) {
+	state := map[string]interface{}{"old_name": "value"}
+	require.NoError(t, upgradeRenamedAttributes(testRenamedAttribute)(state))
+	assert.Equal(t, map[string]interface{}{"old_name": "value", "new_name": "value"}, state)
+
+	// A value already set in the new attribute is kept.
+	state = map[string]interface{}{"old_name": "value", "new_name": "other"}
+	require.NoError(t, upgradeRenamedAttributes(testRenamedAttribute)(state))
+	assert.Equal(t, "other", state["new_name"])
+
+	state = map[string]interface{}{"old_name": nil}
+	require.NoError(t, upgradeRenamedAttributes(testRenamedAttribute)(state))
+	assert.NotContains(t, state, "new_name")
+}
+
+func TestRenamedAttributeDeprecationMessage(t *testing.T) {
+	assert.Contains(t, testRenamedAttribute.deprecationMessage(), "Configure new_name instead.")
+}
+
+func renamedAttributeConfig(oldValue, newValue *string) tfsdk.Config {
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"old_name": schema.StringAttribute{Optional: true, Computed: true},
+			"new_name": schema.StringAttribute{Optional: true, Computed: true},
+		},
+	}
+	values := map[string]tftypes.Value{
+		"old_name": tftypes.NewValue(tftypes.String, nil),
+		"new_name": tftypes.NewValue(tftypes.String, nil),
+	}
+	if oldValue != nil {
+		values["old_name"] = tftypes.NewValue(tftypes.String, *oldValue)
+	}
+	if newValue != nil {
+		values["new_name"] = tftypes.NewValue(tftypes.String, *newValue)
+	}
+	objectType := s.Type().TerraformType(context.Background())
+	return tfsdk.Config{Schema: s, Raw: tftypes.NewValue(objectType, values)}
+}
+
+func TestCopyStringFrom(t *testing.T) {
+	ctx := context.Background()
+	value := "value"
+	modifier := copyStringFrom(path.Root("old_name"))
+
+	// The deprecated attribute is configured.
+	req := planmodifier.StringRequest{
+		Config:      renamedAttributeConfig(&value, nil),
+		ConfigValue: types.StringNull(),
+		PlanValue:   types.StringUnknown(),
+	}
+	resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+	modifier.PlanModifyString(ctx, req, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Equal(t, types.StringValue("value"), resp.PlanValue)
+
+	// The new attribute is configured.
+	req = planmodifier.StringRequest{
+		Config:      renamedAttributeConfig(nil, &value),
+		ConfigValue: types.StringValue("value"),
+		PlanValue:   types.StringValue("value"),
+	}
+	resp = &planmodifier.StringResponse{PlanValue: req.PlanValue}
+	modifier.PlanModifyString(ctx, req, resp)
+	assert.Equal(t, types.StringValue("value"), resp.PlanValue)
+
+	// Neither is configured.
+	req = planmodifier.StringRequest{
+		Config:      renamedAttributeConfig(nil, nil),
+		ConfigValue: types.StringNull(),
+		PlanValue:   types.StringUnknown(),
+	}
+	resp = &planmodifier.StringResponse{PlanValue: req.PlanValue}
+	modifier.PlanModifyString(ctx, req, resp)
+	assert.Equal(t, types.StringUnknown(), resp.PlanValue)
+}
+
+func TestCopyFromOtherTypes(t *testing.T) {
+	ctx := context.Background()
+	config := renamedAttributeConfig(nil, nil)
+
+	int64Resp := &planmodifier.Int64Response{PlanValue: types.Int64Unknown()}
+	copyInt64From(path.Root("old_name")).PlanModifyInt64(ctx, planmodifier.Int64Request{
+		Config:      config,
+		ConfigValue: types.Int64Null(),
+		PlanValue:   types.Int64Unknown(),
+	}, int64Resp)
+	assert.Equal(t, types.Int64Unknown(), int64Resp.PlanValue)
+
+	boolResp := &planmodifier.BoolResponse{PlanValue: types.BoolValue(true)}
+	copyBoolFrom(path.Root("old_name")).PlanModifyBool(ctx, planmodifier.BoolRequest{
+		Config:      config,
+		ConfigValue: types.BoolValue(true),
+		PlanValue:   types.BoolValue(true),
+	}, boolResp)
+	assert.Equal(t, types.BoolValue(true), boolResp.PlanValue)
+}
+
+func TestConvertStringFrom(t *testing.T) {
+	ctx := context.Background()
+	value := "value"
+	convert := func(value string) (string, error) {
+		if value != "value" {
+			return "", errors.New("invalid value")
+		}
+		return "converted", nil
+	}
+	modifier := convertStringFrom(path.Root("old_name"), convert)
+
+	req := planmodifier.StringRequest{
+		Config:      renamedAttributeConfig(&value, nil),
+		ConfigValue: types.StringNull(),
+		PlanValue:   types.StringUnknown(),
+	}
+	resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+	modifier.PlanModifyString(ctx, req, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Equal(t, types.StringValue("converted"), resp.PlanValue)
+
+	invalid := "invalid"
+	req.Config = renamedAttributeConfig(&invalid, nil)
+	resp = &planmodifier.StringResponse{PlanValue: req.PlanValue}
+	modifier.PlanModifyString(ctx, req, resp)
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, types.StringUnknown(), resp.PlanValue)
+}
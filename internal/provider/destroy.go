@@ -0,0 +1,55 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// The model, application, machine and integration resources share the
+// force_destroy and destroy_max_wait attributes, so partially broken
+// environments can be torn down. Changing them only updates the state.
+
+// forceDestroyAttribute returns the force_destroy attribute of the
+// resources of the given entity, dependents describing what a forced
+// destroy removes with it.
+func forceDestroyAttribute(entity, dependents string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: fmt.Sprintf("Force the destroy of the %s, ignoring the errors of its removal, e.g. "+
+			"unreachable agents or failing hooks. %sThe destroy succeeds if the %s was already removed, "+
+			"e.g. by the forced destroy of another resource. Defaults to false.", entity, dependents, entity),
+		Optional: true,
+	}
+}
+
+// destroyMaxWaitAttribute returns the destroy_max_wait attribute of the
+// resources of the given entity.
+func destroyMaxWaitAttribute(entity string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: fmt.Sprintf("How long each step of a forced destroy waits for the %s to be removed "+
+			"cleanly before forcing it, e.g. `5m`. Only used with `force_destroy`, defaults to the Juju default.", entity),
+		Optional: true,
+		Validators: []validator.String{
+			StringIsDurationValidator{},
+		},
+	}
+}
+
+// destroyOptions returns the options of the destroy of a resource from
+// its force_destroy and destroy_max_wait attributes. destroy_max_wait
+// is validated with the configuration.
+func destroyOptions(force types.Bool, maxWait types.String) juju.DestroyOptions {
+	options := juju.DestroyOptions{Force: force.ValueBool()}
+	if duration, err := time.ParseDuration(maxWait.ValueString()); err == nil {
+		options.MaxWait = duration
+	}
+	return options
+}
@@ -0,0 +1,56 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+func TestDestroyOptions(t *testing.T) {
+	assert.Equal(t, juju.DestroyOptions{}, destroyOptions(types.BoolNull(), types.StringNull()))
+	assert.Equal(t, juju.DestroyOptions{Force: true}, destroyOptions(types.BoolValue(true), types.StringNull()))
+	assert.Equal(t, juju.DestroyOptions{Force: true, MaxWait: 5 * time.Minute},
+		destroyOptions(types.BoolValue(true), types.StringValue("5m")))
+}
+
+func TestSameApplications(t *testing.T) {
+	ctx := context.Background()
+	appType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"name":      types.StringType,
+		"endpoint":  types.StringType,
+		"offer_url": types.StringType,
+	}}
+	newApps := func(t *testing.T, apps ...nestedApplication) types.Set {
+		set, diags := types.SetValueFrom(ctx, appType, apps)
+		require.False(t, diags.HasError(), diags)
+		return set
+	}
+	app := func(name, endpoint string) nestedApplication {
+		return nestedApplication{
+			Name:     types.StringValue(name),
+			Endpoint: types.StringValue(endpoint),
+			OfferURL: types.StringNull(),
+		}
+	}
+
+	state := newApps(t, app("wordpress", "db"), app("mysql", "db"))
+	assert.True(t, sameApplications(ctx, newApps(t, app("mysql", "db"), app("wordpress", "db")), state))
+
+	unknownEndpoint := app("mysql", "")
+	unknownEndpoint.Endpoint = types.StringUnknown()
+	assert.True(t, sameApplications(ctx, newApps(t, app("wordpress", "db"), unknownEndpoint), state))
+
+	assert.False(t, sameApplications(ctx, newApps(t, app("wordpress", "db"), app("postgresql", "db")), state))
+	assert.False(t, sameApplications(ctx, newApps(t, app("wordpress", "db"), app("mysql", "shared-db")), state))
+	assert.False(t, sameApplications(ctx, types.SetUnknown(appType), state))
+}
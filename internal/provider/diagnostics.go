@@ -0,0 +1,157 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/juju/errors"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/params"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Error codes of the client error diagnostics. They are part of the
+// diagnostic detail so that automation can match on them, hence must not
+// be changed.
+const (
+	ErrCodeUnauthorized  = "JUJU_UNAUTHORIZED"
+	ErrCodeNotFound      = "JUJU_NOT_FOUND"
+	ErrCodeAlreadyExists = "JUJU_ALREADY_EXISTS"
+	ErrCodeNotValid      = "JUJU_NOT_VALID"
+	ErrCodeTimeout       = "JUJU_TIMEOUT"
+	ErrCodeConnection    = "JUJU_CONNECTION"
+	ErrCodeUnavailable   = "JUJU_CONTROLLER_UNAVAILABLE"
+	ErrCodeUnknown       = "JUJU_ERROR"
+)
+
+// errorClass describes how an error returned by the Juju client is
+// reported to the user.
+type errorClass struct {
+	code    string
+	summary string
+	hint    string
+}
+
+var (
+	unauthorizedErrorClass = errorClass{
+		code:    ErrCodeUnauthorized,
+		summary: "Client Error: Unauthorized",
+		hint: "Check the credentials set on the provider and that the user has been granted " +
+			"access to the controller or model.",
+	}
+	notFoundErrorClass = errorClass{
+		code:    ErrCodeNotFound,
+		summary: "Client Error: Not Found",
+		hint: "The object may have been removed outside of Terraform. Run `terraform refresh` " +
+			"or remove it from the state with `terraform state rm`.",
+	}
+	alreadyExistsErrorClass = errorClass{
+		code:    ErrCodeAlreadyExists,
+		summary: "Client Error: Already Exists",
+		hint:    "Import the existing object with `terraform import` or use a different name.",
+	}
+	notValidErrorClass = errorClass{
+		code:    ErrCodeNotValid,
+		summary: "Client Error: Not Valid",
+		hint:    "Check the values set in the configuration.",
+	}
+	timeoutErrorClass = errorClass{
+		code:    ErrCodeTimeout,
+		summary: "Client Error: Timeout",
+		hint:    "The controller did not complete the operation in time. Check the status of the model and retry.",
+	}
+	connectionErrorClass = errorClass{
+		code:    ErrCodeConnection,
+		summary: "Client Error: Connection Failed",
+		hint: "Check that the controller is reachable and the controller_addresses and " +
+			"ca_certificate properties set on the provider.",
+	}
+	unavailableErrorClass = errorClass{
+		code:    ErrCodeUnavailable,
+		summary: "Client Error: Controller Unavailable",
+		hint: "The connections to the controller kept failing, so the remaining operations fail fast " +
+			"rather than each waiting for the connection timeout. Check that the controller is healthy " +
+			"and reachable, then run Terraform again.",
+	}
+	unknownErrorClass = errorClass{
+		code:    ErrCodeUnknown,
+		summary: "Client Error",
+	}
+)
+
+// classifyError returns the class of an error returned by the Juju
+// client, based on its juju/errors type or its API error code.
+func classifyError(err error) errorClass {
+	x509error := &x509.UnknownAuthorityError{}
+	netOpError := &net.OpError{}
+	switch {
+	case errors.Is(err, juju.ErrControllerUnavailable):
+		return unavailableErrorClass
+	case errors.Is(err, errors.Unauthorized), errors.Is(err, errors.Forbidden), params.IsCodeUnauthorized(err):
+		return unauthorizedErrorClass
+	case errors.Is(err, errors.NotFound), errors.Is(err, errors.UserNotFound), params.IsCodeNotFound(err):
+		return notFoundErrorClass
+	case errors.Is(err, errors.AlreadyExists), params.IsCodeAlreadyExists(err):
+		return alreadyExistsErrorClass
+	case errors.Is(err, errors.NotValid):
+		return notValidErrorClass
+	case errors.Is(err, errors.Timeout):
+		return timeoutErrorClass
+	case errors.As(err, x509error), errors.As(err, &netOpError), errors.Is(err, rpc.ErrShutdown):
+		return connectionErrorClass
+	default:
+		return unknownErrorClass
+	}
+}
+
+// addClientError adds an error diagnostic for an error returned by the
+// Juju client. The message describes the failed operation, e.g.
+// "Unable to create model", the summary and remediation hint depend on
+// the class of the error.
+func addClientError(diags *diag.Diagnostics, err error, format string, args ...interface{}) {
+	class := classifyError(err)
+	detail := fmt.Sprintf("%s, got error: %s", fmt.Sprintf(format, args...), err)
+	if class.hint != "" {
+		detail += "\n\n" + class.hint
+	}
+	detail += "\n\nError code: " + class.code
+	diags.AddError(class.summary, detail)
+}
+
+// handleReadError handles an error returned by the Juju client reading
+// a resource. A resource not found was removed outside of Terraform, it
+// is removed from state so that it is planned for creation rather than
+// failing the refresh. When the controller is unreachable and the
+// client keeps the state of resources then, the prior state in st is
+// kept with a warning. Other errors are added to diags as client errors.
+func handleReadError(ctx context.Context, client *juju.Client, err error, st *tfsdk.State, diags *diag.Diagnostics, format string, args ...interface{}) {
+	if classifyError(err).code == ErrCodeNotFound {
+		tflog.Warn(ctx, "Resource not found, removing it from state", map[string]interface{}{"error": err.Error()})
+		st.RemoveResource(ctx)
+		return
+	}
+	if client.KeepStateWhenUnreachable() && isUnreachableError(err) {
+		diags.AddWarning("Controller Unreachable", fmt.Sprintf("%s, got error: %s\n\nThe prior state is kept.",
+			fmt.Sprintf(format, args...), err))
+		return
+	}
+	addClientError(diags, err, format, args...)
+}
+
+// isUnreachableError reports whether err is returned because the
+// controller cannot be reached, rather than e.g. refusing the
+// credentials or certificate of the provider.
+func isUnreachableError(err error) bool {
+	netOpError := &net.OpError{}
+	return errors.Is(err, juju.ErrControllerUnavailable) || errors.As(err, &netOpError) ||
+		errors.Is(err, rpc.ErrShutdown) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,129 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/juju/errors"
+	"github.com/juju/juju/rpc/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		err  error
+		code string
+	}{
+		{errors.NotFoundf("model %q", "test"), ErrCodeNotFound},
+		{errors.Annotate(errors.UserNotFoundf("bob"), "reading user"), ErrCodeNotFound},
+		{&params.Error{Code: params.CodeNotFound, Message: "application not found"}, ErrCodeNotFound},
+		{fmt.Errorf("reading application: %w", juju.ApplicationNotFoundError), ErrCodeNotFound},
+		{errors.Unauthorizedf("access"), ErrCodeUnauthorized},
+		{&params.Error{Code: params.CodeUnauthorized, Message: "permission denied"}, ErrCodeUnauthorized},
+		{errors.AlreadyExistsf("model %q", "test"), ErrCodeAlreadyExists},
+		{&params.Error{Code: params.CodeAlreadyExists, Message: "user already exists"}, ErrCodeAlreadyExists},
+		{errors.NotValidf("empty command"), ErrCodeNotValid},
+		{errors.Timeoutf("waiting for tasks"), ErrCodeTimeout},
+		{fmt.Errorf("%w: 3 consecutive connection failures", juju.ErrControllerUnavailable), ErrCodeUnavailable},
+		{errors.New("boom"), ErrCodeUnknown},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.code, classifyError(test.err).code, test.err.Error())
+	}
+}
+
+func TestAddClientError(t *testing.T) {
+	var diags diag.Diagnostics
+	addClientError(&diags, errors.NotFoundf("model %q", "test"), "Unable to read model %q", "test")
+
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Client Error: Not Found", diags[0].Summary())
+	assert.Contains(t, diags[0].Detail(), `Unable to read model "test", got error: model "test" not found`)
+	assert.Contains(t, diags[0].Detail(), "Error code: "+ErrCodeNotFound)
+}
+
+func TestHandleReadError(t *testing.T) {
+	ctx := context.Background()
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true},
+		},
+	}
+	newState := func() tfsdk.State {
+		return tfsdk.State{
+			Schema: s,
+			Raw: tftypes.NewValue(s.Type().TerraformType(ctx), map[string]tftypes.Value{
+				"id": tftypes.NewValue(tftypes.String, "development"),
+			}),
+		}
+	}
+
+	var diags diag.Diagnostics
+	state := newState()
+	handleReadError(ctx, nil, errors.NotFoundf("model %q", "development"), &state, &diags, "Unable to read model")
+	assert.Empty(t, diags)
+	assert.True(t, state.Raw.IsNull())
+
+	state = newState()
+	handleReadError(ctx, nil, errors.New("boom"), &state, &diags, "Unable to read model")
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Client Error", diags[0].Summary())
+	assert.False(t, state.Raw.IsNull())
+}
+
+func TestHandleReadErrorUnreachable(t *testing.T) {
+	ctx := context.Background()
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true},
+		},
+	}
+	state := tfsdk.State{
+		Schema: s,
+		Raw: tftypes.NewValue(s.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"id": tftypes.NewValue(tftypes.String, "development"),
+		}),
+	}
+	unreachable := fmt.Errorf("%w: 3 consecutive connection failures", juju.ErrControllerUnavailable)
+
+	var diags diag.Diagnostics
+	handleReadError(ctx, nil, unreachable, &state, &diags, "Unable to read model")
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.SeverityError, diags[0].Severity())
+
+	client, err := juju.NewClient(ctx, juju.ControllerConfiguration{KeepStateWhenUnreachable: true})
+	require.NoError(t, err)
+	diags = nil
+	handleReadError(ctx, client, unreachable, &state, &diags, "Unable to read model")
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.SeverityWarning, diags[0].Severity())
+	assert.Equal(t, "Controller Unreachable", diags[0].Summary())
+	assert.False(t, state.Raw.IsNull())
+
+	// Other errors still fail the read.
+	diags = nil
+	handleReadError(ctx, client, errors.Unauthorizedf("access"), &state, &diags, "Unable to read model")
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.SeverityError, diags[0].Severity())
+}
+
+func TestIsUnreachableError(t *testing.T) {
+	assert.True(t, isUnreachableError(fmt.Errorf("%w: failing fast", juju.ErrControllerUnavailable)))
+	assert.True(t, isUnreachableError(errors.Annotate(&net.OpError{Op: "dial", Err: errors.New("connection refused")}, "connecting")))
+	assert.True(t, isUnreachableError(errors.Annotate(context.DeadlineExceeded, "connecting to controller")))
+	assert.False(t, isUnreachableError(x509.UnknownAuthorityError{}))
+	assert.False(t, isUnreachableError(errors.New("boom")))
+}
@@ -0,0 +1,86 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// experimentalFeatures are the experimental features of the provider,
+// with the resources and data sources they gate. Experimental resources
+// and data sources are registered like the others, as the provider
+// configuration is not known when they are, and call
+// checkExperimentalFeature from their Configure method.
+//
+// A feature graduates by removing it from this map and the check from
+// its resources, configurations enabling it are then warned about it.
+var experimentalFeatures = map[string]string{
+	modelMigrationFeature: "juju_model_migration",
+}
+
+// getExperimentalFeatures returns the experimental features enabled in
+// the provider configuration, falling back to the environment variable.
+// Unknown features are warned about and ignored.
+func getExperimentalFeatures(ctx context.Context, data jujuProviderModel) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var features []string
+	if !data.ExperimentalFeatures.IsNull() && !data.ExperimentalFeatures.IsUnknown() {
+		diags.Append(data.ExperimentalFeatures.ElementsAs(ctx, &features, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+	} else if value := getEnvVar(JujuExperimentalFeaturesEnvKey); value.ValueString() != "" {
+		for _, feature := range strings.Split(value.ValueString(), ",") {
+			if feature = strings.TrimSpace(feature); feature != "" {
+				features = append(features, feature)
+			}
+		}
+	}
+
+	var enabled []string
+	for _, feature := range features {
+		if _, ok := experimentalFeatures[feature]; !ok {
+			diags.AddAttributeWarning(path.Root(JujuExperimentalFeatures), "Unknown Experimental Feature",
+				fmt.Sprintf("Experimental feature %q is unknown and ignored, it may have been made generally available. "+
+					"Known experimental features: %s.", feature, knownExperimentalFeatures()))
+			continue
+		}
+		enabled = append(enabled, feature)
+	}
+	return enabled, diags
+}
+
+func knownExperimentalFeatures() string {
+	if len(experimentalFeatures) == 0 {
+		return "none"
+	}
+	known := make([]string, 0, len(experimentalFeatures))
+	for feature := range experimentalFeatures {
+		known = append(known, fmt.Sprintf("%q", feature))
+	}
+	sort.Strings(known)
+	return strings.Join(known, ", ")
+}
+
+// checkExperimentalFeature reports whether the experimental feature
+// gating the resource or data source typeName is enabled, adding an
+// error otherwise. client may be nil, before the provider is configured.
+func checkExperimentalFeature(client *juju.Client, feature, typeName string, diags *diag.Diagnostics) bool {
+	if client == nil || client.ExperimentalFeatureEnabled(feature) {
+		return true
+	}
+	diags.AddError("Experimental Feature Not Enabled",
+		fmt.Sprintf("%s is experimental, it may change in incompatible ways in any release. "+
+			"Enable it by adding %q to the %s attribute of the provider, or to the %s environment variable.",
+			typeName, feature, JujuExperimentalFeatures, JujuExperimentalFeaturesEnvKey))
+	return false
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+func setExperimentalFeatures(t *testing.T, features map[string]string) {
+	previous := experimentalFeatures
+	experimentalFeatures = features
+	t.Cleanup(func() { experimentalFeatures = previous })
+}
+
+func TestGetExperimentalFeatures(t *testing.T) {
+	setExperimentalFeatures(t, map[string]string{"roles": "juju_role"})
+	ctx := context.Background()
+
+	t.Setenv(JujuExperimentalFeaturesEnvKey, "roles, bundles")
+	features, diags := getExperimentalFeatures(ctx, jujuProviderModel{ExperimentalFeatures: types.SetNull(types.StringType)})
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, []string{"roles"}, features)
+	require.Len(t, diags.Warnings(), 1)
+	assert.Equal(t, `Experimental feature "bundles" is unknown and ignored, it may have been made generally available. `+
+		`Known experimental features: "roles".`, diags.Warnings()[0].Detail())
+
+	configured, diags := types.SetValueFrom(ctx, types.StringType, []string{})
+	require.False(t, diags.HasError(), diags)
+	features, diags = getExperimentalFeatures(ctx, jujuProviderModel{ExperimentalFeatures: configured})
+	require.False(t, diags.HasError(), diags)
+	assert.Empty(t, features)
+}
+
+func TestCheckExperimentalFeature(t *testing.T) {
+	var diags diag.Diagnostics
+	assert.True(t, checkExperimentalFeature(nil, "roles", "juju_role", &diags))
+
+	client, err := juju.NewClient(context.Background(), juju.ControllerConfiguration{ExperimentalFeatures: []string{"roles"}})
+	require.NoError(t, err)
+	assert.True(t, checkExperimentalFeature(client, "roles", "juju_role", &diags))
+	assert.False(t, checkExperimentalFeature(client, "bundles", "juju_bundle", &diags))
+	require.Len(t, diags.Errors(), 1)
+	assert.Equal(t, "Experimental Feature Not Enabled", diags.Errors()[0].Summary())
+}
@@ -0,0 +1,92 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/juju/juju/core/base"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &normalizeBaseFunction{}
+
+// normalizedBaseAttrTypes are the attributes of the object returned by
+// normalize_base.
+var normalizedBaseAttrTypes = map[string]attr.Type{
+	"base":    types.StringType,
+	"os":      types.StringType,
+	"channel": types.StringType,
+	"track":   types.StringType,
+	"risk":    types.StringType,
+}
+
+func NewNormalizeBaseFunction() function.Function {
+	return &normalizeBaseFunction{}
+}
+
+type normalizeBaseFunction struct{}
+
+// Metadata returns the name of the function as used in terraform plans.
+func (f *normalizeBaseFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_base"
+}
+
+// Definition returns the parameters and return type of the function.
+func (f *normalizeBaseFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Validate and normalize a base.",
+		MarkdownDescription: "Validates a base of the form `os@track[/risk]`, e.g. `ubuntu@22.04/stable`, and returns " +
+			"its parts. The `base` returned, e.g. `ubuntu@22.04`, is the form stored in the state of the " +
+			"`juju_application` and `juju_machine` resources. The risk defaults to `stable`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "base",
+				Description: "The base to normalize, e.g. ubuntu@22.04.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: normalizedBaseAttrTypes,
+		},
+	}
+}
+
+// Run validates the base and returns its normalized parts.
+func (f *normalizeBaseFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input string
+
+	resp.Error = req.Arguments.Get(ctx, &input)
+	if resp.Error != nil {
+		return
+	}
+
+	os, channel, ok := strings.Cut(strings.TrimSpace(input), "@")
+	if !ok || os == "" {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid base %q: must conform to os@channel, e.g. ubuntu@22.04", input))
+		return
+	}
+	b, err := base.ParseBase(os, channel)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid base %q: %s", input, err))
+		return
+	}
+
+	result, diags := types.ObjectValue(normalizedBaseAttrTypes, map[string]attr.Value{
+		"base":    types.StringValue(fmt.Sprintf("%s@%s", b.OS, b.Channel.Track)),
+		"os":      types.StringValue(b.OS),
+		"channel": types.StringValue(b.Channel.String()),
+		"track":   types.StringValue(b.Channel.Track),
+		"risk":    types.StringValue(string(b.Channel.Risk)),
+	})
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, result)
+}
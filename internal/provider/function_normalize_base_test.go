@@ -0,0 +1,71 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runNormalizeBase(t *testing.T, input string) *function.RunResponse {
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(input)}),
+	}
+	resp := &function.RunResponse{
+		Result: function.NewResultData(types.ObjectUnknown(normalizedBaseAttrTypes)),
+	}
+	NewNormalizeBaseFunction().Run(context.Background(), req, resp)
+	return resp
+}
+
+func TestNormalizeBase(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected map[string]string
+	}{{
+		input: "ubuntu@22.04",
+		expected: map[string]string{
+			"base": "ubuntu@22.04", "os": "ubuntu", "channel": "22.04/stable", "track": "22.04", "risk": "stable",
+		},
+	}, {
+		input: "Ubuntu@24.04/edge",
+		expected: map[string]string{
+			"base": "ubuntu@24.04", "os": "ubuntu", "channel": "24.04/edge", "track": "24.04", "risk": "edge",
+		},
+	}, {
+		input: " centos@7/stable ",
+		expected: map[string]string{
+			"base": "centos@7", "os": "centos", "channel": "7/stable", "track": "7", "risk": "stable",
+		},
+	}}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			resp := runNormalizeBase(t, test.input)
+			require.Nil(t, resp.Error)
+
+			expected := map[string]attr.Value{}
+			for k, v := range test.expected {
+				expected[k] = types.StringValue(v)
+			}
+			assert.Equal(t, function.NewResultData(types.ObjectValueMust(normalizedBaseAttrTypes, expected)), resp.Result)
+		})
+	}
+}
+
+func TestNormalizeBaseInvalid(t *testing.T) {
+	for _, input := range []string{"", "ubuntu", "@22.04", "ubuntu@", "ubuntu@22.04/unstable", "ubuntu@22.04/stable/x"} {
+		t.Run(input, func(t *testing.T) {
+			resp := runNormalizeBase(t, input)
+			require.NotNil(t, resp.Error)
+			require.NotNil(t, resp.Error.FunctionArgument)
+			assert.Equal(t, int64(0), *resp.Error.FunctionArgument)
+		})
+	}
+}
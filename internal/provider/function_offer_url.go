@@ -0,0 +1,81 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/names/v5"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &offerURLFunction{}
+
+func NewOfferURLFunction() function.Function {
+	return &offerURLFunction{}
+}
+
+type offerURLFunction struct{}
+
+// Metadata returns the name of the function as used in terraform plans.
+func (f *offerURLFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "offer_url"
+}
+
+// Definition returns the parameters and return type of the function.
+func (f *offerURLFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build the URL of an offer.",
+		MarkdownDescription: "Returns the URL of an offer, `<owner>/<model>.<offer>`, as used by the `url` of the " +
+			"`juju_offer` data source and the `offer_url` of the `juju_integration` resource, failing if a part " +
+			"is not valid.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "owner",
+				Description: "The owner of the model of the offer, e.g. admin.",
+			},
+			function.StringParameter{
+				Name:        "model",
+				Description: "The name of the model of the offer.",
+			},
+			function.StringParameter{
+				Name:        "offer",
+				Description: "The name of the offer.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run validates the parts of the offer URL and returns it.
+func (f *offerURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var owner, model, offer string
+
+	resp.Error = req.Arguments.Get(ctx, &owner, &model, &offer)
+	if resp.Error != nil {
+		return
+	}
+
+	switch {
+	case !names.IsValidUser(owner):
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid owner %q", owner))
+	case !names.IsValidModelName(model):
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Invalid model name %q", model))
+	case !names.IsValidApplication(offer):
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("Invalid offer name %q", offer))
+	}
+	if resp.Error != nil {
+		return
+	}
+
+	url := crossmodel.MakeURL(owner, model, offer, "")
+	if _, err := crossmodel.ParseOfferURL(url); err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Invalid offer URL %q: %s", url, err))
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, url)
+}
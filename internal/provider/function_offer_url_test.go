@@ -0,0 +1,60 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runOfferURL(owner, model, offer string) *function.RunResponse {
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{
+			types.StringValue(owner), types.StringValue(model), types.StringValue(offer),
+		}),
+	}
+	resp := &function.RunResponse{
+		Result: function.NewResultData(types.StringUnknown()),
+	}
+	NewOfferURLFunction().Run(context.Background(), req, resp)
+	return resp
+}
+
+func TestOfferURL(t *testing.T) {
+	resp := runOfferURL("admin", "database", "postgresql")
+	require.Nil(t, resp.Error)
+	assert.Equal(t, function.NewResultData(types.StringValue("admin/database.postgresql")), resp.Result)
+
+	resp = runOfferURL("alice@canonical.com", "database", "postgresql")
+	require.Nil(t, resp.Error)
+	assert.Equal(t, function.NewResultData(types.StringValue("alice@canonical.com/database.postgresql")), resp.Result)
+}
+
+func TestOfferURLInvalid(t *testing.T) {
+	tests := []struct {
+		owner, model, offer string
+		argument            int64
+	}{
+		{"", "database", "postgresql", 0},
+		{"admin/x", "database", "postgresql", 0},
+		{"admin", "Database", "postgresql", 1},
+		{"admin", "data.base", "postgresql", 1},
+		{"admin", "database", "", 2},
+		{"admin", "database", "postgresql:db", 2},
+	}
+	for _, test := range tests {
+		t.Run(test.owner+"/"+test.model+"."+test.offer, func(t *testing.T) {
+			resp := runOfferURL(test.owner, test.model, test.offer)
+			require.NotNil(t, resp.Error)
+			require.NotNil(t, resp.Error.FunctionArgument)
+			assert.Equal(t, test.argument, *resp.Error.FunctionArgument)
+		})
+	}
+}
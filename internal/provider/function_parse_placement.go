@@ -0,0 +1,130 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/juju/juju/core/instance"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &parsePlacementFunction{}
+
+// Scopes of the placement directives returned by parse_placement, in
+// addition to the container types.
+const (
+	placementScopeMachine = "machine"
+	placementScopeModel   = "model"
+)
+
+// parsedPlacementAttrTypes are the attributes of the object returned by
+// parse_placement.
+var parsedPlacementAttrTypes = map[string]attr.Type{
+	"placement":      types.StringType,
+	"scope":          types.StringType,
+	"directive":      types.StringType,
+	"machine":        types.StringType,
+	"container_type": types.StringType,
+}
+
+func NewParsePlacementFunction() function.Function {
+	return &parsePlacementFunction{}
+}
+
+type parsePlacementFunction struct{}
+
+// Metadata returns the name of the function as used in terraform plans.
+func (f *parsePlacementFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_placement"
+}
+
+// Definition returns the parameters and return type of the function.
+func (f *parsePlacementFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Validate and parse a placement directive.",
+		MarkdownDescription: "Validates a placement directive, e.g. `3`, `lxd:3` or `zone=us-east-1a`, and returns its parts. " +
+			"The `scope` is `machine` for a machine, the container type, e.g. `lxd`, for a container, `model` for a " +
+			"directive to the provider of the model, e.g. `zone=us-east-1a`, or else the scope given, e.g. the UUID " +
+			"of a model. `machine` is the machine, or the machine hosting the container, if any. `placement` is the " +
+			"directive as used by the `juju_application` and `juju_machine` resources.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "placement",
+				Description: "The placement directive to parse, e.g. lxd:3.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: parsedPlacementAttrTypes,
+		},
+	}
+}
+
+// Run validates the placement directive and returns its parts.
+func (f *parsePlacementFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input string
+
+	resp.Error = req.Arguments.Get(ctx, &input)
+	if resp.Error != nil {
+		return
+	}
+	if input == "" {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid placement: must not be empty")
+		return
+	}
+
+	parts := map[string]string{
+		"placement":      input,
+		"scope":          "",
+		"directive":      "",
+		"machine":        "",
+		"container_type": "",
+	}
+	placement, err := instance.ParsePlacement(input)
+	switch {
+	case err == instance.ErrPlacementScopeMissing && !strings.Contains(input, ":"):
+		// As the juju_machine resource, consider the directive is to
+		// the provider of the model.
+		parts["scope"] = placementScopeModel
+		parts["directive"] = input
+	case err != nil:
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid placement %q: %s", input, err))
+		return
+	case placement.Scope == instance.MachineScope:
+		parts["scope"] = placementScopeMachine
+		parts["directive"] = placement.Directive
+		parts["machine"] = placement.Directive
+	case isContainerScope(placement.Scope):
+		parts["scope"] = placement.Scope
+		parts["directive"] = placement.Directive
+		parts["machine"] = placement.Directive
+		parts["container_type"] = placement.Scope
+	default:
+		parts["scope"] = placement.Scope
+		parts["directive"] = placement.Directive
+	}
+
+	values := make(map[string]attr.Value, len(parts))
+	for k, v := range parts {
+		values[k] = types.StringValue(v)
+	}
+	result, diags := types.ObjectValue(parsedPlacementAttrTypes, values)
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, result)
+}
+
+// isContainerScope returns whether the scope of a placement directive is
+// a container type.
+func isContainerScope(scope string) bool {
+	_, err := instance.ParseContainerType(scope)
+	return err == nil
+}
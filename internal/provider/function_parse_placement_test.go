@@ -0,0 +1,74 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runParsePlacement(input string) *function.RunResponse {
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(input)}),
+	}
+	resp := &function.RunResponse{
+		Result: function.NewResultData(types.ObjectUnknown(parsedPlacementAttrTypes)),
+	}
+	NewParsePlacementFunction().Run(context.Background(), req, resp)
+	return resp
+}
+
+func TestParsePlacement(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected map[string]string
+	}{{
+		input:    "3",
+		expected: map[string]string{"scope": "machine", "directive": "3", "machine": "3", "container_type": ""},
+	}, {
+		input:    "lxd:3",
+		expected: map[string]string{"scope": "lxd", "directive": "3", "machine": "3", "container_type": "lxd"},
+	}, {
+		input:    "lxd",
+		expected: map[string]string{"scope": "lxd", "directive": "", "machine": "", "container_type": "lxd"},
+	}, {
+		input:    "3/lxd/1",
+		expected: map[string]string{"scope": "machine", "directive": "3/lxd/1", "machine": "3/lxd/1", "container_type": ""},
+	}, {
+		input:    "zone=us-east-1a",
+		expected: map[string]string{"scope": "model", "directive": "zone=us-east-1a", "machine": "", "container_type": ""},
+	}, {
+		input:    "maas:node-1",
+		expected: map[string]string{"scope": "maas", "directive": "node-1", "machine": "", "container_type": ""},
+	}}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			resp := runParsePlacement(test.input)
+			require.Nil(t, resp.Error)
+
+			expected := map[string]attr.Value{"placement": types.StringValue(test.input)}
+			for k, v := range test.expected {
+				expected[k] = types.StringValue(v)
+			}
+			assert.Equal(t, function.NewResultData(types.ObjectValueMust(parsedPlacementAttrTypes, expected)), resp.Result)
+		})
+	}
+}
+
+func TestParsePlacementInvalid(t *testing.T) {
+	for _, input := range []string{"", "lxd:", "lxd:x", ":3"} {
+		t.Run(input, func(t *testing.T) {
+			resp := runParsePlacement(input)
+			require.NotNil(t, resp.Error)
+			require.NotNil(t, resp.Error.FunctionArgument)
+			assert.Equal(t, int64(0), *resp.Error.FunctionArgument)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/juju/names/v5"
+	"github.com/juju/utils/v3"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &tagFunction{}
+
+// groupTagKind is the kind of the tags of the groups of JAAS, which
+// are not Juju entities.
+const groupTagKind = "group"
+
+func NewUserTagFunction() function.Function {
+	return &tagFunction{
+		name:        "user_tag",
+		entity:      "user",
+		parameter:   "name",
+		description: "The name of the user, e.g. alice or alice@canonical.com.",
+		tag: func(id string) (string, bool) {
+			if !names.IsValidUser(id) {
+				return "", false
+			}
+			return names.NewUserTag(id).String(), true
+		},
+	}
+}
+
+func NewGroupTagFunction() function.Function {
+	return &tagFunction{
+		name:        "group_tag",
+		entity:      "JAAS group",
+		parameter:   "uuid",
+		description: "The UUID of the group.",
+		tag: func(id string) (string, bool) {
+			if !utils.IsValidUUIDString(id) {
+				return "", false
+			}
+			return groupTagKind + "-" + id, true
+		},
+	}
+}
+
+func NewModelTagFunction() function.Function {
+	return &tagFunction{
+		name:        "model_tag",
+		entity:      "model",
+		parameter:   "uuid",
+		description: "The UUID of the model.",
+		tag: func(id string) (string, bool) {
+			if !names.IsValidModel(id) {
+				return "", false
+			}
+			return names.NewModelTag(id).String(), true
+		},
+	}
+}
+
+func NewControllerTagFunction() function.Function {
+	return &tagFunction{
+		name:        "controller_tag",
+		entity:      "controller",
+		parameter:   "uuid",
+		description: "The UUID of the controller.",
+		tag: func(id string) (string, bool) {
+			if !names.IsValidController(id) {
+				return "", false
+			}
+			return names.NewControllerTag(id).String(), true
+		},
+	}
+}
+
+func NewCloudTagFunction() function.Function {
+	return &tagFunction{
+		name:        "cloud_tag",
+		entity:      "cloud",
+		parameter:   "name",
+		description: "The name of the cloud.",
+		tag: func(id string) (string, bool) {
+			if !names.IsValidCloud(id) {
+				return "", false
+			}
+			return names.NewCloudTag(id).String(), true
+		},
+	}
+}
+
+// tagFunction returns the tag of an entity, e.g. user-alice, as used in
+// the relationship tuples of JAAS and in some import IDs, validating
+// the identifier of the entity.
+type tagFunction struct {
+	name   string
+	entity string
+
+	parameter   string
+	description string
+
+	// tag returns the tag of the entity identified by id, false if id
+	// is not valid.
+	tag func(id string) (string, bool)
+}
+
+// Metadata returns the name of the function as used in terraform plans.
+func (f *tagFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = f.name
+}
+
+// Definition returns the parameters and return type of the function.
+func (f *tagFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     fmt.Sprintf("Build the tag of a %s.", f.entity),
+		Description: fmt.Sprintf("Returns the tag of a %s, failing if its %s is not valid.", f.entity, f.parameter),
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        f.parameter,
+				Description: f.description,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run validates the identifier and returns the tag.
+func (f *tagFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+
+	resp.Error = req.Arguments.Get(ctx, &id)
+	if resp.Error != nil {
+		return
+	}
+
+	tag, ok := f.tag(id)
+	if !ok {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid %s %s %q", f.entity, f.parameter, id))
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, tag)
+}
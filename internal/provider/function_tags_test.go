@@ -0,0 +1,71 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runTagFunction(f function.Function, id string) *function.RunResponse {
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(id)}),
+	}
+	resp := &function.RunResponse{
+		Result: function.NewResultData(types.StringUnknown()),
+	}
+	f.Run(context.Background(), req, resp)
+	return resp
+}
+
+func TestTagFunctions(t *testing.T) {
+	const uuid = "8e5b4a3c-4e3b-4c2a-9a8b-2f6c8d0e1f2a"
+	tests := []struct {
+		function func() function.Function
+		id       string
+		expected string
+	}{
+		{NewUserTagFunction, "alice", "user-alice"},
+		{NewUserTagFunction, "alice@canonical.com", "user-alice@canonical.com"},
+		{NewGroupTagFunction, uuid, "group-" + uuid},
+		{NewModelTagFunction, uuid, "model-" + uuid},
+		{NewControllerTagFunction, uuid, "controller-" + uuid},
+		{NewCloudTagFunction, "localhost", "cloud-localhost"},
+	}
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			resp := runTagFunction(test.function(), test.id)
+			require.Nil(t, resp.Error)
+			assert.Equal(t, function.NewResultData(types.StringValue(test.expected)), resp.Result)
+		})
+	}
+}
+
+func TestTagFunctionsInvalid(t *testing.T) {
+	tests := []struct {
+		function func() function.Function
+		id       string
+	}{
+		{NewUserTagFunction, "alice!"},
+		{NewUserTagFunction, ""},
+		{NewGroupTagFunction, "admins"},
+		{NewModelTagFunction, "my-model"},
+		{NewControllerTagFunction, "not-a-uuid"},
+		{NewCloudTagFunction, "local host"},
+	}
+	for _, test := range tests {
+		t.Run(test.id, func(t *testing.T) {
+			resp := runTagFunction(test.function(), test.id)
+			require.NotNil(t, resp.Error)
+			require.NotNil(t, resp.Error.FunctionArgument)
+			assert.Equal(t, int64(0), *resp.Error.FunctionArgument)
+		})
+	}
+}
@@ -4,10 +4,13 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/juju/collections/set"
 )
 
 // model names for logging
@@ -16,21 +19,47 @@ import (
 //
 //	@module=juju.resource-application
 const (
-	LogDataSourceMachine = "datasource-machine"
-	LogDataSourceModel   = "datasource-model"
-	LogDataSourceOffer   = "datasource-offer"
-	LogDataSourceSecret  = "datasource-secret"
-
-	LogResourceApplication  = "resource-application"
-	LogResourceAccessModel  = "resource-assess-model"
-	LogResourceCredential   = "resource-credential"
-	LogResourceMachine      = "resource-machine"
-	LogResourceModel        = "resource-model"
-	LogResourceOffer        = "resource-offer"
-	LogResourceSSHKey       = "resource-sshkey"
-	LogResourceUser         = "resource-user"
-	LogResourceSecret       = "resource-secret"
-	LogResourceAccessSecret = "resource-access-secret"
+	LogDataSourceAgentVersions  = "datasource-agent-versions"
+	LogDataSourceApplications   = "datasource-applications"
+	LogDataSourceBundleDiff     = "datasource-bundle-diff"
+	LogDataSourceCharmResources = "datasource-charm-resources"
+	LogDataSourceCharmRevision  = "datasource-charm-revision"
+	LogDataSourceCredentials    = "datasource-credentials"
+	LogDataSourceFullStatus     = "datasource-full-status"
+	LogDataSourceMachine        = "datasource-machine"
+	LogDataSourceModel          = "datasource-model"
+	LogDataSourceModelConfig    = "datasource-model-config"
+	LogDataSourceModels         = "datasource-models"
+	LogDataSourceOffer          = "datasource-offer"
+	LogDataSourceSecret         = "datasource-secret"
+	LogDataSourceSecretBackends = "datasource-secret-backends"
+	LogDataSourceSpaces         = "datasource-spaces"
+	LogDataSourceStoragePools   = "datasource-storage-pools"
+	LogDataSourceUnit           = "datasource-unit"
+	LogDataSourceUsers          = "datasource-users"
+	LogDataSourceWaitFor        = "datasource-wait-for"
+
+	LogResourceAnnotations       = "resource-annotations"
+	LogResourceApplication       = "resource-application"
+	LogResourceApplicationConfig = "resource-application-config"
+	LogResourceApplicationExpose = "resource-application-expose"
+	LogResourceAccessModel       = "resource-assess-model"
+	LogResourceBackup            = "resource-backup"
+	LogResourceBundle            = "resource-bundle"
+	LogResourceCharmResource     = "resource-charm-resource"
+	LogResourceCredential        = "resource-credential"
+	LogResourceExec              = "resource-exec"
+	LogResourceFirewallRule      = "resource-firewall-rule"
+	LogResourceMachine           = "resource-machine"
+	LogResourceModel             = "resource-model"
+	LogResourceModelConfig       = "resource-model-config"
+	LogResourceModelDefaults     = "resource-model-defaults"
+	LogResourceModelMigration    = "resource-model-migration"
+	LogResourceOffer             = "resource-offer"
+	LogResourceSSHKey            = "resource-sshkey"
+	LogResourceUser              = "resource-user"
+	LogResourceSecret            = "resource-secret"
+	LogResourceAccessSecret      = "resource-access-secret"
 )
 
 const LogResourceIntegration = "resource-integration"
@@ -55,3 +84,49 @@ func intPtr(value types.Int64) *int {
 	count := int(value.ValueInt64())
 	return &count
 }
+
+// stringCollection is a types.Set or a types.List of strings.
+type stringCollection interface {
+	IsNull() bool
+	IsUnknown() bool
+	Elements() []attr.Value
+	ElementsAs(ctx context.Context, target interface{}, allowUnhandled bool) diag.Diagnostics
+}
+
+// diffSet returns the elements of plan which are not in state, and
+// those of state which are not in plan, sorted. Both are sets or lists
+// of strings, used as sets. A null collection has no elements. known is
+// false, and the diff empty, when either collection or any of their
+// elements is unknown, as the diff is then unknown too.
+func diffSet(ctx context.Context, plan, state stringCollection) (added, removed []string, known bool, diags diag.Diagnostics) {
+	planElements, known, diags := setElements(ctx, plan)
+	if !known || diags.HasError() {
+		return nil, nil, known, diags
+	}
+	stateElements, known, diags := setElements(ctx, state)
+	if !known || diags.HasError() {
+		return nil, nil, known, diags
+	}
+	return planElements.Difference(stateElements).SortedValues(),
+		stateElements.Difference(planElements).SortedValues(), true, diags
+}
+
+// setElements returns the set of the elements of s, known false if s
+// or any of its elements is unknown.
+func setElements(ctx context.Context, s stringCollection) (set.Strings, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if s.IsUnknown() {
+		return nil, false, diags
+	}
+	if s.IsNull() {
+		return set.NewStrings(), true, diags
+	}
+	for _, element := range s.Elements() {
+		if element.IsUnknown() {
+			return nil, false, diags
+		}
+	}
+	var elements []string
+	diags.Append(s.ElementsAs(ctx, &elements, false)...)
+	return set.NewStrings(elements...), true, diags
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stringSet(values ...string) types.Set {
+	elements := make([]attr.Value, 0, len(values))
+	for _, v := range values {
+		elements = append(elements, types.StringValue(v))
+	}
+	return types.SetValueMust(types.StringType, elements)
+}
+
+func TestDiffSet(t *testing.T) {
+	ctx := context.Background()
+
+	added, removed, known, diags := diffSet(ctx, stringSet("alice", "bob", "carol"), stringSet("dave", "bob", "alice"))
+	require.False(t, diags.HasError())
+	assert.True(t, known)
+	assert.Equal(t, []string{"carol"}, added)
+	assert.Equal(t, []string{"dave"}, removed)
+
+	added, removed, known, diags = diffSet(ctx, stringSet("bob", "alice"), stringSet("alice", "bob"))
+	require.False(t, diags.HasError())
+	assert.True(t, known)
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+}
+
+func TestDiffSetNull(t *testing.T) {
+	ctx := context.Background()
+
+	added, removed, known, diags := diffSet(ctx, stringSet("alice"), types.SetNull(types.StringType))
+	require.False(t, diags.HasError())
+	assert.True(t, known)
+	assert.Equal(t, []string{"alice"}, added)
+	assert.Empty(t, removed)
+
+	added, removed, known, diags = diffSet(ctx, types.SetNull(types.StringType), stringSet("alice"))
+	require.False(t, diags.HasError())
+	assert.True(t, known)
+	assert.Empty(t, added)
+	assert.Equal(t, []string{"alice"}, removed)
+}
+
+func TestDiffSetUnknown(t *testing.T) {
+	ctx := context.Background()
+
+	added, removed, known, diags := diffSet(ctx, types.SetUnknown(types.StringType), stringSet("alice"))
+	require.False(t, diags.HasError())
+	assert.False(t, known)
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+
+	unknownElement := types.SetValueMust(types.StringType, []attr.Value{
+		types.StringValue("alice"), types.StringUnknown(),
+	})
+	added, removed, known, diags = diffSet(ctx, unknownElement, stringSet("alice", "bob"))
+	require.False(t, diags.HasError())
+	assert.False(t, known)
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+}
+
+func TestDiffSetList(t *testing.T) {
+	ctx := context.Background()
+
+	plan := types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("mysql"), types.StringValue("wordpress"), types.StringValue("mysql"),
+	})
+	state := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("ubuntu")})
+	added, removed, known, diags := diffSet(ctx, plan, state)
+	require.False(t, diags.HasError())
+	assert.True(t, known)
+	assert.Equal(t, []string{"mysql", "wordpress"}, added)
+	assert.Equal(t, []string{"ubuntu"}, removed)
+}
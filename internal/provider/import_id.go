@@ -0,0 +1,170 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// idFormat is the format of the ID of a resource, made of parts
+// separated by colons, e.g. <model_name>:<application_name>. Resources are
+// imported with their ID, so the format documents the import syntax.
+// Models are referenced by name, by owner qualified name, as in
+// admin/development, or by UUID, none of which contain colons.
+type idFormat struct {
+	// prefix is the literal first part of the ID, if any.
+	prefix string
+	// prefixAliases are other prefixes accepted when parsing, for
+	// compatibility with documented import IDs.
+	prefixAliases []string
+	// parts are the names of the parts of the ID.
+	parts []string
+	// optional are the names of the parts which may follow parts, and
+	// may be empty.
+	optional []string
+}
+
+// String returns the format as documented, e.g.
+// <model_name>:<application_name>.
+func (f idFormat) String() string {
+	var parts []string
+	if f.prefix != "" {
+		parts = append(parts, f.prefix)
+	}
+	for _, p := range f.parts {
+		parts = append(parts, "<"+p+">")
+	}
+	for _, p := range f.optional {
+		parts = append(parts, "[<"+p+">]")
+	}
+	return strings.Join(parts, ":")
+}
+
+// format returns the ID made of the given parts.
+func (f idFormat) format(parts ...string) string {
+	if f.prefix != "" {
+		parts = append([]string{f.prefix}, parts...)
+	}
+	return strings.Join(parts, ":")
+}
+
+// parse returns the parts of id, without the prefix. Optional parts
+// missing from id are returned empty.
+func (f idFormat) parse(id string) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	malformed := func(reason string) ([]string, diag.Diagnostics) {
+		diags.AddError("Malformed ID",
+			fmt.Sprintf("ID %q is malformed, %s. Please use the format %q.", id, reason, f.String()))
+		return nil, diags
+	}
+
+	values := strings.Split(id, ":")
+	if f.prefix != "" {
+		if values[0] != f.prefix && !slices.Contains(f.prefixAliases, values[0]) {
+			return malformed(fmt.Sprintf("expected it to start with %q", f.prefix+":"))
+		}
+		values = values[1:]
+	}
+	if len(values) < len(f.parts) || len(values) > len(f.parts)+len(f.optional) {
+		expected := fmt.Sprintf("%d", len(f.parts))
+		if len(f.optional) > 0 {
+			expected = fmt.Sprintf("%d to %d", len(f.parts), len(f.parts)+len(f.optional))
+		}
+		return malformed(fmt.Sprintf("expected %s colon separated parts, got %d", expected, len(values)))
+	}
+	for i, name := range f.parts {
+		if values[i] == "" {
+			return malformed(fmt.Sprintf("the %s is empty", name))
+		}
+	}
+	for len(values) < len(f.parts)+len(f.optional) {
+		values = append(values, "")
+	}
+	return values, diags
+}
+
+// importState validates the ID being imported before setting it as
+// the id attribute of the resource, so that a malformed ID is reported
+// with the expected format rather than failing the following read.
+// Resources imported with their identity are imported with the ID made
+// of it.
+func (f idFormat) importState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, diags := f.importID(ctx, req)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if _, diags := f.parse(id); diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// identitySchema returns the schema of the identity of the resources
+// with IDs of this format, made of the parts of the ID, so that
+// resources are imported with their identity as with their ID.
+func (f idFormat) identitySchema() identityschema.Schema {
+	attributes := make(map[string]identityschema.Attribute, len(f.parts)+len(f.optional))
+	for _, p := range f.parts {
+		attributes[p] = identityschema.StringAttribute{RequiredForImport: true}
+	}
+	for _, p := range f.optional {
+		attributes[p] = identityschema.StringAttribute{OptionalForImport: true}
+	}
+	return identityschema.Schema{Attributes: attributes}
+}
+
+// setIdentity sets the identity of a resource from its ID. Empty
+// optional parts are null. Nothing is set when the resource has no ID
+// yet.
+func (f idFormat) setIdentity(ctx context.Context, id types.String, identity *tfsdk.ResourceIdentity) diag.Diagnostics {
+	if identity == nil || id.IsNull() || id.IsUnknown() {
+		return nil
+	}
+	values, diags := f.parse(id.ValueString())
+	if diags.HasError() {
+		return diags
+	}
+	for i, name := range append(slices.Clone(f.parts), f.optional...) {
+		value := types.StringValue(values[i])
+		if values[i] == "" {
+			value = types.StringNull()
+		}
+		diags.Append(identity.SetAttribute(ctx, path.Root(name), value)...)
+	}
+	return diags
+}
+
+// importID returns the ID being imported, made of the identity of the
+// resource when imported with it, e.g. by an import block with an
+// identity, rather than with its ID.
+func (f idFormat) importID(ctx context.Context, req resource.ImportStateRequest) (string, diag.Diagnostics) {
+	if req.ID != "" || req.Identity == nil {
+		return req.ID, nil
+	}
+	var diags diag.Diagnostics
+	names := append(slices.Clone(f.parts), f.optional...)
+	values := make([]string, len(names))
+	for i, name := range names {
+		var value types.String
+		diags.Append(req.Identity.GetAttribute(ctx, path.Root(name), &value)...)
+		values[i] = value.ValueString()
+	}
+	// Empty optional parts are left out of the ID.
+	for len(values) > len(f.parts) && values[len(values)-1] == "" {
+		values = values[:len(values)-1]
+	}
+	return f.format(values...), diags
+}
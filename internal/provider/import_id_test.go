@@ -0,0 +1,128 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDFormatString(t *testing.T) {
+	assert.Equal(t, "<model_name>:<application_name>", applicationIDFormat.String())
+	assert.Equal(t, "<model_name>:<machine_id>:[<machine_name>]", machineIDFormat.String())
+	assert.Equal(t, "user:<user_name>", userIDFormat.String())
+}
+
+func TestIDFormatParse(t *testing.T) {
+	parts, diags := applicationIDFormat.parse("admin/development:wordpress")
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, []string{"admin/development", "wordpress"}, parts)
+
+	parts, diags = machineIDFormat.parse("development:0/lxd/1")
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, []string{"development", "0/lxd/1", ""}, parts)
+
+	parts, diags = sshKeyIDFormat.parse("ssh_key:development:dev-user")
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, []string{"development", "dev-user"}, parts)
+}
+
+func TestIDFormatParseMalformed(t *testing.T) {
+	tests := []struct {
+		format idFormat
+		id     string
+		reason string
+	}{{
+		format: applicationIDFormat,
+		id:     "development",
+		reason: "expected 2 colon separated parts, got 1",
+	}, {
+		format: applicationIDFormat,
+		id:     "development:",
+		reason: "the application_name is empty",
+	}, {
+		format: annotationsIDFormat,
+		id:     "development:application-wordpress:extra",
+		reason: "expected 1 to 2 colon separated parts, got 3",
+	}, {
+		format: userIDFormat,
+		id:     "users:dev-user",
+		reason: `expected it to start with "user:"`,
+	}}
+	for _, test := range tests {
+		_, diags := test.format.parse(test.id)
+		require.True(t, diags.HasError(), test.id)
+		assert.Equal(t, "Malformed ID", diags[0].Summary())
+		assert.Contains(t, diags[0].Detail(), test.reason)
+		assert.Contains(t, diags[0].Detail(), test.format.String())
+	}
+}
+
+func TestIDFormatRoundTrip(t *testing.T) {
+	id := newCredentialIDFrom("creddev", "localhost", false, true)
+	assert.Equal(t, "creddev:localhost:false:true", id)
+
+	var diags diag.Diagnostics
+	name, cloud, client, controller := retrieveCredentialDataFromID(id, &diags, "read")
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, "creddev", name)
+	assert.Equal(t, "localhost", cloud)
+	assert.False(t, client)
+	assert.True(t, controller)
+
+	assert.Equal(t, "sshkey:development:dev-user", newSSHKeyID("development", "dev-user"))
+	assert.Equal(t, "development", newAnnotationsID("development", ""))
+}
+
+func TestIDFormatIdentity(t *testing.T) {
+	ctx := context.Background()
+	schema := machineIDFormat.identitySchema()
+	assert.True(t, schema.Attributes["model_name"].IsRequiredForImport())
+	assert.True(t, schema.Attributes["machine_name"].IsOptionalForImport())
+
+	identity := &tfsdk.ResourceIdentity{
+		Schema: schema,
+		Raw:    tftypes.NewValue(schema.Type().TerraformType(ctx), nil),
+	}
+	diags := machineIDFormat.setIdentity(ctx, types.StringValue("development:0/lxd/1"), identity)
+	require.False(t, diags.HasError(), diags)
+	var name types.String
+	require.False(t, identity.GetAttribute(ctx, path.Root("machine_id"), &name).HasError())
+	assert.Equal(t, "0/lxd/1", name.ValueString())
+	require.False(t, identity.GetAttribute(ctx, path.Root("machine_name"), &name).HasError())
+	assert.True(t, name.IsNull())
+
+	id, diags := machineIDFormat.importID(ctx, resource.ImportStateRequest{Identity: identity})
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, "development:0/lxd/1", id)
+
+	// The ID takes precedence over the identity.
+	id, diags = machineIDFormat.importID(ctx, resource.ImportStateRequest{ID: "development:1", Identity: identity})
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, "development:1", id)
+}
+
+func TestIDFormatIdentityPrefix(t *testing.T) {
+	ctx := context.Background()
+	schema := sshKeyIDFormat.identitySchema()
+	identity := &tfsdk.ResourceIdentity{
+		Schema: schema,
+		Raw:    tftypes.NewValue(schema.Type().TerraformType(ctx), nil),
+	}
+	diags := sshKeyIDFormat.setIdentity(ctx, types.StringValue("sshkey:development:dev-user"), identity)
+	require.False(t, diags.HasError(), diags)
+
+	id, diags := sshKeyIDFormat.importID(ctx, resource.ImportStateRequest{Identity: identity})
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, "sshkey:development:dev-user", id)
+}
@@ -0,0 +1,1552 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/juju/terraform-provider-juju/internal/juju (interfaces: AnnotationsClient,ApplicationsClient,BackupsClient,BundlesClient,CharmsClient,CredentialsClient,ExecClient,IntegrationsClient,MachinesClient,ModelsClient,OffersClient,SecretsClient,SSHKeysClient,StatusClient,UsersClient)
+//
+// Generated by this command:
+//
+//	mockgen -package provider -destination juju_mock_test.go github.com/juju/terraform-provider-juju/internal/juju AnnotationsClient,ApplicationsClient,BackupsClient,BundlesClient,CharmsClient,CredentialsClient,ExecClient,IntegrationsClient,MachinesClient,ModelsClient,OffersClient,SecretsClient,SSHKeysClient,StatusClient,UsersClient
+//
+
+// Package provider is a generated GoMock package.
+package provider
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	charm "github.com/juju/charm/v12"
+	api "github.com/juju/juju/api"
+	params "github.com/juju/juju/rpc/params"
+	juju "github.com/juju/terraform-provider-juju/internal/juju"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAnnotationsClient is a mock of AnnotationsClient interface.
+type MockAnnotationsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnnotationsClientMockRecorder
+}
+
+// MockAnnotationsClientMockRecorder is the mock recorder for MockAnnotationsClient.
+type MockAnnotationsClientMockRecorder struct {
+	mock *MockAnnotationsClient
+}
+
+// NewMockAnnotationsClient creates a new mock instance.
+func NewMockAnnotationsClient(ctrl *gomock.Controller) *MockAnnotationsClient {
+	mock := &MockAnnotationsClient{ctrl: ctrl}
+	mock.recorder = &MockAnnotationsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnnotationsClient) EXPECT() *MockAnnotationsClientMockRecorder {
+	return m.recorder
+}
+
+// GetAnnotations mocks base method.
+func (m *MockAnnotationsClient) GetAnnotations(arg0 context.Context, arg1 juju.GetAnnotationsInput) (*juju.GetAnnotationsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnnotations", arg0, arg1)
+	ret0, _ := ret[0].(*juju.GetAnnotationsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAnnotations indicates an expected call of GetAnnotations.
+func (mr *MockAnnotationsClientMockRecorder) GetAnnotations(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnnotations", reflect.TypeOf((*MockAnnotationsClient)(nil).GetAnnotations), arg0, arg1)
+}
+
+// SetAnnotations mocks base method.
+func (m *MockAnnotationsClient) SetAnnotations(arg0 context.Context, arg1 juju.SetAnnotationsInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAnnotations", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAnnotations indicates an expected call of SetAnnotations.
+func (mr *MockAnnotationsClientMockRecorder) SetAnnotations(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAnnotations", reflect.TypeOf((*MockAnnotationsClient)(nil).SetAnnotations), arg0, arg1)
+}
+
+// MockApplicationsClient is a mock of ApplicationsClient interface.
+type MockApplicationsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationsClientMockRecorder
+}
+
+// MockApplicationsClientMockRecorder is the mock recorder for MockApplicationsClient.
+type MockApplicationsClientMockRecorder struct {
+	mock *MockApplicationsClient
+}
+
+// NewMockApplicationsClient creates a new mock instance.
+func NewMockApplicationsClient(ctrl *gomock.Controller) *MockApplicationsClient {
+	mock := &MockApplicationsClient{ctrl: ctrl}
+	mock.recorder = &MockApplicationsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationsClient) EXPECT() *MockApplicationsClientMockRecorder {
+	return m.recorder
+}
+
+// CreateApplication mocks base method.
+func (m *MockApplicationsClient) CreateApplication(arg0 context.Context, arg1 *juju.CreateApplicationInput) (*juju.CreateApplicationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateApplication", arg0, arg1)
+	ret0, _ := ret[0].(*juju.CreateApplicationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateApplication indicates an expected call of CreateApplication.
+func (mr *MockApplicationsClientMockRecorder) CreateApplication(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApplication", reflect.TypeOf((*MockApplicationsClient)(nil).CreateApplication), arg0, arg1)
+}
+
+// DestroyApplication mocks base method.
+func (m *MockApplicationsClient) DestroyApplication(arg0 context.Context, arg1 *juju.DestroyApplicationInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyApplication", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DestroyApplication indicates an expected call of DestroyApplication.
+func (mr *MockApplicationsClientMockRecorder) DestroyApplication(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyApplication", reflect.TypeOf((*MockApplicationsClient)(nil).DestroyApplication), arg0, arg1)
+}
+
+// ExposeApplication mocks base method.
+func (m *MockApplicationsClient) ExposeApplication(arg0 context.Context, arg1 juju.ExposeApplicationInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExposeApplication", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExposeApplication indicates an expected call of ExposeApplication.
+func (mr *MockApplicationsClientMockRecorder) ExposeApplication(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExposeApplication", reflect.TypeOf((*MockApplicationsClient)(nil).ExposeApplication), arg0, arg1)
+}
+
+// IsExposeManaged mocks base method.
+func (m *MockApplicationsClient) IsExposeManaged(arg0 context.Context, arg1, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsExposeManaged", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsExposeManaged indicates an expected call of IsExposeManaged.
+func (mr *MockApplicationsClientMockRecorder) IsExposeManaged(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsExposeManaged", reflect.TypeOf((*MockApplicationsClient)(nil).IsExposeManaged), arg0, arg1, arg2)
+}
+
+// ListApplications mocks base method.
+func (m *MockApplicationsClient) ListApplications(arg0 context.Context, arg1 juju.ListApplicationsInput) (*juju.ListApplicationsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListApplications", arg0, arg1)
+	ret0, _ := ret[0].(*juju.ListApplicationsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListApplications indicates an expected call of ListApplications.
+func (mr *MockApplicationsClientMockRecorder) ListApplications(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApplications", reflect.TypeOf((*MockApplicationsClient)(nil).ListApplications), arg0, arg1)
+}
+
+// ReadApplication mocks base method.
+func (m *MockApplicationsClient) ReadApplication(arg0 context.Context, arg1 *juju.ReadApplicationInput) (*juju.ReadApplicationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadApplication", arg0, arg1)
+	ret0, _ := ret[0].(*juju.ReadApplicationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadApplication indicates an expected call of ReadApplication.
+func (mr *MockApplicationsClientMockRecorder) ReadApplication(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadApplication", reflect.TypeOf((*MockApplicationsClient)(nil).ReadApplication), arg0, arg1)
+}
+
+// ReadApplicationConfig mocks base method.
+func (m *MockApplicationsClient) ReadApplicationConfig(arg0 context.Context, arg1 juju.ReadApplicationConfigInput) (*juju.ReadApplicationConfigResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadApplicationConfig", arg0, arg1)
+	ret0, _ := ret[0].(*juju.ReadApplicationConfigResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadApplicationConfig indicates an expected call of ReadApplicationConfig.
+func (mr *MockApplicationsClientMockRecorder) ReadApplicationConfig(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadApplicationConfig", reflect.TypeOf((*MockApplicationsClient)(nil).ReadApplicationConfig), arg0, arg1)
+}
+
+// ReadApplicationExpose mocks base method.
+func (m *MockApplicationsClient) ReadApplicationExpose(arg0 context.Context, arg1 juju.ReadApplicationExposeInput) (*juju.ReadApplicationExposeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadApplicationExpose", arg0, arg1)
+	ret0, _ := ret[0].(*juju.ReadApplicationExposeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadApplicationExpose indicates an expected call of ReadApplicationExpose.
+func (mr *MockApplicationsClientMockRecorder) ReadApplicationExpose(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadApplicationExpose", reflect.TypeOf((*MockApplicationsClient)(nil).ReadApplicationExpose), arg0, arg1)
+}
+
+// ReadApplicationResource mocks base method.
+func (m *MockApplicationsClient) ReadApplicationResource(arg0 context.Context, arg1 juju.ReadApplicationResourceInput) (*juju.ReadApplicationResourceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadApplicationResource", arg0, arg1)
+	ret0, _ := ret[0].(*juju.ReadApplicationResourceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadApplicationResource indicates an expected call of ReadApplicationResource.
+func (mr *MockApplicationsClientMockRecorder) ReadApplicationResource(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadApplicationResource", reflect.TypeOf((*MockApplicationsClient)(nil).ReadApplicationResource), arg0, arg1)
+}
+
+// ReadApplicationWithRetryOnNotFound mocks base method.
+func (m *MockApplicationsClient) ReadApplicationWithRetryOnNotFound(arg0 context.Context, arg1 *juju.ReadApplicationInput) (*juju.ReadApplicationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadApplicationWithRetryOnNotFound", arg0, arg1)
+	ret0, _ := ret[0].(*juju.ReadApplicationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadApplicationWithRetryOnNotFound indicates an expected call of ReadApplicationWithRetryOnNotFound.
+func (mr *MockApplicationsClientMockRecorder) ReadApplicationWithRetryOnNotFound(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadApplicationWithRetryOnNotFound", reflect.TypeOf((*MockApplicationsClient)(nil).ReadApplicationWithRetryOnNotFound), arg0, arg1)
+}
+
+// ReadUnit mocks base method.
+func (m *MockApplicationsClient) ReadUnit(arg0 context.Context, arg1 juju.ReadUnitInput) (*juju.ReadUnitResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadUnit", arg0, arg1)
+	ret0, _ := ret[0].(*juju.ReadUnitResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadUnit indicates an expected call of ReadUnit.
+func (mr *MockApplicationsClientMockRecorder) ReadUnit(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUnit", reflect.TypeOf((*MockApplicationsClient)(nil).ReadUnit), arg0, arg1)
+}
+
+// SetApplicationConfig mocks base method.
+func (m *MockApplicationsClient) SetApplicationConfig(arg0 context.Context, arg1 juju.SetApplicationConfigInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetApplicationConfig", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetApplicationConfig indicates an expected call of SetApplicationConfig.
+func (mr *MockApplicationsClientMockRecorder) SetApplicationConfig(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetApplicationConfig", reflect.TypeOf((*MockApplicationsClient)(nil).SetApplicationConfig), arg0, arg1)
+}
+
+// SetApplicationResource mocks base method.
+func (m *MockApplicationsClient) SetApplicationResource(arg0 context.Context, arg1 juju.SetApplicationResourceInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetApplicationResource", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetApplicationResource indicates an expected call of SetApplicationResource.
+func (mr *MockApplicationsClientMockRecorder) SetApplicationResource(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetApplicationResource", reflect.TypeOf((*MockApplicationsClient)(nil).SetApplicationResource), arg0, arg1)
+}
+
+// UnexposeApplication mocks base method.
+func (m *MockApplicationsClient) UnexposeApplication(arg0 context.Context, arg1 juju.UnexposeApplicationInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnexposeApplication", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnexposeApplication indicates an expected call of UnexposeApplication.
+func (mr *MockApplicationsClientMockRecorder) UnexposeApplication(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnexposeApplication", reflect.TypeOf((*MockApplicationsClient)(nil).UnexposeApplication), arg0, arg1)
+}
+
+// UnsetApplicationConfig mocks base method.
+func (m *MockApplicationsClient) UnsetApplicationConfig(arg0 context.Context, arg1 juju.UnsetApplicationConfigInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnsetApplicationConfig", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnsetApplicationConfig indicates an expected call of UnsetApplicationConfig.
+func (mr *MockApplicationsClientMockRecorder) UnsetApplicationConfig(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsetApplicationConfig", reflect.TypeOf((*MockApplicationsClient)(nil).UnsetApplicationConfig), arg0, arg1)
+}
+
+// UpdateApplication mocks base method.
+func (m *MockApplicationsClient) UpdateApplication(arg0 context.Context, arg1 *juju.UpdateApplicationInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateApplication", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateApplication indicates an expected call of UpdateApplication.
+func (mr *MockApplicationsClientMockRecorder) UpdateApplication(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateApplication", reflect.TypeOf((*MockApplicationsClient)(nil).UpdateApplication), arg0, arg1)
+}
+
+// MockBackupsClient is a mock of BackupsClient interface.
+type MockBackupsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackupsClientMockRecorder
+}
+
+// MockBackupsClientMockRecorder is the mock recorder for MockBackupsClient.
+type MockBackupsClientMockRecorder struct {
+	mock *MockBackupsClient
+}
+
+// NewMockBackupsClient creates a new mock instance.
+func NewMockBackupsClient(ctrl *gomock.Controller) *MockBackupsClient {
+	mock := &MockBackupsClient{ctrl: ctrl}
+	mock.recorder = &MockBackupsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackupsClient) EXPECT() *MockBackupsClientMockRecorder {
+	return m.recorder
+}
+
+// CreateBackup mocks base method.
+func (m *MockBackupsClient) CreateBackup(arg0 context.Context, arg1 juju.CreateBackupInput) (*juju.CreateBackupResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBackup", arg0, arg1)
+	ret0, _ := ret[0].(*juju.CreateBackupResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBackup indicates an expected call of CreateBackup.
+func (mr *MockBackupsClientMockRecorder) CreateBackup(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBackup", reflect.TypeOf((*MockBackupsClient)(nil).CreateBackup), arg0, arg1)
+}
+
+// MockBundlesClient is a mock of BundlesClient interface.
+type MockBundlesClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockBundlesClientMockRecorder
+}
+
+// MockBundlesClientMockRecorder is the mock recorder for MockBundlesClient.
+type MockBundlesClientMockRecorder struct {
+	mock *MockBundlesClient
+}
+
+// NewMockBundlesClient creates a new mock instance.
+func NewMockBundlesClient(ctrl *gomock.Controller) *MockBundlesClient {
+	mock := &MockBundlesClient{ctrl: ctrl}
+	mock.recorder = &MockBundlesClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBundlesClient) EXPECT() *MockBundlesClientMockRecorder {
+	return m.recorder
+}
+
+// DeployBundle mocks base method.
+func (m *MockBundlesClient) DeployBundle(arg0 context.Context, arg1 juju.DeployBundleInput) (*juju.DeployBundleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeployBundle", arg0, arg1)
+	ret0, _ := ret[0].(*juju.DeployBundleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeployBundle indicates an expected call of DeployBundle.
+func (mr *MockBundlesClientMockRecorder) DeployBundle(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeployBundle", reflect.TypeOf((*MockBundlesClient)(nil).DeployBundle), arg0, arg1)
+}
+
+// DiffBundle mocks base method.
+func (m *MockBundlesClient) DiffBundle(arg0 context.Context, arg1 juju.DiffBundleInput) (*juju.DiffBundleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffBundle", arg0, arg1)
+	ret0, _ := ret[0].(*juju.DiffBundleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffBundle indicates an expected call of DiffBundle.
+func (mr *MockBundlesClientMockRecorder) DiffBundle(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffBundle", reflect.TypeOf((*MockBundlesClient)(nil).DiffBundle), arg0, arg1)
+}
+
+// MockCharmsClient is a mock of CharmsClient interface.
+type MockCharmsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockCharmsClientMockRecorder
+}
+
+// MockCharmsClientMockRecorder is the mock recorder for MockCharmsClient.
+type MockCharmsClientMockRecorder struct {
+	mock *MockCharmsClient
+}
+
+// NewMockCharmsClient creates a new mock instance.
+func NewMockCharmsClient(ctrl *gomock.Controller) *MockCharmsClient {
+	mock := &MockCharmsClient{ctrl: ctrl}
+	mock.recorder = &MockCharmsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCharmsClient) EXPECT() *MockCharmsClientMockRecorder {
+	return m.recorder
+}
+
+// CharmConfig mocks base method.
+func (m *MockCharmsClient) CharmConfig(arg0 context.Context, arg1 *juju.CharmConfigInput) (*charm.Config, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CharmConfig", arg0, arg1)
+	ret0, _ := ret[0].(*charm.Config)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CharmConfig indicates an expected call of CharmConfig.
+func (mr *MockCharmsClientMockRecorder) CharmConfig(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CharmConfig", reflect.TypeOf((*MockCharmsClient)(nil).CharmConfig), arg0, arg1)
+}
+
+// ListCharmResources mocks base method.
+func (m *MockCharmsClient) ListCharmResources(arg0 context.Context, arg1 juju.ResolveCharmInput) (*juju.ListCharmResourcesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCharmResources", arg0, arg1)
+	ret0, _ := ret[0].(*juju.ListCharmResourcesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCharmResources indicates an expected call of ListCharmResources.
+func (mr *MockCharmsClientMockRecorder) ListCharmResources(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCharmResources", reflect.TypeOf((*MockCharmsClient)(nil).ListCharmResources), arg0, arg1)
+}
+
+// ResolveCharm mocks base method.
+func (m *MockCharmsClient) ResolveCharm(arg0 context.Context, arg1 juju.ResolveCharmInput) (*juju.ResolveCharmResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveCharm", arg0, arg1)
+	ret0, _ := ret[0].(*juju.ResolveCharmResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveCharm indicates an expected call of ResolveCharm.
+func (mr *MockCharmsClientMockRecorder) ResolveCharm(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveCharm", reflect.TypeOf((*MockCharmsClient)(nil).ResolveCharm), arg0, arg1)
+}
+
+// MockCredentialsClient is a mock of CredentialsClient interface.
+type MockCredentialsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockCredentialsClientMockRecorder
+}
+
+// MockCredentialsClientMockRecorder is the mock recorder for MockCredentialsClient.
+type MockCredentialsClientMockRecorder struct {
+	mock *MockCredentialsClient
+}
+
+// NewMockCredentialsClient creates a new mock instance.
+func NewMockCredentialsClient(ctrl *gomock.Controller) *MockCredentialsClient {
+	mock := &MockCredentialsClient{ctrl: ctrl}
+	mock.recorder = &MockCredentialsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCredentialsClient) EXPECT() *MockCredentialsClientMockRecorder {
+	return m.recorder
+}
+
+// CreateCredential mocks base method.
+func (m *MockCredentialsClient) CreateCredential(arg0 context.Context, arg1 juju.CreateCredentialInput) (*juju.CreateCredentialResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCredential", arg0, arg1)
+	ret0, _ := ret[0].(*juju.CreateCredentialResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCredential indicates an expected call of CreateCredential.
+func (mr *MockCredentialsClientMockRecorder) CreateCredential(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCredential", reflect.TypeOf((*MockCredentialsClient)(nil).CreateCredential), arg0, arg1)
+}
+
+// DestroyCredential mocks base method.
+func (m *MockCredentialsClient) DestroyCredential(arg0 context.Context, arg1 juju.DestroyCredentialInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyCredential", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DestroyCredential indicates an expected call of DestroyCredential.
+func (mr *MockCredentialsClientMockRecorder) DestroyCredential(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyCredential", reflect.TypeOf((*MockCredentialsClient)(nil).DestroyCredential), arg0, arg1)
+}
+
+// ListCredentials mocks base method.
+func (m *MockCredentialsClient) ListCredentials(arg0 context.Context, arg1 juju.ListCredentialsInput) (juju.ListCredentialsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCredentials", arg0, arg1)
+	ret0, _ := ret[0].(juju.ListCredentialsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCredentials indicates an expected call of ListCredentials.
+func (mr *MockCredentialsClientMockRecorder) ListCredentials(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCredentials", reflect.TypeOf((*MockCredentialsClient)(nil).ListCredentials), arg0, arg1)
+}
+
+// ReadCredential mocks base method.
+func (m *MockCredentialsClient) ReadCredential(arg0 context.Context, arg1 juju.ReadCredentialInput) (*juju.ReadCredentialResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadCredential", arg0, arg1)
+	ret0, _ := ret[0].(*juju.ReadCredentialResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadCredential indicates an expected call of ReadCredential.
+func (mr *MockCredentialsClientMockRecorder) ReadCredential(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadCredential", reflect.TypeOf((*MockCredentialsClient)(nil).ReadCredential), arg0, arg1)
+}
+
+// UpdateCredential mocks base method.
+func (m *MockCredentialsClient) UpdateCredential(arg0 context.Context, arg1 juju.UpdateCredentialInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCredential", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCredential indicates an expected call of UpdateCredential.
+func (mr *MockCredentialsClientMockRecorder) UpdateCredential(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCredential", reflect.TypeOf((*MockCredentialsClient)(nil).UpdateCredential), arg0, arg1)
+}
+
+// ValidateCloudRegion mocks base method.
+func (m *MockCredentialsClient) ValidateCloudRegion(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateCloudRegion", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateCloudRegion indicates an expected call of ValidateCloudRegion.
+func (mr *MockCredentialsClientMockRecorder) ValidateCloudRegion(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateCloudRegion", reflect.TypeOf((*MockCredentialsClient)(nil).ValidateCloudRegion), arg0, arg1, arg2)
+}
+
+// ValidateCredentialForCloud mocks base method.
+func (m *MockCredentialsClient) ValidateCredentialForCloud(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateCredentialForCloud", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateCredentialForCloud indicates an expected call of ValidateCredentialForCloud.
+func (mr *MockCredentialsClientMockRecorder) ValidateCredentialForCloud(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateCredentialForCloud", reflect.TypeOf((*MockCredentialsClient)(nil).ValidateCredentialForCloud), arg0, arg1, arg2)
+}
+
+// MockExecClient is a mock of ExecClient interface.
+type MockExecClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockExecClientMockRecorder
+}
+
+// MockExecClientMockRecorder is the mock recorder for MockExecClient.
+type MockExecClientMockRecorder struct {
+	mock *MockExecClient
+}
+
+// NewMockExecClient creates a new mock instance.
+func NewMockExecClient(ctrl *gomock.Controller) *MockExecClient {
+	mock := &MockExecClient{ctrl: ctrl}
+	mock.recorder = &MockExecClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExecClient) EXPECT() *MockExecClientMockRecorder {
+	return m.recorder
+}
+
+// Exec mocks base method.
+func (m *MockExecClient) Exec(arg0 context.Context, arg1 juju.ExecInput) (*juju.ExecResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exec", arg0, arg1)
+	ret0, _ := ret[0].(*juju.ExecResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exec indicates an expected call of Exec.
+func (mr *MockExecClientMockRecorder) Exec(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockExecClient)(nil).Exec), arg0, arg1)
+}
+
+// MockIntegrationsClient is a mock of IntegrationsClient interface.
+type MockIntegrationsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockIntegrationsClientMockRecorder
+}
+
+// MockIntegrationsClientMockRecorder is the mock recorder for MockIntegrationsClient.
+type MockIntegrationsClientMockRecorder struct {
+	mock *MockIntegrationsClient
+}
+
+// NewMockIntegrationsClient creates a new mock instance.
+func NewMockIntegrationsClient(ctrl *gomock.Controller) *MockIntegrationsClient {
+	mock := &MockIntegrationsClient{ctrl: ctrl}
+	mock.recorder = &MockIntegrationsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIntegrationsClient) EXPECT() *MockIntegrationsClientMockRecorder {
+	return m.recorder
+}
+
+// CreateIntegration mocks base method.
+func (m *MockIntegrationsClient) CreateIntegration(arg0 context.Context, arg1 *juju.IntegrationInput) (*juju.CreateIntegrationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIntegration", arg0, arg1)
+	ret0, _ := ret[0].(*juju.CreateIntegrationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIntegration indicates an expected call of CreateIntegration.
+func (mr *MockIntegrationsClientMockRecorder) CreateIntegration(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIntegration", reflect.TypeOf((*MockIntegrationsClient)(nil).CreateIntegration), arg0, arg1)
+}
+
+// DestroyIntegration mocks base method.
+func (m *MockIntegrationsClient) DestroyIntegration(arg0 context.Context, arg1 *juju.IntegrationInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyIntegration", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DestroyIntegration indicates an expected call of DestroyIntegration.
+func (mr *MockIntegrationsClientMockRecorder) DestroyIntegration(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyIntegration", reflect.TypeOf((*MockIntegrationsClient)(nil).DestroyIntegration), arg0, arg1)
+}
+
+// ReadIntegration mocks base method.
+func (m *MockIntegrationsClient) ReadIntegration(arg0 context.Context, arg1 *juju.IntegrationInput) (*juju.ReadIntegrationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadIntegration", arg0, arg1)
+	ret0, _ := ret[0].(*juju.ReadIntegrationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadIntegration indicates an expected call of ReadIntegration.
+func (mr *MockIntegrationsClientMockRecorder) ReadIntegration(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadIntegration", reflect.TypeOf((*MockIntegrationsClient)(nil).ReadIntegration), arg0, arg1)
+}
+
+// UpdateIntegration mocks base method.
+func (m *MockIntegrationsClient) UpdateIntegration(arg0 context.Context, arg1 *juju.UpdateIntegrationInput) (*juju.UpdateIntegrationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIntegration", arg0, arg1)
+	ret0, _ := ret[0].(*juju.UpdateIntegrationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateIntegration indicates an expected call of UpdateIntegration.
+func (mr *MockIntegrationsClientMockRecorder) UpdateIntegration(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIntegration", reflect.TypeOf((*MockIntegrationsClient)(nil).UpdateIntegration), arg0, arg1)
+}
+
+// MockMachinesClient is a mock of MachinesClient interface.
+type MockMachinesClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockMachinesClientMockRecorder
+}
+
+// MockMachinesClientMockRecorder is the mock recorder for MockMachinesClient.
+type MockMachinesClientMockRecorder struct {
+	mock *MockMachinesClient
+}
+
+// NewMockMachinesClient creates a new mock instance.
+func NewMockMachinesClient(ctrl *gomock.Controller) *MockMachinesClient {
+	mock := &MockMachinesClient{ctrl: ctrl}
+	mock.recorder = &MockMachinesClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMachinesClient) EXPECT() *MockMachinesClientMockRecorder {
+	return m.recorder
+}
+
+// CreateMachine mocks base method.
+func (m *MockMachinesClient) CreateMachine(arg0 context.Context, arg1 *juju.CreateMachineInput) (*juju.CreateMachineResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMachine", arg0, arg1)
+	ret0, _ := ret[0].(*juju.CreateMachineResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMachine indicates an expected call of CreateMachine.
+func (mr *MockMachinesClientMockRecorder) CreateMachine(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMachine", reflect.TypeOf((*MockMachinesClient)(nil).CreateMachine), arg0, arg1)
+}
+
+// DestroyMachine mocks base method.
+func (m *MockMachinesClient) DestroyMachine(arg0 context.Context, arg1 *juju.DestroyMachineInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyMachine", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DestroyMachine indicates an expected call of DestroyMachine.
+func (mr *MockMachinesClientMockRecorder) DestroyMachine(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyMachine", reflect.TypeOf((*MockMachinesClient)(nil).DestroyMachine), arg0, arg1)
+}
+
+// ReadMachine mocks base method.
+func (m *MockMachinesClient) ReadMachine(arg0 context.Context, arg1 juju.ReadMachineInput) (juju.ReadMachineResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadMachine", arg0, arg1)
+	ret0, _ := ret[0].(juju.ReadMachineResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadMachine indicates an expected call of ReadMachine.
+func (mr *MockMachinesClientMockRecorder) ReadMachine(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadMachine", reflect.TypeOf((*MockMachinesClient)(nil).ReadMachine), arg0, arg1)
+}
+
+// MockModelsClient is a mock of ModelsClient interface.
+type MockModelsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockModelsClientMockRecorder
+}
+
+// MockModelsClientMockRecorder is the mock recorder for MockModelsClient.
+type MockModelsClientMockRecorder struct {
+	mock *MockModelsClient
+}
+
+// NewMockModelsClient creates a new mock instance.
+func NewMockModelsClient(ctrl *gomock.Controller) *MockModelsClient {
+	mock := &MockModelsClient{ctrl: ctrl}
+	mock.recorder = &MockModelsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockModelsClient) EXPECT() *MockModelsClientMockRecorder {
+	return m.recorder
+}
+
+// CreateModel mocks base method.
+func (m *MockModelsClient) CreateModel(arg0 context.Context, arg1 juju.CreateModelInput) (juju.CreateModelResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateModel", arg0, arg1)
+	ret0, _ := ret[0].(juju.CreateModelResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateModel indicates an expected call of CreateModel.
+func (mr *MockModelsClientMockRecorder) CreateModel(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateModel", reflect.TypeOf((*MockModelsClient)(nil).CreateModel), arg0, arg1)
+}
+
+// DestroyAccessModel mocks base method.
+func (m *MockModelsClient) DestroyAccessModel(arg0 context.Context, arg1 juju.DestroyAccessModelInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyAccessModel", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DestroyAccessModel indicates an expected call of DestroyAccessModel.
+func (mr *MockModelsClientMockRecorder) DestroyAccessModel(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyAccessModel", reflect.TypeOf((*MockModelsClient)(nil).DestroyAccessModel), arg0, arg1)
+}
+
+// DestroyModel mocks base method.
+func (m *MockModelsClient) DestroyModel(arg0 context.Context, arg1 juju.DestroyModelInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyModel", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DestroyModel indicates an expected call of DestroyModel.
+func (mr *MockModelsClientMockRecorder) DestroyModel(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyModel", reflect.TypeOf((*MockModelsClient)(nil).DestroyModel), arg0, arg1)
+}
+
+// GetConnection mocks base method.
+func (m *MockModelsClient) GetConnection(arg0 context.Context, arg1 *string) (api.Connection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConnection", arg0, arg1)
+	ret0, _ := ret[0].(api.Connection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConnection indicates an expected call of GetConnection.
+func (mr *MockModelsClientMockRecorder) GetConnection(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnection", reflect.TypeOf((*MockModelsClient)(nil).GetConnection), arg0, arg1)
+}
+
+// GetModelByName mocks base method.
+func (m *MockModelsClient) GetModelByName(arg0 context.Context, arg1 string) (*params.ModelInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModelByName", arg0, arg1)
+	ret0, _ := ret[0].(*params.ModelInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModelByName indicates an expected call of GetModelByName.
+func (mr *MockModelsClientMockRecorder) GetModelByName(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModelByName", reflect.TypeOf((*MockModelsClient)(nil).GetModelByName), arg0, arg1)
+}
+
+// GrantModel mocks base method.
+func (m *MockModelsClient) GrantModel(arg0 context.Context, arg1 juju.GrantModelInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantModel", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantModel indicates an expected call of GrantModel.
+func (mr *MockModelsClientMockRecorder) GrantModel(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantModel", reflect.TypeOf((*MockModelsClient)(nil).GrantModel), arg0, arg1)
+}
+
+// ListModels mocks base method.
+func (m *MockModelsClient) ListModels(arg0 context.Context, arg1 juju.ListModelsInput) (juju.ListModelsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListModels", arg0, arg1)
+	ret0, _ := ret[0].(juju.ListModelsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListModels indicates an expected call of ListModels.
+func (mr *MockModelsClientMockRecorder) ListModels(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListModels", reflect.TypeOf((*MockModelsClient)(nil).ListModels), arg0, arg1)
+}
+
+// ListSpaces mocks base method.
+func (m *MockModelsClient) ListSpaces(arg0 context.Context, arg1 juju.ListSpacesInput) (juju.ListSpacesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSpaces", arg0, arg1)
+	ret0, _ := ret[0].(juju.ListSpacesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSpaces indicates an expected call of ListSpaces.
+func (mr *MockModelsClientMockRecorder) ListSpaces(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSpaces", reflect.TypeOf((*MockModelsClient)(nil).ListSpaces), arg0, arg1)
+}
+
+// ListStoragePools mocks base method.
+func (m *MockModelsClient) ListStoragePools(arg0 context.Context, arg1 juju.ListStoragePoolsInput) (juju.ListStoragePoolsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStoragePools", arg0, arg1)
+	ret0, _ := ret[0].(juju.ListStoragePoolsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStoragePools indicates an expected call of ListStoragePools.
+func (mr *MockModelsClientMockRecorder) ListStoragePools(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStoragePools", reflect.TypeOf((*MockModelsClient)(nil).ListStoragePools), arg0, arg1)
+}
+
+// MigrateModel mocks base method.
+func (m *MockModelsClient) MigrateModel(arg0 context.Context, arg1 juju.MigrateModelInput) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MigrateModel", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MigrateModel indicates an expected call of MigrateModel.
+func (mr *MockModelsClientMockRecorder) MigrateModel(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateModel", reflect.TypeOf((*MockModelsClient)(nil).MigrateModel), arg0, arg1)
+}
+
+// ReadAgentVersions mocks base method.
+func (m *MockModelsClient) ReadAgentVersions(arg0 context.Context, arg1 juju.ReadAgentVersionsInput) (*juju.ReadAgentVersionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAgentVersions", arg0, arg1)
+	ret0, _ := ret[0].(*juju.ReadAgentVersionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAgentVersions indicates an expected call of ReadAgentVersions.
+func (mr *MockModelsClientMockRecorder) ReadAgentVersions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAgentVersions", reflect.TypeOf((*MockModelsClient)(nil).ReadAgentVersions), arg0, arg1)
+}
+
+// ReadModel mocks base method.
+func (m *MockModelsClient) ReadModel(arg0 context.Context, arg1 string) (*juju.ReadModelResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadModel", arg0, arg1)
+	ret0, _ := ret[0].(*juju.ReadModelResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadModel indicates an expected call of ReadModel.
+func (mr *MockModelsClientMockRecorder) ReadModel(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadModel", reflect.TypeOf((*MockModelsClient)(nil).ReadModel), arg0, arg1)
+}
+
+// ReadModelConfig mocks base method.
+func (m *MockModelsClient) ReadModelConfig(arg0 context.Context, arg1 juju.ReadModelConfigInput) (juju.ReadModelConfigOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadModelConfig", arg0, arg1)
+	ret0, _ := ret[0].(juju.ReadModelConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadModelConfig indicates an expected call of ReadModelConfig.
+func (mr *MockModelsClientMockRecorder) ReadModelConfig(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadModelConfig", reflect.TypeOf((*MockModelsClient)(nil).ReadModelConfig), arg0, arg1)
+}
+
+// ReadModelDefaults mocks base method.
+func (m *MockModelsClient) ReadModelDefaults(arg0 context.Context, arg1 juju.ReadModelDefaultsInput) (juju.ReadModelDefaultsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadModelDefaults", arg0, arg1)
+	ret0, _ := ret[0].(juju.ReadModelDefaultsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadModelDefaults indicates an expected call of ReadModelDefaults.
+func (mr *MockModelsClientMockRecorder) ReadModelDefaults(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadModelDefaults", reflect.TypeOf((*MockModelsClient)(nil).ReadModelDefaults), arg0, arg1)
+}
+
+// ReadModelMigration mocks base method.
+func (m *MockModelsClient) ReadModelMigration(arg0 context.Context, arg1 string) (*juju.ReadModelMigrationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadModelMigration", arg0, arg1)
+	ret0, _ := ret[0].(*juju.ReadModelMigrationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadModelMigration indicates an expected call of ReadModelMigration.
+func (mr *MockModelsClientMockRecorder) ReadModelMigration(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadModelMigration", reflect.TypeOf((*MockModelsClient)(nil).ReadModelMigration), arg0, arg1)
+}
+
+// UpdateAccessModel mocks base method.
+func (m *MockModelsClient) UpdateAccessModel(arg0 context.Context, arg1 juju.UpdateAccessModelInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccessModel", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccessModel indicates an expected call of UpdateAccessModel.
+func (mr *MockModelsClientMockRecorder) UpdateAccessModel(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccessModel", reflect.TypeOf((*MockModelsClient)(nil).UpdateAccessModel), arg0, arg1)
+}
+
+// UpdateModel mocks base method.
+func (m *MockModelsClient) UpdateModel(arg0 context.Context, arg1 juju.UpdateModelInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateModel", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateModel indicates an expected call of UpdateModel.
+func (mr *MockModelsClientMockRecorder) UpdateModel(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateModel", reflect.TypeOf((*MockModelsClient)(nil).UpdateModel), arg0, arg1)
+}
+
+// UpdateModelDefaults mocks base method.
+func (m *MockModelsClient) UpdateModelDefaults(arg0 context.Context, arg1 juju.UpdateModelDefaultsInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateModelDefaults", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateModelDefaults indicates an expected call of UpdateModelDefaults.
+func (mr *MockModelsClientMockRecorder) UpdateModelDefaults(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateModelDefaults", reflect.TypeOf((*MockModelsClient)(nil).UpdateModelDefaults), arg0, arg1)
+}
+
+// WaitForModelMigration mocks base method.
+func (m *MockModelsClient) WaitForModelMigration(arg0 context.Context, arg1 string, arg2 time.Duration) (*juju.ReadModelMigrationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForModelMigration", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*juju.ReadModelMigrationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitForModelMigration indicates an expected call of WaitForModelMigration.
+func (mr *MockModelsClientMockRecorder) WaitForModelMigration(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForModelMigration", reflect.TypeOf((*MockModelsClient)(nil).WaitForModelMigration), arg0, arg1, arg2)
+}
+
+// MockOffersClient is a mock of OffersClient interface.
+type MockOffersClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockOffersClientMockRecorder
+}
+
+// MockOffersClientMockRecorder is the mock recorder for MockOffersClient.
+type MockOffersClientMockRecorder struct {
+	mock *MockOffersClient
+}
+
+// NewMockOffersClient creates a new mock instance.
+func NewMockOffersClient(ctrl *gomock.Controller) *MockOffersClient {
+	mock := &MockOffersClient{ctrl: ctrl}
+	mock.recorder = &MockOffersClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOffersClient) EXPECT() *MockOffersClientMockRecorder {
+	return m.recorder
+}
+
+// ConsumeRemoteOffer mocks base method.
+func (m *MockOffersClient) ConsumeRemoteOffer(arg0 context.Context, arg1 *juju.ConsumeRemoteOfferInput) (*juju.ConsumeRemoteOfferResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeRemoteOffer", arg0, arg1)
+	ret0, _ := ret[0].(*juju.ConsumeRemoteOfferResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeRemoteOffer indicates an expected call of ConsumeRemoteOffer.
+func (mr *MockOffersClientMockRecorder) ConsumeRemoteOffer(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeRemoteOffer", reflect.TypeOf((*MockOffersClient)(nil).ConsumeRemoteOffer), arg0, arg1)
+}
+
+// CreateOffer mocks base method.
+func (m *MockOffersClient) CreateOffer(arg0 context.Context, arg1 *juju.CreateOfferInput) (*juju.CreateOfferResponse, []error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOffer", arg0, arg1)
+	ret0, _ := ret[0].(*juju.CreateOfferResponse)
+	ret1, _ := ret[1].([]error)
+	return ret0, ret1
+}
+
+// CreateOffer indicates an expected call of CreateOffer.
+func (mr *MockOffersClientMockRecorder) CreateOffer(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOffer", reflect.TypeOf((*MockOffersClient)(nil).CreateOffer), arg0, arg1)
+}
+
+// DestroyOffer mocks base method.
+func (m *MockOffersClient) DestroyOffer(arg0 context.Context, arg1 *juju.DestroyOfferInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyOffer", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DestroyOffer indicates an expected call of DestroyOffer.
+func (mr *MockOffersClientMockRecorder) DestroyOffer(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyOffer", reflect.TypeOf((*MockOffersClient)(nil).DestroyOffer), arg0, arg1)
+}
+
+// ReadOffer mocks base method.
+func (m *MockOffersClient) ReadOffer(arg0 context.Context, arg1 *juju.ReadOfferInput) (*juju.ReadOfferResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadOffer", arg0, arg1)
+	ret0, _ := ret[0].(*juju.ReadOfferResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadOffer indicates an expected call of ReadOffer.
+func (mr *MockOffersClientMockRecorder) ReadOffer(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadOffer", reflect.TypeOf((*MockOffersClient)(nil).ReadOffer), arg0, arg1)
+}
+
+// RemoveRemoteOffer mocks base method.
+func (m *MockOffersClient) RemoveRemoteOffer(arg0 context.Context, arg1 *juju.RemoveRemoteOfferInput) []error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveRemoteOffer", arg0, arg1)
+	ret0, _ := ret[0].([]error)
+	return ret0
+}
+
+// RemoveRemoteOffer indicates an expected call of RemoveRemoteOffer.
+func (mr *MockOffersClientMockRecorder) RemoveRemoteOffer(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveRemoteOffer", reflect.TypeOf((*MockOffersClient)(nil).RemoveRemoteOffer), arg0, arg1)
+}
+
+// MockSecretsClient is a mock of SecretsClient interface.
+type MockSecretsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecretsClientMockRecorder
+}
+
+// MockSecretsClientMockRecorder is the mock recorder for MockSecretsClient.
+type MockSecretsClientMockRecorder struct {
+	mock *MockSecretsClient
+}
+
+// NewMockSecretsClient creates a new mock instance.
+func NewMockSecretsClient(ctrl *gomock.Controller) *MockSecretsClient {
+	mock := &MockSecretsClient{ctrl: ctrl}
+	mock.recorder = &MockSecretsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecretsClient) EXPECT() *MockSecretsClientMockRecorder {
+	return m.recorder
+}
+
+// CreateSecret mocks base method.
+func (m *MockSecretsClient) CreateSecret(arg0 context.Context, arg1 *juju.CreateSecretInput) (juju.CreateSecretOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSecret", arg0, arg1)
+	ret0, _ := ret[0].(juju.CreateSecretOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSecret indicates an expected call of CreateSecret.
+func (mr *MockSecretsClientMockRecorder) CreateSecret(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecret", reflect.TypeOf((*MockSecretsClient)(nil).CreateSecret), arg0, arg1)
+}
+
+// DeleteSecret mocks base method.
+func (m *MockSecretsClient) DeleteSecret(arg0 context.Context, arg1 *juju.DeleteSecretInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecret", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecret indicates an expected call of DeleteSecret.
+func (mr *MockSecretsClientMockRecorder) DeleteSecret(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecret", reflect.TypeOf((*MockSecretsClient)(nil).DeleteSecret), arg0, arg1)
+}
+
+// ListSecretBackends mocks base method.
+func (m *MockSecretsClient) ListSecretBackends(arg0 context.Context, arg1 *juju.ListSecretBackendsInput) (juju.ListSecretBackendsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecretBackends", arg0, arg1)
+	ret0, _ := ret[0].(juju.ListSecretBackendsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecretBackends indicates an expected call of ListSecretBackends.
+func (mr *MockSecretsClientMockRecorder) ListSecretBackends(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecretBackends", reflect.TypeOf((*MockSecretsClient)(nil).ListSecretBackends), arg0, arg1)
+}
+
+// ReadSecret mocks base method.
+func (m *MockSecretsClient) ReadSecret(arg0 context.Context, arg1 *juju.ReadSecretInput) (juju.ReadSecretOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadSecret", arg0, arg1)
+	ret0, _ := ret[0].(juju.ReadSecretOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadSecret indicates an expected call of ReadSecret.
+func (mr *MockSecretsClientMockRecorder) ReadSecret(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadSecret", reflect.TypeOf((*MockSecretsClient)(nil).ReadSecret), arg0, arg1)
+}
+
+// UpdateAccessSecret mocks base method.
+func (m *MockSecretsClient) UpdateAccessSecret(arg0 context.Context, arg1 *juju.GrantRevokeAccessSecretInput, arg2 juju.AccessSecretAction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccessSecret", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccessSecret indicates an expected call of UpdateAccessSecret.
+func (mr *MockSecretsClientMockRecorder) UpdateAccessSecret(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccessSecret", reflect.TypeOf((*MockSecretsClient)(nil).UpdateAccessSecret), arg0, arg1, arg2)
+}
+
+// UpdateSecret mocks base method.
+func (m *MockSecretsClient) UpdateSecret(arg0 context.Context, arg1 *juju.UpdateSecretInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecret", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSecret indicates an expected call of UpdateSecret.
+func (mr *MockSecretsClientMockRecorder) UpdateSecret(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecret", reflect.TypeOf((*MockSecretsClient)(nil).UpdateSecret), arg0, arg1)
+}
+
+// MockSSHKeysClient is a mock of SSHKeysClient interface.
+type MockSSHKeysClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockSSHKeysClientMockRecorder
+}
+
+// MockSSHKeysClientMockRecorder is the mock recorder for MockSSHKeysClient.
+type MockSSHKeysClientMockRecorder struct {
+	mock *MockSSHKeysClient
+}
+
+// NewMockSSHKeysClient creates a new mock instance.
+func NewMockSSHKeysClient(ctrl *gomock.Controller) *MockSSHKeysClient {
+	mock := &MockSSHKeysClient{ctrl: ctrl}
+	mock.recorder = &MockSSHKeysClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSSHKeysClient) EXPECT() *MockSSHKeysClientMockRecorder {
+	return m.recorder
+}
+
+// CreateSSHKey mocks base method.
+func (m *MockSSHKeysClient) CreateSSHKey(arg0 context.Context, arg1 *juju.CreateSSHKeyInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSSHKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSSHKey indicates an expected call of CreateSSHKey.
+func (mr *MockSSHKeysClientMockRecorder) CreateSSHKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSSHKey", reflect.TypeOf((*MockSSHKeysClient)(nil).CreateSSHKey), arg0, arg1)
+}
+
+// DeleteSSHKey mocks base method.
+func (m *MockSSHKeysClient) DeleteSSHKey(arg0 context.Context, arg1 *juju.DeleteSSHKeyInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSSHKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSSHKey indicates an expected call of DeleteSSHKey.
+func (mr *MockSSHKeysClientMockRecorder) DeleteSSHKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSSHKey", reflect.TypeOf((*MockSSHKeysClient)(nil).DeleteSSHKey), arg0, arg1)
+}
+
+// ReadSSHKey mocks base method.
+func (m *MockSSHKeysClient) ReadSSHKey(arg0 context.Context, arg1 *juju.ReadSSHKeyInput) (*juju.ReadSSHKeyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadSSHKey", arg0, arg1)
+	ret0, _ := ret[0].(*juju.ReadSSHKeyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadSSHKey indicates an expected call of ReadSSHKey.
+func (mr *MockSSHKeysClientMockRecorder) ReadSSHKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadSSHKey", reflect.TypeOf((*MockSSHKeysClient)(nil).ReadSSHKey), arg0, arg1)
+}
+
+// MockStatusClient is a mock of StatusClient interface.
+type MockStatusClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusClientMockRecorder
+}
+
+// MockStatusClientMockRecorder is the mock recorder for MockStatusClient.
+type MockStatusClientMockRecorder struct {
+	mock *MockStatusClient
+}
+
+// NewMockStatusClient creates a new mock instance.
+func NewMockStatusClient(ctrl *gomock.Controller) *MockStatusClient {
+	mock := &MockStatusClient{ctrl: ctrl}
+	mock.recorder = &MockStatusClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatusClient) EXPECT() *MockStatusClientMockRecorder {
+	return m.recorder
+}
+
+// ReadFullStatus mocks base method.
+func (m *MockStatusClient) ReadFullStatus(arg0 context.Context, arg1 juju.ReadFullStatusInput) (*juju.ReadFullStatusResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadFullStatus", arg0, arg1)
+	ret0, _ := ret[0].(*juju.ReadFullStatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadFullStatus indicates an expected call of ReadFullStatus.
+func (mr *MockStatusClientMockRecorder) ReadFullStatus(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadFullStatus", reflect.TypeOf((*MockStatusClient)(nil).ReadFullStatus), arg0, arg1)
+}
+
+// Wait mocks base method.
+func (m *MockStatusClient) Wait(arg0 context.Context, arg1 juju.WaitInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Wait", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Wait indicates an expected call of Wait.
+func (mr *MockStatusClientMockRecorder) Wait(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Wait", reflect.TypeOf((*MockStatusClient)(nil).Wait), arg0, arg1)
+}
+
+// WaitForStatus mocks base method.
+func (m *MockStatusClient) WaitForStatus(arg0 context.Context, arg1 juju.WaitForStatusInput) (*juju.WaitForStatusResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForStatus", arg0, arg1)
+	ret0, _ := ret[0].(*juju.WaitForStatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitForStatus indicates an expected call of WaitForStatus.
+func (mr *MockStatusClientMockRecorder) WaitForStatus(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForStatus", reflect.TypeOf((*MockStatusClient)(nil).WaitForStatus), arg0, arg1)
+}
+
+// MockUsersClient is a mock of UsersClient interface.
+type MockUsersClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsersClientMockRecorder
+}
+
+// MockUsersClientMockRecorder is the mock recorder for MockUsersClient.
+type MockUsersClientMockRecorder struct {
+	mock *MockUsersClient
+}
+
+// NewMockUsersClient creates a new mock instance.
+func NewMockUsersClient(ctrl *gomock.Controller) *MockUsersClient {
+	mock := &MockUsersClient{ctrl: ctrl}
+	mock.recorder = &MockUsersClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsersClient) EXPECT() *MockUsersClientMockRecorder {
+	return m.recorder
+}
+
+// CreateUser mocks base method.
+func (m *MockUsersClient) CreateUser(arg0 context.Context, arg1 juju.CreateUserInput) (*juju.CreateUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
+	ret0, _ := ret[0].(*juju.CreateUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockUsersClientMockRecorder) CreateUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUsersClient)(nil).CreateUser), arg0, arg1)
+}
+
+// DestroyUser mocks base method.
+func (m *MockUsersClient) DestroyUser(arg0 context.Context, arg1 juju.DestroyUserInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DestroyUser indicates an expected call of DestroyUser.
+func (mr *MockUsersClientMockRecorder) DestroyUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyUser", reflect.TypeOf((*MockUsersClient)(nil).DestroyUser), arg0, arg1)
+}
+
+// ListUsers mocks base method.
+func (m *MockUsersClient) ListUsers(arg0 context.Context, arg1 juju.ListUsersInput) (juju.ListUsersOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1)
+	ret0, _ := ret[0].(juju.ListUsersOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockUsersClientMockRecorder) ListUsers(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUsersClient)(nil).ListUsers), arg0, arg1)
+}
+
+// ModelUserInfo mocks base method.
+func (m *MockUsersClient) ModelUserInfo(arg0 context.Context, arg1 string) (*juju.ReadModelUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModelUserInfo", arg0, arg1)
+	ret0, _ := ret[0].(*juju.ReadModelUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModelUserInfo indicates an expected call of ModelUserInfo.
+func (mr *MockUsersClientMockRecorder) ModelUserInfo(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModelUserInfo", reflect.TypeOf((*MockUsersClient)(nil).ModelUserInfo), arg0, arg1)
+}
+
+// ReadUser mocks base method.
+func (m *MockUsersClient) ReadUser(arg0 context.Context, arg1 string) (*juju.ReadUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadUser", arg0, arg1)
+	ret0, _ := ret[0].(*juju.ReadUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadUser indicates an expected call of ReadUser.
+func (mr *MockUsersClientMockRecorder) ReadUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUser", reflect.TypeOf((*MockUsersClient)(nil).ReadUser), arg0, arg1)
+}
+
+// UpdateUser mocks base method.
+func (m *MockUsersClient) UpdateUser(arg0 context.Context, arg1 juju.UpdateUserInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockUsersClientMockRecorder) UpdateUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUsersClient)(nil).UpdateUser), arg0, arg1)
+}
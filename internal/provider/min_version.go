@@ -0,0 +1,63 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/juju/version/v2"
+)
+
+// minJujuVersions are the minimum controller versions required by
+// resources and data sources, keyed by type name, and by attributes,
+// keyed by type name and attribute name, e.g. "juju_application.trust".
+// A resource and a data source of the same type name share their
+// requirement.
+//
+// Resources and data sources call checkMinJujuVersion with their type
+// name from their Configure method, and with the key of an attribute
+// from their ModifyPlan method when the attribute is set. Older
+// controllers would otherwise fail with obscure facade errors.
+var minJujuVersions = map[string]version.Number{
+	"juju_access_secret":   version.MustParse("3.3.0"),
+	"juju_secret":          version.MustParse("3.3.0"),
+	"juju_secret_backends": version.MustParse("3.1.0"),
+}
+
+// controllerVersioner is the part of juju.Client checkMinJujuVersion
+// uses.
+type controllerVersioner interface {
+	ControllerVersion() (version.Number, bool)
+}
+
+// checkMinJujuVersion reports whether the controller runs the minimum
+// version declared for key in minJujuVersions, adding an error
+// otherwise. It is satisfied when no version is declared for key, or
+// the version of the controller is not known, e.g. before the provider
+// is configured.
+func checkMinJujuVersion(client controllerVersioner, key string, diags *diag.Diagnostics) bool {
+	minVersion, ok := minJujuVersions[key]
+	if !ok {
+		return true
+	}
+	controllerVersion, ok := client.ControllerVersion()
+	if !ok || controllerVersion.ToPatch().Compare(minVersion) >= 0 {
+		return true
+	}
+
+	summary := "Unsupported Juju Version"
+	detail := fmt.Sprintf("%s requires Juju >= %s, the controller runs Juju %s. "+
+		"Upgrade the controller to use it.", key, minVersion, controllerVersion)
+	if typeName, attribute, ok := strings.Cut(key, "."); ok {
+		diags.AddAttributeError(path.Root(attribute), summary, fmt.Sprintf(
+			"The %s attribute of %s requires Juju >= %s, the controller runs Juju %s. "+
+				"Upgrade the controller or remove the attribute.", attribute, typeName, minVersion, controllerVersion))
+		return false
+	}
+	diags.AddError(summary, detail)
+	return false
+}
@@ -0,0 +1,102 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/juju/version/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+type fakeControllerVersioner struct {
+	version string
+}
+
+func (f fakeControllerVersioner) ControllerVersion() (version.Number, bool) {
+	if f.version == "" {
+		return version.Zero, false
+	}
+	return version.MustParse(f.version), true
+}
+
+func TestCheckMinJujuVersion(t *testing.T) {
+	tests := []struct {
+		about   string
+		client  controllerVersioner
+		key     string
+		success bool
+	}{{
+		about:   "newer controller",
+		client:  fakeControllerVersioner{version: "3.4.2"},
+		key:     "juju_secret",
+		success: true,
+	}, {
+		about:   "same version",
+		client:  fakeControllerVersioner{version: "3.3.0"},
+		key:     "juju_secret",
+		success: true,
+	}, {
+		about:   "build of the same version",
+		client:  fakeControllerVersioner{version: "3.3.0.1"},
+		key:     "juju_secret",
+		success: true,
+	}, {
+		about:   "older controller",
+		client:  fakeControllerVersioner{version: "3.1.8"},
+		key:     "juju_secret",
+		success: false,
+	}, {
+		about:   "unknown controller version",
+		client:  fakeControllerVersioner{},
+		key:     "juju_secret",
+		success: true,
+	}, {
+		about:   "provider not configured",
+		client:  (*juju.Client)(nil),
+		key:     "juju_secret",
+		success: true,
+	}, {
+		about:   "no requirement",
+		client:  fakeControllerVersioner{version: "2.9.49"},
+		key:     "juju_model",
+		success: true,
+	}}
+	for _, test := range tests {
+		t.Run(test.about, func(t *testing.T) {
+			var diags diag.Diagnostics
+			assert.Equal(t, test.success, checkMinJujuVersion(test.client, test.key, &diags))
+			assert.Equal(t, !test.success, diags.HasError())
+		})
+	}
+}
+
+func TestCheckMinJujuVersionError(t *testing.T) {
+	var diags diag.Diagnostics
+	checkMinJujuVersion(fakeControllerVersioner{version: "3.1.8"}, "juju_secret", &diags)
+
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Unsupported Juju Version", diags[0].Summary())
+	assert.Equal(t, "juju_secret requires Juju >= 3.3.0, the controller runs Juju 3.1.8. "+
+		"Upgrade the controller to use it.", diags[0].Detail())
+}
+
+func TestCheckMinJujuVersionAttribute(t *testing.T) {
+	minJujuVersions["juju_application.trust"] = version.MustParse("3.4.0")
+	t.Cleanup(func() { delete(minJujuVersions, "juju_application.trust") })
+
+	var diags diag.Diagnostics
+	checkMinJujuVersion(fakeControllerVersioner{version: "3.3.5"}, "juju_application.trust", &diags)
+
+	require.Len(t, diags, 1)
+	withPath, ok := diags[0].(diag.DiagnosticWithPath)
+	require.True(t, ok)
+	assert.Equal(t, path.Root("trust"), withPath.Path())
+	assert.Contains(t, diags[0].Detail(), "The trust attribute of juju_application requires Juju >= 3.4.0")
+}
@@ -66,7 +66,8 @@ func storageDirectivesMapRequiresReplace(ctx context.Context, req planmodifier.M
 			resp.RequiresReplace = false
 			return
 		}
-		if (value.Size != stateValue.Size) || (value.Pool != stateValue.Pool) || (value.Count != stateValue.Count) {
+		// Increasing the count of a storage is done with add-storage.
+		if (value.Size != stateValue.Size) || (value.Pool != stateValue.Pool) || (value.Count < stateValue.Count) {
 			resp.RequiresReplace = true
 			return
 		}
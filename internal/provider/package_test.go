@@ -0,0 +1,6 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider_test
+
+//go:generate go run go.uber.org/mock/mockgen -package provider -destination juju_mock_test.go github.com/juju/terraform-provider-juju/internal/juju AnnotationsClient,ApplicationsClient,BackupsClient,BundlesClient,CharmsClient,CredentialsClient,ExecClient,IntegrationsClient,MachinesClient,ModelsClient,OffersClient,SecretsClient,SSHKeysClient,StatusClient,UsersClient
@@ -0,0 +1,40 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// setPartialState sets val as the state of a resource whose create
+// fails after some of its steps created objects on the controller, so
+// that Terraform tracks them rather than orphaning them. Terraform
+// taints the resource, which is replaced on the next apply. The values
+// of val still unknown are set to null, as Terraform rejects unknown
+// values after apply.
+//
+// The error of the failed step is expected to be added to the
+// diagnostics by the caller.
+func setPartialState(ctx context.Context, state *tfsdk.State, val interface{}) diag.Diagnostics {
+	diags := state.Set(ctx, val)
+	if diags.HasError() {
+		return diags
+	}
+	raw, err := tftypes.Transform(state.Raw, func(_ *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if !v.IsKnown() {
+			return tftypes.NewValue(v.Type(), nil), nil
+		}
+		return v, nil
+	})
+	if err != nil {
+		diags.AddError("Provider Error", "Unable to save the partial state of the resource, got error: "+err.Error())
+		return diags
+	}
+	state.Raw = raw
+	return diags
+}
@@ -0,0 +1,50 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetPartialState(t *testing.T) {
+	type partialModel struct {
+		ID       types.String `tfsdk:"id"`
+		Name     types.String `tfsdk:"name"`
+		Revision types.Int64  `tfsdk:"revision"`
+	}
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":       schema.StringAttribute{Computed: true},
+			"name":     schema.StringAttribute{Required: true},
+			"revision": schema.Int64Attribute{Computed: true},
+		},
+	}
+	ctx := context.Background()
+	state := tfsdk.State{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(ctx), nil),
+	}
+
+	diags := setPartialState(ctx, &state, &partialModel{
+		ID:       types.StringValue("development:wordpress"),
+		Name:     types.StringValue("wordpress"),
+		Revision: types.Int64Unknown(),
+	})
+	require.False(t, diags.HasError(), diags)
+
+	var got partialModel
+	diags = state.Get(ctx, &got)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, "development:wordpress", got.ID.ValueString())
+	assert.Equal(t, "wordpress", got.Name.ValueString())
+	assert.True(t, got.Revision.IsNull())
+}
@@ -0,0 +1,286 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/juju/juju/core/constraints"
+)
+
+// useStateForUnknownUnlessChanged returns a plan modifier which, like
+// stringplanmodifier.UseStateForUnknown, plans the value in state for
+// a computed attribute, unless one of the attributes at paths changes.
+// It is meant for IDs made of attributes which are updated in place.
+func useStateForUnknownUnlessChanged(paths ...path.Path) planmodifier.String {
+	return useStateForUnknownUnlessChangedModifier{paths: paths}
+}
+
+type useStateForUnknownUnlessChangedModifier struct {
+	paths []path.Path
+}
+
+// Description returns a plain text description of the modifier's behavior.
+func (m useStateForUnknownUnlessChangedModifier) Description(_ context.Context) string {
+	return fmt.Sprintf("Once set, the value of this attribute in state will not change unless %v change.", m.paths)
+}
+
+// MarkdownDescription returns a markdown formatted description of the
+// modifier's behavior.
+func (m useStateForUnknownUnlessChangedModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// PlanModifyString implements planmodifier.String.
+func (m useStateForUnknownUnlessChangedModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to do on create, or if the value is already planned.
+	if req.StateValue.IsNull() || !req.PlanValue.IsUnknown() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	for _, p := range m.paths {
+		var planned, prior attr.Value
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, p, &planned)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, p, &prior)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !planned.Equal(prior) {
+			return
+		}
+	}
+	resp.PlanValue = req.StateValue
+}
+
+// localCharmSHA256 returns the SHA-256 hash of the local charm archive
+// at the path attribute next to p in config, null if the path is not
+// set and unknown if it is not known yet.
+func localCharmSHA256(ctx context.Context, config tfsdk.Config, p path.Path) (types.String, diag.Diagnostics) {
+	return localFileSHA256(ctx, config, p, "Unable to Read Charm")
+}
+
+// localFileSHA256 returns the SHA-256 hash of the local file at the path
+// attribute next to p in config, like localCharmSHA256. summary is the
+// summary of the error reading the file.
+func localFileSHA256(ctx context.Context, config tfsdk.Config, p path.Path, summary string) (types.String, diag.Diagnostics) {
+	var filePath types.String
+	pathAttr := p.ParentPath().AtName(CharmPathKey)
+	diags := config.GetAttribute(ctx, pathAttr, &filePath)
+	if diags.HasError() || filePath.IsNull() {
+		return types.StringNull(), diags
+	}
+	if filePath.IsUnknown() {
+		return types.StringUnknown(), diags
+	}
+	f, err := os.Open(filePath.ValueString())
+	if err != nil {
+		diags.AddAttributeError(pathAttr, summary, err.Error())
+		return types.StringNull(), diags
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		diags.AddAttributeError(pathAttr, summary, err.Error())
+		return types.StringNull(), diags
+	}
+	return types.StringValue(hex.EncodeToString(hash.Sum(nil))), diags
+}
+
+// localCharmSHA256Modifier returns a plan modifier which plans the
+// SHA-256 hash of the local charm archive, so that a change of its
+// content is planned as an update of the charm.
+func localCharmSHA256Modifier() planmodifier.String {
+	return localFileSHA256PlanModifier{file: "local charm archive", summary: "Unable to Read Charm"}
+}
+
+// localResourceSHA256Modifier returns a plan modifier which plans the
+// SHA-256 hash of the local file of a charm resource, so that a change
+// of its content is planned as an upload of the resource.
+func localResourceSHA256Modifier() planmodifier.String {
+	return localFileSHA256PlanModifier{file: "local resource file", summary: "Unable to Read Resource"}
+}
+
+type localFileSHA256PlanModifier struct {
+	file    string
+	summary string
+}
+
+// Description returns a plain text description of the modifier's behavior.
+func (m localFileSHA256PlanModifier) Description(_ context.Context) string {
+	return fmt.Sprintf("The value of this attribute is the SHA-256 hash of the %s.", m.file)
+}
+
+// MarkdownDescription returns a markdown formatted description of the
+// modifier's behavior.
+func (m localFileSHA256PlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// PlanModifyString implements planmodifier.String.
+func (m localFileSHA256PlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to do on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	resp.PlanValue, resp.Diagnostics = localFileSHA256(ctx, req.Config, req.Path, m.summary)
+}
+
+// useStateForUnknownUnlessCharmChanged returns a plan modifier which
+// plans the value in state for a computed attribute of the charm,
+// unless the charm is switched to another name or the content of the
+// local charm archive changes, uploading a new revision.
+func useStateForUnknownUnlessCharmChanged() useStateForUnknownUnlessCharmChangedModifier {
+	return useStateForUnknownUnlessCharmChangedModifier{}
+}
+
+type useStateForUnknownUnlessCharmChangedModifier struct{}
+
+// Description returns a plain text description of the modifier's behavior.
+func (m useStateForUnknownUnlessCharmChangedModifier) Description(_ context.Context) string {
+	return "Once set, the value of this attribute in state will not change unless the charm is switched or the local charm archive changes."
+}
+
+// MarkdownDescription returns a markdown formatted description of the
+// modifier's behavior.
+func (m useStateForUnknownUnlessCharmChangedModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// PlanModifyString implements planmodifier.String.
+func (m useStateForUnknownUnlessCharmChangedModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || !req.PlanValue.IsUnknown() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	unchanged, diags := m.charmUnchanged(ctx, req.Config, req.State, req.Path)
+	resp.Diagnostics.Append(diags...)
+	if unchanged {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// PlanModifyInt64 implements planmodifier.Int64.
+func (m useStateForUnknownUnlessCharmChangedModifier) PlanModifyInt64(ctx context.Context, req planmodifier.Int64Request, resp *planmodifier.Int64Response) {
+	if req.StateValue.IsNull() || !req.PlanValue.IsUnknown() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	unchanged, diags := m.charmUnchanged(ctx, req.Config, req.State, req.Path)
+	resp.Diagnostics.Append(diags...)
+	if unchanged {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+func (m useStateForUnknownUnlessCharmChangedModifier) charmUnchanged(ctx context.Context, config tfsdk.Config, state tfsdk.State, p path.Path) (bool, diag.Diagnostics) {
+	var plannedName, priorName types.String
+	diags := config.GetAttribute(ctx, p.ParentPath().AtName(CharmNameKey), &plannedName)
+	diags.Append(state.GetAttribute(ctx, p.ParentPath().AtName(CharmNameKey), &priorName)...)
+	planned, d := localCharmSHA256(ctx, config, p)
+	diags.Append(d...)
+	var prior types.String
+	diags.Append(state.GetAttribute(ctx, p.ParentPath().AtName(CharmSHA256Key), &prior)...)
+	if diags.HasError() {
+		return false, diags
+	}
+	return plannedName.Equal(priorName) && planned.Equal(prior), diags
+}
+
+// charmNameRequiresReplace requires the replacement of the application
+// when the name of its charm changes, unless the switch of the charm is
+// allowed with allow_charm_switch, refreshing the application like
+// `juju refresh --switch`.
+func charmNameRequiresReplace(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	if req.ConfigValue.IsNull() {
+		return
+	}
+	var allowSwitch types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(AllowCharmSwitchKey), &allowSwitch)...)
+	resp.RequiresReplace = !allowSwitch.ValueBool()
+}
+
+// constraintsRequiresReplace requires the replacement of the machine
+// when its constraints change, which Juju cannot update. Constraints
+// equivalent to the prior ones, e.g. reordered or mem=4G for mem=4096M,
+// are only updated in state.
+func constraintsRequiresReplace(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	if req.ConfigValue.IsNull() {
+		return
+	}
+	prior, err := constraints.Parse(req.StateValue.ValueString())
+	resp.RequiresReplace = err != nil || !sameConstraints(req.PlanValue, prior, "")
+}
+
+// useStateForUnknownUnlessOSChanged returns a plan modifier which plans
+// the value in state for the base or series of the charm, unless the
+// other one, named sibling, is configured with a new value, changing
+// the operating system of the application.
+func useStateForUnknownUnlessOSChanged(sibling string) planmodifier.String {
+	return useStateForUnknownUnlessOSChangedModifier{sibling: sibling}
+}
+
+type useStateForUnknownUnlessOSChangedModifier struct {
+	sibling string
+}
+
+// Description returns a plain text description of the modifier's behavior.
+func (m useStateForUnknownUnlessOSChangedModifier) Description(_ context.Context) string {
+	return fmt.Sprintf("Once set, the value of this attribute in state will not change unless %s changes.", m.sibling)
+}
+
+// MarkdownDescription returns a markdown formatted description of the
+// modifier's behavior.
+func (m useStateForUnknownUnlessOSChangedModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// PlanModifyString implements planmodifier.String.
+func (m useStateForUnknownUnlessOSChangedModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || !req.PlanValue.IsUnknown() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	var configured, prior types.String
+	p := req.Path.ParentPath().AtName(m.sibling)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, p, &configured)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, p, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if configured.IsUnknown() || (!configured.IsNull() && !configured.Equal(prior)) {
+		return
+	}
+	resp.PlanValue = req.StateValue
+}
+
+// placementsListRequiresReplace is a plan modifier function that
+// determines if the placements of the units require the application to
+// be replaced. Units added or removed at the end of the list are
+// updated in place, changing the placement of an existing unit is not.
+func placementsListRequiresReplace(ctx context.Context, req planmodifier.ListRequest, resp *listplanmodifier.RequiresReplaceIfFuncResponse) {
+	if req.PlanValue.IsUnknown() || req.StateValue.IsNull() {
+		return
+	}
+	var planPlacements, statePlacements []types.String
+	resp.Diagnostics.Append(req.PlanValue.ElementsAs(ctx, &planPlacements, false)...)
+	resp.Diagnostics.Append(req.StateValue.ElementsAs(ctx, &statePlacements, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for i := 0; i < len(planPlacements) && i < len(statePlacements); i++ {
+		if !planPlacements[i].Equal(statePlacements[i]) {
+			resp.RequiresReplace = true
+			return
+		}
+	}
+}
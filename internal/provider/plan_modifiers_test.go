@@ -0,0 +1,252 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseStateForUnknownUnlessChanged(t *testing.T) {
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":    schema.StringAttribute{Computed: true},
+			"users": schema.ListAttribute{ElementType: types.StringType, Required: true},
+		},
+	}
+	ctx := context.Background()
+	raw := func(id tftypes.Value, users ...string) tftypes.Value {
+		values := make([]tftypes.Value, 0, len(users))
+		for _, u := range users {
+			values = append(values, tftypes.NewValue(tftypes.String, u))
+		}
+		return tftypes.NewValue(s.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"id":    id,
+			"users": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, values),
+		})
+	}
+	unknown := tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	prior := tftypes.NewValue(tftypes.String, "development:read:bob")
+
+	tests := []struct {
+		about    string
+		users    []string
+		expected types.String
+	}{{
+		about:    "users unchanged",
+		users:    []string{"bob"},
+		expected: types.StringValue("development:read:bob"),
+	}, {
+		about:    "users changed",
+		users:    []string{"bob", "alice"},
+		expected: types.StringUnknown(),
+	}}
+	for _, test := range tests {
+		t.Run(test.about, func(t *testing.T) {
+			req := planmodifier.StringRequest{
+				Path:        path.Root("id"),
+				ConfigValue: types.StringNull(),
+				PlanValue:   types.StringUnknown(),
+				StateValue:  types.StringValue("development:read:bob"),
+				Plan:        tfsdk.Plan{Schema: s, Raw: raw(unknown, test.users...)},
+				State:       tfsdk.State{Schema: s, Raw: raw(prior, "bob")},
+			}
+			resp := planmodifier.StringResponse{PlanValue: req.PlanValue}
+			useStateForUnknownUnlessChanged(path.Root("users")).PlanModifyString(ctx, req, &resp)
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			assert.Equal(t, test.expected, resp.PlanValue)
+		})
+	}
+}
+
+func TestCharmPlanModifiers(t *testing.T) {
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name":     schema.StringAttribute{Required: true},
+			"path":     schema.StringAttribute{Optional: true},
+			"sha256":   schema.StringAttribute{Computed: true},
+			"revision": schema.Int64Attribute{Computed: true},
+		},
+	}
+	ctx := context.Background()
+	charmPath := filepath.Join(t.TempDir(), "local.charm")
+	require.NoError(t, os.WriteFile(charmPath, []byte("charm"), 0600))
+	sum := sha256.Sum256([]byte("charm"))
+	hash := hex.EncodeToString(sum[:])
+
+	raw := func(name string, charmPath, hash tftypes.Value, revision int64) tftypes.Value {
+		return tftypes.NewValue(s.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"name":     tftypes.NewValue(tftypes.String, name),
+			"path":     charmPath,
+			"sha256":   hash,
+			"revision": tftypes.NewValue(tftypes.Number, revision),
+		})
+	}
+	unknown := tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	null := tftypes.NewValue(tftypes.String, nil)
+	config := tfsdk.Config{Schema: s, Raw: raw("local", tftypes.NewValue(tftypes.String, charmPath), null, 0)}
+
+	req := planmodifier.StringRequest{
+		Path:       path.Root("sha256"),
+		PlanValue:  types.StringUnknown(),
+		StateValue: types.StringNull(),
+		Config:     config,
+		Plan:       tfsdk.Plan{Schema: s, Raw: raw("local", tftypes.NewValue(tftypes.String, charmPath), unknown, 0)},
+	}
+	resp := planmodifier.StringResponse{PlanValue: req.PlanValue}
+	localCharmSHA256Modifier().PlanModifyString(ctx, req, &resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Equal(t, types.StringValue(hash), resp.PlanValue)
+
+	req.Config = tfsdk.Config{Schema: s, Raw: raw("local", null, null, 0)}
+	localCharmSHA256Modifier().PlanModifyString(ctx, req, &resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Equal(t, types.StringNull(), resp.PlanValue)
+
+	tests := []struct {
+		about     string
+		priorName string
+		prior     string
+		expected  types.Int64
+	}{{
+		about:     "archive unchanged",
+		priorName: "local",
+		prior:     hash,
+		expected:  types.Int64Value(3),
+	}, {
+		about:     "archive changed",
+		priorName: "local",
+		prior:     "0123",
+		expected:  types.Int64Unknown(),
+	}, {
+		about:     "charm switched",
+		priorName: "upstream",
+		prior:     hash,
+		expected:  types.Int64Unknown(),
+	}}
+	for _, test := range tests {
+		t.Run(test.about, func(t *testing.T) {
+			req := planmodifier.Int64Request{
+				Path:        path.Root("revision"),
+				ConfigValue: types.Int64Null(),
+				PlanValue:   types.Int64Unknown(),
+				StateValue:  types.Int64Value(3),
+				Config:      config,
+				State:       tfsdk.State{Schema: s, Raw: raw(test.priorName, tftypes.NewValue(tftypes.String, charmPath), tftypes.NewValue(tftypes.String, test.prior), 3)},
+			}
+			resp := planmodifier.Int64Response{PlanValue: req.PlanValue}
+			useStateForUnknownUnlessCharmChanged().PlanModifyInt64(ctx, req, &resp)
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			assert.Equal(t, test.expected, resp.PlanValue)
+		})
+	}
+}
+
+func TestPlacementsListRequiresReplace(t *testing.T) {
+	ctx := context.Background()
+	placements := func(values ...string) types.List {
+		elements := make([]attr.Value, 0, len(values))
+		for _, v := range values {
+			elements = append(elements, types.StringValue(v))
+		}
+		return types.ListValueMust(types.StringType, elements)
+	}
+	tests := []struct {
+		name    string
+		state   types.List
+		plan    types.List
+		replace bool
+	}{
+		{"unchanged", placements("0", "lxd:1"), placements("0", "lxd:1"), false},
+		{"appended", placements("0"), placements("0", "lxd:1", "zone=us-east-1a"), false},
+		{"truncated", placements("0", "lxd:1"), placements("0"), false},
+		{"changed", placements("0", "lxd:1"), placements("0", "lxd:2"), true},
+		{"new", types.ListNull(types.StringType), placements("0"), false},
+		{"unknown", placements("0"), types.ListUnknown(types.StringType), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := &listplanmodifier.RequiresReplaceIfFuncResponse{}
+			placementsListRequiresReplace(ctx, planmodifier.ListRequest{StateValue: test.state, PlanValue: test.plan}, resp)
+			require.False(t, resp.Diagnostics.HasError())
+			assert.Equal(t, test.replace, resp.RequiresReplace)
+		})
+	}
+}
+
+func TestCharmNameRequiresReplace(t *testing.T) {
+	ctx := context.Background()
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			AllowCharmSwitchKey: schema.BoolAttribute{Optional: true},
+		},
+	}
+	config := func(allow tftypes.Value) tfsdk.Config {
+		return tfsdk.Config{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), map[string]tftypes.Value{
+			AllowCharmSwitchKey: allow,
+		})}
+	}
+	tests := []struct {
+		name    string
+		allow   tftypes.Value
+		replace bool
+	}{
+		{"not set", tftypes.NewValue(tftypes.Bool, nil), true},
+		{"not allowed", tftypes.NewValue(tftypes.Bool, false), true},
+		{"allowed", tftypes.NewValue(tftypes.Bool, true), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := &stringplanmodifier.RequiresReplaceIfFuncResponse{}
+			charmNameRequiresReplace(ctx, planmodifier.StringRequest{
+				Config:      config(test.allow),
+				ConfigValue: types.StringValue("postgresql-fork"),
+				StateValue:  types.StringValue("postgresql"),
+			}, resp)
+			require.False(t, resp.Diagnostics.HasError())
+			assert.Equal(t, test.replace, resp.RequiresReplace)
+		})
+	}
+}
+
+func TestConstraintsRequiresReplace(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  types.String
+		replace bool
+	}{
+		{"reordered", types.StringValue("mem=4G cores=2"), false},
+		{"equivalent", types.StringValue("cores=2 mem=4096M"), false},
+		{"changed", types.StringValue("cores=4 mem=4G"), true},
+		{"not set", types.StringNull(), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := &stringplanmodifier.RequiresReplaceIfFuncResponse{}
+			constraintsRequiresReplace(context.Background(), planmodifier.StringRequest{
+				ConfigValue: test.config,
+				PlanValue:   test.config,
+				StateValue:  types.StringValue("cores=2 mem=4G"),
+			}, resp)
+			require.False(t, resp.Diagnostics.HasError())
+			assert.Equal(t, test.replace, resp.RequiresReplace)
+		})
+	}
+}
@@ -10,11 +10,15 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -34,6 +38,13 @@ const (
 	JujuClientIDEnvKey     = "JUJU_CLIENT_ID"
 	JujuClientSecretEnvKey = "JUJU_CLIENT_SECRET"
 
+	JujuTransientRetryTimeoutEnvKey = "JUJU_TRANSIENT_RETRY_TIMEOUT"
+	JujuExperimentalFeaturesEnvKey  = "JUJU_EXPERIMENTAL_FEATURES"
+	JujuWatchModelsEnvKey           = "JUJU_WATCH_MODELS"
+	JujuUnreachableControllerEnvKey = "JUJU_UNREACHABLE_CONTROLLER"
+	JujuOTLPEndpointEnvKey          = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	JujuOTLPHeadersEnvKey           = "OTEL_EXPORTER_OTLP_HEADERS"
+
 	JujuController   = "controller_addresses"
 	JujuUsername     = "username"
 	JujuPassword     = "password"
@@ -41,9 +52,22 @@ const (
 	JujuClientSecret = "client_secret"
 	JujuCACert       = "ca_certificate"
 
+	JujuTransientRetryTimeout = "transient_retry_timeout"
+	JujuExperimentalFeatures  = "experimental_features"
+	JujuWatchModels           = "watch_models"
+	JujuUnreachableController = "unreachable_controller"
+	JujuOTLPEndpoint          = "otlp_endpoint"
+	JujuOTLPHeaders           = "otlp_headers"
+
 	TwoSourcesAuthWarning = "Two sources of identity for controller login"
 )
 
+// Values of the unreachable_controller attribute.
+const (
+	UnreachableControllerFail = "fail"
+	UnreachableControllerWarn = "warn"
+)
+
 // jujuProviderModelEnvVar gets the controller config,
 // from environment variables.
 func jujuProviderModelEnvVar() jujuProviderModel {
@@ -91,6 +115,7 @@ func getEnvVar(field string) types.String {
 
 // Ensure jujuProvider satisfies various provider interfaces.
 var _ provider.Provider = &jujuProvider{}
+var _ provider.ProviderWithFunctions = &jujuProvider{}
 
 // NewJujuProvider returns a framework style terraform provider.
 func NewJujuProvider(version string) provider.Provider {
@@ -108,6 +133,13 @@ type jujuProviderModel struct {
 	CACert          types.String `tfsdk:"ca_certificate"`
 	ClientID        types.String `tfsdk:"client_id"`
 	ClientSecret    types.String `tfsdk:"client_secret"`
+
+	TransientRetryTimeout types.String `tfsdk:"transient_retry_timeout"`
+	ExperimentalFeatures  types.Set    `tfsdk:"experimental_features"`
+	WatchModels           types.Bool   `tfsdk:"watch_models"`
+	UnreachableController types.String `tfsdk:"unreachable_controller"`
+	OTLPEndpoint          types.String `tfsdk:"otlp_endpoint"`
+	OTLPHeaders           types.Map    `tfsdk:"otlp_headers"`
 }
 
 func (j jujuProviderModel) loginViaUsername() bool {
@@ -118,6 +150,25 @@ func (j jujuProviderModel) loginViaClientCredentials() bool {
 	return j.ClientID.ValueString() != "" && j.ClientSecret.ValueString() != ""
 }
 
+// redacted returns the model with its password, client secret and
+// the values of the OTLP headers masked, to be logged.
+func (j jujuProviderModel) redacted() jujuProviderModel {
+	if j.Password.ValueString() != "" {
+		j.Password = types.StringValue(juju.RedactedValue)
+	}
+	if j.ClientSecret.ValueString() != "" {
+		j.ClientSecret = types.StringValue(juju.RedactedValue)
+	}
+	if headers := j.OTLPHeaders.Elements(); len(headers) > 0 {
+		redacted := make(map[string]attr.Value, len(headers))
+		for k := range headers {
+			redacted[k] = types.StringValue(juju.RedactedValue)
+		}
+		j.OTLPHeaders = types.MapValueMust(types.StringType, redacted)
+	}
+	return j
+}
+
 func (j jujuProviderModel) valid() bool {
 	validUserPass := j.loginViaUsername()
 	validClientCredentials := j.loginViaClientCredentials()
@@ -231,6 +282,52 @@ func (p *jujuProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 				Description: fmt.Sprintf("This is the certificate to use for identification. This can also be set by the `%s` environment variable", JujuCACertEnvKey),
 				Optional:    true,
 			},
+			JujuTransientRetryTimeout: schema.StringAttribute{
+				Description: fmt.Sprintf("How long to retry the calls failing while the controller or a model is upgrading or "+
+					"migrating, e.g. `10m`. Defaults to `%s`, `0s` disables the retries. This can also be set by the `%s` "+
+					"environment variable", juju.DefaultTransientRetryTimeout, JujuTransientRetryTimeoutEnvKey),
+				Optional: true,
+			},
+			JujuExperimentalFeatures: schema.SetAttribute{
+				Description: fmt.Sprintf("The experimental resources and data sources to enable. They may change "+
+					"in incompatible ways, or be removed, in any release. This can also be set by the `%s` "+
+					"environment variable, as a comma separated list.", JujuExperimentalFeaturesEnvKey),
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			JujuWatchModels: schema.BoolAttribute{
+				Description: fmt.Sprintf("Whether to watch the models of the controller while the provider runs, so that "+
+					"renamed and removed models are known without listing the models again. Defaults to false. This can "+
+					"also be set by the `%s` environment variable.", JujuWatchModelsEnvKey),
+				Optional: true,
+			},
+			JujuUnreachableController: schema.StringAttribute{
+				Description: fmt.Sprintf("What to do when the controller is unreachable while refreshing resources. `%s` "+
+					"fails the refresh, `%s` warns and keeps the prior state of the resources, so that plans are not "+
+					"blocked by intermittent connectivity. Creating, updating and deleting resources, and reading data "+
+					"sources, fail either way. Defaults to `%s`. This can also be set by the `%s` environment variable.",
+					UnreachableControllerFail, UnreachableControllerWarn, UnreachableControllerFail, JujuUnreachableControllerEnvKey),
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(UnreachableControllerFail, UnreachableControllerWarn),
+				},
+			},
+			JujuOTLPEndpoint: schema.StringAttribute{
+				Description: fmt.Sprintf("The URL of an OpenTelemetry collector receiving OTLP over HTTP, e.g. "+
+					"`http://localhost:4318`, to export traces of the resource and data source operations to, with "+
+					"spans for the connections to the controller and its API calls. Traces are posted in batches, with "+
+					"the protobuf encoding, to the `%s` path. Tracing is disabled when not set. This can also be set by the `%s` "+
+					"environment variable.", otlpTracesPath, JujuOTLPEndpointEnvKey),
+				Optional: true,
+			},
+			JujuOTLPHeaders: schema.MapAttribute{
+				Description: fmt.Sprintf("The headers of the requests exporting traces, e.g. for authentication. This "+
+					"can also be set by the `%s` environment variable, as a comma separated list of key=value pairs.",
+					JujuOTLPHeadersEnvKey),
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
 		},
 	}
 }
@@ -250,14 +347,43 @@ func (p *jujuProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		return
 	}
 
-	config := juju.ControllerConfiguration{
-		ControllerAddresses: strings.Split(data.ControllerAddrs.ValueString(), ","),
-		Username:            data.UserName.ValueString(),
-		Password:            data.Password.ValueString(),
-		CACert:              data.CACert.ValueString(),
-		ClientID:            data.ClientID.ValueString(),
-		ClientSecret:        data.ClientSecret.ValueString(),
+	transientRetryTimeout, err := getTransientRetryTimeout(data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root(JujuTransientRetryTimeout), "Invalid Transient Retry Timeout", err.Error())
+		return
+	}
+
+	experimentalFeatures, diags := getExperimentalFeatures(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	unreachableController, err := getUnreachableController(data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root(JujuUnreachableController), "Invalid Unreachable Controller", err.Error())
+		return
+	}
+
+	tracing, diags := getTracingConfig(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+
+	config := juju.ControllerConfiguration{
+		ControllerAddresses:      strings.Split(data.ControllerAddrs.ValueString(), ","),
+		Username:                 data.UserName.ValueString(),
+		Password:                 data.Password.ValueString(),
+		CACert:                   data.CACert.ValueString(),
+		ClientID:                 data.ClientID.ValueString(),
+		ClientSecret:             data.ClientSecret.ValueString(),
+		TransientRetryTimeout:    transientRetryTimeout,
+		ExperimentalFeatures:     experimentalFeatures,
+		KeepStateWhenUnreachable: unreachableController == UnreachableControllerWarn,
+		Tracing:                  tracing,
+	}
+	ctx = juju.RedactLogs(ctx, config)
 	client, err := juju.NewClient(ctx, config)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create juju client, got error: %s", err))
@@ -266,17 +392,82 @@ func (p *jujuProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 
 	// Here we are testing that we can connect successfully to the Juju server
 	// this prevents having logic to check the connection is OK in every function
-	testConn, err := client.Models.GetConnection(nil)
+	testConn, err := client.Models.GetConnection(ctx, nil)
+	if err != nil && config.KeepStateWhenUnreachable && isUnreachableError(err) {
+		// Reads keep the prior state of the resources, other
+		// operations fail on use of the client.
+		resp.Diagnostics.AddWarning("Controller Unreachable",
+			fmt.Sprintf("Unable to connect to the controller, got error: %s\n\n"+
+				"Refreshed resources keep their prior state as %s is set to %q.",
+				err, JujuUnreachableController, UnreachableControllerWarn))
+		resp.ResourceData = client
+		resp.DataSourceData = client
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.Append(checkClientErr(err, config)...)
 		return
 	}
 	_ = testConn.Close()
 
+	// Fill the model cache once, rather than on the first use of each
+	// model. Failing to is not fatal, models are then looked up on use.
+	if err := client.WarmModelCache(ctx, getWatchModels(data)); err != nil {
+		tflog.Warn(ctx, "Unable to fill the model cache", map[string]interface{}{"error": err.Error()})
+	}
+
 	resp.ResourceData = client
 	resp.DataSourceData = client
 }
 
+// getTransientRetryTimeout returns the retry timeout set in the plan,
+// falling back to the environment variable and the default.
+func getTransientRetryTimeout(data jujuProviderModel) (time.Duration, error) {
+	value := data.TransientRetryTimeout
+	if value.ValueString() == "" {
+		value = getEnvVar(JujuTransientRetryTimeoutEnvKey)
+	}
+	if value.ValueString() == "" {
+		return juju.DefaultTransientRetryTimeout, nil
+	}
+	timeout, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse %q as a duration: %w", value.ValueString(), err)
+	}
+	if timeout < 0 {
+		return 0, fmt.Errorf("negative duration %q", value.ValueString())
+	}
+	return timeout, nil
+}
+
+// getWatchModels returns whether to watch the models, set in the plan or
+// by the environment variable.
+func getWatchModels(data jujuProviderModel) bool {
+	if !data.WatchModels.IsNull() {
+		return data.WatchModels.ValueBool()
+	}
+	watch, _ := strconv.ParseBool(os.Getenv(JujuWatchModelsEnvKey))
+	return watch
+}
+
+// getUnreachableController returns what to do when the controller is
+// unreachable, set in the plan or the environment variable. The value
+// of the plan is validated by the schema.
+func getUnreachableController(data jujuProviderModel) (string, error) {
+	if !data.UnreachableController.IsNull() {
+		return data.UnreachableController.ValueString(), nil
+	}
+	switch value := os.Getenv(JujuUnreachableControllerEnvKey); value {
+	case "":
+		return UnreachableControllerFail, nil
+	case UnreachableControllerFail, UnreachableControllerWarn:
+		return value, nil
+	default:
+		return "", fmt.Errorf("invalid value %q of %s, expected %q or %q",
+			value, JujuUnreachableControllerEnvKey, UnreachableControllerFail, UnreachableControllerWarn)
+	}
+}
+
 // getJujuProviderModel a filled in jujuProviderModel if able. First check
 // the plan being used, then fall back to the JUJU_ environment variables,
 // lastly check to see if an active juju can supply the data.
@@ -354,7 +545,7 @@ func getJujuProviderModel(ctx context.Context, req provider.ConfigureRequest) (j
 	}
 	if diags.HasError() {
 		tflog.Debug(ctx, "Current login values.",
-			map[string]interface{}{"jujuProviderModel": planData})
+			map[string]interface{}{"jujuProviderModel": planData.redacted()})
 	}
 
 	return errMsgDataModel, diags
@@ -368,11 +559,22 @@ func getJujuProviderModel(ctx context.Context, req provider.ConfigureRequest) (j
 func (p *jujuProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		func() resource.Resource { return NewAccessModelResource() },
+		func() resource.Resource { return NewAnnotationsResource() },
 		func() resource.Resource { return NewApplicationResource() },
+		func() resource.Resource { return NewApplicationConfigResource() },
+		func() resource.Resource { return NewApplicationExposeResource() },
+		func() resource.Resource { return NewBackupResource() },
+		func() resource.Resource { return NewBundleResource() },
+		func() resource.Resource { return NewCharmResourceResource() },
 		func() resource.Resource { return NewCredentialResource() },
+		func() resource.Resource { return NewExecResource() },
+		func() resource.Resource { return NewFirewallRuleResource() },
 		func() resource.Resource { return NewIntegrationResource() },
 		func() resource.Resource { return NewMachineResource() },
 		func() resource.Resource { return NewModelResource() },
+		func() resource.Resource { return NewModelConfigResource() },
+		func() resource.Resource { return NewModelDefaultsResource() },
+		func() resource.Resource { return NewModelMigrationResource() },
 		func() resource.Resource { return NewOfferResource() },
 		func() resource.Resource { return NewSSHKeyResource() },
 		func() resource.Resource { return NewUserResource() },
@@ -388,10 +590,25 @@ func (p *jujuProvider) Resources(_ context.Context) []func() resource.Resource {
 // the Metadata method. All data sources must have unique names.
 func (p *jujuProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		func() datasource.DataSource { return NewAgentVersionsDataSource() },
+		func() datasource.DataSource { return NewApplicationsDataSource() },
+		func() datasource.DataSource { return NewBundleDiffDataSource() },
+		func() datasource.DataSource { return NewCharmResourcesDataSource() },
+		func() datasource.DataSource { return NewCharmRevisionDataSource() },
+		func() datasource.DataSource { return NewCredentialsDataSource() },
+		func() datasource.DataSource { return NewFullStatusDataSource() },
 		func() datasource.DataSource { return NewMachineDataSource() },
 		func() datasource.DataSource { return NewModelDataSource() },
+		func() datasource.DataSource { return NewModelConfigDataSource() },
+		func() datasource.DataSource { return NewModelsDataSource() },
 		func() datasource.DataSource { return NewOfferDataSource() },
 		func() datasource.DataSource { return NewSecretDataSource() },
+		func() datasource.DataSource { return NewSecretBackendsDataSource() },
+		func() datasource.DataSource { return NewSpacesDataSource() },
+		func() datasource.DataSource { return NewStoragePoolsDataSource() },
+		func() datasource.DataSource { return NewUnitDataSource() },
+		func() datasource.DataSource { return NewUsersDataSource() },
+		func() datasource.DataSource { return NewWaitForDataSource() },
 	}
 }
 
@@ -419,3 +636,21 @@ func checkClientErr(err error, config juju.ControllerConfiguration) diag.Diagnos
 	diags.AddError("Client Error", err.Error())
 	return diags
 }
+
+// Functions returns a slice of functions to instantiate each Function
+// implementation.
+//
+// The function name is determined by the Function implementing the
+// Metadata method. All functions must have unique names.
+func (p *jujuProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		func() function.Function { return NewCloudTagFunction() },
+		func() function.Function { return NewControllerTagFunction() },
+		func() function.Function { return NewGroupTagFunction() },
+		func() function.Function { return NewModelTagFunction() },
+		func() function.Function { return NewNormalizeBaseFunction() },
+		func() function.Function { return NewOfferURLFunction() },
+		func() function.Function { return NewParsePlacementFunction() },
+		func() function.Function { return NewUserTagFunction() },
+	}
+}
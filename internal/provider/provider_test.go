@@ -9,6 +9,7 @@ import (
 	"os"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -185,7 +186,10 @@ func configureProvider(t *testing.T, p provider.Provider) provider.ConfigureResp
 	Provider.Schema(context.Background(), provider.SchemaRequest{}, &schemaResp)
 	assert.Equal(t, schemaResp.Diagnostics.HasError(), false)
 
-	conf := jujuProviderModel{}
+	conf := jujuProviderModel{
+		ExperimentalFeatures: types.SetNull(types.StringType),
+		OTLPHeaders:          types.MapNull(types.StringType),
+	}
 
 	mapTypes := map[string]attr.Type{
 		JujuController:   types.StringType,
@@ -194,6 +198,13 @@ func configureProvider(t *testing.T, p provider.Provider) provider.ConfigureResp
 		JujuCACert:       types.StringType,
 		JujuClientID:     types.StringType,
 		JujuClientSecret: types.StringType,
+
+		JujuTransientRetryTimeout: types.StringType,
+		JujuExperimentalFeatures:  types.SetType{ElemType: types.StringType},
+		JujuWatchModels:           types.BoolType,
+		JujuUnreachableController: types.StringType,
+		JujuOTLPEndpoint:          types.StringType,
+		JujuOTLPHeaders:           types.MapType{ElemType: types.StringType},
 	}
 
 	val, confObjErr := types.ObjectValueFrom(context.Background(), mapTypes, conf)
@@ -218,5 +229,59 @@ func TestFrameworkProviderSchema(t *testing.T) {
 	resp := provider.SchemaResponse{}
 	jujuProvider.Schema(context.Background(), req, &resp)
 	assert.Equal(t, resp.Diagnostics.HasError(), false)
-	assert.Len(t, resp.Schema.Attributes, 6)
+	assert.Len(t, resp.Schema.Attributes, 12)
+}
+
+func TestGetTransientRetryTimeout(t *testing.T) {
+	t.Setenv(JujuTransientRetryTimeoutEnvKey, "")
+	timeout, err := getTransientRetryTimeout(jujuProviderModel{})
+	assert.NoError(t, err)
+	assert.Equal(t, juju.DefaultTransientRetryTimeout, timeout)
+
+	timeout, err = getTransientRetryTimeout(jujuProviderModel{TransientRetryTimeout: types.StringValue("0s")})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), timeout)
+
+	t.Setenv(JujuTransientRetryTimeoutEnvKey, "2m")
+	timeout, err = getTransientRetryTimeout(jujuProviderModel{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2*time.Minute, timeout)
+
+	timeout, err = getTransientRetryTimeout(jujuProviderModel{TransientRetryTimeout: types.StringValue("5m")})
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, timeout)
+
+	_, err = getTransientRetryTimeout(jujuProviderModel{TransientRetryTimeout: types.StringValue("soon")})
+	assert.Error(t, err)
+	_, err = getTransientRetryTimeout(jujuProviderModel{TransientRetryTimeout: types.StringValue("-1m")})
+	assert.Error(t, err)
+}
+
+func TestGetWatchModels(t *testing.T) {
+	t.Setenv(JujuWatchModelsEnvKey, "")
+	assert.False(t, getWatchModels(jujuProviderModel{}))
+
+	t.Setenv(JujuWatchModelsEnvKey, "true")
+	assert.True(t, getWatchModels(jujuProviderModel{}))
+	assert.False(t, getWatchModels(jujuProviderModel{WatchModels: types.BoolValue(false)}))
+}
+
+func TestGetUnreachableController(t *testing.T) {
+	t.Setenv(JujuUnreachableControllerEnvKey, "")
+	value, err := getUnreachableController(jujuProviderModel{})
+	require.NoError(t, err)
+	assert.Equal(t, UnreachableControllerFail, value)
+
+	t.Setenv(JujuUnreachableControllerEnvKey, UnreachableControllerWarn)
+	value, err = getUnreachableController(jujuProviderModel{})
+	require.NoError(t, err)
+	assert.Equal(t, UnreachableControllerWarn, value)
+
+	value, err = getUnreachableController(jujuProviderModel{UnreachableController: types.StringValue(UnreachableControllerFail)})
+	require.NoError(t, err)
+	assert.Equal(t, UnreachableControllerFail, value)
+
+	t.Setenv(JujuUnreachableControllerEnvKey, "ignore")
+	_, err = getUnreachableController(jujuProviderModel{})
+	assert.ErrorContains(t, err, `invalid value "ignore" of JUJU_UNREACHABLE_CONTROLLER`)
 }
@@ -27,6 +27,8 @@ import (
 var _ resource.Resource = &accessModelResource{}
 var _ resource.ResourceWithConfigure = &accessModelResource{}
 var _ resource.ResourceWithImportState = &accessModelResource{}
+var _ resource.ResourceWithUpgradeState = &accessModelResource{}
+var _ resource.ResourceWithIdentity = &accessModelResource{}
 
 func NewAccessModelResource() resource.Resource {
 	return &accessModelResource{}
@@ -50,10 +52,13 @@ type accessModelResourceModel struct {
 
 func (a *accessModelResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_access_model"
+	// The ID changes when the resource is updated, and so does its identity.
+	resp.ResourceBehavior = resource.ResourceBehavior{MutableIdentity: true}
 }
 
 func (a *accessModelResource) Schema(_ context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     accessModelSchemaVersion,
 		Description: "A resource that represent a Juju Access Model.",
 		Attributes: map[string]schema.Attribute{
 			"model": schema.StringAttribute{
@@ -81,11 +86,20 @@ func (a *accessModelResource) Schema(_ context.Context, req resource.SchemaReque
 			// ID required by the testing framework
 			"id": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					useStateForUnknownUnlessChanged(path.Root("users")),
+				},
 			},
 		},
 	}
 }
 
+// UpgradeState returns the state upgraders from the prior schema
+// versions of the resource, keyed by version.
+func (a *accessModelResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
 // Configure enables provider-level data or clients to be set in the
 // provider-defined DataSource type. It is separately executed for each
 // ReadDataSource RPC.
@@ -105,10 +119,13 @@ func (a *accessModelResource) Configure(ctx context.Context, req resource.Config
 	}
 	a.client = client
 	// Create the local logging subsystem here, using the TF context when creating it.
-	a.subCtx = tflog.NewSubsystem(ctx, LogResourceAccessModel)
+	a.subCtx = a.client.NewLogSubsystem(ctx, LogResourceAccessModel)
 }
 
 func (a *accessModelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := traceOperation(ctx, a.client, "juju_access_model", "Create", &resp.Diagnostics)
+	defer endSpan()
+
 	// Check first if the client is configured
 	if a.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "access model", "create")
@@ -132,14 +149,23 @@ func (a *accessModelResource) Create(ctx context.Context, req resource.CreateReq
 	modelNameStr := plan.Model.ValueString()
 	accessStr := plan.Access.ValueString()
 	// Call Models.GrantModel
-	for _, user := range users {
-		err := a.client.Models.GrantModel(juju.GrantModelInput{
+	for i, user := range users {
+		err := a.client.Models.GrantModel(ctx, juju.GrantModelInput{
 			User:      user,
 			Access:    accessStr,
 			ModelName: modelNameStr,
 		})
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create access model resource, got error: %s", err))
+			addClientError(&resp.Diagnostics, err, "Unable to create access model resource")
+			if i > 0 {
+				// Save the access granted to the previous users, so
+				// that it is revoked when the resource is replaced.
+				granted, diags := types.ListValueFrom(ctx, types.StringType, users[:i])
+				resp.Diagnostics.Append(diags...)
+				plan.Users = granted
+				plan.ID = types.StringValue(newAccessModelIDFrom(modelNameStr, accessStr, users[:i]))
+				resp.Diagnostics.Append(setPartialState(ctx, &resp.State, &plan)...)
+			}
 			return
 		}
 	}
@@ -147,9 +173,13 @@ func (a *accessModelResource) Create(ctx context.Context, req resource.CreateReq
 
 	// Set the plan onto the Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(accessModelIDFormat.setIdentity(ctx, plan.ID, resp.Identity)...)
 }
 
 func (a *accessModelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, a.client, "juju_access_model", "Read", &resp.Diagnostics)
+	defer endSpan()
+
 	// Check first if the client is configured
 	if a.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "access model", "read")
@@ -168,9 +198,9 @@ func (a *accessModelResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	response, err := a.client.Users.ModelUserInfo(modelName)
+	response, err := a.client.Users.ModelUserInfo(ctx, modelName)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read access model resource, got error: %s", err))
+		handleReadError(ctx, a.client, err, &resp.State, &resp.Diagnostics, "Unable to read access model resource")
 		return
 	}
 
@@ -196,6 +226,7 @@ func (a *accessModelResource) Read(ctx context.Context, req resource.ReadRequest
 
 	// Set the plan onto the Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(accessModelIDFormat.setIdentity(ctx, plan.ID, resp.Identity)...)
 }
 
 // Update on the access model supports three cases
@@ -207,6 +238,9 @@ func (a *accessModelResource) Read(ctx context.Context, req resource.ReadRequest
 // for new users - apply access
 // access changed - apply new access
 func (a *accessModelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := traceOperation(ctx, a.client, "juju_access_model", "Update", &resp.Diagnostics)
+	defer endSpan()
+
 	// Check first if the client is configured
 	if a.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "access model", "update")
@@ -245,15 +279,12 @@ func (a *accessModelResource) Update(ctx context.Context, req resource.UpdateReq
 	if !plan.Users.Equal(state.Users) {
 		anyChange = true
 
-		// Get the users that are in the current state
-		var stateUsers []string
-		resp.Diagnostics.Append(plan.Users.ElementsAs(ctx, &stateUsers, false)...)
+		var diags diag.Diagnostics
+		addedUserList, missingUserList, _, diags = diffSet(ctx, plan.Users, state.Users)
+		resp.Diagnostics.Append(diags...)
 		if resp.Diagnostics.HasError() {
 			return
 		}
-
-		missingUserList = getMissingUsers(stateUsers, planUsers)
-		addedUserList = getAddedUsers(stateUsers, planUsers)
 	}
 
 	// Check if access has changed
@@ -272,7 +303,7 @@ func (a *accessModelResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	err := a.client.Models.UpdateAccessModel(juju.UpdateAccessModelInput{
+	err := a.client.Models.UpdateAccessModel(ctx, juju.UpdateAccessModelInput{
 		ModelName: modelName,
 		OldAccess: oldAccess,
 		Grant:     addedUserList,
@@ -280,7 +311,7 @@ func (a *accessModelResource) Update(ctx context.Context, req resource.UpdateReq
 		Access:    access,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update access model resource, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to update access model resource")
 	}
 	a.trace(fmt.Sprintf("updated access model resource for model %q", modelName))
 
@@ -288,9 +319,13 @@ func (a *accessModelResource) Update(ctx context.Context, req resource.UpdateReq
 
 	// Set the plan onto the Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(accessModelIDFormat.setIdentity(ctx, plan.ID, resp.Identity)...)
 }
 
 func (a *accessModelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := traceOperation(ctx, a.client, "juju_access_model", "Delete", &resp.Diagnostics)
+	defer endSpan()
+
 	// Check first if the client is configured
 	if a.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "access model", "delete")
@@ -312,61 +347,24 @@ func (a *accessModelResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
-	err := a.client.Models.DestroyAccessModel(juju.DestroyAccessModelInput{
+	err := a.client.Models.DestroyAccessModel(ctx, juju.DestroyAccessModelInput{
 		ModelName: plan.Model.ValueString(),
 		Revoke:    stateUsers,
 		Access:    plan.Access.ValueString(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete access model resource, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to delete access model resource")
 	}
 }
 
-func getMissingUsers(oldUsers, newUsers []string) []string {
-	var missing []string
-	for _, user := range oldUsers {
-		found := false
-		for _, newUser := range newUsers {
-			if user == newUser {
-				found = true
-				break
-			}
-		}
-		if !found {
-			missing = append(missing, user)
-		}
-	}
-	return missing
+func (a *accessModelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	accessModelIDFormat.importState(ctx, req, resp)
 }
 
-func getAddedUsers(oldUsers, newUsers []string) []string {
-	var added []string
-	for _, user := range newUsers {
-		found := false
-		for _, oldUser := range oldUsers {
-			if user == oldUser {
-				found = true
-				break
-			}
-		}
-		if !found {
-			added = append(added, user)
-		}
-	}
-	return added
-}
-
-func (a *accessModelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	IDstr := req.ID
-	if len(strings.Split(IDstr, ":")) != 3 {
-		resp.Diagnostics.AddError(
-			"ImportState Failure",
-			fmt.Sprintf("Malformed AccessModel ID %q, "+
-				"please use format '<modelname>:<access>:<user1,user1>'", IDstr),
-		)
-		return
-	}
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+// IdentitySchema returns the schema of the identity of the resource,
+// made of the parts of its ID.
+func (a *accessModelResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = accessModelIDFormat.identitySchema()
 }
 
 func (a *accessModelResource) trace(msg string, additionalFields ...map[string]interface{}) {
@@ -380,8 +378,12 @@ func (a *accessModelResource) trace(msg string, additionalFields ...map[string]i
 	tflog.SubsystemTrace(a.subCtx, LogResourceAccessModel, msg, additionalFields...)
 }
 
+// accessModelIDFormat is the format of the ID of access models. The
+// users are comma separated.
+var accessModelIDFormat = idFormat{parts: []string{"model_name", "access", "users"}}
+
 func newAccessModelIDFrom(modelNameStr string, accessStr string, users []string) string {
-	return fmt.Sprintf("%s:%s:%s", modelNameStr, accessStr, strings.Join(users, ","))
+	return accessModelIDFormat.format(modelNameStr, accessStr, strings.Join(users, ","))
 }
 
 func retrieveAccessModelDataFromID(ctx context.Context, ID types.String, users types.List, diag *diag.Diagnostics) (string, string,
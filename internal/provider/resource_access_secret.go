@@ -6,7 +6,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -15,7 +14,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/juju/collections/set"
 
 	"github.com/juju/terraform-provider-juju/internal/juju"
 )
@@ -24,6 +22,8 @@ import (
 var _ resource.Resource = &accessSecretResource{}
 var _ resource.ResourceWithConfigure = &accessSecretResource{}
 var _ resource.ResourceWithImportState = &accessSecretResource{}
+var _ resource.ResourceWithUpgradeState = &accessSecretResource{}
+var _ resource.ResourceWithIdentity = &accessSecretResource{}
 
 func NewAccessSecretResource() resource.Resource {
 	return &accessSecretResource{}
@@ -48,31 +48,24 @@ type accessSecretResourceModel struct {
 }
 
 // ImportState reads the secret based on the model name and secret name to be
-// imported into terraform.
+// imported into terraform, or on the model name and secret ID when imported
+// with the identity of the resource.
 func (s *accessSecretResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Prevent panic if the provider has not been configured.
 	if s.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "access secret", "import")
 		return
 	}
-	// model:name
-	parts := strings.Split(req.ID, ":")
-	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
-		resp.Diagnostics.AddError(
-			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: <modelname>:<secretname>. Got: %q", req.ID),
-		)
+	input, diags := secretImportInput(ctx, req)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
 		return
 	}
-	modelName := parts[0]
-	secretName := parts[1]
+	modelName := input.ModelName
 
-	readSecretOutput, err := s.client.Secrets.ReadSecret(&juju.ReadSecretInput{
-		ModelName: modelName,
-		Name:      &secretName,
-	})
+	readSecretOutput, err := s.client.Secrets.ReadSecret(ctx, input)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read secret for import, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to read secret for import")
 		return
 	}
 
@@ -97,6 +90,12 @@ func (s *accessSecretResource) ImportState(ctx context.Context, req resource.Imp
 	s.trace(fmt.Sprintf("import access secret resource %q", state.SecretId))
 }
 
+// IdentitySchema returns the schema of the identity of the resource,
+// the parts of its ID, with the ID of the secret rather than its name.
+func (s *accessSecretResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = secretIDFormat.identitySchema()
+}
+
 func (s *accessSecretResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_access_secret"
 }
@@ -104,6 +103,7 @@ func (s *accessSecretResource) Metadata(_ context.Context, req resource.Metadata
 // Schema is called when the resource schema is being initialized.
 func (s *accessSecretResource) Schema(_ context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     accessSecretSchemaVersion,
 		Description: "A resource that represents a Juju secret access.",
 		Attributes: map[string]schema.Attribute{
 			"model": schema.StringAttribute{
@@ -136,6 +136,12 @@ func (s *accessSecretResource) Schema(_ context.Context, req resource.SchemaRequ
 	}
 }
 
+// UpgradeState returns the state upgraders from the prior schema
+// versions of the resource, keyed by version.
+func (s *accessSecretResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
 // Configure is called when the resource is being configured.
 func (s *accessSecretResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
@@ -152,12 +158,16 @@ func (s *accessSecretResource) Configure(ctx context.Context, req resource.Confi
 		return
 	}
 	s.client = client
+	checkMinJujuVersion(client, "juju_access_secret", &resp.Diagnostics)
 	// Create the local logging subsystem here, using the TF context when creating it.
-	s.subCtx = tflog.NewSubsystem(ctx, LogResourceAccessSecret)
+	s.subCtx = s.client.NewLogSubsystem(ctx, LogResourceAccessSecret)
 }
 
 // Create is called when the resource is being created.
 func (s *accessSecretResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := traceOperation(ctx, s.client, "juju_access_secret", "Create", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if s.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "secret", "create")
@@ -175,25 +185,29 @@ func (s *accessSecretResource) Create(ctx context.Context, req resource.CreateRe
 	applications := make([]string, len(plan.Applications.Elements()))
 	resp.Diagnostics.Append(plan.Applications.ElementsAs(ctx, &applications, false)...)
 
-	err := s.client.Secrets.UpdateAccessSecret(&juju.GrantRevokeAccessSecretInput{
+	err := s.client.Secrets.UpdateAccessSecret(ctx, &juju.GrantRevokeAccessSecretInput{
 		ModelName:    plan.Model.ValueString(),
 		SecretId:     plan.SecretId.ValueString(),
 		Applications: applications,
 	}, juju.GrantAccess)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to grant secret access, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to grant secret access")
 		return
 	}
 
 	// Save plan into Terraform state
 	plan.ID = types.StringValue(newSecretID(plan.Model.ValueString(), plan.SecretId.ValueString()))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(secretIDFormat.setIdentity(ctx, plan.ID, resp.Identity)...)
 
 	s.trace(fmt.Sprintf("grant secret access to %s", plan.SecretId))
 }
 
 // Read is called when the resource is being read.
 func (s *accessSecretResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, s.client, "juju_access_secret", "Read", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if s.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "access_secret", "read")
@@ -208,12 +222,12 @@ func (s *accessSecretResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
-	readSecretOutput, err := s.client.Secrets.ReadSecret(&juju.ReadSecretInput{
+	readSecretOutput, err := s.client.Secrets.ReadSecret(ctx, &juju.ReadSecretInput{
 		SecretId:  state.SecretId.ValueString(),
 		ModelName: state.Model.ValueString(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read secret, got error: %s", err))
+		handleReadError(ctx, s.client, err, &resp.State, &resp.Diagnostics, "Unable to read secret")
 		return
 	}
 
@@ -229,12 +243,16 @@ func (s *accessSecretResource) Read(ctx context.Context, req resource.ReadReques
 
 	// Save state into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(secretIDFormat.setIdentity(ctx, state.ID, resp.Identity)...)
 
 	s.trace(fmt.Sprintf("read secret access %s", state.SecretId))
 }
 
 // Update is called when the resource is being updated.
 func (s *accessSecretResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := traceOperation(ctx, s.client, "juju_access_secret", "Update", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if s.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "access_secret", "update")
@@ -263,24 +281,12 @@ func (s *accessSecretResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	planApplications := make([]string, len(plan.Applications.Elements()))
-	resp.Diagnostics.Append(plan.Applications.ElementsAs(ctx, &planApplications, false)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	stateApplications := make([]string, len(state.Applications.Elements()))
-	resp.Diagnostics.Append(state.Applications.ElementsAs(ctx, &stateApplications, false)...)
+	applicationsToGrant, applicationsToRevoke, _, diags := diffSet(ctx, plan.Applications, state.Applications)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	planSet := set.NewStrings(planApplications...)
-	stateSet := set.NewStrings(stateApplications...)
-
-	applicationsToGrant := planSet.Difference(stateSet)
-	applicationsToRevoke := stateSet.Difference(planSet)
-
 	s.trace(fmt.Sprintf("applications to revoke secret: %v", applicationsToRevoke))
 	s.trace(fmt.Sprintf("applications to grant secret: %v", applicationsToGrant))
 
@@ -294,27 +300,27 @@ func (s *accessSecretResource) Update(ctx context.Context, req resource.UpdateRe
 	}
 
 	// revoke access to applications that are in the state but not in the plan
-	if !applicationsToGrant.IsEmpty() {
-		err := s.client.Secrets.UpdateAccessSecret(&juju.GrantRevokeAccessSecretInput{
+	if len(applicationsToGrant) > 0 {
+		err := s.client.Secrets.UpdateAccessSecret(ctx, &juju.GrantRevokeAccessSecretInput{
 			ModelName:    state.Model.ValueString(),
 			SecretId:     state.SecretId.ValueString(),
-			Applications: applicationsToGrant.Values(),
+			Applications: applicationsToGrant,
 		}, juju.GrantAccess)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to grant secret access, got error: %s", err))
+			addClientError(&resp.Diagnostics, err, "Unable to grant secret access")
 			return
 		}
 	}
 
 	// grant access to applications that are in the plan but not in the state
-	if !applicationsToRevoke.IsEmpty() {
-		err := s.client.Secrets.UpdateAccessSecret(&juju.GrantRevokeAccessSecretInput{
+	if len(applicationsToRevoke) > 0 {
+		err := s.client.Secrets.UpdateAccessSecret(ctx, &juju.GrantRevokeAccessSecretInput{
 			ModelName:    state.Model.ValueString(),
 			SecretId:     state.SecretId.ValueString(),
-			Applications: applicationsToRevoke.Values(),
+			Applications: applicationsToRevoke,
 		}, juju.RevokeAccess)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke secret access, got error: %s", err))
+			addClientError(&resp.Diagnostics, err, "Unable to revoke secret access")
 			return
 		}
 	}
@@ -327,6 +333,9 @@ func (s *accessSecretResource) Update(ctx context.Context, req resource.UpdateRe
 
 // Delete is called when the resource is being deleted.
 func (s *accessSecretResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := traceOperation(ctx, s.client, "juju_access_secret", "Delete", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if s.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "access_secret", "delete")
@@ -347,13 +356,13 @@ func (s *accessSecretResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
-	err := s.client.Secrets.UpdateAccessSecret(&juju.GrantRevokeAccessSecretInput{
+	err := s.client.Secrets.UpdateAccessSecret(ctx, &juju.GrantRevokeAccessSecretInput{
 		ModelName:    state.Model.ValueString(),
 		SecretId:     state.SecretId.ValueString(),
 		Applications: applications,
 	}, juju.RevokeAccess)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke secret access, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to revoke secret access")
 		return
 	}
 
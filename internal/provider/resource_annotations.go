@@ -0,0 +1,362 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/juju/errors"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &annotationsResource{}
+var _ resource.ResourceWithConfigure = &annotationsResource{}
+var _ resource.ResourceWithImportState = &annotationsResource{}
+var _ resource.ResourceWithUpgradeState = &annotationsResource{}
+var _ resource.ResourceWithIdentity = &annotationsResource{}
+
+func NewAnnotationsResource() resource.Resource {
+	return &annotationsResource{}
+}
+
+type annotationsResource struct {
+	client *juju.Client
+
+	// subCtx is the context created with the new tflog subsystem for annotations.
+	subCtx context.Context
+}
+
+type annotationsResourceModel struct {
+	ModelName   types.String `tfsdk:"model"`
+	Entity      types.String `tfsdk:"entity"`
+	Annotations types.Map    `tfsdk:"annotations"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+func (r *annotationsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_annotations"
+}
+
+func (r *annotationsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: annotationsSchemaVersion,
+		Description: "A resource that sets annotations on a Juju model, application, machine or unit. Only the " +
+			"annotations configured here are managed, other annotations of the entity are left untouched.",
+		Attributes: map[string]schema.Attribute{
+			"model": schema.StringAttribute{
+				Description: "The name of the model.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"entity": schema.StringAttribute{
+				Description: "The tag of the entity to annotate, e.g. `application-mysql`, `machine-0` or " +
+					"`unit-mysql-0`. If not set, the model itself is annotated.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"annotations": schema.MapAttribute{
+				Description: "The annotations to set.",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// UpgradeState returns the state upgraders from the prior schema
+// versions of the resource, keyed by version.
+func (r *annotationsResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+func (r *annotationsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+	// Create the local logging subsystem here, using the TF context when creating it.
+	r.subCtx = r.client.NewLogSubsystem(ctx, LogResourceAnnotations)
+}
+
+// ImportState is called when the provider must import the state of a
+// resource instance. The ID is of the form <model>:<entity tag>, or only
+// <model> for the annotations of the model itself. All annotations of
+// the entity are imported.
+func (r *annotationsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	annotationsIDFormat.importState(ctx, req, resp)
+}
+
+// IdentitySchema returns the schema of the identity of the resource,
+// made of the parts of its ID.
+func (r *annotationsResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = annotationsIDFormat.identitySchema()
+}
+
+func (r *annotationsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_annotations", "Create", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "annotations", "create")
+		return
+	}
+
+	var plan annotationsResourceModel
+
+	// Read Terraform configuration from the request into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	annotations := make(map[string]string)
+	resp.Diagnostics.Append(plan.Annotations.ElementsAs(ctx, &annotations, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Annotations.SetAnnotations(ctx, juju.SetAnnotationsInput{
+		ModelName:   plan.ModelName.ValueString(),
+		EntityTag:   plan.Entity.ValueString(),
+		Annotations: annotations,
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to set annotations")
+		return
+	}
+
+	plan.ID = types.StringValue(newAnnotationsID(plan.ModelName.ValueString(), plan.Entity.ValueString()))
+	r.trace(fmt.Sprintf("annotations set on %q", plan.ID.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(annotationsIDFormat.setIdentity(ctx, plan.ID, resp.Identity)...)
+}
+
+func (r *annotationsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_annotations", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "annotations", "read")
+		return
+	}
+
+	var state annotationsResourceModel
+
+	// Read Terraform prior state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	modelName, entity := modelEntityFromAnnotationsID(state.ID.ValueString())
+	response, err := r.client.Annotations.GetAnnotations(ctx, juju.GetAnnotationsInput{
+		ModelName: modelName,
+		EntityTag: entity,
+	})
+	if err != nil {
+		handleReadError(ctx, r.client, err, &resp.State, &resp.Diagnostics, "Unable to read annotations")
+		return
+	}
+	r.trace(fmt.Sprintf("read annotations of %q", state.ID.ValueString()), map[string]interface{}{"annotations": response.Annotations})
+
+	// Only keep the annotations managed by this resource. On import,
+	// the state is empty and every annotation is kept.
+	var dErr diag.Diagnostics
+	if state.Annotations.IsNull() {
+		state.Annotations, dErr = types.MapValueFrom(ctx, types.StringType, response.Annotations)
+		resp.Diagnostics.Append(dErr...)
+	} else {
+		state.Annotations, dErr = managedAnnotations(ctx, state.Annotations, response.Annotations)
+		resp.Diagnostics.Append(dErr...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.ModelName = types.StringValue(modelName)
+	if entity != "" {
+		state.Entity = types.StringValue(entity)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(annotationsIDFormat.setIdentity(ctx, state.ID, resp.Identity)...)
+}
+
+func (r *annotationsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_annotations", "Update", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "annotations", "update")
+		return
+	}
+
+	var plan, state annotationsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planAnnotations, dErr := annotationChanges(ctx, plan.Annotations, state.Annotations)
+	resp.Diagnostics.Append(dErr...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Annotations.SetAnnotations(ctx, juju.SetAnnotationsInput{
+		ModelName:   plan.ModelName.ValueString(),
+		EntityTag:   plan.Entity.ValueString(),
+		Annotations: planAnnotations,
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to update annotations")
+		return
+	}
+	r.trace(fmt.Sprintf("annotations updated on %q", plan.ID.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *annotationsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_annotations", "Delete", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "annotations", "delete")
+		return
+	}
+
+	var state annotationsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	annotations := make(map[string]string)
+	resp.Diagnostics.Append(state.Annotations.ElementsAs(ctx, &annotations, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for key := range annotations {
+		annotations[key] = ""
+	}
+
+	err := r.client.Annotations.SetAnnotations(ctx, juju.SetAnnotationsInput{
+		ModelName:   state.ModelName.ValueString(),
+		EntityTag:   state.Entity.ValueString(),
+		Annotations: annotations,
+	})
+	// The entity may have been removed along with its annotations.
+	if err != nil && !errors.Is(err, errors.NotFound) {
+		addClientError(&resp.Diagnostics, err, "Unable to remove annotations")
+		return
+	}
+	r.trace(fmt.Sprintf("annotations removed from %q", state.ID.ValueString()))
+}
+
+// managedAnnotations returns the current values of the annotations
+// managed, those of the prior state, the others are left out.
+func managedAnnotations(ctx context.Context, managed types.Map, current map[string]string) (types.Map, diag.Diagnostics) {
+	keys := make(map[string]string)
+	diags := managed.ElementsAs(ctx, &keys, false)
+	if diags.HasError() {
+		return managed, diags
+	}
+	annotations := make(map[string]string)
+	for key := range keys {
+		if value, ok := current[key]; ok {
+			annotations[key] = value
+		}
+	}
+	result, dErr := types.MapValueFrom(ctx, types.StringType, annotations)
+	diags.Append(dErr...)
+	return result, diags
+}
+
+// annotationChanges returns the annotations to set to move from the
+// state to the plan. Annotations removed from the plan are unset, with
+// an empty value.
+func annotationChanges(ctx context.Context, plan, state types.Map) (map[string]string, diag.Diagnostics) {
+	planAnnotations := make(map[string]string)
+	stateAnnotations := make(map[string]string)
+	var diags diag.Diagnostics
+	diags.Append(plan.ElementsAs(ctx, &planAnnotations, false)...)
+	diags.Append(state.ElementsAs(ctx, &stateAnnotations, false)...)
+	// A null plan, all the annotations being removed, is read as nil.
+	if planAnnotations == nil {
+		planAnnotations = make(map[string]string)
+	}
+	for key := range stateAnnotations {
+		if _, ok := planAnnotations[key]; !ok {
+			planAnnotations[key] = ""
+		}
+	}
+	return planAnnotations, diags
+}
+
+func (r *annotationsResource) trace(msg string, additionalFields ...map[string]interface{}) {
+	if r.subCtx == nil {
+		return
+	}
+
+	//SubsystemTrace(subCtx, "my-subsystem", "hello, world", map[string]interface{}{"foo": 123})
+	// Output:
+	// {"@level":"trace","@message":"hello, world","@module":"provider.my-subsystem","foo":123}
+	tflog.SubsystemTrace(r.subCtx, LogResourceAnnotations, msg, additionalFields...)
+}
+
+// annotationsIDFormat is the format of the ID of annotations, the
+// entity tag being omitted for the annotations of the model.
+var annotationsIDFormat = idFormat{parts: []string{"model_name"}, optional: []string{"entity_tag"}}
+
+func newAnnotationsID(model, entity string) string {
+	if entity == "" {
+		return annotationsIDFormat.format(model)
+	}
+	return annotationsIDFormat.format(model, entity)
+}
+
+func modelEntityFromAnnotationsID(id string) (string, string) {
+	model, entity, _ := strings.Cut(id, ":")
+	return model, entity
+}
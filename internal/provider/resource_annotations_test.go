@@ -0,0 +1,112 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagedAnnotations(t *testing.T) {
+	ctx := context.Background()
+	managed, diags := types.MapValueFrom(ctx, types.StringType, map[string]string{"team": "data", "cost": "1"})
+	require.False(t, diags.HasError())
+
+	annotations, diags := managedAnnotations(ctx, managed, map[string]string{"team": "web", "other": "x"})
+	require.False(t, diags.HasError())
+	var got map[string]string
+	require.False(t, annotations.ElementsAs(ctx, &got, false).HasError())
+	assert.Equal(t, map[string]string{"team": "web"}, got)
+}
+
+func TestAnnotationChanges(t *testing.T) {
+	ctx := context.Background()
+	plan, diags := types.MapValueFrom(ctx, types.StringType, map[string]string{"team": "web", "env": "prod"})
+	require.False(t, diags.HasError())
+	state, diags := types.MapValueFrom(ctx, types.StringType, map[string]string{"team": "data", "cost": "1"})
+	require.False(t, diags.HasError())
+
+	changes, diags := annotationChanges(ctx, plan, state)
+	require.False(t, diags.HasError())
+	assert.Equal(t, map[string]string{"team": "web", "env": "prod", "cost": ""}, changes)
+
+	changes, diags = annotationChanges(ctx, types.MapNull(types.StringType), state)
+	require.False(t, diags.HasError())
+	assert.Equal(t, map[string]string{"team": "", "cost": ""}, changes)
+}
+
+func TestAcc_ResourceAnnotations(t *testing.T) {
+	if testingCloud != LXDCloudTesting {
+		t.Skip(t.Name() + " only runs with LXD")
+	}
+	modelName := acctest.RandomWithPrefix("tf-test-annotations")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceAnnotations(modelName, `owner = "team-a"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_annotations.model", "id", modelName),
+					resource.TestCheckResourceAttr("juju_annotations.model", "annotations.owner", "team-a"),
+					resource.TestCheckResourceAttr("juju_annotations.app", "id", fmt.Sprintf("%s:application-ubuntu", modelName)),
+					resource.TestCheckResourceAttr("juju_annotations.app", "annotations.owner", "team-a"),
+				),
+			},
+			{
+				Config: testAccResourceAnnotations(modelName, `owner = "team-b"
+    tier  = "frontend"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_annotations.app", "annotations.%", "2"),
+					resource.TestCheckResourceAttr("juju_annotations.app", "annotations.owner", "team-b"),
+					resource.TestCheckResourceAttr("juju_annotations.app", "annotations.tier", "frontend"),
+				),
+			},
+			{
+				ImportStateVerify: true,
+				ImportState:       true,
+				ResourceName:      "juju_annotations.app",
+			},
+		},
+	})
+}
+
+func testAccResourceAnnotations(modelName, annotations string) string {
+	return fmt.Sprintf(`
+resource "juju_model" "this" {
+  name = %q
+}
+
+resource "juju_application" "this" {
+  model = juju_model.this.name
+  name  = "ubuntu"
+  charm {
+    name = "jameinel-ubuntu-lite"
+  }
+}
+
+resource "juju_annotations" "model" {
+  model = juju_model.this.name
+  annotations = {
+    owner = "team-a"
+  }
+}
+
+resource "juju_annotations" "app" {
+  model  = juju_model.this.name
+  entity = "application-${juju_application.this.name}"
+  annotations = {
+    %s
+  }
+}
+`, modelName, annotations)
+}
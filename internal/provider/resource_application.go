@@ -6,9 +6,13 @@ package provider
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -16,34 +20,57 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/juju/errors"
+	"github.com/juju/juju/core/arch"
 	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/devices"
 	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/names/v5"
 
 	"github.com/juju/terraform-provider-juju/internal/juju"
 )
 
 const (
-	CharmKey            = "charm"
-	CidrsKey            = "cidrs"
-	ConfigKey           = "config"
-	EndpointsKey        = "endpoints"
-	ExposeKey           = "expose"
-	SpacesKey           = "spaces"
-	EndpointBindingsKey = "endpoint_bindings"
-	ResourceKey         = "resources"
-	StorageKey          = "storage"
+	AllowCharmSwitchKey      = "allow_charm_switch"
+	CharmArchitectureKey     = "architecture"
+	CharmKey                 = "charm"
+	CharmDeployedRevisionKey = "deployed_revision"
+	CharmNameKey             = "name"
+	CharmPathKey             = "path"
+	CharmRefreshPolicyKey    = "refresh_policy"
+	CharmSHA256Key           = "sha256"
+	CidrsKey                 = "cidrs"
+	DestroyOptionsKey        = "destroy_options"
+	ConfigKey                = "config"
+	ConfigJSONKey            = "config_json"
+	ConfigModeKey            = "config_mode"
+	EndpointsKey             = "endpoints"
+	ExposeKey                = "expose"
+	SpacesKey                = "spaces"
+	EndpointBindingsKey      = "endpoint_bindings"
+	ResourceKey              = "resources"
+	ResourceFilesKey         = "resource_files"
+	StorageKey               = "storage"
+	WaitForKey               = "wait_for"
+
+	// ConfigModeFull and ConfigModeManagedKeysOnly are the values of
+	// config_mode.
+	ConfigModeFull            = "full"
+	ConfigModeManagedKeysOnly = "managed_keys_only"
+
+	// RefreshPolicyManual and RefreshPolicyChannel are the values of
+	// the refresh_policy of the charm.
+	RefreshPolicyManual  = "manual"
+	RefreshPolicyChannel = "channel"
 
 	resourceKeyMarkdownDescription = `
 Charm resources. Must evaluate to a string. A resource could be a resource revision number from CharmHub or a custom OCI image resource.
@@ -61,6 +88,9 @@ Notes:
 var _ resource.Resource = &applicationResource{}
 var _ resource.ResourceWithConfigure = &applicationResource{}
 var _ resource.ResourceWithImportState = &applicationResource{}
+var _ resource.ResourceWithUpgradeState = &applicationResource{}
+var _ resource.ResourceWithModifyPlan = &applicationResource{}
+var _ resource.ResourceWithIdentity = &applicationResource{}
 
 func NewApplicationResource() resource.Resource {
 	return &applicationResource{}
@@ -76,23 +106,44 @@ type applicationResource struct {
 // applicationResourceModel describes the application data model.
 // tfsdk must match user resource schema attribute names.
 type applicationResourceModel struct {
-	ApplicationName   types.String `tfsdk:"name"`
-	Charm             types.List   `tfsdk:"charm"`
-	Config            types.Map    `tfsdk:"config"`
-	Constraints       types.String `tfsdk:"constraints"`
-	Expose            types.List   `tfsdk:"expose"`
-	ModelName         types.String `tfsdk:"model"`
-	Placement         types.String `tfsdk:"placement"`
-	EndpointBindings  types.Set    `tfsdk:"endpoint_bindings"`
-	Resources         types.Map    `tfsdk:"resources"`
-	StorageDirectives types.Map    `tfsdk:"storage_directives"`
-	Storage           types.Set    `tfsdk:"storage"`
+	ApplicationName   types.String   `tfsdk:"name"`
+	AllowCharmSwitch  types.Bool     `tfsdk:"allow_charm_switch"`
+	Annotations       types.Map      `tfsdk:"annotations"`
+	Charm             types.List     `tfsdk:"charm"`
+	Config            types.Map      `tfsdk:"config"`
+	ConfigJSON        types.String   `tfsdk:"config_json"`
+	ConfigMode        types.String   `tfsdk:"config_mode"`
+	Constraints       types.String   `tfsdk:"constraints"`
+	Expose            types.List     `tfsdk:"expose"`
+	ModelName         types.String   `tfsdk:"model"`
+	Placement         types.String   `tfsdk:"placement"`
+	Placements        types.List     `tfsdk:"placements"`
+	EndpointBindings  types.Set      `tfsdk:"endpoint_bindings"`
+	Resources         types.Map      `tfsdk:"resources"`
+	ResourceFiles     types.Map      `tfsdk:"resource_files"`
+	StorageDirectives types.Map      `tfsdk:"storage_directives"`
+	AttachStorage     types.List     `tfsdk:"attach_storage"`
+	Devices           types.Map      `tfsdk:"devices"`
+	Storage           types.Set      `tfsdk:"storage"`
+	WaitFor           types.List     `tfsdk:"wait_for"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
 	// TODO - remove Principal when we version the schema
 	// and remove deprecated elements. Once we create upgrade
 	// functionality it can be removed from the structure.
-	Principal types.Bool  `tfsdk:"principal"`
-	Trust     types.Bool  `tfsdk:"trust"`
-	UnitCount types.Int64 `tfsdk:"units"`
+	Principal      types.Bool   `tfsdk:"principal"`
+	ScaleStrategy  types.String `tfsdk:"scale_strategy"`
+	Subordinate    types.Bool   `tfsdk:"subordinate"`
+	PublicAddress  types.String `tfsdk:"public_address"`
+	LeaderUnit     types.String `tfsdk:"leader_unit"`
+	UnitAddresses  types.Map    `tfsdk:"unit_addresses"`
+	UnitMachines   types.Map    `tfsdk:"unit_machines"`
+	UnitList       types.List   `tfsdk:"unit_list"`
+	Status         types.Object `tfsdk:"status"`
+	Trust          types.Bool   `tfsdk:"trust"`
+	UnitCount      types.Int64  `tfsdk:"units"`
+	ForceDestroy   types.Bool   `tfsdk:"force_destroy"`
+	DestroyMaxWait types.String `tfsdk:"destroy_max_wait"`
+	DestroyOptions types.Object `tfsdk:"destroy_options"`
 	// ID required by the testing framework
 	ID types.String `tfsdk:"id"`
 }
@@ -101,6 +152,12 @@ func (r *applicationResource) Metadata(_ context.Context, req resource.MetadataR
 	resp.TypeName = req.ProviderTypeName + "_application"
 }
 
+// UpgradeState returns the state upgraders from the prior schema
+// versions of the resource, keyed by version.
+func (r *applicationResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
 // Configure enables provider-level data or clients to be set in the
 // provider-defined DataSource type. It is separately executed for each
 // ReadDataSource RPC.
@@ -121,11 +178,12 @@ func (r *applicationResource) Configure(ctx context.Context, req resource.Config
 
 	r.client = client
 	// Create the local logging subsystem here, using the TF context when creating it.
-	r.subCtx = tflog.NewSubsystem(ctx, LogResourceApplication)
+	r.subCtx = r.client.NewLogSubsystem(ctx, LogResourceApplication)
 }
 
-func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *applicationResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: applicationSchemaVersion,
 		Description: "A resource that represents a single Juju application deployment from a charm. Deployment of bundles" +
 			" is not supported.",
 		Attributes: map[string]schema.Attribute{
@@ -138,6 +196,12 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			AllowCharmSwitchKey: schema.BoolAttribute{
+				Description: "Allow changing the name of the charm to refresh the application to another charm, " +
+					"e.g. a fork, like `juju refresh --switch`, rather than replacing the application. The new " +
+					"charm must be compatible with the current one. Defaults to false.",
+				Optional: true,
+			},
 			"model": schema.StringAttribute{
 				Description: "The name of the model where the application is to be deployed.",
 				Required:    true,
@@ -145,32 +209,71 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 					stringplanmodifier.RequiresReplaceIfConfigured(),
 				},
 			},
-			"units": schema.Int64Attribute{
-				Description: "The number of application units to deploy for the charm.",
+			"annotations": schema.MapAttribute{
+				Description: "Annotations of the application, arbitrary key/value pairs, e.g. for cost attribution. " +
+					"Only the annotations configured here are managed, other annotations of the application are left untouched.",
 				Optional:    true,
-				Computed:    true,
-				Default:     int64default.StaticInt64(int64(1)),
+				ElementType: types.StringType,
+			},
+			"units": schema.Int64Attribute{
+				Description: "The number of application units to deploy for the charm. Ignored for subordinate charms, " +
+					"the units of which follow those of the principal applications they are integrated with.",
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(int64(1)),
 			},
 			ConfigKey: schema.MapAttribute{
 				Description: "Application specific configuration. Must evaluate to a string, integer or boolean.",
 				Optional:    true,
 				ElementType: types.StringType,
 			},
+			ConfigJSONKey: schema.StringAttribute{
+				Description: "Application specific configuration, as a JSON object the values of which are strings, numbers or booleans, " +
+					"e.g. `jsonencode({ port = 8080, debug = true })`. The values are converted to the types of the config options of the charm. " +
+					"The keys must not be set in config too. The config is validated against the config options of the charm when planned.",
+				Optional: true,
+				Validators: []validator.String{
+					StringIsConfigJSONValidator{},
+				},
+			},
+			ConfigModeKey: schema.StringAttribute{
+				Description: "How the config of the application is read back: `full` reads every option set to a " +
+					"non-default value, so that the options set out of band are removed by the next apply, " +
+					"`managed_keys_only` only reads the options set in config, ignoring the others, e.g. those " +
+					"set by the charm itself. Defaults to `full`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(ConfigModeFull, ConfigModeManagedKeysOnly),
+				},
+			},
 			"constraints": schema.StringAttribute{
-				Description: "Constraints imposed on this application.",
-				Optional:    true,
+				Description: "Constraints imposed on this application. Changing them updates the application in place, " +
+					"the new constraints apply to the units added later, not to the existing ones.",
+				Optional: true,
 				// Set as "computed" to pre-populate and preserve any implicit constraints
 				Computed: true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplaceIfConfigured(),
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"attach_storage": schema.ListAttribute{
+				Description: "The IDs of existing storage instances to attach to the unit deployed, e.g. " +
+					"`[\"pgdata/0\"]`, like `juju deploy --attach-storage`, so that a redeployed application " +
+					"re-attaches its detached storage instead of provisioning new storage. Only one unit can " +
+					"be deployed with it. It is only used when the application is created: changing it replaces " +
+					"the application, removing it does not.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
 			"storage_directives": schema.MapAttribute{
 				Description: "Storage directives (constraints) for the juju application." +
 					" The map key is the label of the storage defined by the charm," +
 					" the map value is the storage directive in the form <pool>,<count>,<size>." +
-					" Changing an existing key/value pair will cause the application to be replaced." +
+					" Increasing the count of an existing key/value pair adds storage to each unit of the application, including the units added later," +
+					" changing it otherwise will cause the application to be replaced." +
 					" Adding a new key/value pair will add storage to the application on upgrade.",
 				ElementType: types.StringType,
 				Optional:    true,
@@ -181,6 +284,21 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 					mapplanmodifier.RequiresReplaceIf(storageDirectivesMapRequiresReplace, "", ""),
 				},
 			},
+			"devices": schema.MapAttribute{
+				Description: "Device directives (constraints) for the juju application, on Kubernetes clouds." +
+					" The map key is the name of the device defined by the charm," +
+					" the map value is the device directive in the form [<count>,]<type>[,<attributes>]," +
+					" e.g. `10,nvidia.com/gpu`. The attributes are key=value pairs separated by `;`." +
+					" Changing this value will cause the application to be replaced.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Map{
+					StringIsDeviceDirectiveValidator{},
+				},
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
 			"storage": schema.SetNestedAttribute{
 				Description: "Storage used by the application.",
 				Optional:    true,
@@ -206,21 +324,51 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 					},
 				},
 			},
+			"scale_strategy": schema.StringAttribute{
+				Description: "How the units are scaled down on Kubernetes models: `graceful` waits for the units " +
+					"removed to run their teardown hooks and be removed, `immediate` forces their removal. If not set, " +
+					"the units are scaled down without waiting for their removal. Ignored on machine models.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(juju.ScaleStrategyGraceful, juju.ScaleStrategyImmediate),
+				},
+			},
 			"trust": schema.BoolAttribute{
-				Description: "Set the trust for the application.",
-				Optional:    true,
-				Computed:    true,
-				Default:     booldefault.StaticBool(false),
+				Description: "Set the trust for the application, granting it access to the credentials of the cloud of " +
+					"its model. Setting it to false revokes the trust. If not set, the trust is not managed, it is read " +
+					"back from the controller.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"placement": schema.StringAttribute{
-				Description: "Specify the target location for the application's units",
-				Optional:    true,
-				Computed:    true,
+				Description: "Specify the target location for the application's units, comma separated, e.g. `0,1`. " +
+					"A container is created on an existing machine with the container type and machine ID, " +
+					"e.g. `lxd:${juju_machine.this.machine_id}`.",
+				Optional: true,
+				Computed: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplaceIfConfigured(),
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"placements": schema.ListAttribute{
+				Description: "The placement directives of the units, in order, e.g. `[\"0\", \"lxd:1\", \"zone=us-east-1a\"]`. " +
+					"Units added are placed with the next directives, units removed are the last ones. " +
+					"Changing the placement of an existing unit replaces the application.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ConflictsWith(path.Expressions{
+						path.MatchRoot("placement"),
+					}...),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplaceIf(placementsListRequiresReplace, "", ""),
+				},
+			},
 			"principal": schema.BoolAttribute{
 				Description: "Whether this is a Principal application",
 				Computed:    true,
@@ -229,6 +377,83 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 				},
 				DeprecationMessage: "Principal is computed only and not needed. This attribute will be removed in the next major version of the provider.",
 			},
+			"subordinate": schema.BoolAttribute{
+				Description: "Whether the charm of the application is subordinate, as declared in its metadata.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"public_address": schema.StringAttribute{
+				Description: "The public address of the application: the address of its service on Kubernetes " +
+					"clouds, the public address of its leader unit on machine clouds. Empty until assigned.",
+				Computed: true,
+			},
+			"leader_unit": schema.StringAttribute{
+				Description: "The name of the leader unit of the application, e.g. `postgresql/0`. Empty until elected.",
+				Computed:    true,
+			},
+			"unit_addresses": schema.MapAttribute{
+				Description: "The public addresses of the units, by unit name. Units without an address yet are left out.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"unit_machines": schema.MapAttribute{
+				Description: "The IDs of the machines of the units, by unit name. Units not assigned to a machine yet are left out.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"unit_list": schema.ListNestedAttribute{
+				Description: "The units of the application, ordered by unit number, e.g. to configure the " +
+					"backends of a load balancer in a stable order.",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The name of the unit, e.g. `postgresql/0`.",
+							Computed:    true,
+						},
+						"machine": schema.StringAttribute{
+							Description: "The ID of the machine of the unit. Empty until assigned.",
+							Computed:    true,
+						},
+						"address": schema.StringAttribute{
+							Description: "The public address of the unit. Empty until assigned.",
+							Computed:    true,
+						},
+						"leader": schema.BoolAttribute{
+							Description: "Whether the unit is the leader of the application.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"status": schema.SingleNestedAttribute{
+				Description: "The status of the application, as shown by `juju status`, refreshed when the " +
+					"application is read. It can be asserted in checks or postconditions, e.g. that the " +
+					"application is active after apply.",
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"status": schema.StringAttribute{
+						Description: "The workload status of the application, e.g. active or blocked.",
+						Computed:    true,
+					},
+					"message": schema.StringAttribute{
+						Description: "The message of the workload status.",
+						Computed:    true,
+					},
+					"charm_version": schema.StringAttribute{
+						Description: "The version of the charm, set by the charm author.",
+						Computed:    true,
+					},
+					"workload_version": schema.StringAttribute{
+						Description: "The version of the workload, set by the charm.",
+						Computed:    true,
+					},
+				},
+			},
+			"force_destroy":    forceDestroyAttribute("application", "Its units and integrations are removed with it, the units whatever the errors of their machines. "),
+			"destroy_max_wait": destroyMaxWaitAttribute("application"),
 			"id": schema.StringAttribute{
 				Computed: true,
 				PlanModifiers: []planmodifier.String{
@@ -264,17 +489,63 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 				},
 				MarkdownDescription: resourceKeyMarkdownDescription,
 			},
+			ResourceFilesKey: schema.MapNestedAttribute{
+				Description: "Charm resources uploaded from local files, by resource name, e.g. `{ snapshot = { path = \"./dump.tar.gz\" } }`. " +
+					"A change of the content of a file uploads it again. Removing a resource resets it to the default of the charm channel. " +
+					"The resources must not be set in resources too.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						CharmPathKey: schema.StringAttribute{
+							Description: "The path of the local file.",
+							Required:    true,
+						},
+						CharmSHA256Key: schema.StringAttribute{
+							Description: "The SHA-256 hash of the local file, computed when planned.",
+							Computed:    true,
+							PlanModifiers: []planmodifier.String{
+								localResourceSHA256Modifier(),
+							},
+						},
+					},
+				},
+			},
 		},
 		Blocks: map[string]schema.Block{
+			TimeoutsKey: timeoutsBlock(ctx, "application"),
+			DestroyOptionsKey: schema.SingleNestedBlock{
+				Description: "How the application is removed when destroyed, like the options of `juju remove-application`, " +
+					"so that stuck applications, e.g. with units in error or dying machines, do not block the destroy. " +
+					"Changing them only updates the state.",
+				Attributes: map[string]schema.Attribute{
+					"force": schema.BoolAttribute{
+						Description: "Force the removal of the application, like `force_destroy`. Defaults to false.",
+						Optional:    true,
+					},
+					"no_wait": schema.BoolAttribute{
+						Description: "Force the removal of the application without waiting for each step to complete " +
+							"cleanly, like `--no-wait`. Implies `force`. Defaults to false.",
+						Optional: true,
+					},
+					"destroy_storage": schema.BoolAttribute{
+						Description: "Destroy the storage of the application. When false, the storage is detached " +
+							"and kept, so that it can be attached again with `attach_storage`. Defaults to true.",
+						Optional: true,
+					},
+				},
+			},
 			CharmKey: schema.ListNestedBlock{
-				Description: "The name of the charm to be installed from Charmhub.",
+				Description: "The name of the charm to be installed from Charmhub, or the path of a local charm.",
 				NestedObject: schema.NestedBlockObject{
 					Attributes: map[string]schema.Attribute{
-						"name": schema.StringAttribute{
-							Required:    true,
-							Description: "The name of the charm",
+						CharmNameKey: schema.StringAttribute{
+							Required: true,
+							Description: "The name of the charm. Changing it replaces the application, unless " +
+								"`allow_charm_switch` is set.",
 							PlanModifiers: []planmodifier.String{
-								stringplanmodifier.RequiresReplaceIfConfigured(),
+								stringplanmodifier.RequiresReplaceIf(charmNameRequiresReplace,
+									"Changing the name of the charm replaces the application unless allow_charm_switch is set.",
+									"Changing the name of the charm replaces the application unless `allow_charm_switch` is set."),
 							},
 						},
 						"channel": schema.StringAttribute{
@@ -282,18 +553,82 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 							Optional:    true,
 							Computed:    true,
 							PlanModifiers: []planmodifier.String{
-								stringplanmodifier.UseStateForUnknown(),
+								useStateForUnknownUnlessCharmChanged(),
 							},
 							Validators: []validator.String{
 								StringIsChannelValidator{},
 							},
 						},
 						"revision": schema.Int64Attribute{
-							Description: "The revision of the charm to deploy. During the update phase, the charm revision should be update before config update, to avoid issues with config parameters parsing.",
-							Optional:    true,
-							Computed:    true,
+							Description: "The revision of the charm to deploy. During the update phase, the charm revision should be update before config update, to avoid issues with config parameters parsing. " +
+								"With the `channel` refresh policy, it only pins the revision deployed when the application is created.",
+							Optional: true,
+							Computed: true,
+							PlanModifiers: []planmodifier.Int64{
+								useStateForUnknownUnlessCharmChanged(),
+							},
+						},
+						CharmRefreshPolicyKey: schema.StringAttribute{
+							Description: "How the next applies refresh the charm. With `manual`, the default, the charm is " +
+								"refreshed when `revision` or `channel` change. With `channel`, the charm is refreshed to the " +
+								"latest revision published in `channel`, whatever the revision pinned.",
+							Optional: true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(RefreshPolicyManual, RefreshPolicyChannel),
+								stringvalidator.ConflictsWith(path.Expressions{
+									path.MatchRelative().AtParent().AtName(CharmPathKey),
+								}...),
+							},
+						},
+						CharmDeployedRevisionKey: schema.Int64Attribute{
+							Description: "The revision of the charm deployed. It differs from `revision` when the charm " +
+								"is refreshed to the latest revision of its channel, with the `channel` refresh policy.",
+							Computed: true,
 							PlanModifiers: []planmodifier.Int64{
-								int64planmodifier.UseStateForUnknown(),
+								useStateForUnknownUnlessCharmChanged(),
+							},
+						},
+						CharmPathKey: schema.StringAttribute{
+							Description: "The path of a local charm archive to deploy instead of a charm from Charmhub. " +
+								"The charm is refreshed when the content of the archive changes. Removing the path " +
+								"replaces the application.",
+							Optional: true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.RequiresReplaceIf(func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+									resp.RequiresReplace = !req.StateValue.IsNull() && req.PlanValue.IsNull()
+								}, "Removing the path of a local charm replaces the application.",
+									"Removing the path of a local charm replaces the application."),
+							},
+							Validators: []validator.String{
+								stringvalidator.ConflictsWith(path.Expressions{
+									path.MatchRelative().AtParent().AtName("channel"),
+									path.MatchRelative().AtParent().AtName("revision"),
+								}...),
+							},
+						},
+						CharmSHA256Key: schema.StringAttribute{
+							Description: "The SHA-256 hash of the local charm archive, used to detect changes of its content.",
+							Computed:    true,
+							PlanModifiers: []planmodifier.String{
+								localCharmSHA256Modifier(),
+							},
+						},
+						CharmArchitectureKey: schema.StringAttribute{
+							Description: "The architecture of the charm revision to deploy, e.g. `arm64`, so that the revisions " +
+								"of multi-arch charms are resolved deterministically. It is set as the arch constraint of the " +
+								"application. Defaults to the constraints of the application, then of the model. Changing it " +
+								"replaces the application.",
+							Optional: true,
+							Computed: true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+								stringplanmodifier.RequiresReplaceIfConfigured(),
+							},
+							Validators: []validator.String{
+								stringvalidator.OneOf(arch.AllSupportedArches...),
+								stringvalidator.ConflictsWith(path.Expressions{
+									path.MatchRelative().AtParent().AtName(CharmPathKey),
+								}...),
 							},
 						},
 						SeriesKey: schema.StringAttribute{
@@ -301,7 +636,7 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 							Optional:    true,
 							Computed:    true,
 							PlanModifiers: []planmodifier.String{
-								stringplanmodifier.UseStateForUnknown(),
+								useStateForUnknownUnlessOSChanged(BaseKey),
 							},
 							Validators: []validator.String{
 								stringvalidator.ConflictsWith(path.Expressions{
@@ -311,11 +646,14 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 							DeprecationMessage: "Configure base instead. This attribute will be removed in the next major version of the provider.",
 						},
 						BaseKey: schema.StringAttribute{
-							Description: "The operating system on which to deploy. E.g. ubuntu@22.04.",
-							Optional:    true,
-							Computed:    true,
+							Description: "The operating system on which to deploy. E.g. ubuntu@22.04. " +
+								"Changing it upgrades the application in place, like `juju upgrade-machine`: the machines of its units " +
+								"are upgraded to the next release of the operating system and rebooted, one at a time, " +
+								"so the base must be that release. The charm must support the base.",
+							Optional: true,
+							Computed: true,
 							PlanModifiers: []planmodifier.String{
-								stringplanmodifier.UseStateForUnknown(),
+								useStateForUnknownUnlessOSChanged(SeriesKey),
 							},
 							Validators: []validator.String{
 								stringvalidator.ConflictsWith(path.Expressions{
@@ -332,7 +670,7 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 				},
 			},
 			ExposeKey: schema.ListNestedBlock{
-				Description: "Makes an application publicly available over the network",
+				Description: "Makes an application publicly available over the network. Must not be used together with juju_application_expose.",
 				NestedObject: schema.NestedBlockObject{
 					Attributes: map[string]schema.Attribute{
 						EndpointsKey: schema.StringAttribute{
@@ -353,6 +691,33 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 					listvalidator.SizeAtMost(1),
 				},
 			},
+			WaitForKey: schema.ListNestedBlock{
+				Description: "Wait for the units of the application to settle when it is created or updated, so that " +
+					"the resources depending on it, e.g. integrations, are created once the charm is installed. " +
+					"Applications without units, e.g. subordinates, are not waited for.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"status": schema.StringAttribute{
+							Description: "The workload status all the units must reach. Defaults to `active`.",
+							Optional:    true,
+						},
+						"agent_status": schema.StringAttribute{
+							Description: "The agent status all the units must reach too, e.g. `idle`.",
+							Optional:    true,
+						},
+						"timeout": schema.StringAttribute{
+							Description: "How long to wait, e.g. `15m`. Defaults to 10m.",
+							Optional:    true,
+							Validators: []validator.String{
+								StringIsDurationValidator{},
+							},
+						},
+					},
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+			},
 		},
 	}
 }
@@ -360,11 +725,63 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 // nestedCharm represents the single element of the charm ListNestedBlock
 // of the in the application resource schema
 type nestedCharm struct {
-	Name     types.String `tfsdk:"name"`
-	Channel  types.String `tfsdk:"channel"`
-	Revision types.Int64  `tfsdk:"revision"`
-	Base     types.String `tfsdk:"base"`
-	Series   types.String `tfsdk:"series"`
+	Name             types.String `tfsdk:"name"`
+	Channel          types.String `tfsdk:"channel"`
+	Revision         types.Int64  `tfsdk:"revision"`
+	RefreshPolicy    types.String `tfsdk:"refresh_policy"`
+	DeployedRevision types.Int64  `tfsdk:"deployed_revision"`
+	Base             types.String `tfsdk:"base"`
+	Series           types.String `tfsdk:"series"`
+	Architecture     types.String `tfsdk:"architecture"`
+	Path             types.String `tfsdk:"path"`
+	SHA256           types.String `tfsdk:"sha256"`
+}
+
+// nestedDestroyOptions represents the destroy_options block of the
+// application resource schema.
+type nestedDestroyOptions struct {
+	Force          types.Bool `tfsdk:"force"`
+	NoWait         types.Bool `tfsdk:"no_wait"`
+	DestroyStorage types.Bool `tfsdk:"destroy_storage"`
+}
+
+// applicationStatusModel represents the status object of the
+// application resource schema.
+type applicationStatusModel struct {
+	Status          types.String `tfsdk:"status"`
+	Message         types.String `tfsdk:"message"`
+	CharmVersion    types.String `tfsdk:"charm_version"`
+	WorkloadVersion types.String `tfsdk:"workload_version"`
+}
+
+var applicationStatusAttrTypes = map[string]attr.Type{
+	"status":           types.StringType,
+	"message":          types.StringType,
+	"charm_version":    types.StringType,
+	"workload_version": types.StringType,
+}
+
+// applicationUnitModel represents an element of the unit_list
+// attribute of the application resource schema.
+type applicationUnitModel struct {
+	Name    types.String `tfsdk:"name"`
+	Machine types.String `tfsdk:"machine"`
+	Address types.String `tfsdk:"address"`
+	Leader  types.Bool   `tfsdk:"leader"`
+}
+
+var applicationUnitAttrTypes = map[string]attr.Type{
+	"name":    types.StringType,
+	"machine": types.StringType,
+	"address": types.StringType,
+	"leader":  types.BoolType,
+}
+
+// nestedResourceFile represents an element of the resource_files
+// MapNestedAttribute of the application resource schema.
+type nestedResourceFile struct {
+	Path   types.String `tfsdk:"path"`
+	SHA256 types.String `tfsdk:"sha256"`
 }
 
 // nestedExpose represents the single element of expose ListNestedBlock
@@ -375,6 +792,14 @@ type nestedExpose struct {
 	Cidrs     types.String `tfsdk:"cidrs"`
 }
 
+// nestedWaitFor represents the single element of the wait_for
+// ListNestedBlock of the application resource schema.
+type nestedWaitFor struct {
+	Status      types.String `tfsdk:"status"`
+	AgentStatus types.String `tfsdk:"agent_status"`
+	Timeout     types.String `tfsdk:"timeout"`
+}
+
 func (n nestedExpose) transformToMapStringInterface() map[string]interface{} {
 	// An empty map is equivalent to `juju expose` with no
 	// endpoints, cidrs nor spaces
@@ -430,6 +855,9 @@ type nestedStorage struct {
 // and planned state values should be read from the
 // CreateRequest and new state values set on the CreateResponse.
 func (r *applicationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_application", "Create", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if r.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "application", "create")
@@ -443,6 +871,11 @@ func (r *applicationResource) Create(ctx context.Context, req resource.CreateReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := withOperationTimeout(ctx, plan.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	r.trace("Create", applicationResourceModelForLogging(ctx, &plan))
 
@@ -454,7 +887,10 @@ func (r *applicationResource) Create(ctx context.Context, req resource.CreateReq
 	planCharm := charms[0]
 	charmName := planCharm.Name.ValueString()
 	channel := "stable"
-	if !planCharm.Channel.IsUnknown() {
+	if !planCharm.Path.IsNull() {
+		// Local charms have no channel.
+		channel = ""
+	} else if !planCharm.Channel.IsUnknown() {
 		channel = planCharm.Channel.ValueString()
 	}
 	revision := -1
@@ -462,19 +898,22 @@ func (r *applicationResource) Create(ctx context.Context, req resource.CreateReq
 		revision = int(planCharm.Revision.ValueInt64())
 	}
 
-	// TODO: investigate using map[string]string here and let
-	// terraform do the conversion, will help in CreateApplication.
-	configField := map[string]string{}
-	resp.Diagnostics.Append(plan.Config.ElementsAs(ctx, &configField, false)...)
+	configField, configDiags := applicationConfig(ctx, plan)
+	resp.Diagnostics.Append(configDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resourceRevisions := make(map[string]string)
 	resp.Diagnostics.Append(plan.Resources.ElementsAs(ctx, &resourceRevisions, false)...)
+	resourceFiles, resourceDiags := resourceFilePaths(ctx, plan)
+	resp.Diagnostics.Append(resourceDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	for name, filePath := range resourceFiles {
+		resourceRevisions[name] = filePath
+	}
 
 	// If the plan has an empty expose block, that has meaning.
 	// It's equivalent to using the expose flag on the juju cli.
@@ -523,22 +962,40 @@ func (r *applicationResource) Create(ctx context.Context, req resource.CreateReq
 	// Parse storage
 	var storageConstraints map[string]jujustorage.Constraints
 	if !plan.StorageDirectives.IsUnknown() {
-		storageDirectives := make(map[string]string)
-		resp.Diagnostics.Append(plan.StorageDirectives.ElementsAs(ctx, &storageDirectives, false)...)
+		var dErr diag.Diagnostics
+		storageConstraints, dErr = parseStorageDirectives(ctx, plan.StorageDirectives)
+		resp.Diagnostics.Append(dErr...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Parse devices
+	var deviceConstraints map[string]devices.Constraints
+	if !plan.Devices.IsUnknown() {
+		deviceDirectives := make(map[string]string)
+		resp.Diagnostics.Append(plan.Devices.ElementsAs(ctx, &deviceDirectives, false)...)
 		if resp.Diagnostics.HasError() {
 			return
 		}
-		storageConstraints = make(map[string]jujustorage.Constraints, len(storageDirectives))
-		for k, v := range storageDirectives {
-			result, err := jujustorage.ParseConstraints(v)
+		deviceConstraints = make(map[string]devices.Constraints, len(deviceDirectives))
+		for k, v := range deviceDirectives {
+			result, err := devices.ParseConstraints(v)
 			if err != nil {
-				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse storage directives, got error: %s", err))
+				addClientError(&resp.Diagnostics, err, "Unable to parse device directives")
 				return
 			}
-			storageConstraints[k] = result
+			deviceConstraints[k] = result
 		}
 	}
 
+	var placements, attachStorage []string
+	resp.Diagnostics.Append(plan.Placements.ElementsAs(ctx, &placements, false)...)
+	resp.Diagnostics.Append(plan.AttachStorage.ElementsAs(ctx, &attachStorage, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	modelName := plan.ModelName.ValueString()
 	createResp, err := r.client.Applications.CreateApplication(ctx,
 		&juju.CreateApplicationInput{
@@ -547,21 +1004,32 @@ func (r *applicationResource) Create(ctx context.Context, req resource.CreateReq
 			CharmName:          charmName,
 			CharmChannel:       channel,
 			CharmRevision:      revision,
+			CharmPath:          planCharm.Path.ValueString(),
 			CharmBase:          planCharm.Base.ValueString(),
 			CharmSeries:        planCharm.Series.ValueString(),
+			CharmArchitecture:  planCharm.Architecture.ValueString(),
 			Units:              int(plan.UnitCount.ValueInt64()),
 			Config:             configField,
 			Constraints:        parsedConstraints,
 			Trust:              plan.Trust.ValueBool(),
 			Expose:             expose,
 			Placement:          plan.Placement.ValueString(),
+			Placements:         placements,
 			EndpointBindings:   endpointBindings,
 			Resources:          resourceRevisions,
 			StorageConstraints: storageConstraints,
+			Devices:            deviceConstraints,
+			AttachStorage:      attachStorage,
 		},
 	)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create application, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to create application")
+		if createResp != nil {
+			// The application is deployed, save it so that it is
+			// replaced rather than deployed again.
+			plan.ID = types.StringValue(newAppID(modelName, createResp.AppName))
+			resp.Diagnostics.Append(setPartialState(ctx, &resp.State, &plan)...)
+		}
 		return
 	}
 
@@ -572,7 +1040,9 @@ func (r *applicationResource) Create(ctx context.Context, req resource.CreateReq
 		AppName:   createResp.AppName,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read application, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to read application")
+		plan.ID = types.StringValue(newAppID(modelName, createResp.AppName))
+		resp.Diagnostics.Append(setPartialState(ctx, &resp.State, &plan)...)
 		return
 	}
 	r.trace(fmt.Sprintf("read application resource %q", createResp.AppName))
@@ -581,13 +1051,45 @@ func (r *applicationResource) Create(ctx context.Context, req resource.CreateReq
 
 	// Constraints do not apply to subordinate applications. If the application
 	// is subordinate, the constraints will be set to the empty string.
-	plan.Constraints = types.StringValue(readResp.Constraints.String())
-	plan.Placement = types.StringValue(readResp.Placement)
+	if !sameConstraints(plan.Constraints, readResp.Constraints, readResp.Architecture) {
+		plan.Constraints = types.StringValue(readResp.Constraints.String())
+	}
+	// The placement directives configured are kept, e.g. lxd:1 for
+	// the machine 1/lxd/0.
+	if plan.Placement.IsUnknown() || !juju.PlacementSatisfied(plan.Placement.ValueString(), readResp.Placement) {
+		plan.Placement = types.StringValue(readResp.Placement)
+	}
 	plan.Principal = types.BoolNull()
+	plan.Subordinate = types.BoolValue(!readResp.Principal)
+	plan.Trust = types.BoolValue(readResp.Trust)
 	plan.ApplicationName = types.StringValue(createResp.AppName)
+	if !plan.Annotations.IsNull() {
+		annotations := make(map[string]string)
+		resp.Diagnostics.Append(plan.Annotations.ElementsAs(ctx, &annotations, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		err := r.client.Annotations.SetAnnotations(ctx, juju.SetAnnotationsInput{
+			ModelName:   modelName,
+			EntityTag:   applicationTag(createResp.AppName),
+			Annotations: annotations,
+		})
+		if err != nil {
+			addClientError(&resp.Diagnostics, err, "Unable to set application annotations")
+			plan.ID = types.StringValue(newAppID(modelName, createResp.AppName))
+			resp.Diagnostics.Append(setPartialState(ctx, &resp.State, &plan)...)
+			return
+		}
+	}
+	resp.Diagnostics.Append(setUnitOutputs(ctx, &plan, readResp)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	planCharm.Revision = types.Int64Value(int64(readResp.Revision))
+	planCharm.DeployedRevision = types.Int64Value(int64(readResp.Revision))
 	planCharm.Base = types.StringValue(readResp.Base)
 	planCharm.Series = types.StringValue(readResp.Series)
+	planCharm.Architecture = types.StringValue(readResp.Architecture)
 	planCharm.Channel = types.StringValue(readResp.Channel)
 	charmType := req.Config.Schema.GetBlocks()[CharmKey].(schema.ListNestedBlock).NestedObject.Type()
 	var dErr diag.Diagnostics
@@ -622,6 +1124,110 @@ func (r *applicationResource) Create(ctx context.Context, req resource.CreateReq
 	r.trace("Created", applicationResourceModelForLogging(ctx, &plan))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(applicationIDFormat.setIdentity(ctx, plan.ID, resp.Identity)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.waitForApplication(ctx, plan)...)
+	if resp.Diagnostics.HasError() || plan.WaitFor.IsNull() {
+		return
+	}
+	// The units have addresses once settled.
+	resp.Diagnostics.Append(r.readUnitOutputs(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// readUnitOutputs reads the application to set the computed addresses
+// and unit details of the plan.
+func (r *applicationResource) readUnitOutputs(ctx context.Context, plan *applicationResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	readResp, err := r.client.Applications.ReadApplication(ctx, &juju.ReadApplicationInput{
+		ModelName: plan.ModelName.ValueString(),
+		AppName:   plan.ApplicationName.ValueString(),
+	})
+	if err != nil {
+		addClientError(&diags, err, "Unable to read application units")
+		return diags
+	}
+	diags.Append(setUnitOutputs(ctx, plan, readResp)...)
+	return diags
+}
+
+// applicationTag returns the tag of the application, the entity of
+// its annotations.
+func applicationTag(appName string) string {
+	return names.NewApplicationTag(appName).String()
+}
+
+// setUnitOutputs sets the computed addresses, unit details and status
+// of the application from the response of a read.
+func setUnitOutputs(ctx context.Context, app *applicationResourceModel, response *juju.ReadApplicationResponse) diag.Diagnostics {
+	var diags, dErr diag.Diagnostics
+	app.PublicAddress = types.StringValue(response.PublicAddress)
+	app.LeaderUnit = types.StringValue(response.LeaderUnit)
+	app.UnitAddresses, dErr = types.MapValueFrom(ctx, types.StringType, response.UnitAddresses)
+	diags.Append(dErr...)
+	app.UnitMachines, dErr = types.MapValueFrom(ctx, types.StringType, response.UnitMachines)
+	diags.Append(dErr...)
+	units := make([]applicationUnitModel, len(response.UnitNames))
+	for i, name := range response.UnitNames {
+		units[i] = applicationUnitModel{
+			Name:    types.StringValue(name),
+			Machine: types.StringValue(response.UnitMachines[name]),
+			Address: types.StringValue(response.UnitAddresses[name]),
+			Leader:  types.BoolValue(name == response.LeaderUnit),
+		}
+	}
+	app.UnitList, dErr = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: applicationUnitAttrTypes}, units)
+	diags.Append(dErr...)
+	app.Status, dErr = types.ObjectValueFrom(ctx, applicationStatusAttrTypes, applicationStatusModel{
+		Status:          types.StringValue(response.Status),
+		Message:         types.StringValue(response.StatusMessage),
+		CharmVersion:    types.StringValue(response.CharmVersion),
+		WorkloadVersion: types.StringValue(response.WorkloadVersion),
+	})
+	diags.Append(dErr...)
+	return diags
+}
+
+// waitForApplication blocks until the units of the application reach
+// the statuses of the wait_for block, if any.
+func (r *applicationResource) waitForApplication(ctx context.Context, plan applicationResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if plan.WaitFor.IsNull() || plan.UnitCount.ValueInt64() == 0 || plan.Subordinate.ValueBool() {
+		return diags
+	}
+	var waitFor []nestedWaitFor
+	diags.Append(plan.WaitFor.ElementsAs(ctx, &waitFor, false)...)
+	if diags.HasError() || len(waitFor) == 0 {
+		return diags
+	}
+
+	appName := plan.ApplicationName.ValueString()
+	workloadStatus := "active"
+	if !waitFor[0].Status.IsNull() {
+		workloadStatus = waitFor[0].Status.ValueString()
+	}
+	condition := juju.UnitsStatus(appName, workloadStatus)
+	if agentStatus := waitFor[0].AgentStatus.ValueString(); agentStatus != "" {
+		condition = juju.AllConditions(condition, juju.UnitsAgentStatus(appName, agentStatus))
+	}
+	// The timeout is validated with the configuration.
+	timeout := defaultWaitForTimeout
+	if duration, err := time.ParseDuration(waitFor[0].Timeout.ValueString()); err == nil {
+		timeout = duration
+	}
+
+	r.trace(fmt.Sprintf("waiting for %s", condition.Description))
+	err := r.client.Status.Wait(ctx, juju.WaitInput{
+		ModelName: plan.ModelName.ValueString(),
+		Condition: condition,
+		Timeout:   timeout,
+	})
+	if err != nil {
+		addClientError(&diags, err, "Unable to wait for application %q", appName)
+	}
+	return diags
 }
 
 func transformSizeToHumanizedFormat(size uint64) string {
@@ -634,23 +1240,18 @@ func transformSizeToHumanizedFormat(size uint64) string {
 	return formattedSize
 }
 
-func handleApplicationNotFoundError(ctx context.Context, err error, st *tfsdk.State) diag.Diagnostics {
-	if errors.As(err, &juju.ApplicationNotFoundError) {
-		// Application manually removed
-		st.RemoveResource(ctx)
-		return diag.Diagnostics{}
-	}
-	var diags diag.Diagnostics
-	diags.AddError("Not Found", err.Error())
-	return diags
-}
-
 // Read is called when the provider must read resource values in order
 // to update state. Planned state values should be read from the
 // ReadRequest and new state values set on the ReadResponse.
 // Take the juju api input from the ID, it may not exist in the plan.
 // Only set optional values if they exist.
 func (r *applicationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_application", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// The reads of a refresh share the status of the model.
+	ctx = juju.WithRefresh(ctx)
+
 	// Prevent panic if the provider has not been configured.
 	if r.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "application", "read")
@@ -674,12 +1275,12 @@ func (r *applicationResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	response, err := r.client.Applications.ReadApplication(&juju.ReadApplicationInput{
+	response, err := r.client.Applications.ReadApplication(ctx, &juju.ReadApplicationInput{
 		ModelName: modelName,
 		AppName:   appName,
 	})
 	if err != nil {
-		resp.Diagnostics.Append(handleApplicationNotFoundError(ctx, err, &resp.State)...)
+		handleReadError(ctx, r.client, err, &resp.State, &resp.Diagnostics, "Unable to read application")
 		return
 	}
 	if response == nil {
@@ -692,18 +1293,51 @@ func (r *applicationResource) Read(ctx context.Context, req resource.ReadRequest
 
 	// Use the response to fill in state
 
-	state.Placement = types.StringValue(response.Placement)
+	if state.Placement.IsNull() || !juju.PlacementSatisfied(state.Placement.ValueString(), response.Placement) {
+		state.Placement = types.StringValue(response.Placement)
+	}
 	state.Principal = types.BoolNull()
-	state.UnitCount = types.Int64Value(int64(response.Units))
+	state.Subordinate = types.BoolValue(!response.Principal)
+	// Subordinates have the units of their principals, the count
+	// configured is kept rather than reconciled.
+	if response.Principal || state.UnitCount.IsNull() {
+		state.UnitCount = types.Int64Value(int64(response.Units))
+	}
 	state.Trust = types.BoolValue(response.Trust)
+	resp.Diagnostics.Append(setUnitOutputs(ctx, &state, response)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// state requiring transformation
 	dataCharm := nestedCharm{
-		Name:     types.StringValue(response.Name),
-		Channel:  types.StringValue(response.Channel),
-		Revision: types.Int64Value(int64(response.Revision)),
-		Base:     types.StringValue(response.Base),
-		Series:   types.StringValue(response.Series),
+		Name:             types.StringValue(response.Name),
+		Channel:          types.StringValue(response.Channel),
+		Revision:         types.Int64Value(int64(response.Revision)),
+		RefreshPolicy:    types.StringNull(),
+		DeployedRevision: types.Int64Value(int64(response.Revision)),
+		Base:             types.StringValue(response.Base),
+		Series:           types.StringValue(response.Series),
+		Architecture:     types.StringValue(response.Architecture),
+		Path:             types.StringNull(),
+		SHA256:           types.StringNull(),
+	}
+	// The path and content of a local charm archive are not known
+	// to juju, keep them from the prior state. So is the refresh
+	// policy, the revision pinned is kept when the charm tracks its
+	// channel.
+	var stateCharms []nestedCharm
+	resp.Diagnostics.Append(state.Charm.ElementsAs(ctx, &stateCharms, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(stateCharms) == 1 {
+		dataCharm.Path = stateCharms[0].Path
+		dataCharm.SHA256 = stateCharms[0].SHA256
+		dataCharm.RefreshPolicy = stateCharms[0].RefreshPolicy
+		if dataCharm.RefreshPolicy.ValueString() == RefreshPolicyChannel && !stateCharms[0].Revision.IsNull() {
+			dataCharm.Revision = stateCharms[0].Revision
+		}
 	}
 	charmType := req.State.Schema.GetBlocks()[CharmKey].(schema.ListNestedBlock).NestedObject.Type()
 	state.Charm, dErr = types.ListValueFrom(ctx, charmType, []nestedCharm{dataCharm})
@@ -714,10 +1348,39 @@ func (r *applicationResource) Read(ctx context.Context, req resource.ReadRequest
 
 	// Constraints do not apply to subordinate applications. If the application
 	// is subordinate, the constraints will be set to the empty string.
-	state.Constraints = types.StringValue(response.Constraints.String())
+	if !sameConstraints(state.Constraints, response.Constraints, response.Architecture) {
+		state.Constraints = types.StringValue(response.Constraints.String())
+	}
+
+	// Only the annotations configured are read back.
+	if !state.Annotations.IsNull() {
+		annotationsResp, err := r.client.Annotations.GetAnnotations(ctx, juju.GetAnnotationsInput{
+			ModelName: modelName,
+			EntityTag: applicationTag(appName),
+		})
+		if err != nil {
+			addClientError(&resp.Diagnostics, err, "Unable to read application annotations")
+			return
+		}
+		state.Annotations, dErr = managedAnnotations(ctx, state.Annotations, annotationsResp.Annotations)
+		if dErr.HasError() {
+			resp.Diagnostics.Append(dErr...)
+			return
+		}
+	}
 
 	exposeType := req.State.Schema.GetBlocks()[ExposeKey].(schema.ListNestedBlock).NestedObject.Type()
-	if response.Expose != nil {
+	// Exposure managed by a juju_application_expose resource is not
+	// reflected here, otherwise every plan would unexpose the application.
+	exposeManaged := false
+	if response.Expose != nil && state.Expose.IsNull() {
+		exposeManaged, err = r.client.Applications.IsExposeManaged(ctx, modelName, appName)
+		if err != nil {
+			addClientError(&resp.Diagnostics, err, "Unable to read application expose annotations")
+			return
+		}
+	}
+	if response.Expose != nil && !exposeManaged {
 		exp := parseNestedExpose(response.Expose)
 		state.Expose, dErr = types.ListValueFrom(ctx, exposeType, []nestedExpose{exp})
 		if dErr.HasError() {
@@ -731,7 +1394,8 @@ func (r *applicationResource) Read(ctx context.Context, req resource.ReadRequest
 	// we only set changes if there is any difference between
 	// the previous and the current config values
 	configType := req.State.Schema.GetAttributes()[ConfigKey].(schema.MapAttribute).ElementType
-	state.Config, dErr = r.configureConfigData(ctx, configType, state.Config, response.Config)
+	managedKeysOnly := state.ConfigMode.ValueString() == ConfigModeManagedKeysOnly
+	state.Config, dErr = r.configureConfigData(ctx, configType, state.Config, state.ConfigJSON, response.Config, managedKeysOnly)
 	if dErr.HasError() {
 		resp.Diagnostics.Append(dErr...)
 		return
@@ -777,12 +1441,14 @@ func (r *applicationResource) Read(ctx context.Context, req resource.ReadRequest
 
 	r.trace("Found", applicationResourceModelForLogging(ctx, &state))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(applicationIDFormat.setIdentity(ctx, state.ID, resp.Identity)...)
 }
 
-func (r *applicationResource) configureConfigData(ctx context.Context, configType attr.Type, config types.Map, respCfg map[string]juju.ConfigEntry) (types.Map, diag.Diagnostics) {
+func (r *applicationResource) configureConfigData(ctx context.Context, configType attr.Type, config types.Map, configJSON types.String, respCfg map[string]juju.ConfigEntry, managedKeysOnly bool) (types.Map, diag.Diagnostics) {
 	// We focus on those config entries that are not the default value.
 	// If the value was the same we ignore it. If no changes were made,
-	// jump to the next step.
+	// jump to the next step. With managedKeysOnly, only the entries
+	// previously known are updated.
 	var previousConfig map[string]string
 	diagErr := config.ElementsAs(ctx, &previousConfig, false)
 	if diagErr.HasError() {
@@ -792,10 +1458,22 @@ func (r *applicationResource) configureConfigData(ctx context.Context, configTyp
 	if previousConfig == nil {
 		previousConfig = make(map[string]string)
 	}
+	// The entries of config_json are not copied to config.
+	jsonConfig := map[string]string{}
+	if configJSON.ValueString() != "" {
+		var err error
+		if jsonConfig, err = parseConfigJSON(configJSON.ValueString()); err != nil {
+			diagErr.AddAttributeError(path.Root(ConfigJSONKey), "Invalid Config JSON", err.Error())
+			return types.Map{}, diagErr
+		}
+	}
 	// known previously
 	// update the values from the previous config
 	changes := false
 	for k, v := range respCfg {
+		if _, found := jsonConfig[k]; found {
+			continue
+		}
 		// Add if the value has changed from the previous state
 		if previousValue, found := previousConfig[k]; found {
 			if !juju.EqualConfigEntries(v, previousValue) {
@@ -803,7 +1481,7 @@ func (r *applicationResource) configureConfigData(ctx context.Context, configTyp
 				previousConfig[k] = v.String()
 				changes = true
 			}
-		} else if !v.IsDefault {
+		} else if !v.IsDefault && !managedKeysOnly {
 			// Add if the value is not default
 			previousConfig[k] = v.String()
 			changes = true
@@ -815,6 +1493,215 @@ func (r *applicationResource) configureConfigData(ctx context.Context, configTyp
 	return config, nil
 }
 
+// applicationConfig returns the config of the application, merging
+// config and config_json.
+func applicationConfig(ctx context.Context, app applicationResourceModel) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	config := map[string]string{}
+	diags.Append(app.Config.ElementsAs(ctx, &config, false)...)
+	if diags.HasError() || app.ConfigJSON.ValueString() == "" {
+		return config, diags
+	}
+	jsonConfig, err := parseConfigJSON(app.ConfigJSON.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root(ConfigJSONKey), "Invalid Config JSON", err.Error())
+		return nil, diags
+	}
+	for k, v := range jsonConfig {
+		if _, found := config[k]; found {
+			diags.AddAttributeError(path.Root(ConfigJSONKey), "Conflicting Configuration",
+				fmt.Sprintf("Config option %q is set in both config and config_json.", k))
+			continue
+		}
+		config[k] = v
+	}
+	return config, diags
+}
+
+// ModifyPlan validates the config of the application against the config
+// options of the charm, read from Charmhub or the local charm archive,
+// when the config or the charm changes. The validation is skipped if
+// the charm, its model or the config are not known yet.
+func (r *applicationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to validate when destroying, or before the provider
+	// is configured.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	var plan applicationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var state applicationResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !plan.ModelName.IsUnknown() && !plan.Charm.IsUnknown() {
+			var revision types.Int64
+			resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(CharmKey).AtListIndex(0).AtName("revision"), &revision)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			charm, diags := r.planCharmRefresh(ctx, plan, state, !revision.IsNull())
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			plan.Charm = charm
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(CharmKey), plan.Charm)...)
+		}
+	}
+	if plan.ModelName.IsUnknown() || !isFullyKnown(ctx, plan.Config) || plan.ConfigJSON.IsUnknown() || plan.Charm.IsUnknown() {
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		if plan.Config.Equal(state.Config) && plan.ConfigJSON.Equal(state.ConfigJSON) && plan.Charm.Equal(state.Charm) {
+			return
+		}
+	}
+	config, diags := applicationConfig(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || len(config) == 0 {
+		return
+	}
+
+	var planCharms []nestedCharm
+	resp.Diagnostics.Append(plan.Charm.ElementsAs(ctx, &planCharms, false)...)
+	if resp.Diagnostics.HasError() || len(planCharms) == 0 {
+		return
+	}
+	planCharm := planCharms[0]
+	// The channel is unknown on create if not set, the default
+	// release of the charm is used then.
+	if planCharm.Name.IsUnknown() || planCharm.Path.IsUnknown() {
+		return
+	}
+	options, err := r.client.Charms.CharmConfig(ctx, &juju.CharmConfigInput{
+		ModelName: plan.ModelName.ValueString(),
+		CharmName: planCharm.Name.ValueString(),
+		Channel:   planCharm.Channel.ValueString(),
+		CharmPath: planCharm.Path.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to Validate Config",
+			fmt.Sprintf("Unable to read the config options of charm %q, got error: %s", planCharm.Name.ValueString(), err))
+		return
+	}
+	if _, err := options.ParseSettingsStrings(config); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root(ConfigKey), "Invalid Config",
+			fmt.Sprintf("Invalid config of charm %q: %s", planCharm.Name.ValueString(), err))
+	}
+}
+
+// planCharmRefresh plans the revision of the charm deployed by the
+// update. With the channel refresh policy, it is the latest revision
+// published in the channel, the revision too unless configured.
+// Otherwise, it is the revision planned, unknown when the channel
+// changes without a revision configured.
+func (r *applicationResource) planCharmRefresh(ctx context.Context, plan, state applicationResourceModel, revisionConfigured bool) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var planCharms, stateCharms []nestedCharm
+	diags.Append(plan.Charm.ElementsAs(ctx, &planCharms, false)...)
+	diags.Append(state.Charm.ElementsAs(ctx, &stateCharms, false)...)
+	if diags.HasError() || len(planCharms) != 1 || len(stateCharms) != 1 {
+		return plan.Charm, diags
+	}
+	planCharm := planCharms[0]
+	stateCharm := stateCharms[0]
+	// A switched charm or a local charm archive are refreshed when
+	// their name or their content change.
+	if !planCharm.Path.IsNull() || !planCharm.Name.Equal(stateCharm.Name) {
+		return plan.Charm, diags
+	}
+
+	switch {
+	case planCharm.RefreshPolicy.ValueString() == RefreshPolicyChannel:
+		planCharm.DeployedRevision = types.Int64Unknown()
+		if !planCharm.Channel.IsUnknown() && !planCharm.Name.IsUnknown() {
+			resolved, err := r.client.Charms.ResolveCharm(ctx, juju.ResolveCharmInput{
+				ModelName:    plan.ModelName.ValueString(),
+				Name:         planCharm.Name.ValueString(),
+				Channel:      planCharm.Channel.ValueString(),
+				Base:         stateCharm.Base.ValueString(),
+				Architecture: stateCharm.Architecture.ValueString(),
+			})
+			if err != nil {
+				diags.AddWarning("Unable to Resolve Charm",
+					fmt.Sprintf("Unable to resolve the latest revision of charm %q in channel %q, got error: %s",
+						planCharm.Name.ValueString(), planCharm.Channel.ValueString(), err))
+				planCharm.DeployedRevision = deployedRevision(stateCharm)
+			} else {
+				planCharm.DeployedRevision = types.Int64Value(int64(resolved.Revision))
+			}
+		}
+		if !revisionConfigured {
+			planCharm.Revision = planCharm.DeployedRevision
+		}
+	case !revisionConfigured && !planCharm.Channel.Equal(stateCharm.Channel):
+		planCharm.Revision = types.Int64Unknown()
+		planCharm.DeployedRevision = types.Int64Unknown()
+	default:
+		planCharm.DeployedRevision = planCharm.Revision
+	}
+	return types.ListValueFrom(ctx, plan.Charm.ElementType(ctx), []nestedCharm{planCharm})
+}
+
+// deployedRevision returns the revision of the charm deployed, the
+// revision in the states prior to the deployed revision.
+func deployedRevision(charm nestedCharm) types.Int64 {
+	if charm.DeployedRevision.IsNull() {
+		return charm.Revision
+	}
+	return charm.DeployedRevision
+}
+
+// sameConstraints reports whether the constraints written in value are
+// cons, whatever their order, so that the value written is kept. The
+// arch constraint is implied by the architecture of the charm, if any.
+func sameConstraints(value types.String, cons constraints.Value, architecture string) bool {
+	if value.IsNull() || value.IsUnknown() {
+		return false
+	}
+	parsed, err := constraints.Parse(value.ValueString())
+	if err == nil && !parsed.HasArch() && architecture != "" {
+		parsed.Arch = &architecture
+	}
+	return err == nil && parsed.String() == cons.String()
+}
+
+// resourceFilePaths returns the paths of the resource files of the
+// application, by resource name.
+func resourceFilePaths(ctx context.Context, app applicationResourceModel) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var files map[string]nestedResourceFile
+	diags.Append(app.ResourceFiles.ElementsAs(ctx, &files, false)...)
+	var resources map[string]string
+	diags.Append(app.Resources.ElementsAs(ctx, &resources, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	paths := make(map[string]string, len(files))
+	for name, file := range files {
+		if _, found := resources[name]; found {
+			diags.AddAttributeError(path.Root(ResourceFilesKey), "Conflicting Configuration",
+				fmt.Sprintf("Resource %q is set in both resources and resource_files.", name))
+			continue
+		}
+		paths[name] = file.Path.ValueString()
+	}
+	return paths, diags
+}
+
+// isFullyKnown returns whether the value and all the values it
+// contains are known.
+func isFullyKnown(ctx context.Context, value attr.Value) bool {
+	tfValue, err := value.ToTerraformValue(ctx)
+	return err == nil && tfValue.IsFullyKnown()
+}
+
 // Convert the endpoint bindings from the juju api to terraform nestedEndpointBinding set
 func (r *applicationResource) toEndpointBindingsSet(ctx context.Context, endpointBindingsType attr.Type, endpointBindings map[string]string) (types.Set, diag.Diagnostics) {
 	endpointBindingsSlice := make([]nestedEndpointBinding, 0, len(endpointBindings))
@@ -864,6 +1751,9 @@ func (r *applicationResource) configureResourceData(ctx context.Context, resourc
 // state, and prior state values should be read from the
 // UpdateRequest and new state values set on the UpdateResponse.
 func (r *applicationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_application", "Update", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if r.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "application", "update")
@@ -877,6 +1767,11 @@ func (r *applicationResource) Update(ctx context.Context, req resource.UpdateReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := withOperationTimeout(ctx, plan.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	r.trace("Proposed update", applicationResourceModelForLogging(ctx, &plan))
 	r.trace("Current state", applicationResourceModelForLogging(ctx, &state))
@@ -890,11 +1785,39 @@ func (r *applicationResource) Update(ctx context.Context, req resource.UpdateReq
 		resp.Diagnostics.AddWarning("Unsupported", "unable to update application name")
 	}
 
-	if !plan.UnitCount.Equal(state.UnitCount) {
+	if !plan.UnitCount.Equal(state.UnitCount) && !state.Subordinate.ValueBool() {
 		updateApplicationInput.Units = intPtr(plan.UnitCount)
+		updateApplicationInput.ScaleStrategy = plan.ScaleStrategy.ValueString()
+
+		// The units added get the storage of the storage directives
+		// whose count was increased after the deployment.
+		if !plan.StorageDirectives.IsUnknown() {
+			directives, dErr := parseStorageDirectives(ctx, plan.StorageDirectives)
+			resp.Diagnostics.Append(dErr...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			updateApplicationInput.StorageDirectives = directives
+		}
+
+		// The units added are placed with the directives following
+		// those of the existing units.
+		var placements []string
+		resp.Diagnostics.Append(plan.Placements.ElementsAs(ctx, &placements, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		from, to := int(state.UnitCount.ValueInt64()), int(plan.UnitCount.ValueInt64())
+		if from < len(placements) && from < to {
+			updateApplicationInput.UnitPlacements = placements[from:min(to, len(placements))]
+		}
 	}
 
-	if !plan.Trust.Equal(state.Trust) {
+	// The trust is only changed when configured, e.g. revoked when
+	// set to false.
+	if plan.Trust.IsUnknown() {
+		plan.Trust = state.Trust
+	} else if !plan.Trust.Equal(state.Trust) {
 		updateApplicationInput.Trust = plan.Trust.ValueBoolPointer()
 	}
 
@@ -907,27 +1830,63 @@ func (r *applicationResource) Update(ctx context.Context, req resource.UpdateReq
 		}
 		planCharm := planCharms[0]
 		stateCharm := stateCharms[0]
-		if !planCharm.Channel.Equal(stateCharm.Channel) && !planCharm.Revision.Equal(stateCharm.Revision) {
-			resp.Diagnostics.AddWarning("Not Supported", "Changing an application's revision and channel at the same time.")
-		} else if !planCharm.Channel.Equal(stateCharm.Channel) {
+		if !planCharm.Path.IsNull() {
+			if !planCharm.Path.Equal(stateCharm.Path) || !planCharm.SHA256.Equal(stateCharm.SHA256) {
+				updateApplicationInput.CharmPath = planCharm.Path.ValueString()
+			}
+		} else if !planCharm.Name.Equal(stateCharm.Name) {
+			// The charm is switched, allowed by allow_charm_switch.
+			updateApplicationInput.CharmName = planCharm.Name.ValueString()
 			updateApplicationInput.Channel = planCharm.Channel.ValueString()
-		} else if !planCharm.Revision.Equal(stateCharm.Revision) {
-			updateApplicationInput.Revision = intPtr(planCharm.Revision)
+			if !planCharm.Revision.IsUnknown() {
+				updateApplicationInput.Revision = intPtr(planCharm.Revision)
+			}
+		} else if planCharm.RefreshPolicy.ValueString() == RefreshPolicyChannel {
+			// The revision pinned is ignored, the charm is refreshed
+			// to the latest revision of the channel planned.
+			if !planCharm.Channel.Equal(stateCharm.Channel) || !planCharm.DeployedRevision.Equal(deployedRevision(stateCharm)) {
+				updateApplicationInput.Channel = planCharm.Channel.ValueString()
+				if !planCharm.DeployedRevision.IsUnknown() {
+					updateApplicationInput.Revision = intPtr(planCharm.DeployedRevision)
+				}
+			}
+		} else {
+			// The revision and the channel are refreshed at once,
+			// the revision pinned is deployed again when the charm
+			// tracked its channel before.
+			if !planCharm.Channel.Equal(stateCharm.Channel) {
+				updateApplicationInput.Channel = planCharm.Channel.ValueString()
+			}
+			if !planCharm.Revision.IsUnknown() && !planCharm.Revision.Equal(stateCharm.Revision) {
+				updateApplicationInput.Revision = intPtr(planCharm.Revision)
+			} else if !planCharm.DeployedRevision.IsUnknown() && !planCharm.DeployedRevision.Equal(deployedRevision(stateCharm)) {
+				updateApplicationInput.Revision = intPtr(planCharm.DeployedRevision)
+			}
 		}
 
-		if !planCharm.Series.Equal(stateCharm.Series) || !planCharm.Base.Equal(stateCharm.Base) {
-			// This violates Terraform's declarative model. We could implement
-			// `juju set-application-base`, usually used after `upgrade-machine`,
-			// which would change the operating system used for future units of
-			// the application provided the charm supported it, but not change
-			// the current. This provider does not implement an equivalent to
-			// `upgrade-machine`. There is also a question of how to handle a
-			// change to series, revision and channel at the same time.
-			resp.Diagnostics.AddWarning("Not Supported", "Changing an application's operating system after deploy.")
+		// The machines of the units are upgraded in place, like `juju
+		// upgrade-machine`, the application is not replaced.
+		if !planCharm.Base.IsUnknown() && !planCharm.Base.Equal(stateCharm.Base) {
+			updateApplicationInput.Base = planCharm.Base.ValueString()
+		} else if !planCharm.Series.IsUnknown() && !planCharm.Series.Equal(stateCharm.Series) {
+			updateApplicationInput.Series = planCharm.Series.ValueString()
 		}
 	}
 
 	if !plan.Expose.Equal(state.Expose) {
+		if !plan.Expose.IsNull() {
+			managed, err := r.client.Applications.IsExposeManaged(ctx, plan.ModelName.ValueString(), plan.ApplicationName.ValueString())
+			if err != nil {
+				addClientError(&resp.Diagnostics, err, "Unable to read application expose annotations")
+				return
+			}
+			if managed {
+				resp.Diagnostics.AddAttributeError(path.Root(ExposeKey), "Conflicting Configuration",
+					fmt.Sprintf("The exposure of application %q is managed by a juju_application_expose resource, "+
+						"remove the expose block.", plan.ApplicationName.ValueString()))
+				return
+			}
+		}
 		expose, unexpose, exposeDiags := r.computeExposeDeltas(ctx, state.Expose, plan.Expose)
 		resp.Diagnostics.Append(exposeDiags...)
 		if resp.Diagnostics.HasError() {
@@ -937,11 +1896,11 @@ func (r *applicationResource) Update(ctx context.Context, req resource.UpdateReq
 		updateApplicationInput.Unexpose = unexpose
 	}
 
-	if !plan.Config.Equal(state.Config) {
-		planConfigMap := map[string]string{}
-		stateConfigMap := map[string]string{}
-		resp.Diagnostics.Append(plan.Config.ElementsAs(ctx, &planConfigMap, false)...)
-		resp.Diagnostics.Append(state.Config.ElementsAs(ctx, &stateConfigMap, false)...)
+	if !plan.Config.Equal(state.Config) || !plan.ConfigJSON.Equal(state.ConfigJSON) {
+		planConfigMap, dErr := applicationConfig(ctx, plan)
+		resp.Diagnostics.Append(dErr...)
+		stateConfigMap, dErr := applicationConfig(ctx, state)
+		resp.Diagnostics.Append(dErr...)
 		if resp.Diagnostics.HasError() {
 			return
 		}
@@ -1002,6 +1961,31 @@ func (r *applicationResource) Update(ctx context.Context, req resource.UpdateReq
 		}
 	}
 
+	// Upload the resource files changed, reset those removed.
+	if !plan.ResourceFiles.Equal(state.ResourceFiles) {
+		var planFiles, stateFiles map[string]nestedResourceFile
+		resp.Diagnostics.Append(plan.ResourceFiles.ElementsAs(ctx, &planFiles, false)...)
+		resp.Diagnostics.Append(state.ResourceFiles.ElementsAs(ctx, &stateFiles, false)...)
+		planPaths, dErr := resourceFilePaths(ctx, plan)
+		resp.Diagnostics.Append(dErr...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if updateApplicationInput.Resources == nil {
+			updateApplicationInput.Resources = make(map[string]string)
+		}
+		for name, file := range planFiles {
+			if stateFile, found := stateFiles[name]; !found || !file.Path.Equal(stateFile.Path) || !file.SHA256.Equal(stateFile.SHA256) {
+				updateApplicationInput.Resources[name] = planPaths[name]
+			}
+		}
+		for name := range stateFiles {
+			if _, found := planFiles[name]; !found {
+				updateApplicationInput.Resources[name] = "-1"
+			}
+		}
+	}
+
 	// Do not use .Equal() here as we should consider null constraints the same
 	// as empty-string constraints. Terraform considers them different, so will
 	// incorrectly attempt to update the constraints, which can cause trouble
@@ -1010,6 +1994,7 @@ func (r *applicationResource) Update(ctx context.Context, req resource.UpdateReq
 		appConstraints, err := constraints.Parse(plan.Constraints.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError("Conversion", fmt.Sprintf("Unable to parse plan constraints, got error: %s", err))
+			return
 		}
 		updateApplicationInput.Constraints = &appConstraints
 	}
@@ -1026,25 +2011,48 @@ func (r *applicationResource) Update(ctx context.Context, req resource.UpdateReq
 	// Check if we have new storage in plan that not existed in the state, and add their constraints to the
 	// update application input.
 	if !plan.StorageDirectives.Equal(state.StorageDirectives) {
-		directives, dErr := r.updateStorage(ctx, plan, state)
+		directives, additions, dErr := r.updateStorage(ctx, plan, state)
 		resp.Diagnostics.Append(dErr...)
 		if resp.Diagnostics.HasError() {
 			return
 		}
 		updateApplicationInput.StorageConstraints = directives
+		updateApplicationInput.StorageAdditions = additions
 	}
 
-	if err := r.client.Applications.UpdateApplication(&updateApplicationInput); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update application resource, got error: %s", err))
+	if err := r.client.Applications.UpdateApplication(ctx, &updateApplicationInput); err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to update application resource")
 		return
 	}
 
+	if !plan.Annotations.Equal(state.Annotations) {
+		annotations, dErr := annotationChanges(ctx, plan.Annotations, state.Annotations)
+		resp.Diagnostics.Append(dErr...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		err := r.client.Annotations.SetAnnotations(ctx, juju.SetAnnotationsInput{
+			ModelName:   updateApplicationInput.ModelName,
+			EntityTag:   applicationTag(updateApplicationInput.AppName),
+			Annotations: annotations,
+		})
+		if err != nil {
+			addClientError(&resp.Diagnostics, err, "Unable to update application annotations")
+			return
+		}
+	}
+
 	// If the plan has refreshed the charm, changed the unit count,
 	// or changed placement, wait for the changes to be seen in
 	// status. Including storage as it can be added on a refresh.
 	storageType := req.Config.Schema.GetAttributes()[StorageKey].(schema.SetNestedAttribute).NestedObject.Type()
 	if updateApplicationInput.Channel != "" ||
 		updateApplicationInput.Revision != nil ||
+		updateApplicationInput.CharmPath != "" ||
+		updateApplicationInput.CharmName != "" ||
+		updateApplicationInput.Base != "" ||
+		updateApplicationInput.Series != "" ||
+		len(updateApplicationInput.StorageAdditions) > 0 ||
 		updateApplicationInput.Placement != nil ||
 		updateApplicationInput.Units != nil {
 		readResp, err := r.client.Applications.ReadApplicationWithRetryOnNotFound(ctx, &juju.ReadApplicationInput{
@@ -1052,10 +2060,43 @@ func (r *applicationResource) Update(ctx context.Context, req resource.UpdateReq
 			AppName:   updateApplicationInput.AppName,
 		})
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read application resource after update, got error: %s", err))
+			addClientError(&resp.Diagnostics, err, "Unable to read application resource after update")
 			return
 		}
-		plan.Placement = types.StringValue(readResp.Placement)
+		if plan.Placement.IsUnknown() || !juju.PlacementSatisfied(plan.Placement.ValueString(), readResp.Placement) {
+			plan.Placement = types.StringValue(readResp.Placement)
+		}
+
+		// The controller assigns the revision of an uploaded local
+		// charm or of a switched charm, the base and series are set
+		// from one another. The revision deployed is read after any
+		// refresh.
+		if updateApplicationInput.CharmPath != "" || updateApplicationInput.CharmName != "" ||
+			updateApplicationInput.Channel != "" || updateApplicationInput.Revision != nil ||
+			updateApplicationInput.Base != "" || updateApplicationInput.Series != "" {
+			var planCharms []nestedCharm
+			resp.Diagnostics.Append(plan.Charm.ElementsAs(ctx, &planCharms, false)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			if updateApplicationInput.CharmPath != "" || updateApplicationInput.CharmName != "" {
+				planCharms[0].Revision = types.Int64Value(int64(readResp.Revision))
+				planCharms[0].Channel = types.StringValue(readResp.Channel)
+			} else if planCharms[0].Revision.IsUnknown() {
+				planCharms[0].Revision = types.Int64Value(int64(readResp.Revision))
+			}
+			planCharms[0].DeployedRevision = types.Int64Value(int64(readResp.Revision))
+			planCharms[0].Base = types.StringValue(readResp.Base)
+			planCharms[0].Series = types.StringValue(readResp.Series)
+			planCharms[0].Architecture = types.StringValue(readResp.Architecture)
+			charmType := req.Config.Schema.GetBlocks()[CharmKey].(schema.ListNestedBlock).NestedObject.Type()
+			var dErr diag.Diagnostics
+			plan.Charm, dErr = types.ListValueFrom(ctx, charmType, planCharms)
+			if dErr.HasError() {
+				resp.Diagnostics.Append(dErr...)
+				return
+			}
+		}
 
 		var nestedStorageSlice []nestedStorage
 		for name, storage := range readResp.Storage {
@@ -1087,52 +2128,100 @@ func (r *applicationResource) Update(ctx context.Context, req resource.UpdateReq
 
 	plan.ID = types.StringValue(newAppID(plan.ModelName.ValueString(), plan.ApplicationName.ValueString()))
 	plan.Principal = types.BoolNull()
+	resp.Diagnostics.Append(r.readUnitOutputs(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	r.trace("Updated", applicationResourceModelForLogging(ctx, &plan))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.waitForApplication(ctx, plan)...)
+	if resp.Diagnostics.HasError() || plan.WaitFor.IsNull() {
+		return
+	}
+	resp.Diagnostics.Append(r.readUnitOutputs(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// parseStorageDirectives returns the storage constraints of the
+// storage directives, by storage label.
+func parseStorageDirectives(ctx context.Context, directives types.Map) (map[string]jujustorage.Constraints, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+	storageDirectives := make(map[string]string)
+	diagnostics.Append(directives.ElementsAs(ctx, &storageDirectives, false)...)
+	if diagnostics.HasError() {
+		return nil, diagnostics
+	}
+	storageConstraints := make(map[string]jujustorage.Constraints, len(storageDirectives))
+	for k, v := range storageDirectives {
+		result, err := jujustorage.ParseConstraints(v)
+		if err != nil {
+			addClientError(&diagnostics, err, "Unable to parse storage directives")
+			return nil, diagnostics
+		}
+		storageConstraints[k] = result
+	}
+	return storageConstraints, diagnostics
 }
 
 // updateStorage compares the plan storage directives to the
 // state storage directives, any new labels are returned to be
-// added as storage constraints.
+// added as storage constraints. The increases of the count of existing
+// labels are returned as storage to add to each unit.
 func (r *applicationResource) updateStorage(
 	ctx context.Context,
 	plan applicationResourceModel,
 	state applicationResourceModel,
-) (map[string]jujustorage.Constraints, diag.Diagnostics) {
+) (map[string]jujustorage.Constraints, map[string]jujustorage.Constraints, diag.Diagnostics) {
 	diagnostics := diag.Diagnostics{}
-	var updatedStorageDirectivesMap map[string]jujustorage.Constraints
+	var updatedStorageDirectivesMap, storageAdditionsMap map[string]jujustorage.Constraints
 
 	var planStorageDirectives, stateStorageDirectives map[string]string
 	diagnostics.Append(plan.StorageDirectives.ElementsAs(ctx, &planStorageDirectives, false)...)
 	if diagnostics.HasError() {
-		return updatedStorageDirectivesMap, diagnostics
+		return updatedStorageDirectivesMap, storageAdditionsMap, diagnostics
 	}
 	diagnostics.Append(state.StorageDirectives.ElementsAs(ctx, &stateStorageDirectives, false)...)
 	if diagnostics.HasError() {
-		return updatedStorageDirectivesMap, diagnostics
+		return updatedStorageDirectivesMap, storageAdditionsMap, diagnostics
 	}
 
-	// Create a map of updated storage directives that are in the plan but not in the state
+	// Create a map of updated storage directives that are in the plan but not in the state,
+	// and of the storage added by increasing the count of the ones in the state.
 	updatedStorageDirectivesMap = make(map[string]jujustorage.Constraints)
+	storageAdditionsMap = make(map[string]jujustorage.Constraints)
 	for label, constraintString := range planStorageDirectives {
-		if _, ok := stateStorageDirectives[label]; !ok {
-			cons, err := jujustorage.ParseConstraints(constraintString)
-			if err != nil {
-				// Just in case, as this should have been validated out before now.
-				diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse storage directives, got error: %s", err))
-				continue
-			}
+		cons, err := jujustorage.ParseConstraints(constraintString)
+		if err != nil {
+			// Just in case, as this should have been validated out before now.
+			addClientError(&diagnostics, err, "Unable to parse storage directives")
+			continue
+		}
+		stateConstraintString, ok := stateStorageDirectives[label]
+		if !ok {
 			updatedStorageDirectivesMap[label] = cons
+			continue
+		}
+		stateCons, err := jujustorage.ParseConstraints(stateConstraintString)
+		if err != nil {
+			addClientError(&diagnostics, err, "Unable to parse storage directives")
+			continue
+		}
+		if cons.Count > stateCons.Count {
+			cons.Count -= stateCons.Count
+			storageAdditionsMap[label] = cons
 		}
 	}
 
-	return updatedStorageDirectivesMap, diagnostics
+	return updatedStorageDirectivesMap, storageAdditionsMap, diagnostics
 }
 
 // computeExposeDeltas computes the differences between the previously
-// stored expose value and the current one. The valueSet argument is used
-// to indicate whether the value was already set or not in the latest
-// read of the plan.
+// stored expose value and the current one. It returns the expose rule
+// to apply and the endpoints to unexpose first, so that narrowing or
+// expanding the rule is done in place.
 func (r *applicationResource) computeExposeDeltas(ctx context.Context, stateExpose types.List, planExpose types.List) (map[string]interface{}, []string, diag.Diagnostics) {
 	diags := diag.Diagnostics{}
 	if planExpose.IsNull() {
@@ -1163,28 +2252,39 @@ func (r *applicationResource) computeExposeDeltas(ctx context.Context, stateExpo
 		return nil, []string{}, diags
 	}
 
-	toExpose := make(map[string]interface{})
-	toUnexpose := make([]string, 0)
-
 	plan := planNestedExpose[0].transformToMapStringInterface()
 	state := stateNestedExpose[0].transformToMapStringInterface()
+	if reflect.DeepEqual(plan, state) {
+		return nil, []string{}, diags
+	}
 
-	// if we have plan endpoints we have to expose them
-	for endpoint, v := range plan {
-		_, found := state[endpoint]
-		if found {
-			// this was already set
-			// If it is different, unexpose and then expose
-			if v != state[endpoint] {
-				toUnexpose = append(toUnexpose, endpoint)
-				toExpose[endpoint] = v
-			}
-		} else {
-			// this was not set, expose it
-			toExpose[endpoint] = v
+	// The endpoints exposed in state but not in the plan are unexposed,
+	// the endpoints of the plan are exposed again with the spaces and
+	// CIDRs of the plan, replacing the rules of the state. An empty
+	// endpoint stands for all the endpoints.
+	planEndpoints := exposedEndpoints(planNestedExpose[0].Endpoints.ValueString())
+	toUnexpose := make([]string, 0)
+	for _, endpoint := range exposedEndpoints(stateNestedExpose[0].Endpoints.ValueString()) {
+		if !slices.Contains(planEndpoints, endpoint) {
+			toUnexpose = append(toUnexpose, endpoint)
+		}
+	}
+	return plan, toUnexpose, diags
+}
+
+// exposedEndpoints returns the endpoints of the comma-delimited list,
+// or the empty endpoint standing for all of them if the list is empty.
+func exposedEndpoints(list string) []string {
+	var endpoints []string
+	for _, endpoint := range strings.Split(list, ",") {
+		if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+			endpoints = append(endpoints, endpoint)
 		}
 	}
-	return toExpose, toUnexpose, diags
+	if len(endpoints) == 0 {
+		return []string{""}
+	}
+	return endpoints
 }
 
 // computeEndpointBindingsDeltas computes the differences between the previously
@@ -1228,6 +2328,9 @@ func (*applicationResource) computeEndpointBindingsDeltas(ctx context.Context, s
 // Juju refers to deletion as "destroy" so we call the Destroy function of our client here rather than delete
 // This function remains named Delete for parity across the provider and to stick within terraform naming conventions
 func (r *applicationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_application", "Delete", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if r.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "application", "delete")
@@ -1239,6 +2342,11 @@ func (r *applicationResource) Delete(ctx context.Context, req resource.DeleteReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := withOperationTimeout(ctx, state.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	r.trace("Deleting", map[string]interface{}{
 		"ID": state.ID.ValueString(),
@@ -1249,36 +2357,67 @@ func (r *applicationResource) Delete(ctx context.Context, req resource.DeleteReq
 		resp.Diagnostics.Append(dErr...)
 	}
 
-	if err := r.client.Applications.DestroyApplication(&juju.DestroyApplicationInput{
-		ApplicationName: appName,
-		ModelName:       modelName,
-	}); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete application, got error: %s", err))
+	input, dErr := applicationDestroyInput(ctx, state)
+	resp.Diagnostics.Append(dErr...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	input.ApplicationName = appName
+	input.ModelName = modelName
+	if err := r.client.Applications.DestroyApplication(ctx, &input); err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to delete application")
 	}
 	r.trace(fmt.Sprintf("deleted application resource %q", state.ID.ValueString()))
 }
 
+// applicationDestroyInput returns the input of the destroy of the
+// application from its force_destroy and destroy_max_wait attributes
+// and its destroy_options block.
+func applicationDestroyInput(ctx context.Context, app applicationResourceModel) (juju.DestroyApplicationInput, diag.Diagnostics) {
+	input := juju.DestroyApplicationInput{
+		DestroyOptions: destroyOptions(app.ForceDestroy, app.DestroyMaxWait),
+	}
+	if app.DestroyOptions.IsNull() || app.DestroyOptions.IsUnknown() {
+		return input, nil
+	}
+	var options nestedDestroyOptions
+	diags := app.DestroyOptions.As(ctx, &options, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return input, diags
+	}
+	input.NoWait = options.NoWait.ValueBool()
+	input.Force = input.Force || options.Force.ValueBool() || input.NoWait
+	input.KeepStorage = !options.DestroyStorage.IsNull() && !options.DestroyStorage.ValueBool()
+	return input, diags
+}
+
 // ImportState is called when the provider must import the state of a
 // resource instance. This method must return enough state so the Read
 // method can properly refresh the full resource.
 //
-// If setting an attribute with the import identifier, it is recommended
-// to use the ImportStatePassthroughID() call in this method.
+// The import identifier is validated against the format of the ID of
+// the resource, and set as its id attribute.
 func (r *applicationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	applicationIDFormat.importState(ctx, req, resp)
+}
+
+// IdentitySchema returns the schema of the identity of the resource,
+// made of the parts of its ID.
+func (r *applicationResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = applicationIDFormat.identitySchema()
 }
 
-// ID is '<model name>:<app name>'
+// applicationIDFormat is the format of the ID of applications, also
+// used by application_expose.
+var applicationIDFormat = idFormat{parts: []string{"model_name", "application_name"}}
+
 func newAppID(model, app string) string {
-	return fmt.Sprintf("%s:%s", model, app)
+	return applicationIDFormat.format(model, app)
 }
 
 func modelAppNameFromID(value string) (string, string, diag.Diagnostics) {
-	var diags diag.Diagnostics
-	id := strings.Split(value, ":")
-	//If importing with an incorrect ID we need to catch and provide a user-friendly error
-	if len(id) != 2 {
-		diags.AddError("Malformed ID", fmt.Sprintf("unable to parse model and application name from provided ID: %q", value))
+	id, diags := applicationIDFormat.parse(value)
+	if diags.HasError() {
 		return "", "", diags
 	}
 	return id[0], id[1], diags
@@ -1302,6 +2441,7 @@ func applicationResourceModelForLogging(_ context.Context, app *applicationResou
 		"constraints":      app.Constraints.ValueString(),
 		"model":            app.ModelName.ValueString(),
 		"placement":        app.Placement.ValueString(),
+		"placements":       app.Placements.String(),
 		"expose":           app.Expose.String(),
 		"trust":            app.Trust.ValueBoolPointer(),
 		"units":            app.UnitCount.ValueInt64(),
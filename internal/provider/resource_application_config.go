@@ -0,0 +1,380 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/juju/errors"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &applicationConfigResource{}
+var _ resource.ResourceWithConfigure = &applicationConfigResource{}
+var _ resource.ResourceWithImportState = &applicationConfigResource{}
+var _ resource.ResourceWithUpgradeState = &applicationConfigResource{}
+var _ resource.ResourceWithIdentity = &applicationConfigResource{}
+
+func NewApplicationConfigResource() resource.Resource {
+	return &applicationConfigResource{}
+}
+
+type applicationConfigResource struct {
+	client *juju.Client
+
+	// subCtx is the context created with the new tflog subsystem for application config.
+	subCtx context.Context
+}
+
+type applicationConfigResourceModel struct {
+	ModelName       types.String `tfsdk:"model"`
+	ApplicationName types.String `tfsdk:"application"`
+	Config          types.Map    `tfsdk:"config"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+func (r *applicationConfigResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application_config"
+}
+
+func (r *applicationConfigResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: applicationConfigSchemaVersion,
+		Description: "A resource that manages a subset of the config options of an existing application, " +
+			"independently of the application resource. Only the options configured here are managed, they are " +
+			"reset to the defaults of the charm when removed. The options must not be set in the config of " +
+			"`juju_application` too, which should use `config_mode = \"managed_keys_only\"`.",
+		Attributes: map[string]schema.Attribute{
+			"model": schema.StringAttribute{
+				Description: "The name of the model where the application is deployed.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"application": schema.StringAttribute{
+				Description: "The name of the application to configure.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"config": schema.MapAttribute{
+				Description: "The config options to set, by name. Must evaluate to a string, integer or boolean.",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// UpgradeState returns the state upgraders from the prior schema
+// versions of the resource, keyed by version.
+func (r *applicationConfigResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+func (r *applicationConfigResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+	// Create the local logging subsystem here, using the TF context when creating it.
+	r.subCtx = r.client.NewLogSubsystem(ctx, LogResourceApplicationConfig)
+}
+
+// ImportState is called when the provider must import the state of a
+// resource instance. The ID is of the form <model>:<application>. Every
+// option set to a non-default value is imported.
+func (r *applicationConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	applicationIDFormat.importState(ctx, req, resp)
+}
+
+// IdentitySchema returns the schema of the identity of the resource,
+// made of the parts of its ID.
+func (r *applicationConfigResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = applicationIDFormat.identitySchema()
+}
+
+func (r *applicationConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_application_config", "Create", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "application_config", "create")
+		return
+	}
+
+	var plan applicationConfigResourceModel
+
+	// Read Terraform configuration from the request into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config := make(map[string]string)
+	resp.Diagnostics.Append(plan.Config.ElementsAs(ctx, &config, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	modelName := plan.ModelName.ValueString()
+	appName := plan.ApplicationName.ValueString()
+	err := r.client.Applications.SetApplicationConfig(ctx, juju.SetApplicationConfigInput{
+		ModelName: modelName,
+		AppName:   appName,
+		Config:    config,
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to set config of application %q", appName)
+		return
+	}
+	r.trace(fmt.Sprintf("set config of application %q", appName))
+
+	plan.ID = types.StringValue(newAppID(modelName, appName))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(applicationIDFormat.setIdentity(ctx, plan.ID, resp.Identity)...)
+}
+
+func (r *applicationConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_application_config", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "application_config", "read")
+		return
+	}
+
+	var state applicationConfigResourceModel
+
+	// Read Terraform prior state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	modelName, appName, dErr := modelAppNameFromID(state.ID.ValueString())
+	if dErr.HasError() {
+		resp.Diagnostics.Append(dErr...)
+		return
+	}
+
+	response, err := r.client.Applications.ReadApplicationConfig(ctx, juju.ReadApplicationConfigInput{
+		ModelName: modelName,
+		AppName:   appName,
+	})
+	if err != nil {
+		handleReadError(ctx, r.client, err, &resp.State, &resp.Diagnostics, "Unable to read application config")
+		return
+	}
+	r.trace(fmt.Sprintf("read config of application %q", appName), map[string]interface{}{"config": response.Config})
+
+	var managed map[string]string
+	if !state.Config.IsNull() {
+		resp.Diagnostics.Append(state.Config.ElementsAs(ctx, &managed, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	state.Config, dErr = types.MapValueFrom(ctx, types.StringType, managedConfig(managed, response.Config))
+	if dErr.HasError() {
+		resp.Diagnostics.Append(dErr...)
+		return
+	}
+	state.ModelName = types.StringValue(modelName)
+	state.ApplicationName = types.StringValue(appName)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(applicationIDFormat.setIdentity(ctx, state.ID, resp.Identity)...)
+}
+
+func (r *applicationConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_application_config", "Update", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "application_config", "update")
+		return
+	}
+
+	var plan, state applicationConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planConfig := make(map[string]string)
+	stateConfig := make(map[string]string)
+	resp.Diagnostics.Append(plan.Config.ElementsAs(ctx, &planConfig, false)...)
+	resp.Diagnostics.Append(state.Config.ElementsAs(ctx, &stateConfig, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	modelName := plan.ModelName.ValueString()
+	appName := plan.ApplicationName.ValueString()
+	// Options removed from the plan are reset.
+	if removed := removedConfigKeys(planConfig, stateConfig); len(removed) > 0 {
+		err := r.client.Applications.UnsetApplicationConfig(ctx, juju.UnsetApplicationConfigInput{
+			ModelName: modelName,
+			AppName:   appName,
+			Keys:      removed,
+		})
+		if err != nil {
+			addClientError(&resp.Diagnostics, err, "Unable to reset config of application %q", appName)
+			return
+		}
+	}
+	err := r.client.Applications.SetApplicationConfig(ctx, juju.SetApplicationConfigInput{
+		ModelName: modelName,
+		AppName:   appName,
+		Config:    planConfig,
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to update config of application %q", appName)
+		return
+	}
+	r.trace(fmt.Sprintf("updated config of application %q", appName))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *applicationConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_application_config", "Delete", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "application_config", "delete")
+		return
+	}
+
+	var state applicationConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config := make(map[string]string)
+	resp.Diagnostics.Append(state.Config.ElementsAs(ctx, &config, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(config) == 0 {
+		return
+	}
+	appName := state.ApplicationName.ValueString()
+	err := r.client.Applications.UnsetApplicationConfig(ctx, juju.UnsetApplicationConfigInput{
+		ModelName: state.ModelName.ValueString(),
+		AppName:   appName,
+		Keys:      removedConfigKeys(nil, config),
+	})
+	// Nothing to reset once the application is removed.
+	if err != nil && !errors.Is(err, errors.NotFound) {
+		addClientError(&resp.Diagnostics, err, "Unable to reset config of application %q", appName)
+		return
+	}
+	r.trace(fmt.Sprintf("reset config of application %q", appName))
+}
+
+// managedConfig returns the current values of the config options
+// managed, those in the prior state. The value in the prior state is
+// kept when equivalent to the current one, e.g. `True` for true.
+// Without prior state, on import, all the options set to a non-default
+// value are returned.
+func managedConfig(managed map[string]string, current map[string]juju.ConfigEntry) map[string]string {
+	config := make(map[string]string)
+	for key, entry := range current {
+		value, found := managed[key]
+		switch {
+		case found && juju.EqualConfigEntries(configValueAs(value, entry.Value), entry.Value):
+			config[key] = value
+		case found || (managed == nil && !entry.IsDefault):
+			config[key] = entry.String()
+		}
+	}
+	return config
+}
+
+// configValueAs returns the config value given as a string converted to
+// the type of the value like, as reported by juju, or the string itself
+// if it cannot be.
+func configValueAs(value string, like interface{}) interface{} {
+	switch like.(type) {
+	case bool:
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	case int64:
+		if f, err := strconv.ParseFloat(value, 64); err == nil && f == math.Trunc(f) {
+			return int64(f)
+		}
+	case float64:
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return value
+}
+
+// removedConfigKeys returns the keys of the state config not in the
+// plan config, sorted.
+func removedConfigKeys(plan, state map[string]string) []string {
+	var removed []string
+	for key := range state {
+		if _, found := plan[key]; !found {
+			removed = append(removed, key)
+		}
+	}
+	sort.Strings(removed)
+	return removed
+}
+
+func (r *applicationConfigResource) trace(msg string, additionalFields ...map[string]interface{}) {
+	if r.subCtx == nil {
+		return
+	}
+
+	//SubsystemTrace(subCtx, "my-subsystem", "hello, world", map[string]interface{}{"foo": 123})
+	// Output:
+	// {"@level":"trace","@message":"hello, world","@module":"provider.my-subsystem","foo":123}
+	tflog.SubsystemTrace(r.subCtx, LogResourceApplicationConfig, msg, additionalFields...)
+}
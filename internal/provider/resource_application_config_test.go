@@ -0,0 +1,96 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+func TestManagedConfig(t *testing.T) {
+	current := map[string]juju.ConfigEntry{
+		"port":    {Value: int64(5432), IsDefault: true},
+		"profile": {Value: "production"},
+		"debug":   {Value: true},
+	}
+	assert.Equal(t, map[string]string{"port": "5432", "profile": "production"},
+		managedConfig(map[string]string{"port": "5433", "profile": "testing", "removed": "x"}, current))
+	// On import, the options set to a non-default value are managed.
+	assert.Equal(t, map[string]string{"profile": "production", "debug": "true"}, managedConfig(nil, current))
+	// The values equivalent to the current ones are kept as written.
+	assert.Equal(t, map[string]string{"port": "5432.0", "debug": "True"},
+		managedConfig(map[string]string{"port": "5432.0", "debug": "True"}, current))
+	assert.Equal(t, map[string]string{"port": "5432", "debug": "true"},
+		managedConfig(map[string]string{"port": "5432.5", "debug": "yes"}, current))
+}
+
+func TestRemovedConfigKeys(t *testing.T) {
+	assert.Equal(t, []string{"debug", "port"},
+		removedConfigKeys(map[string]string{"profile": "a"}, map[string]string{"port": "1", "profile": "b", "debug": "true"}))
+	assert.Empty(t, removedConfigKeys(map[string]string{"port": "1"}, map[string]string{"port": "2"}))
+}
+
+func TestAcc_ResourceApplicationConfig(t *testing.T) {
+	if testingCloud != LXDCloudTesting {
+		t.Skip(t.Name() + " only runs with LXD")
+	}
+	modelName := acctest.RandomWithPrefix("tf-test-application-config")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceApplicationConfig(modelName, "myhostname"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_application_config.this", "id", fmt.Sprintf("%s:ubuntu", modelName)),
+					resource.TestCheckResourceAttr("juju_application_config.this", "config.juju-external-hostname", "myhostname"),
+					resource.TestCheckNoResourceAttr("juju_application.this", "config.%"),
+				),
+			},
+			{
+				Config: testAccResourceApplicationConfig(modelName, "otherhostname"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_application_config.this", "config.juju-external-hostname", "otherhostname"),
+				),
+			},
+			{
+				ImportStateVerify: true,
+				ImportState:       true,
+				ResourceName:      "juju_application_config.this",
+			},
+		},
+	})
+}
+
+func testAccResourceApplicationConfig(modelName, hostname string) string {
+	return fmt.Sprintf(`
+resource "juju_model" "this" {
+  name = %q
+}
+
+resource "juju_application" "this" {
+  model       = juju_model.this.name
+  name        = "ubuntu"
+  config_mode = "managed_keys_only"
+  charm {
+    name = "jameinel-ubuntu-lite"
+  }
+}
+
+resource "juju_application_config" "this" {
+  model       = juju_model.this.name
+  application = juju_application.this.name
+  config = {
+    juju-external-hostname = %q
+  }
+}
+`, modelName, hostname)
+}
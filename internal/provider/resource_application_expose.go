@@ -0,0 +1,343 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &applicationExposeResource{}
+var _ resource.ResourceWithConfigure = &applicationExposeResource{}
+var _ resource.ResourceWithImportState = &applicationExposeResource{}
+var _ resource.ResourceWithUpgradeState = &applicationExposeResource{}
+var _ resource.ResourceWithIdentity = &applicationExposeResource{}
+
+func NewApplicationExposeResource() resource.Resource {
+	return &applicationExposeResource{}
+}
+
+type applicationExposeResource struct {
+	client *juju.Client
+
+	// subCtx is the context created with the new tflog subsystem for application expose.
+	subCtx context.Context
+}
+
+type applicationExposeResourceModel struct {
+	ModelName       types.String `tfsdk:"model"`
+	ApplicationName types.String `tfsdk:"application"`
+	Endpoints       types.Set    `tfsdk:"endpoints"`
+	Spaces          types.Set    `tfsdk:"spaces"`
+	CIDRs           types.Set    `tfsdk:"cidrs"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+func (r *applicationExposeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application_expose"
+}
+
+func (r *applicationExposeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: applicationExposeSchemaVersion,
+		Description: "A resource that manages the exposure of an existing application, independently of the " +
+			"application resource. The application must not use the inline `expose` block of `juju_application`.",
+		Attributes: map[string]schema.Attribute{
+			"model": schema.StringAttribute{
+				Description: "The name of the model where the application is deployed.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"application": schema.StringAttribute{
+				Description: "The name of the application to expose.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"endpoints": schema.SetAttribute{
+				Description: "Expose only the ports that charms have opened for these endpoints. If not set, " +
+					"all opened ports are exposed.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"spaces": schema.SetAttribute{
+				Description: "The spaces that should be able to access the application ports once exposed.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"cidrs": schema.SetAttribute{
+				Description: "The CIDRs that should be able to access the application ports once exposed.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// UpgradeState returns the state upgraders from the prior schema
+// versions of the resource, keyed by version.
+func (r *applicationExposeResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+func (r *applicationExposeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+	// Create the local logging subsystem here, using the TF context when creating it.
+	r.subCtx = r.client.NewLogSubsystem(ctx, LogResourceApplicationExpose)
+}
+
+// ImportState is called when the provider must import the state of a
+// resource instance. The ID is of the form <model>:<application>.
+func (r *applicationExposeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	applicationIDFormat.importState(ctx, req, resp)
+}
+
+// IdentitySchema returns the schema of the identity of the resource,
+// made of the parts of its ID.
+func (r *applicationExposeResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = applicationIDFormat.identitySchema()
+}
+
+func (r *applicationExposeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_application_expose", "Create", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "application_expose", "create")
+		return
+	}
+
+	var plan applicationExposeResourceModel
+
+	// Read Terraform configuration from the request into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	modelName := plan.ModelName.ValueString()
+	appName := plan.ApplicationName.ValueString()
+
+	// An application exposed without the managed marker is most likely
+	// exposed by the inline expose block of juju_application.
+	current, err := r.client.Applications.ReadApplicationExpose(ctx, juju.ReadApplicationExposeInput{
+		ModelName: modelName,
+		AppName:   appName,
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to read application %q", appName)
+		return
+	}
+	if current.Exposed && !current.Managed {
+		resp.Diagnostics.AddError("Conflicting Configuration",
+			fmt.Sprintf("Application %q is already exposed. Remove the expose block from its juju_application "+
+				"resource, or unexpose it, before managing the exposure with juju_application_expose.", appName))
+		return
+	}
+
+	input, diags := r.exposeInput(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := r.client.Applications.ExposeApplication(ctx, input); err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to expose application %q", appName)
+		return
+	}
+	r.trace(fmt.Sprintf("exposed application %q", appName))
+
+	plan.ID = types.StringValue(newAppID(modelName, appName))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(applicationIDFormat.setIdentity(ctx, plan.ID, resp.Identity)...)
+}
+
+func (r *applicationExposeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_application_expose", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// The reads of a refresh share the status of the model.
+	ctx = juju.WithRefresh(ctx)
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "application_expose", "read")
+		return
+	}
+
+	var state applicationExposeResourceModel
+
+	// Read Terraform prior state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	modelName, appName, dErr := modelAppNameFromID(state.ID.ValueString())
+	if dErr.HasError() {
+		resp.Diagnostics.Append(dErr...)
+		return
+	}
+
+	response, err := r.client.Applications.ReadApplicationExpose(ctx, juju.ReadApplicationExposeInput{
+		ModelName: modelName,
+		AppName:   appName,
+	})
+	if err != nil {
+		handleReadError(ctx, r.client, err, &resp.State, &resp.Diagnostics, "Unable to read application expose")
+		return
+	}
+	r.trace(fmt.Sprintf("read application %q expose", appName), map[string]interface{}{"response": response})
+
+	// The application was unexposed outside of Terraform.
+	if !response.Exposed {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ModelName = types.StringValue(modelName)
+	state.ApplicationName = types.StringValue(appName)
+	resp.Diagnostics.Append(r.setExposeValues(ctx, &state, response)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(applicationIDFormat.setIdentity(ctx, state.ID, resp.Identity)...)
+}
+
+func (r *applicationExposeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_application_expose", "Update", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "application_expose", "update")
+		return
+	}
+
+	var plan applicationExposeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input, diags := r.exposeInput(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := r.client.Applications.ExposeApplication(ctx, input); err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to update expose of application %q", input.AppName)
+		return
+	}
+	r.trace(fmt.Sprintf("updated expose of application %q", input.AppName))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *applicationExposeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_application_expose", "Delete", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "application_expose", "delete")
+		return
+	}
+
+	var state applicationExposeResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Applications.UnexposeApplication(ctx, juju.UnexposeApplicationInput{
+		ModelName: state.ModelName.ValueString(),
+		AppName:   state.ApplicationName.ValueString(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to unexpose application %q", state.ApplicationName.ValueString())
+		return
+	}
+	r.trace(fmt.Sprintf("unexposed application %q", state.ApplicationName.ValueString()))
+}
+
+func (r *applicationExposeResource) exposeInput(ctx context.Context, plan applicationExposeResourceModel) (juju.ExposeApplicationInput, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	input := juju.ExposeApplicationInput{
+		ModelName: plan.ModelName.ValueString(),
+		AppName:   plan.ApplicationName.ValueString(),
+	}
+	diags.Append(plan.Endpoints.ElementsAs(ctx, &input.Endpoints, false)...)
+	diags.Append(plan.Spaces.ElementsAs(ctx, &input.Spaces, false)...)
+	diags.Append(plan.CIDRs.ElementsAs(ctx, &input.CIDRs, false)...)
+	return input, diags
+}
+
+// setExposeValues fills the state from the response. Empty values are
+// kept null unless previously set to an empty set, to avoid spurious
+// diffs on optional attributes.
+func (r *applicationExposeResource) setExposeValues(ctx context.Context, state *applicationExposeResourceModel, response *juju.ReadApplicationExposeResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+	toSet := func(current types.Set, values []string) types.Set {
+		if len(values) == 0 && current.IsNull() {
+			return current
+		}
+		value, d := types.SetValueFrom(ctx, types.StringType, values)
+		diags.Append(d...)
+		return value
+	}
+	state.Endpoints = toSet(state.Endpoints, response.Endpoints)
+	state.Spaces = toSet(state.Spaces, response.Spaces)
+	state.CIDRs = toSet(state.CIDRs, response.CIDRs)
+	return diags
+}
+
+func (r *applicationExposeResource) trace(msg string, additionalFields ...map[string]interface{}) {
+	if r.subCtx == nil {
+		return
+	}
+
+	//SubsystemTrace(subCtx, "my-subsystem", "hello, world", map[string]interface{}{"foo": 123})
+	// Output:
+	// {"@level":"trace","@message":"hello, world","@module":"provider.my-subsystem","foo":123}
+	tflog.SubsystemTrace(r.subCtx, LogResourceApplicationExpose, msg, additionalFields...)
+}
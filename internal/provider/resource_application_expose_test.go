@@ -0,0 +1,95 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_ResourceApplicationExpose(t *testing.T) {
+	if testingCloud != LXDCloudTesting {
+		t.Skip(t.Name() + " only runs with LXD")
+	}
+	modelName := acctest.RandomWithPrefix("tf-test-application-expose")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceApplicationExpose(modelName, `cidrs = ["10.0.0.0/24"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_application_expose.this", "id", fmt.Sprintf("%s:ubuntu", modelName)),
+					resource.TestCheckTypeSetElemAttr("juju_application_expose.this", "cidrs.*", "10.0.0.0/24"),
+					resource.TestCheckNoResourceAttr("juju_application.this", "expose.#"),
+				),
+			},
+			{
+				Config: testAccResourceApplicationExpose(modelName, `cidrs = ["10.0.0.0/24", "10.0.1.0/24"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_application_expose.this", "cidrs.#", "2"),
+				),
+			},
+			{
+				ImportStateVerify: true,
+				ImportState:       true,
+				ResourceName:      "juju_application_expose.this",
+			},
+			{
+				Config:      testAccResourceApplicationExposeInline(modelName),
+				ExpectError: regexp.MustCompile("managed by a juju_application_expose resource"),
+			},
+		},
+	})
+}
+
+func testAccResourceApplicationExpose(modelName, exposeArgs string) string {
+	return fmt.Sprintf(`
+resource "juju_model" "this" {
+  name = %q
+}
+
+resource "juju_application" "this" {
+  model = juju_model.this.name
+  name  = "ubuntu"
+  charm {
+    name = "jameinel-ubuntu-lite"
+  }
+}
+
+resource "juju_application_expose" "this" {
+  model       = juju_model.this.name
+  application = juju_application.this.name
+  %s
+}
+`, modelName, exposeArgs)
+}
+
+func testAccResourceApplicationExposeInline(modelName string) string {
+	return fmt.Sprintf(`
+resource "juju_model" "this" {
+  name = %q
+}
+
+resource "juju_application" "this" {
+  model = juju_model.this.name
+  name  = "ubuntu"
+  charm {
+    name = "jameinel-ubuntu-lite"
+  }
+  expose {}
+}
+
+resource "juju_application_expose" "this" {
+  model       = juju_model.this.name
+  application = juju_application.this.name
+  cidrs       = ["10.0.0.0/24", "10.0.1.0/24"]
+}
+`, modelName)
+}
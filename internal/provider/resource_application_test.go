@@ -11,6 +11,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -18,8 +20,11 @@ import (
 	apiapplication "github.com/juju/juju/api/client/application"
 	apiclient "github.com/juju/juju/api/client/client"
 	apispaces "github.com/juju/juju/api/client/spaces"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/names/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/juju/terraform-provider-juju/internal/juju"
 	internaljuju "github.com/juju/terraform-provider-juju/internal/juju"
@@ -57,6 +62,16 @@ func TestAcc_ResourceApplication(t *testing.T) {
 					resource.TestCheckResourceAttr("juju_application.this", "constraints", "arch=amd64 cores=1 mem=4096M"),
 				),
 			},
+			{
+				// Constraints are updated without replacing the application.
+				SkipFunc: func() (bool, error) {
+					return testingCloud != LXDCloudTesting, nil
+				},
+				Config: testAccResourceApplicationConstraints(modelName, "arch=amd64 cores=2 mem=4096M"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_application.this", "constraints", "arch=amd64 cores=2 mem=4096M"),
+				),
+			},
 			{
 				// specific constraints for k8s
 				SkipFunc: func() (bool, error) {
@@ -170,7 +185,7 @@ func TestAcc_ResourceApplication_UpdateImportedSubordinate(t *testing.T) {
 
 	ctx := context.Background()
 
-	_, err := TestClient.Models.CreateModel(juju.CreateModelInput{
+	_, err := TestClient.Models.CreateModel(context.Background(), juju.CreateModelInput{
 		Name: modelName,
 	})
 	if err != nil {
@@ -211,8 +226,183 @@ func TestAcc_ResourceApplication_UpdateImportedSubordinate(t *testing.T) {
 	})
 }
 
+func TestAcc_ResourceApplication_TrustRevoked(t *testing.T) {
+	if testingCloud != LXDCloudTesting {
+		t.Skip(t.Name() + " only runs with LXD")
+	}
+	modelName := acctest.RandomWithPrefix("tf-test-application")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceApplicationTrust(modelName, "true"),
+				Check:  resource.TestCheckResourceAttr("juju_application.this", "trust", "true"),
+			},
+			{
+				Config: testAccResourceApplicationTrust(modelName, "false"),
+				Check:  resource.TestCheckResourceAttr("juju_application.this", "trust", "false"),
+			},
+			{
+				// Not managed anymore, the trust read back is kept.
+				Config:   testAccResourceApplicationTrust(modelName, "null"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAcc_ResourceApplication_Annotations(t *testing.T) {
+	if testingCloud != LXDCloudTesting {
+		t.Skip(t.Name() + " only runs with LXD")
+	}
+	modelName := acctest.RandomWithPrefix("tf-test-application")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceApplicationAnnotations(modelName, `{ team = "data", cost-center = "42" }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_application.this", "annotations.%", "2"),
+					resource.TestCheckResourceAttr("juju_application.this", "annotations.team", "data"),
+				),
+			},
+			{
+				Config: testAccResourceApplicationAnnotations(modelName, `{ team = "web" }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_application.this", "annotations.%", "1"),
+					resource.TestCheckResourceAttr("juju_application.this", "annotations.team", "web"),
+				),
+			},
+		},
+	})
+}
+
+func TestAcc_ResourceApplication_ContainerPlacement(t *testing.T) {
+	if testingCloud != LXDCloudTesting {
+		t.Skip(t.Name() + " only runs with LXD")
+	}
+	modelName := acctest.RandomWithPrefix("tf-test-application")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceApplicationContainerPlacement(modelName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_machine.this", "machine_id", "0"),
+					resource.TestCheckResourceAttr("juju_application.this", "placement", "lxd:0"),
+				),
+			},
+			{
+				Config:   testAccResourceApplicationContainerPlacement(modelName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAcc_ResourceApplication_CharmArchitecture(t *testing.T) {
+	if testingCloud != LXDCloudTesting {
+		t.Skip(t.Name() + " only runs with LXD")
+	}
+	modelName := acctest.RandomWithPrefix("tf-test-application")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceApplicationCharmArchitecture(modelName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_application.this", "charm.0.architecture", "amd64"),
+					resource.TestCheckResourceAttrSet("juju_application.this", "charm.0.revision"),
+					resource.TestCheckResourceAttr("juju_application.this", "constraints", "mem=2048M"),
+				),
+			},
+			{
+				Config:   testAccResourceApplicationCharmArchitecture(modelName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAcc_ResourceApplication_SubordinateUnits(t *testing.T) {
+	if testingCloud != LXDCloudTesting {
+		t.Skip(t.Name() + " only runs with LXD")
+	}
+	modelName := acctest.RandomWithPrefix("tf-test-application")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceApplicationSubordinateDefaultUnits(modelName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_application.subordinate", "subordinate", "true"),
+					resource.TestCheckResourceAttr("juju_application.subordinate", "units", "1"),
+				),
+			},
+			{
+				Config:   testAccResourceApplicationSubordinateDefaultUnits(modelName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 // TestAcc_ResourceApplication_UpdatesRevisionConfig will test the revision update that have new config parameters on
 // the charm. The test will check that the config is updated and the revision is updated as well.
+func TestAcc_ResourceApplication_RefreshPolicyChannel(t *testing.T) {
+	if testingCloud != LXDCloudTesting {
+		t.Skip(t.Name() + " only runs with LXD")
+	}
+	modelName := acctest.RandomWithPrefix("tf-test-application")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceApplicationRefreshPolicy(modelName, RefreshPolicyManual),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_application.this", "charm.0.revision", "21"),
+					resource.TestCheckResourceAttr("juju_application.this", "charm.0.deployed_revision", "21"),
+				),
+			},
+			{
+				Config: testAccResourceApplicationRefreshPolicy(modelName, RefreshPolicyChannel),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_application.this", "charm.0.revision", "21"),
+					resource.TestCheckResourceAttrWith("juju_application.this", "charm.0.deployed_revision", func(value string) error {
+						if value == "21" {
+							return fmt.Errorf("expected the latest revision of the channel to be deployed")
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config:   testAccResourceApplicationRefreshPolicy(modelName, RefreshPolicyChannel),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccResourceApplicationRefreshPolicy(modelName, RefreshPolicyManual),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_application.this", "charm.0.revision", "21"),
+					resource.TestCheckResourceAttr("juju_application.this", "charm.0.deployed_revision", "21"),
+				),
+			},
+		},
+	})
+}
+
 func TestAcc_ResourceApplication_UpdatesRevisionConfig(t *testing.T) {
 	if testingCloud != LXDCloudTesting {
 		t.Skip(t.Name() + " only runs with LXD")
@@ -603,6 +793,30 @@ func TestAcc_ResourceApplication_Minimal(t *testing.T) {
 	})
 }
 
+func TestAcc_ResourceApplication_WaitFor(t *testing.T) {
+	if testingCloud != LXDCloudTesting {
+		t.Skip(t.Name() + " only runs with LXD")
+	}
+	modelName := acctest.RandomWithPrefix("tf-test-application-wait-for")
+	resourceName := "juju_application.testapp"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceApplicationWaitFor(modelName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "wait_for.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "wait_for.0.agent_status", "idle"),
+					resource.TestCheckResourceAttr(resourceName, "status.status", "active"),
+					resource.TestCheckResourceAttr("data.juju_wait_for.unit", "current_status", "active"),
+				),
+			},
+		},
+	})
+}
+
 func TestAcc_ResourceApplication_UpgradeProvider(t *testing.T) {
 	modelName := acctest.RandomWithPrefix("tf-test-application")
 	appName := "test-app"
@@ -809,6 +1023,34 @@ func testAccResourceApplicationBasic_Minimal(modelName, charmName string) string
 		`, modelName, charmName)
 }
 
+func testAccResourceApplicationWaitFor(modelName string) string {
+	return fmt.Sprintf(`
+		resource "juju_model" "testmodel" {
+		  name = %q
+		}
+
+		resource "juju_application" "testapp" {
+		  model = juju_model.testmodel.name
+		  charm {
+			name = "juju-qa-test"
+		  }
+		  wait_for {
+			agent_status = "idle"
+			timeout      = "15m"
+		  }
+		}
+
+		# The unit is active once the application is created.
+		data "juju_wait_for" "unit" {
+		  model   = juju_model.testmodel.name
+		  type    = "unit"
+		  name    = "${juju_application.testapp.name}/0"
+		  status  = ["active"]
+		  timeout = "1s"
+		}
+		`, modelName)
+}
+
 func testAccResourceApplicationBasic(modelName, appName string) string {
 	if testingCloud == LXDCloudTesting {
 		return fmt.Sprintf(`
@@ -1086,6 +1328,125 @@ resource "juju_application" "telegraf" {
 `, modelName, subordinateRevision)
 }
 
+func testAccResourceApplicationTrust(modelName, trust string) string {
+	return fmt.Sprintf(`
+resource "juju_model" "this" {
+  name = %q
+}
+
+resource "juju_application" "this" {
+  model = juju_model.this.name
+  name  = "test-app"
+
+  charm {
+    name = "jameinel-ubuntu-lite"
+  }
+
+  trust = %s
+}
+`, modelName, trust)
+}
+
+func testAccResourceApplicationAnnotations(modelName, annotations string) string {
+	return fmt.Sprintf(`
+resource "juju_model" "this" {
+  name = %q
+}
+
+resource "juju_application" "this" {
+  model = juju_model.this.name
+  name  = "test-app"
+
+  charm {
+    name = "jameinel-ubuntu-lite"
+  }
+
+  annotations = %s
+}
+`, modelName, annotations)
+}
+
+func testAccResourceApplicationSubordinateDefaultUnits(modelName string) string {
+	return fmt.Sprintf(`
+resource "juju_model" "this" {
+  name = %q
+}
+
+resource "juju_application" "subordinate" {
+  model = juju_model.this.name
+  name  = "telegraf"
+
+  charm {
+    name = "telegraf"
+  }
+}
+`, modelName)
+}
+
+func testAccResourceApplicationRefreshPolicy(modelName, refreshPolicy string) string {
+	return fmt.Sprintf(`
+resource "juju_model" "this" {
+  name = %q
+}
+
+resource "juju_application" "this" {
+  model = juju_model.this.name
+  name  = "test-app"
+
+  charm {
+    name           = "juju-qa-test"
+    channel        = "latest/stable"
+    revision       = 21
+    refresh_policy = %q
+  }
+}
+`, modelName, refreshPolicy)
+}
+
+func testAccResourceApplicationContainerPlacement(modelName string) string {
+	return fmt.Sprintf(`
+resource "juju_model" "this" {
+  name = %q
+}
+
+resource "juju_machine" "this" {
+  model = juju_model.this.name
+  base  = "ubuntu@22.04"
+}
+
+resource "juju_application" "this" {
+  model     = juju_model.this.name
+  name      = "test-app"
+  placement = "lxd:${juju_machine.this.machine_id}"
+
+  charm {
+    name = "ubuntu"
+    base = "ubuntu@22.04"
+  }
+}
+`, modelName)
+}
+
+func testAccResourceApplicationCharmArchitecture(modelName string) string {
+	return fmt.Sprintf(`
+resource "juju_model" "this" {
+  name = %q
+}
+
+resource "juju_application" "this" {
+  model       = juju_model.this.name
+  name        = "test-app"
+  constraints = "mem=2048M"
+
+  charm {
+    name         = "ubuntu"
+    base         = "ubuntu@22.04"
+    architecture = "amd64"
+  }
+}
+`, modelName)
+}
+
 func testAccResourceApplicationConstraintsSubordinate(modelName string, constraints string) string {
 	return fmt.Sprintf(`
 resource "juju_model" "this" {
@@ -1121,19 +1482,19 @@ func setupModelAndSpaces(t *testing.T, modelName string) (string, string, func()
 	// All the space setup is needed until https://github.com/juju/terraform-provider-juju/issues/336 is implemented
 	// called to have TestClient populated
 	testAccPreCheck(t)
-	model, err := TestClient.Models.CreateModel(internaljuju.CreateModelInput{
+	model, err := TestClient.Models.CreateModel(context.Background(), internaljuju.CreateModelInput{
 		Name: modelName,
 	})
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	conn, err := TestClient.Models.GetConnection(&modelName)
+	conn, err := TestClient.Models.GetConnection(context.Background(), &modelName)
 	if err != nil {
 		t.Fatal(err)
 	}
 	cleanUp := func() {
-		_ = TestClient.Models.DestroyModel(internaljuju.DestroyModelInput{UUID: model.UUID})
+		_ = TestClient.Models.DestroyModel(context.Background(), internaljuju.DestroyModelInput{UUID: model.UUID})
 		_ = conn.Close()
 	}
 
@@ -1261,14 +1622,14 @@ resource "juju_application" "{{.AppName}}" {
 
 func testCheckEndpointsAreSetToCorrectSpace(modelName, appName, defaultSpace string, configuredEndpoints map[string]string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		conn, err := TestClient.Models.GetConnection(&modelName)
+		conn, err := TestClient.Models.GetConnection(context.Background(), &modelName)
 		if err != nil {
 			return err
 		}
 		defer func() { _ = conn.Close() }()
 
 		applicationAPIClient := apiapplication.NewClient(conn)
-		clientAPIClient := apiclient.NewClient(conn, TestClient.Applications.JujuLogger())
+		clientAPIClient := apiclient.NewClient(conn, TestClient.Applications.(juju.SharedClient).JujuLogger())
 
 		apps, err := applicationAPIClient.ApplicationsInfo([]names.ApplicationTag{names.NewApplicationTag(appName)})
 		if err != nil {
@@ -1328,3 +1689,186 @@ func testCheckEndpointsAreSetToCorrectSpace(modelName, appName, defaultSpace str
 		return nil
 	}
 }
+
+func TestComputeExposeDeltas(t *testing.T) {
+	ctx := context.Background()
+	exposeType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		EndpointsKey: types.StringType,
+		SpacesKey:    types.StringType,
+		CidrsKey:     types.StringType,
+	}}
+	exposeList := func(endpoints, cidrs string) types.List {
+		value := nestedExpose{Endpoints: types.StringNull(), Spaces: types.StringNull(), Cidrs: types.StringNull()}
+		if endpoints != "" {
+			value.Endpoints = types.StringValue(endpoints)
+		}
+		if cidrs != "" {
+			value.Cidrs = types.StringValue(cidrs)
+		}
+		list, diags := types.ListValueFrom(ctx, exposeType, []nestedExpose{value})
+		require.False(t, diags.HasError(), diags)
+		return list
+	}
+
+	tests := []struct {
+		about            string
+		state, plan      types.List
+		expectedExpose   map[string]interface{}
+		expectedUnexpose []string
+	}{{
+		about:            "unchanged",
+		state:            exposeList("db,admin", "10.0.0.0/24"),
+		plan:             exposeList("db,admin", "10.0.0.0/24"),
+		expectedUnexpose: []string{},
+	}, {
+		about:            "endpoints narrowed",
+		state:            exposeList("db,admin", "10.0.0.0/24"),
+		plan:             exposeList("db", "10.0.0.0/24"),
+		expectedExpose:   map[string]interface{}{EndpointsKey: "db", CidrsKey: "10.0.0.0/24"},
+		expectedUnexpose: []string{"admin"},
+	}, {
+		about:            "cidrs expanded",
+		state:            exposeList("db", "10.0.0.0/24"),
+		plan:             exposeList("db", "10.0.0.0/24,10.0.1.0/24"),
+		expectedExpose:   map[string]interface{}{EndpointsKey: "db", CidrsKey: "10.0.0.0/24,10.0.1.0/24"},
+		expectedUnexpose: []string{},
+	}, {
+		about:            "all endpoints to some",
+		state:            exposeList("", ""),
+		plan:             exposeList("db", ""),
+		expectedExpose:   map[string]interface{}{EndpointsKey: "db"},
+		expectedUnexpose: []string{""},
+	}, {
+		about:            "unexposed",
+		state:            exposeList("db", ""),
+		plan:             types.ListNull(exposeType),
+		expectedUnexpose: []string{""},
+	}}
+	for _, test := range tests {
+		t.Run(test.about, func(t *testing.T) {
+			expose, unexpose, diags := (&applicationResource{}).computeExposeDeltas(ctx, test.state, test.plan)
+			require.False(t, diags.HasError(), diags)
+			assert.Equal(t, test.expectedExpose, expose)
+			assert.Equal(t, test.expectedUnexpose, unexpose)
+		})
+	}
+}
+
+func TestResourceFilePaths(t *testing.T) {
+	ctx := context.Background()
+	fileType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		CharmPathKey:   types.StringType,
+		CharmSHA256Key: types.StringType,
+	}}
+	files, diags := types.MapValueFrom(ctx, fileType, map[string]nestedResourceFile{
+		"snapshot": {Path: types.StringValue("./dump.tar.gz"), SHA256: types.StringUnknown()},
+	})
+	require.False(t, diags.HasError(), diags)
+
+	app := applicationResourceModel{
+		ResourceFiles: files,
+		Resources:     types.MapValueMust(types.StringType, map[string]attr.Value{"image": types.StringValue("3")}),
+	}
+	paths, diags := resourceFilePaths(ctx, app)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, map[string]string{"snapshot": "./dump.tar.gz"}, paths)
+
+	app.Resources = types.MapValueMust(types.StringType, map[string]attr.Value{"snapshot": types.StringValue("3")})
+	_, diags = resourceFilePaths(ctx, app)
+	assert.True(t, diags.HasError())
+}
+
+func TestConfigureConfigData(t *testing.T) {
+	ctx := context.Background()
+	r := &applicationResource{}
+	config := types.MapValueMust(types.StringType, map[string]attr.Value{"port": types.StringValue("8080")})
+	respCfg := map[string]juju.ConfigEntry{
+		"port":     {Value: int64(8081)},
+		"log-file": {Value: "/var/log/app.log"},
+		"debug":    {Value: false, IsDefault: true},
+	}
+
+	full, diags := r.configureConfigData(ctx, types.StringType, config, types.StringNull(), respCfg, false)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, types.MapValueMust(types.StringType, map[string]attr.Value{
+		"port":     types.StringValue("8081"),
+		"log-file": types.StringValue("/var/log/app.log"),
+	}), full)
+
+	managed, diags := r.configureConfigData(ctx, types.StringType, config, types.StringNull(), respCfg, true)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, types.MapValueMust(types.StringType, map[string]attr.Value{
+		"port": types.StringValue("8081"),
+	}), managed)
+}
+
+func TestSameConstraints(t *testing.T) {
+	cons := constraints.MustParse("cores=2 mem=4G")
+	assert.True(t, sameConstraints(types.StringValue("mem=4096M cores=2"), cons, ""))
+	assert.False(t, sameConstraints(types.StringValue("cores=4 mem=4G"), cons, ""))
+	assert.False(t, sameConstraints(types.StringNull(), cons, ""))
+	assert.True(t, sameConstraints(types.StringValue(""), constraints.Value{}, ""))
+	armCons := constraints.MustParse("arch=arm64 mem=4G")
+	assert.True(t, sameConstraints(types.StringValue("mem=4G"), armCons, "arm64"))
+	assert.False(t, sameConstraints(types.StringValue("mem=4G"), armCons, ""))
+}
+
+func TestDeployedRevision(t *testing.T) {
+	charm := nestedCharm{Revision: types.Int64Value(21), DeployedRevision: types.Int64Value(24)}
+	assert.Equal(t, types.Int64Value(24), deployedRevision(charm))
+	charm.DeployedRevision = types.Int64Null()
+	assert.Equal(t, types.Int64Value(21), deployedRevision(charm))
+}
+
+func TestApplicationDestroyInput(t *testing.T) {
+	optionTypes := map[string]attr.Type{
+		"force":           types.BoolType,
+		"no_wait":         types.BoolType,
+		"destroy_storage": types.BoolType,
+	}
+	app := applicationResourceModel{
+		ForceDestroy:   types.BoolNull(),
+		DestroyMaxWait: types.StringNull(),
+		DestroyOptions: types.ObjectNull(optionTypes),
+	}
+	input, diags := applicationDestroyInput(context.Background(), app)
+	require.False(t, diags.HasError())
+	assert.False(t, input.Force)
+	assert.False(t, input.KeepStorage)
+
+	app.DestroyOptions = types.ObjectValueMust(optionTypes, map[string]attr.Value{
+		"force":           types.BoolNull(),
+		"no_wait":         types.BoolValue(true),
+		"destroy_storage": types.BoolValue(false),
+	})
+	input, diags = applicationDestroyInput(context.Background(), app)
+	require.False(t, diags.HasError())
+	assert.True(t, input.Force)
+	assert.True(t, input.NoWait)
+	assert.True(t, input.KeepStorage)
+}
+
+func TestSetUnitOutputsUnitList(t *testing.T) {
+	var app applicationResourceModel
+	diags := setUnitOutputs(context.Background(), &app, &juju.ReadApplicationResponse{
+		LeaderUnit:    "app/10",
+		UnitNames:     []string{"app/2", "app/10"},
+		UnitAddresses: map[string]string{"app/10": "10.0.0.10"},
+		UnitMachines:  map[string]string{"app/2": "2", "app/10": "10"},
+	})
+	require.False(t, diags.HasError(), diags)
+
+	var units []applicationUnitModel
+	require.False(t, app.UnitList.ElementsAs(context.Background(), &units, false).HasError())
+	assert.Equal(t, []applicationUnitModel{{
+		Name:    types.StringValue("app/2"),
+		Machine: types.StringValue("2"),
+		Address: types.StringValue(""),
+		Leader:  types.BoolValue(false),
+	}, {
+		Name:    types.StringValue("app/10"),
+		Machine: types.StringValue("10"),
+		Address: types.StringValue("10.0.0.10"),
+		Leader:  types.BoolValue(true),
+	}}, units)
+}
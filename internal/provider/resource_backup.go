@@ -0,0 +1,290 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &backupResource{}
+var _ resource.ResourceWithConfigure = &backupResource{}
+var _ resource.ResourceWithUpgradeState = &backupResource{}
+
+func NewBackupResource() resource.Resource {
+	return &backupResource{}
+}
+
+type backupResource struct {
+	client *juju.Client
+
+	// subCtx is the context created with the new tflog subsystem for backups.
+	subCtx context.Context
+}
+
+type backupResourceModel struct {
+	Notes          types.String `tfsdk:"notes"`
+	DownloadPath   types.String `tfsdk:"download_path"`
+	Triggers       types.Map    `tfsdk:"triggers"`
+	Filename       types.String `tfsdk:"filename"`
+	Checksum       types.String `tfsdk:"checksum"`
+	Size           types.Int64  `tfsdk:"size"`
+	Started        types.String `tfsdk:"started"`
+	Finished       types.String `tfsdk:"finished"`
+	ControllerUUID types.String `tfsdk:"controller_uuid"`
+	JujuVersion    types.String `tfsdk:"juju_version"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+func (r *backupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_backup"
+}
+
+func (r *backupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: backupSchemaVersion,
+		Description: "A resource that represents a backup of the Juju controller. Creating the resource triggers a " +
+			"backup, equivalent to `juju create-backup`. Destroying the resource only removes it from the Terraform " +
+			"state, the backup archive is left on the controller.",
+		Attributes: map[string]schema.Attribute{
+			"notes": schema.StringAttribute{
+				Description: "Notes to be stored with the backup.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"download_path": schema.StringAttribute{
+				Description: "Local path to download the backup archive to. If not set, the archive is only " +
+					"stored on the controller.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary map of values that, when changed, will trigger a new backup. Useful to " +
+					"schedule backups from a pipeline, e.g. with a timestamp.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"filename": schema.StringAttribute{
+				Description: "The name of the backup archive on the controller.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"checksum": schema.StringAttribute{
+				Description: "The checksum of the backup archive.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"size": schema.Int64Attribute{
+				Description: "The size of the backup archive in bytes.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"started": schema.StringAttribute{
+				Description: "The time the backup started, in RFC3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"finished": schema.StringAttribute{
+				Description: "The time the backup finished, in RFC3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"controller_uuid": schema.StringAttribute{
+				Description: "The UUID of the controller which was backed up.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"juju_version": schema.StringAttribute{
+				Description: "The version of the controller agent when the backup was taken.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// UpgradeState returns the state upgraders from the prior schema
+// versions of the resource, keyed by version.
+func (r *backupResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+func (r *backupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+	// Create the local logging subsystem here, using the TF context when creating it.
+	r.subCtx = r.client.NewLogSubsystem(ctx, LogResourceBackup)
+}
+
+func (r *backupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_backup", "Create", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "backup", "create")
+		return
+	}
+
+	var plan backupResourceModel
+
+	// Read Terraform configuration from the request into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := r.client.Backups.CreateBackup(ctx, juju.CreateBackupInput{
+		Notes:        plan.Notes.ValueString(),
+		DownloadPath: plan.DownloadPath.ValueString(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to create backup")
+		return
+	}
+	r.trace(fmt.Sprintf("backup created: %q", response.ID))
+
+	plan.Filename = types.StringValue(response.Filename)
+	plan.Checksum = types.StringValue(response.Checksum)
+	plan.Size = types.Int64Value(response.Size)
+	plan.Started = types.StringValue(response.Started.Format(time.RFC3339))
+	plan.Finished = types.StringValue(response.Finished.Format(time.RFC3339))
+	plan.ControllerUUID = types.StringValue(response.ControllerUUID)
+	plan.JujuVersion = types.StringValue(response.Version)
+	plan.ID = types.StringValue(response.ID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read keeps the existing state. Juju does not provide an API to list or
+// query existing backups, the data is only available when the backup is
+// created.
+func (r *backupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_backup", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "backup", "read")
+		return
+	}
+
+	var state backupResourceModel
+
+	// Read Terraform prior state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.trace(fmt.Sprintf("read backup: %q", state.ID.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called with changes as every configurable attribute
+// requires replacement. It persists the plan to satisfy the framework.
+func (r *backupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_backup", "Update", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "backup", "update")
+		return
+	}
+
+	var plan backupResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the backup from the Terraform state. Juju does not
+// provide an API to remove a backup archive from the controller.
+func (r *backupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_backup", "Delete", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "backup", "delete")
+		return
+	}
+
+	var state backupResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.trace(fmt.Sprintf("removed backup %q from state, archive %q is kept on the controller",
+		state.ID.ValueString(), state.Filename.ValueString()))
+}
+
+func (r *backupResource) trace(msg string, additionalFields ...map[string]interface{}) {
+	if r.subCtx == nil {
+		return
+	}
+
+	//SubsystemTrace(subCtx, "my-subsystem", "hello, world", map[string]interface{}{"foo": 123})
+	// Output:
+	// {"@level":"trace","@message":"hello, world","@module":"provider.my-subsystem","foo":123}
+	tflog.SubsystemTrace(r.subCtx, LogResourceBackup, msg, additionalFields...)
+}
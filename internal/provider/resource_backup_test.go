@@ -0,0 +1,53 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_ResourceBackup(t *testing.T) {
+	if testingCloud != LXDCloudTesting {
+		t.Skip(t.Name() + " only runs with LXD")
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceBackup("first"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_backup.this", "notes", "terraform acceptance test"),
+					resource.TestCheckResourceAttrSet("juju_backup.this", "filename"),
+					resource.TestCheckResourceAttrSet("juju_backup.this", "checksum"),
+					resource.TestCheckResourceAttrSet("juju_backup.this", "controller_uuid"),
+				),
+			},
+			{
+				// Changing the triggers creates a new backup.
+				Config: testAccResourceBackup("second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_backup.this", "triggers.run", "second"),
+					resource.TestCheckResourceAttrSet("juju_backup.this", "filename"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceBackup(run string) string {
+	return fmt.Sprintf(`
+resource "juju_backup" "this" {
+  notes = "terraform acceptance test"
+
+  triggers = {
+    run = %q
+  }
+}
+`, run)
+}
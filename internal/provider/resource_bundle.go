@@ -0,0 +1,397 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/juju/collections/set"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &bundleResource{}
+var _ resource.ResourceWithConfigure = &bundleResource{}
+var _ resource.ResourceWithUpgradeState = &bundleResource{}
+
+func NewBundleResource() resource.Resource {
+	return &bundleResource{}
+}
+
+type bundleResource struct {
+	client *juju.Client
+
+	// subCtx is the context created with the new tflog subsystem for bundles.
+	subCtx context.Context
+}
+
+type bundleResourceModel struct {
+	ModelName    types.String   `tfsdk:"model"`
+	Name         types.String   `tfsdk:"name"`
+	Channel      types.String   `tfsdk:"channel"`
+	Bundle       types.String   `tfsdk:"bundle"`
+	Overlays     types.List     `tfsdk:"overlays"`
+	Applications types.List     `tfsdk:"applications"`
+	Integrations types.List     `tfsdk:"integrations"`
+	Machines     types.List     `tfsdk:"machines"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+func (r *bundleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bundle"
+}
+
+func (r *bundleResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: bundleSchemaVersion,
+		Description: "A resource that deploys a bundle, from Charmhub or a local definition, with overlays. " +
+			"The applications of the bundle must not exist in the model. Changing the bundle replaces the " +
+			"applications deployed, the bundle being deployed again.",
+		Attributes: map[string]schema.Attribute{
+			"model": schema.StringAttribute{
+				Description: "The name of the model where the bundle is deployed.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the bundle in Charmhub. Either `name` or `bundle` must be set.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.Expressions{
+						path.MatchRoot("bundle"),
+					}...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"channel": schema.StringAttribute{
+				Description: "The channel of the Charmhub bundle, e.g. `latest/stable`. Defaults to the default " +
+					"channel of the bundle.",
+				Optional: true,
+				Validators: []validator.String{
+					StringIsChannelValidator{},
+					stringvalidator.AlsoRequires(path.Expressions{
+						path.MatchRoot("name"),
+					}...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"bundle": schema.StringAttribute{
+				Description: "The bundle definition in YAML, e.g. `file(\"bundle.yaml\")`. The paths of its " +
+					"local charms are relative to the working directory.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"overlays": schema.ListAttribute{
+				Description: "The overlays in YAML applied to the bundle, in order, e.g. " +
+					"`[file(\"overlay.yaml\")]`.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"applications": schema.ListAttribute{
+				Description: "The names of the applications deployed, in order of deployment.",
+				ElementType: types.StringType,
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"integrations": schema.ListAttribute{
+				Description: "The integrations created, as their two endpoints separated by a space, e.g. " +
+					"`wordpress:db mysql:db`.",
+				ElementType: types.StringType,
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"machines": schema.ListAttribute{
+				Description: "The ids of the machines created for the bundle. They are removed by Juju with " +
+					"the units of the applications.",
+				ElementType: types.StringType,
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			TimeoutsKey: timeoutsBlock(ctx, "bundle"),
+		},
+	}
+}
+
+// UpgradeState returns the state upgraders from the prior schema
+// versions of the resource, keyed by version.
+func (r *bundleResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+func (r *bundleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+	// Create the local logging subsystem here, using the TF context when creating it.
+	r.subCtx = r.client.NewLogSubsystem(ctx, LogResourceBundle)
+}
+
+func (r *bundleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_bundle", "Create", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "bundle", "create")
+		return
+	}
+
+	var plan bundleResourceModel
+
+	// Read Terraform configuration from the request into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, plan.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := juju.DeployBundleInput{
+		ModelName:  plan.ModelName.ValueString(),
+		BundleName: plan.Name.ValueString(),
+		Channel:    plan.Channel.ValueString(),
+		Bundle:     plan.Bundle.ValueString(),
+	}
+	resp.Diagnostics.Append(plan.Overlays.ElementsAs(ctx, &input.Overlays, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := r.client.Bundles.DeployBundle(ctx, input)
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to deploy bundle")
+		if response != nil && len(response.Applications) > 0 {
+			// Some applications are deployed, save them so that they
+			// are removed when the bundle is replaced.
+			resp.Diagnostics.Append(r.setDeployed(ctx, &plan, response)...)
+			resp.Diagnostics.Append(setPartialState(ctx, &resp.State, &plan)...)
+		}
+		return
+	}
+	r.trace("deployed bundle", map[string]interface{}{"response": response})
+
+	resp.Diagnostics.Append(r.setDeployed(ctx, &plan, response)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// setDeployed sets the computed attributes of the entities deployed.
+func (r *bundleResource) setDeployed(ctx context.Context, plan *bundleResourceModel, response *juju.DeployBundleResponse) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+	plan.Applications, d = types.ListValueFrom(ctx, types.StringType, response.Applications)
+	diags.Append(d...)
+	plan.Integrations, d = types.ListValueFrom(ctx, types.StringType, response.Integrations)
+	diags.Append(d...)
+	plan.Machines, d = types.ListValueFrom(ctx, types.StringType, response.Machines)
+	diags.Append(d...)
+	plan.ID = types.StringValue(newBundleID(plan.ModelName.ValueString(), response.Applications))
+	return diags
+}
+
+// newBundleID returns the ID of a bundle, the model and the
+// applications deployed, e.g. "development:wordpress,mysql".
+func newBundleID(model string, applications []string) string {
+	return model + ":" + strings.Join(applications, ",")
+}
+
+func (r *bundleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_bundle", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// The reads of a refresh share the status of the model.
+	ctx = juju.WithRefresh(ctx)
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "bundle", "read")
+		return
+	}
+
+	var state bundleResourceModel
+
+	// Read Terraform prior state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := r.client.Applications.ListApplications(ctx, juju.ListApplicationsInput{
+		ModelName: state.ModelName.ValueString(),
+	})
+	if err != nil {
+		handleReadError(ctx, r.client, err, &resp.State, &resp.Diagnostics, "Unable to read bundle")
+		return
+	}
+	existing := set.NewStrings()
+	for _, app := range response.Applications {
+		existing.Add(app.Name)
+	}
+
+	var applications, integrations []string
+	resp.Diagnostics.Append(state.Applications.ElementsAs(ctx, &applications, false)...)
+	resp.Diagnostics.Append(state.Integrations.ElementsAs(ctx, &integrations, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	applications, integrations = keepExistingApplications(applications, integrations, existing)
+	r.trace("read bundle", map[string]interface{}{"applications": applications, "integrations": integrations})
+
+	// The applications of the bundle were removed outside of Terraform.
+	if len(applications) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	var d diag.Diagnostics
+	state.Applications, d = types.ListValueFrom(ctx, types.StringType, applications)
+	resp.Diagnostics.Append(d...)
+	state.Integrations, d = types.ListValueFrom(ctx, types.StringType, integrations)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// keepExistingApplications returns the applications which exist, and
+// the integrations between them.
+func keepExistingApplications(applications, integrations []string, existing set.Strings) ([]string, []string) {
+	var keptApplications, keptIntegrations []string
+	for _, app := range applications {
+		if existing.Contains(app) {
+			keptApplications = append(keptApplications, app)
+		}
+	}
+	for _, integration := range integrations {
+		kept := true
+		for _, endpoint := range strings.Fields(integration) {
+			app, _, _ := strings.Cut(endpoint, ":")
+			kept = kept && existing.Contains(app)
+		}
+		if kept {
+			keptIntegrations = append(keptIntegrations, integration)
+		}
+	}
+	return keptApplications, keptIntegrations
+}
+
+// Update only saves the plan, all the changes of the configuration
+// replace the bundle.
+func (r *bundleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_bundle", "Update", &resp.Diagnostics)
+	defer endSpan()
+
+	var plan bundleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *bundleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_bundle", "Delete", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "bundle", "delete")
+		return
+	}
+
+	var state bundleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, state.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var applications []string
+	resp.Diagnostics.Append(state.Applications.ElementsAs(ctx, &applications, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, appName := range applications {
+		if err := r.client.Applications.DestroyApplication(ctx, &juju.DestroyApplicationInput{
+			ApplicationName: appName,
+			ModelName:       state.ModelName.ValueString(),
+		}); err != nil {
+			addClientError(&resp.Diagnostics, err, "Unable to delete application %q of bundle", appName)
+			continue
+		}
+		r.trace(fmt.Sprintf("deleted application %q of bundle", appName))
+	}
+}
+
+func (r *bundleResource) trace(msg string, additionalFields ...map[string]interface{}) {
+	if r.subCtx == nil {
+		return
+	}
+
+	tflog.SubsystemTrace(r.subCtx, LogResourceBundle, msg, additionalFields...)
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/juju/collections/set"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeepExistingApplications(t *testing.T) {
+	applications, integrations := keepExistingApplications(
+		[]string{"wordpress", "mysql", "haproxy"},
+		[]string{"wordpress:db mysql:db", "haproxy:reverseproxy wordpress:website"},
+		set.NewStrings("wordpress", "mysql", "ubuntu"),
+	)
+	assert.Equal(t, []string{"wordpress", "mysql"}, applications)
+	assert.Equal(t, []string{"wordpress:db mysql:db"}, integrations)
+}
+
+func TestAcc_ResourceBundle(t *testing.T) {
+	if testingCloud != LXDCloudTesting {
+		t.Skip(t.Name() + " only runs with LXD")
+	}
+	modelName := acctest.RandomWithPrefix("tf-test-bundle")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceBundle(modelName, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_bundle.this", "applications.#", "2"),
+					resource.TestCheckTypeSetElemAttr("juju_bundle.this", "applications.*", "ubuntu"),
+					resource.TestCheckTypeSetElemAttr("juju_bundle.this", "applications.*", "ntp"),
+					resource.TestCheckResourceAttr("juju_bundle.this", "integrations.#", "1"),
+					resource.TestCheckResourceAttr("juju_bundle.this", "machines.#", "1"),
+				),
+			},
+			{
+				// Changing the overlay deploys the bundle again.
+				Config: testAccResourceBundle(modelName, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_bundle.this", "applications.#", "2"),
+					resource.TestCheckResourceAttr("juju_bundle.this", "machines.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceBundle(modelName string, units int) string {
+	return fmt.Sprintf(`
+resource "juju_model" "this" {
+  name = %q
+}
+
+resource "juju_bundle" "this" {
+  model  = juju_model.this.name
+  bundle = <<-EOT
+    applications:
+      ubuntu:
+        charm: jameinel-ubuntu-lite
+        num_units: 1
+      ntp:
+        charm: ntp
+    relations:
+    - - ubuntu:juju-info
+      - ntp:juju-info
+  EOT
+  overlays = [<<-EOT
+    applications:
+      ubuntu:
+        num_units: %d
+  EOT
+  ]
+}
+`, modelName, units)
+}
@@ -0,0 +1,342 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/juju/errors"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &charmResourceResource{}
+var _ resource.ResourceWithConfigure = &charmResourceResource{}
+var _ resource.ResourceWithImportState = &charmResourceResource{}
+var _ resource.ResourceWithUpgradeState = &charmResourceResource{}
+var _ resource.ResourceWithIdentity = &charmResourceResource{}
+
+func NewCharmResourceResource() resource.Resource {
+	return &charmResourceResource{}
+}
+
+type charmResourceResource struct {
+	client *juju.Client
+
+	// subCtx is the context created with the new tflog subsystem for charm resources.
+	subCtx context.Context
+}
+
+type charmResourceResourceModel struct {
+	ModelName       types.String `tfsdk:"model"`
+	ApplicationName types.String `tfsdk:"application"`
+	Name            types.String `tfsdk:"name"`
+	Value           types.String `tfsdk:"value"`
+	Revision        types.Int64  `tfsdk:"revision"`
+	Origin          types.String `tfsdk:"origin"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+func (r *charmResourceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_charm_resource"
+}
+
+func (r *charmResourceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: charmResourceSchemaVersion,
+		Description: "A resource that attaches a charm resource, e.g. an OCI image, to an existing application, " +
+			"equivalent to `juju refresh --resource`. The resource must not also be set in the `resources` of " +
+			"the `juju_application`. Destroying it resets the resource to the default of the charm channel.",
+		Attributes: map[string]schema.Attribute{
+			"model": schema.StringAttribute{
+				Description: "The name of the model where the application is deployed.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"application": schema.StringAttribute{
+				Description: "The name of the application.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the resource, as defined in the charm metadata.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				Description: "A resource revision number from CharmHub or the URL of a custom OCI image. " +
+					"Resources of type 'file' can only be specified by revision number.",
+				Required: true,
+				Validators: []validator.String{
+					StringIsResourceKeyValidator{},
+				},
+			},
+			"revision": schema.Int64Attribute{
+				Description: "The revision of the resource used by the application.",
+				Computed:    true,
+			},
+			"origin": schema.StringAttribute{
+				Description: "The origin of the resource, `store` for CharmHub revisions or `upload` for " +
+					"custom images.",
+				Computed: true,
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// UpgradeState returns the state upgraders from the prior schema
+// versions of the resource, keyed by version.
+func (r *charmResourceResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+func (r *charmResourceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+	// Create the local logging subsystem here, using the TF context when creating it.
+	r.subCtx = r.client.NewLogSubsystem(ctx, LogResourceCharmResource)
+}
+
+// ImportState is called when the provider must import the state of a
+// resource instance. The ID is of the form <model>:<application>:<resource>.
+func (r *charmResourceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	charmResourceIDFormat.importState(ctx, req, resp)
+}
+
+// IdentitySchema returns the schema of the identity of the resource,
+// made of the parts of its ID.
+func (r *charmResourceResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = charmResourceIDFormat.identitySchema()
+}
+
+func (r *charmResourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_charm_resource", "Create", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "charm_resource", "create")
+		return
+	}
+
+	var plan charmResourceResourceModel
+
+	// Read Terraform configuration from the request into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(newCharmResourceID(plan.ModelName.ValueString(), plan.ApplicationName.ValueString(), plan.Name.ValueString()))
+	resp.Diagnostics.Append(r.attach(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(charmResourceIDFormat.setIdentity(ctx, plan.ID, resp.Identity)...)
+}
+
+func (r *charmResourceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_charm_resource", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "charm_resource", "read")
+		return
+	}
+
+	var state charmResourceResourceModel
+
+	// Read Terraform prior state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	modelName, appName, resourceName, dErr := charmResourceFromID(state.ID.ValueString())
+	if dErr.HasError() {
+		resp.Diagnostics.Append(dErr...)
+		return
+	}
+
+	response, err := r.client.Applications.ReadApplicationResource(ctx, juju.ReadApplicationResourceInput{
+		ModelName:    modelName,
+		AppName:      appName,
+		ResourceName: resourceName,
+	})
+	if err != nil {
+		handleReadError(ctx, r.client, err, &resp.State, &resp.Diagnostics, "Unable to read resource %q", resourceName)
+		return
+	}
+	r.trace(fmt.Sprintf("read resource %q", state.ID.ValueString()), map[string]interface{}{"response": response})
+
+	state.ModelName = types.StringValue(modelName)
+	state.ApplicationName = types.StringValue(appName)
+	state.Name = types.StringValue(resourceName)
+	state.Revision = types.Int64Value(int64(response.Revision))
+	state.Origin = types.StringValue(response.Origin)
+	// An image URL cannot be read back from Juju, only revisions are
+	// compared to detect changes made outside of Terraform.
+	if _, err := strconv.Atoi(state.Value.ValueString()); err == nil || state.Value.IsNull() {
+		state.Value = types.StringValue(strconv.Itoa(response.Revision))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(charmResourceIDFormat.setIdentity(ctx, state.ID, resp.Identity)...)
+}
+
+func (r *charmResourceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_charm_resource", "Update", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "charm_resource", "update")
+		return
+	}
+
+	var plan charmResourceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.attach(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete resets the resource to the revision published in the charm
+// channel of the application.
+func (r *charmResourceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_charm_resource", "Delete", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "charm_resource", "delete")
+		return
+	}
+
+	var state charmResourceResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Applications.SetApplicationResource(ctx, juju.SetApplicationResourceInput{
+		ModelName:    state.ModelName.ValueString(),
+		AppName:      state.ApplicationName.ValueString(),
+		ResourceName: state.Name.ValueString(),
+		Value:        strconv.Itoa(juju.UnspecifiedRevision),
+	})
+	// The application may have been removed along with its resources.
+	if err != nil && !errors.As(err, &juju.ApplicationNotFoundError) {
+		addClientError(&resp.Diagnostics, err, "Unable to reset resource %q", state.Name.ValueString())
+		return
+	}
+	r.trace(fmt.Sprintf("reset resource %q", state.ID.ValueString()))
+}
+
+// attach sets the resource on the application and fills the computed
+// values of the model.
+func (r *charmResourceResource) attach(ctx context.Context, plan *charmResourceResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	modelName := plan.ModelName.ValueString()
+	appName := plan.ApplicationName.ValueString()
+	resourceName := plan.Name.ValueString()
+
+	err := r.client.Applications.SetApplicationResource(ctx, juju.SetApplicationResourceInput{
+		ModelName:    modelName,
+		AppName:      appName,
+		ResourceName: resourceName,
+		Value:        plan.Value.ValueString(),
+	})
+	if err != nil {
+		addClientError(&diags, err, "Unable to attach resource %q to application %q", resourceName, appName)
+		return diags
+	}
+	r.trace(fmt.Sprintf("attached resource %q to application %q", resourceName, appName))
+
+	response, err := r.client.Applications.ReadApplicationResource(ctx, juju.ReadApplicationResourceInput{
+		ModelName:    modelName,
+		AppName:      appName,
+		ResourceName: resourceName,
+	})
+	if err != nil {
+		addClientError(&diags, err, "Unable to read resource %q", resourceName)
+		return diags
+	}
+	plan.Revision = types.Int64Value(int64(response.Revision))
+	plan.Origin = types.StringValue(response.Origin)
+	return diags
+}
+
+func (r *charmResourceResource) trace(msg string, additionalFields ...map[string]interface{}) {
+	if r.subCtx == nil {
+		return
+	}
+
+	//SubsystemTrace(subCtx, "my-subsystem", "hello, world", map[string]interface{}{"foo": 123})
+	// Output:
+	// {"@level":"trace","@message":"hello, world","@module":"provider.my-subsystem","foo":123}
+	tflog.SubsystemTrace(r.subCtx, LogResourceCharmResource, msg, additionalFields...)
+}
+
+var charmResourceIDFormat = idFormat{parts: []string{"model_name", "application_name", "resource_name"}}
+
+func newCharmResourceID(model, app, resourceName string) string {
+	return charmResourceIDFormat.format(model, app, resourceName)
+}
+
+func charmResourceFromID(value string) (string, string, string, diag.Diagnostics) {
+	id, diags := charmResourceIDFormat.parse(value)
+	if diags.HasError() {
+		return "", "", "", diags
+	}
+	return id[0], id[1], id[2], diags
+}
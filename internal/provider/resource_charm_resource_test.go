@@ -0,0 +1,71 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_ResourceCharmResource(t *testing.T) {
+	if testingCloud != MicroK8sTesting {
+		t.Skip(t.Name() + " only runs with Microk8s")
+	}
+	modelName := acctest.RandomWithPrefix("tf-test-charm-resource")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceCharmResource(modelName, "gatici/grafana:10"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_charm_resource.image", "value", "gatici/grafana:10"),
+					resource.TestCheckResourceAttr("juju_charm_resource.image", "origin", "upload"),
+				),
+			},
+			{
+				Config: testAccResourceCharmResource(modelName, "61"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_charm_resource.image", "value", "61"),
+					resource.TestCheckResourceAttr("juju_charm_resource.image", "revision", "61"),
+					resource.TestCheckResourceAttr("juju_charm_resource.image", "origin", "store"),
+				),
+			},
+			{
+				ImportStateVerify: true,
+				ImportState:       true,
+				ResourceName:      "juju_charm_resource.image",
+			},
+		},
+	})
+}
+
+func testAccResourceCharmResource(modelName, value string) string {
+	return fmt.Sprintf(`
+resource "juju_model" "this" {
+  name = %q
+}
+
+resource "juju_application" "this" {
+  model = juju_model.this.name
+  name  = "test-app"
+
+  charm {
+    name    = "grafana-k8s"
+    channel = "1.0/stable"
+  }
+}
+
+resource "juju_charm_resource" "image" {
+  model       = juju_model.this.name
+  application = juju_application.this.name
+  name        = "grafana-image"
+  value       = %q
+}
+`, modelName, value)
+}
@@ -7,12 +7,10 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -30,6 +28,8 @@ import (
 var _ resource.Resource = &credentialResource{}
 var _ resource.ResourceWithConfigure = &credentialResource{}
 var _ resource.ResourceWithImportState = &credentialResource{}
+var _ resource.ResourceWithUpgradeState = &credentialResource{}
+var _ resource.ResourceWithIdentity = &credentialResource{}
 
 func NewCredentialResource() resource.Resource {
 	return &credentialResource{}
@@ -56,10 +56,13 @@ type credentialResourceModel struct {
 
 func (c *credentialResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_credential"
+	// The ID changes when the resource is updated, and so does its identity.
+	resp.ResourceBehavior = resource.ResourceBehavior{MutableIdentity: true}
 }
 
 func (c *credentialResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     credentialSchemaVersion,
 		Description: "A resource that represent a credential for a cloud.",
 		Blocks: map[string]schema.Block{
 			"cloud": schema.ListNestedBlock{
@@ -123,6 +126,9 @@ func (c *credentialResource) Schema(_ context.Context, _ resource.SchemaRequest,
 }
 
 func (c *credentialResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := traceOperation(ctx, c.client, "juju_credential", "Create", &resp.Diagnostics)
+	defer endSpan()
+
 	// Check first if the client is configured
 	if c.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "credential", "create")
@@ -165,7 +171,7 @@ func (c *credentialResource) Create(ctx context.Context, req resource.CreateRequ
 	credentialName := data.Name.ValueString()
 
 	// Perform logic or external calls
-	response, err := c.client.Credentials.CreateCredential(juju.CreateCredentialInput{
+	response, err := c.client.Credentials.CreateCredential(ctx, juju.CreateCredentialInput{
 		Attributes:           attributes,
 		AuthType:             authType,
 		ClientCredential:     clientCredential,
@@ -174,7 +180,7 @@ func (c *credentialResource) Create(ctx context.Context, req resource.CreateRequ
 		Name:                 credentialName,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create credential resource, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to create credential resource")
 		return
 	}
 	c.trace(fmt.Sprintf("created credential resource %q", credentialName))
@@ -183,9 +189,13 @@ func (c *credentialResource) Create(ctx context.Context, req resource.CreateRequ
 
 	// Write the state data into the Response.State
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(credentialIDFormat.setIdentity(ctx, data.ID, resp.Identity)...)
 }
 
 func (c *credentialResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, c.client, "juju_credential", "Read", &resp.Diagnostics)
+	defer endSpan()
+
 	// Check first if the client is configured
 	if c.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "credential", "read")
@@ -209,15 +219,14 @@ func (c *credentialResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	// Retrieve updated resource state from upstream
-	response, err := c.client.Credentials.ReadCredential(juju.ReadCredentialInput{
+	response, err := c.client.Credentials.ReadCredential(ctx, juju.ReadCredentialInput{
 		ClientCredential:     clientCredential,
 		CloudName:            cloudName,
 		ControllerCredential: controllerCredential,
 		Name:                 credentialName,
 	})
 	if err != nil {
-		// TODO (cderici): call resp.State.RemoveResource() if NotFound
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read credential resource, got error: %s", err))
+		handleReadError(ctx, c.client, err, &resp.State, &resp.Diagnostics, "Unable to read credential resource")
 		return
 	}
 	c.trace(fmt.Sprintf("read credential resource %q", credentialName))
@@ -264,9 +273,13 @@ func (c *credentialResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	// Write the state data into the Response.State
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(credentialIDFormat.setIdentity(ctx, data.ID, resp.Identity)...)
 }
 
 func (c *credentialResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := traceOperation(ctx, c.client, "juju_credential", "Update", &resp.Diagnostics)
+	defer endSpan()
+
 	// Check first if the client is configured
 	if c.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "credential", "update")
@@ -319,7 +332,7 @@ func (c *credentialResource) Update(ctx context.Context, req resource.UpdateRequ
 	}
 
 	// Perform external call to modify resource
-	err := c.client.Credentials.UpdateCredential(juju.UpdateCredentialInput{
+	err := c.client.Credentials.UpdateCredential(ctx, juju.UpdateCredentialInput{
 		Attributes:           newAttributes,
 		AuthType:             newAuthType,
 		ClientCredential:     newClientCredential,
@@ -328,7 +341,7 @@ func (c *credentialResource) Update(ctx context.Context, req resource.UpdateRequ
 		Name:                 credentialName,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update credential resource, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to update credential resource")
 		return
 	}
 	c.trace(fmt.Sprintf("updated credential resource %q", credentialName))
@@ -337,9 +350,13 @@ func (c *credentialResource) Update(ctx context.Context, req resource.UpdateRequ
 
 	// Write the updated state data into the Response.State
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(credentialIDFormat.setIdentity(ctx, data.ID, resp.Identity)...)
 }
 
 func (c *credentialResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := traceOperation(ctx, c.client, "juju_credential", "Delete", &resp.Diagnostics)
+	defer endSpan()
+
 	// Check first if the client is configured
 	if c.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "credential", "delete")
@@ -364,18 +381,24 @@ func (c *credentialResource) Delete(ctx context.Context, req resource.DeleteRequ
 	}
 
 	// Perform external call to destroy the resource
-	err := c.client.Credentials.DestroyCredential(juju.DestroyCredentialInput{
+	err := c.client.Credentials.DestroyCredential(ctx, juju.DestroyCredentialInput{
 		ClientCredential:     clientCredential,
 		CloudName:            cloudName,
 		ControllerCredential: controllerCredential,
 		Name:                 credentialName,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete credential resource, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to delete credential resource")
 	}
 	c.trace(fmt.Sprintf("deleted credential resource %q", credentialName))
 }
 
+// UpgradeState returns the state upgraders from the prior schema
+// versions of the resource, keyed by version.
+func (c *credentialResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
 func (c *credentialResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -392,11 +415,17 @@ func (c *credentialResource) Configure(ctx context.Context, req resource.Configu
 	}
 	c.client = client
 	// Create the local logging subsystem here, using the TF context when creating it.
-	c.subCtx = tflog.NewSubsystem(ctx, LogResourceCredential)
+	c.subCtx = c.client.NewLogSubsystem(ctx, LogResourceCredential)
 }
 
 func (c credentialResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	credentialIDFormat.importState(ctx, req, resp)
+}
+
+// IdentitySchema returns the schema of the identity of the resource,
+// made of the parts of its ID.
+func (c credentialResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = credentialIDFormat.identitySchema()
 }
 
 func (c *credentialResource) trace(msg string, additionalFields ...map[string]interface{}) {
@@ -446,17 +475,16 @@ func newCredentialCloudFromCloudName(ctx context.Context, cloudName string, diag
 	return cloud, diag
 }
 
+var credentialIDFormat = idFormat{parts: []string{"credential_name", "cloud_name", "client_credential", "controller_credential"}}
+
 func newCredentialIDFrom(credentialName string, cloudName string, clientCredential bool, controllerCredential bool) string {
-	return fmt.Sprintf("%s:%s:%t:%t", credentialName, cloudName, clientCredential, controllerCredential)
+	return credentialIDFormat.format(credentialName, cloudName, strconv.FormatBool(clientCredential), strconv.FormatBool(controllerCredential))
 }
 
 func retrieveCredentialDataFromID(idStr string, diag *diag.Diagnostics, method string) (string, string, bool, bool) {
-	resID := strings.Split(idStr, ":")
-	if len(resID) != 4 {
-		diag.AddError("Provider Error",
-			fmt.Sprintf("unable to %s credential resource, invalid ID, expected {credentialName, cloudName, "+
-				"isClient, isController} - given : %q",
-				method, resID))
+	resID, diags := credentialIDFormat.parse(idStr)
+	diag.Append(diags...)
+	if diags.HasError() {
 		return "", "", false, false
 	}
 	credentialName, cloudName, clientCredentialStr, controllerCredentialStr := resID[0], resID[1], resID[2], resID[3]
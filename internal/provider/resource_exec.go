@@ -0,0 +1,382 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// defaultExecTimeout is used when no timeout is configured.
+const defaultExecTimeout = 5 * time.Minute
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &execResource{}
+var _ resource.ResourceWithConfigure = &execResource{}
+var _ resource.ResourceWithConfigValidators = &execResource{}
+var _ resource.ResourceWithUpgradeState = &execResource{}
+
+func NewExecResource() resource.Resource {
+	return &execResource{}
+}
+
+type execResource struct {
+	client *juju.Client
+
+	// subCtx is the context created with the new tflog subsystem for exec.
+	subCtx context.Context
+}
+
+type execResourceModel struct {
+	ModelName    types.String `tfsdk:"model"`
+	Command      types.String `tfsdk:"command"`
+	Applications types.Set    `tfsdk:"applications"`
+	Machines     types.Set    `tfsdk:"machines"`
+	Units        types.Set    `tfsdk:"units"`
+	Timeout      types.String `tfsdk:"timeout"`
+	FailOnError  types.Bool   `tfsdk:"fail_on_error"`
+	Triggers     types.Map    `tfsdk:"triggers"`
+	Results      types.List   `tfsdk:"results"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+type execResultModel struct {
+	Target   types.String `tfsdk:"target"`
+	Status   types.String `tfsdk:"status"`
+	ExitCode types.Int64  `tfsdk:"exit_code"`
+	Stdout   types.String `tfsdk:"stdout"`
+	Stderr   types.String `tfsdk:"stderr"`
+}
+
+var execResultAttrTypes = map[string]attr.Type{
+	"target":    types.StringType,
+	"status":    types.StringType,
+	"exit_code": types.Int64Type,
+	"stdout":    types.StringType,
+	"stderr":    types.StringType,
+}
+
+func (r *execResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_exec"
+}
+
+func (r *execResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: execSchemaVersion,
+		Description: "A resource that runs a command on units or machines, equivalent to `juju exec`, and " +
+			"records its output. The command runs when the resource is created, and again whenever an attribute " +
+			"requiring replacement changes, e.g. `triggers`. Destroying the resource only removes it from the " +
+			"Terraform state.",
+		Attributes: map[string]schema.Attribute{
+			"model": schema.StringAttribute{
+				Description: "The name of the model to run the command in.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"command": schema.StringAttribute{
+				Description: "The command to run.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"applications": schema.SetAttribute{
+				Description: "Run the command on every unit of these applications.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"machines": schema.SetAttribute{
+				Description: "Run the command on these machines.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"units": schema.SetAttribute{
+				Description: "Run the command on these units.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeout": schema.StringAttribute{
+				Description: "How long the command is allowed to run, as a duration string such as `30s` or " +
+					"`5m`. Defaults to `5m`.",
+				Optional: true,
+			},
+			"fail_on_error": schema.BoolAttribute{
+				Description: "Whether to fail when the command exits with a non-zero code on any target. " +
+					"Defaults to true.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary map of values that, when changed, will run the command again.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"results": schema.ListNestedAttribute{
+				Description: "The outcome of the command on each target, ordered by target.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"target": schema.StringAttribute{
+							Description: "The unit name or machine id the command ran on.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "The status of the task, e.g. `completed` or `failed`.",
+							Computed:    true,
+						},
+						"exit_code": schema.Int64Attribute{
+							Description: "The exit code of the command, -1 if the command did not complete.",
+							Computed:    true,
+						},
+						"stdout": schema.StringAttribute{
+							Description: "The standard output of the command.",
+							Computed:    true,
+						},
+						"stderr": schema.StringAttribute{
+							Description: "The standard error of the command.",
+							Computed:    true,
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ConfigValidators requires at least one target for the command.
+func (r *execResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("applications"),
+			path.MatchRoot("machines"),
+			path.MatchRoot("units"),
+		),
+	}
+}
+
+// UpgradeState returns the state upgraders from the prior schema
+// versions of the resource, keyed by version.
+func (r *execResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+func (r *execResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+	// Create the local logging subsystem here, using the TF context when creating it.
+	r.subCtx = r.client.NewLogSubsystem(ctx, LogResourceExec)
+}
+
+func (r *execResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_exec", "Create", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "exec", "create")
+		return
+	}
+
+	var plan execResourceModel
+
+	// Read Terraform configuration from the request into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout := defaultExecTimeout
+	if !plan.Timeout.IsNull() {
+		var err error
+		timeout, err = time.ParseDuration(plan.Timeout.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("timeout"), "Invalid Attribute Value",
+				fmt.Sprintf("Unable to parse timeout %q, got error: %s", plan.Timeout.ValueString(), err))
+			return
+		}
+	}
+
+	input := juju.ExecInput{
+		ModelName: plan.ModelName.ValueString(),
+		Command:   plan.Command.ValueString(),
+		Timeout:   timeout,
+	}
+	resp.Diagnostics.Append(plan.Applications.ElementsAs(ctx, &input.Applications, false)...)
+	resp.Diagnostics.Append(plan.Machines.ElementsAs(ctx, &input.Machines, false)...)
+	resp.Diagnostics.Append(plan.Units.ElementsAs(ctx, &input.Units, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := r.client.Exec.Exec(ctx, input)
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to run command")
+		return
+	}
+	r.trace(fmt.Sprintf("command ran in operation %q", response.OperationID), map[string]interface{}{"results": response.Results})
+
+	results := make([]execResultModel, len(response.Results))
+	var failed []string
+	for i, result := range response.Results {
+		results[i] = execResultModel{
+			Target:   types.StringValue(result.Target),
+			Status:   types.StringValue(result.Status),
+			ExitCode: types.Int64Value(int64(result.ExitCode)),
+			Stdout:   types.StringValue(result.Stdout),
+			Stderr:   types.StringValue(result.Stderr),
+		}
+		if result.ExitCode != 0 {
+			failed = append(failed, fmt.Sprintf("%s (exit code %d): %s", result.Target, result.ExitCode,
+				strings.TrimSpace(result.Stderr+" "+result.Message)))
+		}
+	}
+	if len(failed) > 0 && plan.FailOnError.ValueBool() {
+		resp.Diagnostics.AddError("Command Failed",
+			fmt.Sprintf("The command failed on %d target(s):\n%s", len(failed), strings.Join(failed, "\n")))
+		return
+	}
+
+	var dErr diag.Diagnostics
+	plan.Results, dErr = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: execResultAttrTypes}, results)
+	resp.Diagnostics.Append(dErr...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ID = types.StringValue(fmt.Sprintf("%s:%s", input.ModelName, response.OperationID))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read keeps the existing state. The command output is only available
+// when the command runs.
+func (r *execResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_exec", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "exec", "read")
+		return
+	}
+
+	var state execResourceModel
+
+	// Read Terraform prior state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.trace(fmt.Sprintf("read exec: %q", state.ID.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update only persists changes of the timeout and fail_on_error, which
+// do not run the command again.
+func (r *execResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_exec", "Update", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "exec", "update")
+		return
+	}
+
+	var plan execResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the exec from the Terraform state, a command cannot be
+// undone.
+func (r *execResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_exec", "Delete", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "exec", "delete")
+		return
+	}
+
+	var state execResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.trace(fmt.Sprintf("removed exec %q from state", state.ID.ValueString()))
+}
+
+func (r *execResource) trace(msg string, additionalFields ...map[string]interface{}) {
+	if r.subCtx == nil {
+		return
+	}
+
+	//SubsystemTrace(subCtx, "my-subsystem", "hello, world", map[string]interface{}{"foo": 123})
+	// Output:
+	// {"@level":"trace","@message":"hello, world","@module":"provider.my-subsystem","foo":123}
+	tflog.SubsystemTrace(r.subCtx, LogResourceExec, msg, additionalFields...)
+}
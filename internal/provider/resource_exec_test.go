@@ -0,0 +1,75 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_ResourceExec(t *testing.T) {
+	if testingCloud != LXDCloudTesting {
+		t.Skip(t.Name() + " only runs with LXD")
+	}
+	modelName := acctest.RandomWithPrefix("tf-test-exec")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceExec(modelName, "echo hello", "first"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_exec.this", "results.#", "1"),
+					resource.TestCheckResourceAttr("juju_exec.this", "results.0.target", "test-app/0"),
+					resource.TestCheckResourceAttr("juju_exec.this", "results.0.exit_code", "0"),
+					resource.TestCheckResourceAttr("juju_exec.this", "results.0.stdout", "hello\n"),
+				),
+			},
+			{
+				// Changing the triggers runs the command again.
+				Config: testAccResourceExec(modelName, "echo hello", "second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_exec.this", "triggers.run", "second"),
+					resource.TestCheckResourceAttr("juju_exec.this", "results.0.exit_code", "0"),
+				),
+			},
+			{
+				Config:      testAccResourceExec(modelName, "exit 3", "third"),
+				ExpectError: regexp.MustCompile("exit code 3"),
+			},
+		},
+	})
+}
+
+func testAccResourceExec(modelName, command, run string) string {
+	return fmt.Sprintf(`
+resource "juju_model" "model" {
+  name = %q
+}
+
+resource "juju_application" "app" {
+  model = juju_model.model.name
+  name  = "test-app"
+
+  charm {
+    name = "ubuntu"
+  }
+}
+
+resource "juju_exec" "this" {
+  model        = juju_model.model.name
+  command      = %q
+  applications = [juju_application.app.name]
+
+  triggers = {
+    run = %q
+  }
+}
+`, modelName, command, run)
+}
@@ -0,0 +1,331 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/juju/errors"
+	"github.com/juju/juju/core/network/firewall"
+	"github.com/juju/juju/environs/config"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &firewallRuleResource{}
+var _ resource.ResourceWithConfigure = &firewallRuleResource{}
+var _ resource.ResourceWithImportState = &firewallRuleResource{}
+var _ resource.ResourceWithUpgradeState = &firewallRuleResource{}
+var _ resource.ResourceWithIdentity = &firewallRuleResource{}
+
+func NewFirewallRuleResource() resource.Resource {
+	return &firewallRuleResource{}
+}
+
+type firewallRuleResource struct {
+	client *juju.Client
+
+	// subCtx is the context created with the new tflog subsystem for firewall rules.
+	subCtx context.Context
+}
+
+type firewallRuleResourceModel struct {
+	ModelName      types.String `tfsdk:"model"`
+	Service        types.String `tfsdk:"service"`
+	WhitelistCIDRs types.Set    `tfsdk:"whitelist_cidrs"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+// firewallRuleConfigKeys are the keys of the config of a model holding
+// the whitelist of the well known services, which `juju set-firewall-rule`
+// sets.
+var firewallRuleConfigKeys = map[string]string{
+	string(firewall.SSHRule):                  config.SSHAllowKey,
+	string(firewall.JujuApplicationOfferRule): config.SAASIngressAllowKey,
+}
+
+func (r *firewallRuleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_firewall_rule"
+}
+
+func (r *firewallRuleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: firewallRuleSchemaVersion,
+		Description: "A resource that represents the firewall rule of a well known service of a model, like " +
+			"`juju set-firewall-rule`, the CIDRs allowed to reach the service. The rule is reset to allow " +
+			"any address when the resource is destroyed.",
+		Attributes: map[string]schema.Attribute{
+			"model": schema.StringAttribute{
+				Description: "The name or the UUID of the model of the rule.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"service": schema.StringAttribute{
+				Description: "The well known service of the rule, `ssh` for the SSH connections to the machines " +
+					"of the model or `juju-application-offer` for the connections to the applications offered " +
+					"by the model, from the consuming models.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(string(firewall.SSHRule), string(firewall.JujuApplicationOfferRule)),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"whitelist_cidrs": schema.SetAttribute{
+				Description: "The CIDRs allowed to reach the service, e.g. `192.168.1.0/24`.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(StringIsCIDRValidator{}),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// UpgradeState returns the state upgraders from the prior schema
+// versions of the resource, keyed by version.
+func (r *firewallRuleResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+func (r *firewallRuleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+	// Create the local logging subsystem here, using the TF context when creating it.
+	r.subCtx = r.client.NewLogSubsystem(ctx, LogResourceFirewallRule)
+}
+
+// ImportState is called when the provider must import the state of a
+// resource instance. The ID is the name or the UUID of the model and
+// the service, e.g. development:ssh.
+func (r *firewallRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	firewallRuleIDFormat.importState(ctx, req, resp)
+}
+
+// IdentitySchema returns the schema of the identity of the resource,
+// made of the parts of its ID.
+func (r *firewallRuleResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = firewallRuleIDFormat.identitySchema()
+}
+
+func (r *firewallRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_firewall_rule", "Create", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "firewall_rule", "create")
+		return
+	}
+
+	var plan firewallRuleResourceModel
+
+	// Read Terraform configuration from the request into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	modelName := plan.ModelName.ValueString()
+	service := plan.Service.ValueString()
+	if err := r.setWhitelist(ctx, modelName, service, plan.WhitelistCIDRs); err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to set firewall rule %q of model %q", service, modelName)
+		return
+	}
+	r.trace(fmt.Sprintf("set firewall rule %q of model %q", service, modelName))
+
+	plan.ID = types.StringValue(firewallRuleIDFormat.format(modelName, service))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(firewallRuleIDFormat.setIdentity(ctx, plan.ID, resp.Identity)...)
+}
+
+func (r *firewallRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_firewall_rule", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "firewall_rule", "read")
+		return
+	}
+
+	var state firewallRuleResourceModel
+
+	// Read Terraform prior state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, dErr := firewallRuleIDFormat.parse(state.ID.ValueString())
+	if dErr.HasError() {
+		resp.Diagnostics.Append(dErr...)
+		return
+	}
+	modelName, service := id[0], id[1]
+	key, found := firewallRuleConfigKeys[service]
+	if !found {
+		resp.Diagnostics.AddError("Malformed ID",
+			fmt.Sprintf("ID %q is malformed, the service %q is not supported.", state.ID.ValueString(), service))
+		return
+	}
+
+	response, err := r.client.Models.ReadModelConfig(ctx, juju.ReadModelConfigInput{
+		ModelName: modelName,
+	})
+	if err != nil {
+		handleReadError(ctx, r.client, err, &resp.State, &resp.Diagnostics, "Unable to read firewall rule")
+		return
+	}
+	r.trace(fmt.Sprintf("read firewall rule %q of model %q", service, modelName))
+
+	state.WhitelistCIDRs, dErr = types.SetValueFrom(ctx, types.StringType, firewallRuleCIDRs(response.Config[key].Value))
+	if dErr.HasError() {
+		resp.Diagnostics.Append(dErr...)
+		return
+	}
+	state.ModelName = types.StringValue(modelName)
+	state.Service = types.StringValue(service)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(firewallRuleIDFormat.setIdentity(ctx, state.ID, resp.Identity)...)
+}
+
+func (r *firewallRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_firewall_rule", "Update", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "firewall_rule", "update")
+		return
+	}
+
+	var plan firewallRuleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the whitelist can be updated, the model and the service
+	// require replace.
+	modelName := plan.ModelName.ValueString()
+	service := plan.Service.ValueString()
+	if err := r.setWhitelist(ctx, modelName, service, plan.WhitelistCIDRs); err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to update firewall rule %q of model %q", service, modelName)
+		return
+	}
+	r.trace(fmt.Sprintf("updated firewall rule %q of model %q", service, modelName))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *firewallRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_firewall_rule", "Delete", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "firewall_rule", "delete")
+		return
+	}
+
+	var state firewallRuleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The config key is reset to its default, allowing any address.
+	modelName := state.ModelName.ValueString()
+	service := state.Service.ValueString()
+	err := r.client.Models.UpdateModel(ctx, juju.UpdateModelInput{
+		Name:  modelName,
+		Unset: []string{firewallRuleConfigKeys[service]},
+	})
+	// Nothing to reset once the model is destroyed.
+	if err != nil && !errors.Is(err, errors.NotFound) {
+		addClientError(&resp.Diagnostics, err, "Unable to reset firewall rule %q of model %q", service, modelName)
+		return
+	}
+	r.trace(fmt.Sprintf("reset firewall rule %q of model %q", service, modelName))
+}
+
+// setWhitelist sets the config key of the model holding the whitelist
+// of the service to the CIDRs, separated by commas.
+func (r *firewallRuleResource) setWhitelist(ctx context.Context, modelName, service string, whitelist types.Set) error {
+	var cidrs []string
+	if diags := whitelist.ElementsAs(ctx, &cidrs, false); diags.HasError() {
+		return errors.Errorf("reading whitelist_cidrs: %v", diags.Errors())
+	}
+	return r.client.Models.UpdateModel(ctx, juju.UpdateModelInput{
+		Name:   modelName,
+		Config: map[string]string{firewallRuleConfigKeys[service]: strings.Join(cidrs, ",")},
+	})
+}
+
+// firewallRuleIDFormat is the format of the ID of the firewall rule
+// resource, the name or the UUID of the model and the service.
+var firewallRuleIDFormat = idFormat{parts: []string{"model_name", "service"}}
+
+// firewallRuleCIDRs returns the CIDRs of the comma separated value of
+// the config key of a firewall rule.
+func firewallRuleCIDRs(value string) []string {
+	cidrs := []string{}
+	for _, cidr := range strings.Split(value, ",") {
+		if cidr = strings.TrimSpace(cidr); cidr != "" {
+			cidrs = append(cidrs, cidr)
+		}
+	}
+	return cidrs
+}
+
+func (r *firewallRuleResource) trace(msg string, additionalFields ...map[string]interface{}) {
+	if r.subCtx == nil {
+		return
+	}
+
+	//SubsystemTrace(subCtx, "my-subsystem", "hello, world", map[string]interface{}{"foo": 123})
+	// Output:
+	// {"@level":"trace","@message":"hello, world","@module":"provider.my-subsystem","foo":123}
+	tflog.SubsystemTrace(r.subCtx, LogResourceFirewallRule, msg, additionalFields...)
+}
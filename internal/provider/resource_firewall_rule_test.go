@@ -0,0 +1,67 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFirewallRuleCIDRs(t *testing.T) {
+	assert.Equal(t, []string{"192.168.1.0/24", "10.0.0.0/8"}, firewallRuleCIDRs("192.168.1.0/24, 10.0.0.0/8"))
+	assert.Equal(t, []string{"0.0.0.0/0", "::/0"}, firewallRuleCIDRs("0.0.0.0/0,::/0"))
+	assert.Equal(t, []string{}, firewallRuleCIDRs(""))
+}
+
+func TestAcc_ResourceFirewallRule(t *testing.T) {
+	if testingCloud != LXDCloudTesting {
+		t.Skip(t.Name() + " only runs with LXD")
+	}
+	modelName := acctest.RandomWithPrefix("tf-test-firewall-rule")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceFirewallRule(modelName, `"192.168.1.0/24"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_firewall_rule.ssh", "id", modelName+":ssh"),
+					resource.TestCheckResourceAttr("juju_firewall_rule.ssh", "whitelist_cidrs.#", "1"),
+					resource.TestCheckTypeSetElemAttr("juju_firewall_rule.ssh", "whitelist_cidrs.*", "192.168.1.0/24"),
+				),
+			},
+			{
+				Config: testAccResourceFirewallRule(modelName, `"192.168.1.0/24", "10.0.0.0/8"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_firewall_rule.ssh", "whitelist_cidrs.#", "2"),
+					resource.TestCheckTypeSetElemAttr("juju_firewall_rule.ssh", "whitelist_cidrs.*", "10.0.0.0/8"),
+				),
+			},
+			{
+				ImportStateVerify: true,
+				ImportState:       true,
+				ResourceName:      "juju_firewall_rule.ssh",
+			},
+		},
+	})
+}
+
+func testAccResourceFirewallRule(modelName, cidrs string) string {
+	return fmt.Sprintf(`
+resource "juju_model" "this" {
+  name = %q
+}
+
+resource "juju_firewall_rule" "ssh" {
+  model           = juju_model.this.name
+  service         = "ssh"
+  whitelist_cidrs = [%s]
+}
+`, modelName, cidrs)
+}
@@ -5,9 +5,8 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"strings"
+	"slices"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -17,7 +16,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -29,6 +27,8 @@ var _ resource.Resource = &integrationResource{}
 var _ resource.ResourceWithConfigure = &integrationResource{}
 var _ resource.ResourceWithImportState = &integrationResource{}
 var _ resource.ResourceWithValidateConfig = &integrationResource{}
+var _ resource.ResourceWithUpgradeState = &integrationResource{}
+var _ resource.ResourceWithIdentity = &integrationResource{}
 
 func NewIntegrationResource() resource.Resource {
 	return &integrationResource{}
@@ -42,9 +42,11 @@ type integrationResource struct {
 }
 
 type integrationResourceModel struct {
-	ModelName   types.String `tfsdk:"model"`
-	Via         types.String `tfsdk:"via"`
-	Application types.Set    `tfsdk:"application"`
+	ModelName      types.String `tfsdk:"model"`
+	Via            types.String `tfsdk:"via"`
+	Application    types.Set    `tfsdk:"application"`
+	ForceDestroy   types.Bool   `tfsdk:"force_destroy"`
+	DestroyMaxWait types.String `tfsdk:"destroy_max_wait"`
 	// ID required by the testing framework
 	ID types.String `tfsdk:"id"`
 }
@@ -59,7 +61,19 @@ type nestedApplication struct {
 
 func (r *integrationResource) ImportState(ctx context.Context, req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	integrationIDFormat.importState(ctx, req, resp)
+}
+
+// IdentitySchema returns the schema of the identity of the resource,
+// made of the parts of its ID.
+func (r *integrationResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = integrationIDFormat.identitySchema()
+}
+
+// UpgradeState returns the state upgraders from the prior schema
+// versions of the resource, keyed by version.
+func (r *integrationResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
 }
 
 func (r *integrationResource) Configure(ctx context.Context, req resource.ConfigureRequest,
@@ -78,7 +92,7 @@ func (r *integrationResource) Configure(ctx context.Context, req resource.Config
 		return
 	}
 	r.client = client
-	r.subCtx = tflog.NewSubsystem(ctx, LogResourceIntegration)
+	r.subCtx = r.client.NewLogSubsystem(ctx, LogResourceIntegration)
 }
 
 // Called during terraform validate through ValidateResourceConfig RPC
@@ -107,10 +121,13 @@ func (r *integrationResource) ValidateConfig(ctx context.Context, req resource.V
 
 func (r *integrationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_integration"
+	// The ID changes when the resource is updated, and so does its identity.
+	resp.ResourceBehavior = resource.ResourceBehavior{MutableIdentity: true}
 }
 
 func (r *integrationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     integrationSchemaVersion,
 		Description: "A resource that represents a Juju Integration.",
 		Attributes: map[string]schema.Attribute{
 			"model": schema.StringAttribute{
@@ -121,6 +138,8 @@ func (r *integrationResource) Schema(_ context.Context, _ resource.SchemaRequest
 				Description: "A comma separated list of CIDRs for outbound traffic.",
 				Optional:    true,
 			},
+			"force_destroy":    forceDestroyAttribute("integration", ""),
+			"destroy_max_wait": destroyMaxWaitAttribute("integration"),
 			"id": schema.StringAttribute{
 				Computed: true,
 				PlanModifiers: []planmodifier.String{
@@ -161,6 +180,9 @@ func (r *integrationResource) Schema(_ context.Context, _ resource.SchemaRequest
 }
 
 func (r *integrationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_integration", "Create", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if r.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "integration", "create")
@@ -184,18 +206,18 @@ func (r *integrationResource) Create(ctx context.Context, req resource.CreateReq
 
 	endpoints, offerURL, appNames, err := parseEndpoints(apps)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse endpoints, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to parse endpoints")
 		return
 	}
 
 	var offerResponse = &juju.ConsumeRemoteOfferResponse{}
 	if offerURL != nil {
-		offerResponse, err = r.client.Offers.ConsumeRemoteOffer(&juju.ConsumeRemoteOfferInput{
+		offerResponse, err = r.client.Offers.ConsumeRemoteOffer(ctx, &juju.ConsumeRemoteOfferInput{
 			ModelName: modelName,
 			OfferURL:  *offerURL,
 		})
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to consume remote offer, got error: %s", err))
+			addClientError(&resp.Diagnostics, err, "Unable to consume remote offer")
 			return
 		}
 		r.trace(fmt.Sprintf("remote offer created : %q", *offerURL))
@@ -206,14 +228,14 @@ func (r *integrationResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	viaCIDRs := plan.Via.ValueString()
-	response, err := r.client.Integrations.CreateIntegration(&juju.IntegrationInput{
+	response, err := r.client.Integrations.CreateIntegration(ctx, &juju.IntegrationInput{
 		ModelName: modelName,
 		Apps:      appNames,
 		Endpoints: endpoints,
 		ViaCIDRs:  viaCIDRs,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create integration, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to create integration")
 		return
 	}
 	r.trace(fmt.Sprintf("integration created on Juju between %q at %q on model %q", appNames, endpoints, modelName))
@@ -234,9 +256,16 @@ func (r *integrationResource) Create(ctx context.Context, req resource.CreateReq
 	r.trace(fmt.Sprintf("integration resource created: %q", id))
 	// Write the state plan into the Response.State
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(integrationIDFormat.setIdentity(ctx, plan.ID, resp.Identity)...)
 }
 
 func (r *integrationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_integration", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// The reads of a refresh share the status of the model.
+	ctx = juju.WithRefresh(ctx)
+
 	// Prevent panic if the provider has not been configured.
 	if r.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "integration", "read")
@@ -265,9 +294,9 @@ func (r *integrationResource) Read(ctx context.Context, req resource.ReadRequest
 		},
 	}
 
-	response, err := r.client.Integrations.ReadIntegration(integration)
+	response, err := r.client.Integrations.ReadIntegration(ctx, integration)
 	if err != nil {
-		resp.Diagnostics.Append(handleIntegrationNotFoundError(ctx, err, &resp.State)...)
+		handleReadError(ctx, r.client, err, &resp.State, &resp.Diagnostics, "Unable to read integration")
 		return
 	}
 	r.trace(fmt.Sprintf("found integration: %v", integration))
@@ -286,9 +315,13 @@ func (r *integrationResource) Read(ctx context.Context, req resource.ReadRequest
 	r.trace(fmt.Sprintf("read integration resource: %v", state.ID.ValueString()))
 	// Set the state onto the Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(integrationIDFormat.setIdentity(ctx, state.ID, resp.Identity)...)
 }
 
 func (r *integrationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_integration", "Update", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if r.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "integration", "update")
@@ -303,6 +336,14 @@ func (r *integrationResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	// Only the destroy options changed, they are not saved in juju.
+	if plan.Via.Equal(state.Via) && sameApplications(ctx, plan.Application, state.Application) {
+		state.ForceDestroy = plan.ForceDestroy
+		state.DestroyMaxWait = plan.DestroyMaxWait
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
 	modelName := plan.ModelName.ValueString()
 
 	var oldEndpoints, endpoints []string
@@ -332,25 +373,25 @@ func (r *integrationResource) Update(ctx context.Context, req resource.UpdateReq
 	if oldOfferURL != offerURL && !(oldOfferURL == nil && offerURL == nil) {
 		if oldOfferURL != nil {
 			//destroy old offer
-			errs := r.client.Offers.RemoveRemoteOffer(&juju.RemoveRemoteOfferInput{
+			errs := r.client.Offers.RemoveRemoteOffer(ctx, &juju.RemoveRemoteOfferInput{
 				ModelName: modelName,
 				OfferURL:  *oldOfferURL,
 			})
 			if len(errs) > 0 {
 				for _, v := range errs {
-					resp.Diagnostics.AddError("Client Error", v.Error())
+					addClientError(&resp.Diagnostics, v, "Unable to remove offer %q", *oldOfferURL)
 				}
 				return
 			}
 			r.trace(fmt.Sprintf("removed offer on Juju: %q", *oldOfferURL))
 		}
 		if offerURL != nil {
-			offerResponse, err = r.client.Offers.ConsumeRemoteOffer(&juju.ConsumeRemoteOfferInput{
+			offerResponse, err = r.client.Offers.ConsumeRemoteOffer(ctx, &juju.ConsumeRemoteOfferInput{
 				ModelName: modelName,
 				OfferURL:  *offerURL,
 			})
 			if err != nil {
-				resp.Diagnostics.AddError("Client Error", err.Error())
+				addClientError(&resp.Diagnostics, err, "Unable to consume remote offer")
 				return
 			}
 			endpoints = append(endpoints, offerResponse.SAASName)
@@ -365,9 +406,9 @@ func (r *integrationResource) Update(ctx context.Context, req resource.UpdateReq
 		OldEndpoints: oldEndpoints,
 		ViaCIDRs:     viaCIDRs,
 	}
-	response, err := r.client.Integrations.UpdateIntegration(input)
+	response, err := r.client.Integrations.UpdateIntegration(ctx, input)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", err.Error())
+		addClientError(&resp.Diagnostics, err, "Unable to update integration")
 		return
 	}
 
@@ -384,9 +425,13 @@ func (r *integrationResource) Update(ctx context.Context, req resource.UpdateReq
 	r.trace(fmt.Sprintf("Updated integration resource: %q", newId))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(integrationIDFormat.setIdentity(ctx, plan.ID, resp.Identity)...)
 }
 
 func (r *integrationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_integration", "Delete", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if r.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "integration", "delete")
@@ -412,26 +457,38 @@ func (r *integrationResource) Delete(ctx context.Context, req resource.DeleteReq
 	}
 
 	// Remove the integration
-	err = r.client.Integrations.DestroyIntegration(&juju.IntegrationInput{
+	err = r.client.Integrations.DestroyIntegration(ctx, &juju.IntegrationInput{
 		ModelName: modelName,
 		Endpoints: endpoints,
+		Destroy:   destroyOptions(state.ForceDestroy, state.DestroyMaxWait),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", err.Error())
+		addClientError(&resp.Diagnostics, err, "Unable to delete integration")
 		return
 	}
 	r.trace(fmt.Sprintf("Deleted integration resource: %q", state.ID.ValueString()))
 }
 
-func handleIntegrationNotFoundError(ctx context.Context, err error, st *tfsdk.State) diag.Diagnostics {
-	if errors.As(err, &juju.NoIntegrationFoundError) {
-		// Integration manually removed
-		st.RemoveResource(ctx)
-		return diag.Diagnostics{}
+// sameApplications reports whether the planned applications of an
+// integration are those of its state. Endpoints unknown in the plan are
+// computed by Juju, and match any endpoint.
+func sameApplications(ctx context.Context, plan, state types.Set) bool {
+	var planApps, stateApps []nestedApplication
+	if plan.ElementsAs(ctx, &planApps, false).HasError() || state.ElementsAs(ctx, &stateApps, false).HasError() {
+		return false
+	}
+	if len(planApps) != len(stateApps) {
+		return false
+	}
+	for _, planApp := range planApps {
+		if !slices.ContainsFunc(stateApps, func(stateApp nestedApplication) bool {
+			return planApp.Name.Equal(stateApp.Name) && planApp.OfferURL.Equal(stateApp.OfferURL) &&
+				(planApp.Endpoint.IsUnknown() || planApp.Endpoint.Equal(stateApp.Endpoint))
+		}) {
+			return false
+		}
 	}
-	var diags diag.Diagnostics
-	diags.AddError("Client Error", err.Error())
-	return diags
+	return true
 }
 
 func newIDForIntegrationResource(modelName string, apps []juju.Application) string {
@@ -455,12 +512,11 @@ func newIDForIntegrationResource(modelName string, apps []juju.Application) stri
 	return id
 }
 
+var integrationIDFormat = idFormat{parts: []string{"model_name", "provider_app_name", "provider_endpoint", "requirer_app_name", "requirer_endpoint"}}
+
 func modelNameAndEndpointsFromID(ID string) (string, string, string, diag.Diagnostics) {
-	var diags diag.Diagnostics
-	id := strings.Split(ID, ":")
-	if len(id) != 5 {
-		diags.AddError("Malformed ID",
-			fmt.Sprintf("unable to parse model and application name from provided ID: %q", ID))
+	id, diags := integrationIDFormat.parse(ID)
+	if diags.HasError() {
 		return "", "", "", diags
 	}
 	return id[0], fmt.Sprintf("%v:%v", id[1], id[2]), fmt.Sprintf("%v:%v", id[3], id[4]), diags
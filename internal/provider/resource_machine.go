@@ -6,19 +6,24 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	corebase "github.com/juju/juju/core/base"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/names/v5"
 
 	"github.com/juju/terraform-provider-juju/internal/juju"
 )
@@ -27,6 +32,8 @@ import (
 var _ resource.Resource = &machineResource{}
 var _ resource.ResourceWithConfigure = &machineResource{}
 var _ resource.ResourceWithImportState = &machineResource{}
+var _ resource.ResourceWithUpgradeState = &machineResource{}
+var _ resource.ResourceWithIdentity = &machineResource{}
 
 func NewMachineResource() resource.Resource {
 	return &machineResource{}
@@ -40,17 +47,26 @@ type machineResource struct {
 }
 
 type machineResourceModel struct {
-	Name           types.String `tfsdk:"name"`
-	ModelName      types.String `tfsdk:"model"`
-	Constraints    types.String `tfsdk:"constraints"`
-	Disks          types.String `tfsdk:"disks"`
-	Base           types.String `tfsdk:"base"`
-	Series         types.String `tfsdk:"series"`
-	Placement      types.String `tfsdk:"placement"`
-	MachineID      types.String `tfsdk:"machine_id"`
-	SSHAddress     types.String `tfsdk:"ssh_address"`
-	PublicKeyFile  types.String `tfsdk:"public_key_file"`
-	PrivateKeyFile types.String `tfsdk:"private_key_file"`
+	Name           types.String   `tfsdk:"name"`
+	ModelName      types.String   `tfsdk:"model"`
+	Constraints    types.String   `tfsdk:"constraints"`
+	Disks          types.String   `tfsdk:"disks"`
+	Base           types.String   `tfsdk:"base"`
+	Series         types.String   `tfsdk:"series"`
+	Placement      types.String   `tfsdk:"placement"`
+	ParentMachine  types.String   `tfsdk:"parent_machine"`
+	ContainerType  types.String   `tfsdk:"container_type"`
+	MachineID      types.String   `tfsdk:"machine_id"`
+	SSHAddress     types.String   `tfsdk:"ssh_address"`
+	PublicKeyFile  types.String   `tfsdk:"public_key_file"`
+	PrivateKeyFile types.String   `tfsdk:"private_key_file"`
+	Wait           types.Bool     `tfsdk:"wait"`
+	InstanceID     types.String   `tfsdk:"instance_id"`
+	Hostname       types.String   `tfsdk:"hostname"`
+	IPAddresses    types.List     `tfsdk:"ip_addresses"`
+	ForceDestroy   types.Bool     `tfsdk:"force_destroy"`
+	DestroyMaxWait types.String   `tfsdk:"destroy_max_wait"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
 	// ID required by the testing framework
 	ID types.String `tfsdk:"id"`
 }
@@ -59,6 +75,16 @@ func (r *machineResource) Metadata(_ context.Context, req resource.MetadataReque
 	resp.TypeName = req.ProviderTypeName + "_machine"
 }
 
+// UpgradeState returns the state upgraders from the prior schema
+// versions of the resource, keyed by version.
+func (r *machineResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 1 deprecates series for base: the base of the
+		// machines created with a series only is set from it.
+		0: rawStateUpgrader(upgradeRenamedAttributes(machineSeriesRenamed)),
+	}
+}
+
 // Configure enables provider-level data or clients to be set in the
 // provider-defined DataSource type. It is separately executed for each
 // ReadDataSource RPC.
@@ -79,7 +105,7 @@ func (r *machineResource) Configure(ctx context.Context, req resource.ConfigureR
 
 	r.client = client
 	// Create the local logging subsystem here, using the TF context when creating it.
-	r.subCtx = tflog.NewSubsystem(ctx, LogResourceMachine)
+	r.subCtx = r.client.NewLogSubsystem(ctx, LogResourceMachine)
 }
 
 const (
@@ -94,10 +120,21 @@ const (
 	SSHAddressKey     = "ssh_address"
 	PrivateKeyFileKey = "private_key_file"
 	PublicKeyFileKey  = "public_key_file"
+	ParentMachineKey  = "parent_machine"
+	ContainerTypeKey  = "container_type"
 )
 
-func (r *machineResource) Schema(_ context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+// machineSeriesRenamed deprecates series for base, the value of series
+// being converted into a base.
+var machineSeriesRenamed = renamedAttribute{from: SeriesKey, to: BaseKey, convert: baseFromSeries}
+
+// defaultMachineStartedTimeout is how long a machine is waited for to
+// start without a create timeout.
+const defaultMachineStartedTimeout = 30 * time.Minute
+
+func (r *machineResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     machineSchemaVersion,
 		Description: "A resource that represents a Juju machine deployment. Refer to the juju add-machine CLI command for more information and limitations.",
 		Attributes: map[string]schema.Attribute{
 			NameKey: schema.StringAttribute{
@@ -116,15 +153,20 @@ func (r *machineResource) Schema(_ context.Context, req resource.SchemaRequest,
 				},
 			},
 			ConstraintsKey: schema.StringAttribute{
-				Description: "Machine constraints that overwrite those available from 'juju get-model-constraints' and provider's defaults.",
-				Optional:    true,
+				Description: "Machine constraints that overwrite those available from 'juju get-model-constraints' and provider's defaults. " +
+					"They are validated on plan. Defaults to the constraints of the model, read back from the machine. " +
+					"Changing them replaces the machine, unless they are equivalent, e.g. reordered or `mem=4096M` for `mem=4G`.",
+				Optional: true,
+				Computed: true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIf(constraintsRequiresReplace, "", ""),
 				},
 				Validators: []validator.String{
 					stringvalidator.ConflictsWith(path.Expressions{
 						path.MatchRoot(SSHAddressKey),
 					}...),
+					StringIsConstraintsValidator{},
 				},
 			},
 			DisksKey: schema.StringAttribute{
@@ -146,6 +188,7 @@ func (r *machineResource) Schema(_ context.Context, req resource.SchemaRequest,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplaceIfConfigured(),
 					stringplanmodifier.UseStateForUnknown(),
+					convertStringFrom(path.Root(SeriesKey), baseFromSeries),
 				},
 				Validators: []validator.String{
 					stringvalidator.ConflictsWith(path.Expressions{
@@ -162,6 +205,7 @@ func (r *machineResource) Schema(_ context.Context, req resource.SchemaRequest,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplaceIfConfigured(),
 					stringplanmodifier.UseStateForUnknown(),
+					convertStringFrom(path.Root(BaseKey), seriesFromBase),
 				},
 				Validators: []validator.String{
 					stringvalidator.ConflictsWith(path.Expressions{
@@ -169,19 +213,56 @@ func (r *machineResource) Schema(_ context.Context, req resource.SchemaRequest,
 						path.MatchRoot(BaseKey),
 					}...),
 				},
-				DeprecationMessage: "Configure base instead. This attribute will be removed in the next major version of the provider.",
+				DeprecationMessage: machineSeriesRenamed.deprecationMessage(),
 			},
 			PlacementKey: schema.StringAttribute{
-				Description: "Additional information about how to allocate the machine in the cloud.",
+				Description: "Additional information about how to allocate the machine in the cloud, e.g. `lxd:3` " +
+					"to add the machine as a LXD container of machine 3.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.Expressions{
+						path.MatchRoot(SSHAddressKey),
+					}...),
+				},
+			},
+			ParentMachineKey: schema.StringAttribute{
+				Description: "The id of the machine hosting the container, e.g. the `machine_id` of another machine " +
+					"resource, like the placement `lxd:3`. Requires container_type. The container is added to a new " +
+					"machine if not set.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.Expressions{
+						path.MatchRoot(PlacementKey),
+						path.MatchRoot(SSHAddressKey),
+					}...),
+					stringvalidator.AlsoRequires(path.Expressions{
+						path.MatchRoot(ContainerTypeKey),
+					}...),
+				},
+			},
+			ContainerTypeKey: schema.StringAttribute{
+				Description: "The type of the container to add the machine as, `lxd` or `kvm`, in parent_machine.",
 				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplaceIfConfigured(),
 					stringplanmodifier.UseStateForUnknown(),
 				},
 				Validators: []validator.String{
 					stringvalidator.ConflictsWith(path.Expressions{
+						path.MatchRoot(PlacementKey),
 						path.MatchRoot(SSHAddressKey),
 					}...),
+					stringvalidator.OneOf(string(instance.LXD), string(instance.KVM)),
 				},
 			},
 			MachineIDKey: schema.StringAttribute{
@@ -235,6 +316,36 @@ func (r *machineResource) Schema(_ context.Context, req resource.SchemaRequest,
 					}...),
 				},
 			},
+			"wait": schema.BoolAttribute{
+				Description: "Wait for the machine to reach the started status when it is created, its agent " +
+					"running, so that its addresses are known once created. The wait is bounded by the create " +
+					"timeout, 30m if not set. Defaults to false.",
+				Optional: true,
+			},
+			"instance_id": schema.StringAttribute{
+				Description: "The ID of the instance of the machine in the cloud, empty until it is provisioned.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"hostname": schema.StringAttribute{
+				Description: "The hostname of the machine, empty until it is started.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ip_addresses": schema.ListAttribute{
+				Description: "The IP addresses of the machine, empty until it is started.",
+				ElementType: types.StringType,
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"force_destroy":    forceDestroyAttribute("machine", "The units and containers it hosts are removed with it, Juju refuses to destroy such a machine otherwise. "),
+			"destroy_max_wait": destroyMaxWaitAttribute("machine"),
 			"id": schema.StringAttribute{
 				Computed: true,
 				PlanModifiers: []planmodifier.String{
@@ -242,6 +353,9 @@ func (r *machineResource) Schema(_ context.Context, req resource.SchemaRequest,
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			TimeoutsKey: timeoutsBlock(ctx, "machine"),
+		},
 	}
 }
 
@@ -249,6 +363,9 @@ func (r *machineResource) Schema(_ context.Context, req resource.SchemaRequest,
 // and planned state values should be read from the
 // CreateRequest and new state values set on the CreateResponse.
 func (r *machineResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_machine", "Create", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if r.client == nil {
 		resp.Diagnostics.AddError(
@@ -264,6 +381,11 @@ func (r *machineResource) Create(ctx context.Context, req resource.CreateRequest
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := withOperationTimeout(ctx, data.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	response, err := r.client.Machines.CreateMachine(ctx, &juju.CreateMachineInput{
 		Constraints:    data.Constraints.ValueString(),
@@ -272,12 +394,12 @@ func (r *machineResource) Create(ctx context.Context, req resource.CreateRequest
 		Base:           data.Base.ValueString(),
 		Series:         data.Series.ValueString(),
 		SSHAddress:     data.SSHAddress.ValueString(),
-		Placement:      data.Placement.ValueString(),
+		Placement:      machinePlacement(data),
 		PublicKeyFile:  data.PublicKeyFile.ValueString(),
 		PrivateKeyFile: data.PrivateKeyFile.ValueString(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create machine, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to create machine")
 		return
 	}
 	r.trace(fmt.Sprintf("create machine resource %q", response.ID))
@@ -290,25 +412,46 @@ func (r *machineResource) Create(ctx context.Context, req resource.CreateRequest
 	id := newMachineID(data.ModelName.ValueString(), response.ID, machineName)
 	data.ID = types.StringValue(id)
 	data.MachineID = types.StringValue(response.ID)
+	data.ParentMachine, data.ContainerType = machineParentAndContainerType(response.ID)
 	data.Base = types.StringValue(response.Base)
 	data.Series = types.StringValue(response.Series)
 	data.Name = types.StringValue(machineName)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-}
-
-func IsMachineNotFound(err error) bool {
-	return strings.Contains(err.Error(), "no status returned for machine")
-}
+	if data.Constraints.IsUnknown() {
+		data.Constraints = types.StringValue(response.Constraints)
+	}
 
-func handleMachineNotFoundError(ctx context.Context, err error, st *tfsdk.State) diag.Diagnostics {
-	if IsMachineNotFound(err) {
-		// Machine manually removed
-		st.RemoveResource(ctx)
-		return diag.Diagnostics{}
+	// The machine is created, it is saved on errors so that it is
+	// tracked.
+	modelName := data.ModelName.ValueString()
+	if data.Wait.ValueBool() {
+		timeout := defaultMachineStartedTimeout
+		if deadline, ok := ctx.Deadline(); ok {
+			timeout = time.Until(deadline)
+		}
+		if _, err := r.client.Status.WaitForStatus(ctx, juju.WaitForStatusInput{
+			ModelName: modelName,
+			Kind:      juju.EntityKindMachine,
+			Name:      response.ID,
+			Status:    []string{"started"},
+			Timeout:   timeout,
+		}); err != nil {
+			addClientError(&resp.Diagnostics, err, "Unable to wait for machine %q to start", response.ID)
+			resp.Diagnostics.Append(setPartialState(ctx, &resp.State, &data)...)
+			return
+		}
 	}
-	var diags diag.Diagnostics
-	diags.AddError("Not Found", err.Error())
-	return diags
+	readResponse, err := r.client.Machines.ReadMachine(ctx, juju.ReadMachineInput{
+		ModelName: modelName,
+		ID:        response.ID,
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to read machine %q", response.ID)
+		resp.Diagnostics.Append(setPartialState(ctx, &resp.State, &data)...)
+		return
+	}
+	resp.Diagnostics.Append(setMachineAddresses(ctx, &data, readResponse)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(machineIDFormat.setIdentity(ctx, data.ID, resp.Identity)...)
 }
 
 // Read is called when the provider must read resource values in order
@@ -317,6 +460,12 @@ func handleMachineNotFoundError(ctx context.Context, err error, st *tfsdk.State)
 // Take the juju api input from the ID, it may not exist in the plan.
 // Only set optional values if they exist.
 func (r *machineResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_machine", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// The reads of a refresh share the status of the model.
+	ctx = juju.WithRefresh(ctx)
+
 	// Prevent panic if the provider has not been configured.
 	if r.client == nil {
 		resp.Diagnostics.AddError(
@@ -338,31 +487,45 @@ func (r *machineResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	response, err := r.client.Machines.ReadMachine(juju.ReadMachineInput{
+	response, err := r.client.Machines.ReadMachine(ctx, juju.ReadMachineInput{
 		ModelName: modelName,
 		ID:        machineID,
 	})
 	if err != nil {
-		resp.Diagnostics.Append(handleMachineNotFoundError(ctx, err, &resp.State)...)
+		handleReadError(ctx, r.client, err, &resp.State, &resp.Diagnostics, "Unable to read machine")
 		return
 	}
 	r.trace(fmt.Sprintf("read machine resource %q", machineID))
 
+	readConstraints, err := constraints.Parse(response.Constraints)
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to parse constraints of machine")
+		return
+	}
+
 	data.Name = types.StringValue(machineName)
 	data.ModelName = types.StringValue(modelName)
 	data.MachineID = types.StringValue(machineID)
+	data.ParentMachine, data.ContainerType = machineParentAndContainerType(machineID)
 	data.Series = types.StringValue(response.Series)
 	data.Base = types.StringValue(response.Base)
-	if response.Constraints != "" {
+	// The constraints written are kept if they are equivalent to the
+	// constraints read, e.g. mem=4G for mem=4096M.
+	if !sameConstraints(data.Constraints, readConstraints, "") {
 		data.Constraints = types.StringValue(response.Constraints)
 	}
+	resp.Diagnostics.Append(setMachineAddresses(ctx, &data, response)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(machineIDFormat.setIdentity(ctx, data.ID, resp.Identity)...)
 }
 
 // Update is called to update the state of the resource. Config, planned
 // state, and prior state values should be read from the
 // UpdateRequest and new state values set on the UpdateResponse.
 func (r *machineResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_machine", "Update", &resp.Diagnostics)
+	defer endSpan()
+
 	var plan, state machineResourceModel
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -374,16 +537,19 @@ func (r *machineResource) Update(ctx context.Context, req resource.UpdateRequest
 	// TODO hml 28-Jul-2023
 	// Delete the machine resource if it no longer exists in juju.
 
-	// Only the name can be updated it is terraform data and
-	// not saved in juju.
-	if plan.Name.Equal(state.Name) {
-		return
-	}
+	// Only the name, destroy options and timeouts can be updated,
+	// they are terraform data and not saved in juju. So are the
+	// constraints equivalent to those of the machine.
+	state.Constraints = plan.Constraints
+	state.Wait = plan.Wait
+	state.ForceDestroy = plan.ForceDestroy
+	state.DestroyMaxWait = plan.DestroyMaxWait
+	state.Timeouts = plan.Timeouts
 	state.Name = plan.Name
-	id := newMachineID(plan.ModelName.ValueString(), plan.MachineID.ValueString(), plan.Name.ValueString())
+	id := newMachineID(state.ModelName.ValueString(), state.MachineID.ValueString(), plan.Name.ValueString())
 	state.ID = types.StringValue(id)
 
-	r.trace(fmt.Sprintf("update machine resource %q", plan.MachineID.ValueString()))
+	r.trace(fmt.Sprintf("update machine resource %q", state.MachineID.ValueString()))
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -399,6 +565,9 @@ func (r *machineResource) Update(ctx context.Context, req resource.UpdateRequest
 // Juju refers to deletion as "destroy" so we call the Destroy function of our client here rather than delete
 // This function remains named Delete for parity across the provider and to stick within terraform naming conventions
 func (r *machineResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_machine", "Delete", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if r.client == nil {
 		resp.Diagnostics.AddError(
@@ -414,17 +583,23 @@ func (r *machineResource) Delete(ctx context.Context, req resource.DeleteRequest
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := withOperationTimeout(ctx, data.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	modelName, machineID, _ := modelMachineIDAndName(data.ID.ValueString(), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if err := r.client.Machines.DestroyMachine(&juju.DestroyMachineInput{
-		ModelName: modelName,
-		ID:        machineID,
+	if err := r.client.Machines.DestroyMachine(ctx, &juju.DestroyMachineInput{
+		ModelName:      modelName,
+		ID:             machineID,
+		DestroyOptions: destroyOptions(data.ForceDestroy, data.DestroyMaxWait),
 	}); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete machine, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to delete machine")
 	}
 	r.trace(fmt.Sprintf("delete machine resource %q", machineID))
 }
@@ -433,10 +608,16 @@ func (r *machineResource) Delete(ctx context.Context, req resource.DeleteRequest
 // resource instance. This method must return enough state so the Read
 // method can properly refresh the full resource.
 //
-// If setting an attribute with the import identifier, it is recommended
-// to use the ImportStatePassthroughID() call in this method.
+// The import identifier is validated against the format of the ID of
+// the resource, and set as its id attribute.
 func (r *machineResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	machineIDFormat.importState(ctx, req, resp)
+}
+
+// IdentitySchema returns the schema of the identity of the resource,
+// made of the parts of its ID.
+func (r *machineResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = machineIDFormat.identitySchema()
 }
 
 func (r *machineResource) trace(msg string, additionalFields ...map[string]interface{}) {
@@ -450,16 +631,73 @@ func (r *machineResource) trace(msg string, additionalFields ...map[string]inter
 	tflog.SubsystemTrace(r.subCtx, LogResourceMachine, msg, additionalFields...)
 }
 
+// machinePlacement returns the placement of the machine, the container
+// type and the parent machine if set, e.g. lxd:3.
+func machinePlacement(data machineResourceModel) string {
+	if data.ContainerType.ValueString() == "" {
+		return data.Placement.ValueString()
+	}
+	if data.ParentMachine.ValueString() == "" {
+		return data.ContainerType.ValueString()
+	}
+	return data.ContainerType.ValueString() + ":" + data.ParentMachine.ValueString()
+}
+
+// machineParentAndContainerType returns the id of the parent machine and
+// the type of the container of the machine with the given id, e.g. 3
+// and lxd for 3/lxd/0, empty if it is not a container.
+func machineParentAndContainerType(machineID string) (types.String, types.String) {
+	if !names.IsContainerMachine(machineID) {
+		return types.StringValue(""), types.StringValue("")
+	}
+	tag := names.NewMachineTag(machineID)
+	return types.StringValue(tag.Parent().Id()), types.StringValue(tag.ContainerType())
+}
+
+// baseFromSeries returns the base of the series, in the form read back
+// into base, e.g. ubuntu@22.04 for jammy.
+func baseFromSeries(series string) (string, error) {
+	b, err := corebase.GetBaseFromSeries(series)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s@%s", b.OS, b.Channel.Track), nil
+}
+
+// seriesFromBase returns the series of the base, e.g. jammy for
+// ubuntu@22.04.
+func seriesFromBase(base string) (string, error) {
+	b, err := corebase.ParseBaseFromString(base)
+	if err != nil {
+		return "", err
+	}
+	return corebase.GetSeriesFromBase(b)
+}
+
+// setMachineAddresses sets the instance ID, hostname and IP addresses
+// of the machine read.
+func setMachineAddresses(ctx context.Context, data *machineResourceModel, response juju.ReadMachineResponse) diag.Diagnostics {
+	addresses := response.IPAddresses
+	if addresses == nil {
+		addresses = []string{}
+	}
+	var diags diag.Diagnostics
+	data.IPAddresses, diags = types.ListValueFrom(ctx, types.StringType, addresses)
+	data.InstanceID = types.StringValue(response.InstanceID)
+	data.Hostname = types.StringValue(response.Hostname)
+	return diags
+}
+
+var machineIDFormat = idFormat{parts: []string{"model_name", "machine_id"}, optional: []string{"machine_name"}}
+
 func newMachineID(model, machine_id, machine_name string) string {
-	return fmt.Sprintf("%s:%s:%s", model, machine_id, machine_name)
+	return machineIDFormat.format(model, machine_id, machine_name)
 }
 
-// Machines can be imported using the format: `model_name:machine_id:machine_name`.
 func modelMachineIDAndName(value string, diags *diag.Diagnostics) (string, string, string) {
-	id := strings.Split(value, ":")
-	//If importing with an incorrect ID we need to catch and provide a user-friendly error
-	if len(id) != 3 {
-		diags.AddError("Malformed ID", fmt.Sprintf("unable to parse model name, machine id, and machine name from provided ID: %q", value))
+	id, parseDiags := machineIDFormat.parse(value)
+	diags.Append(parseDiags...)
+	if parseDiags.HasError() {
 		return "", "", ""
 	}
 	return id[0], id[1], id[2]
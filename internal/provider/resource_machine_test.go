@@ -4,14 +4,83 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
 	internaltesting "github.com/juju/terraform-provider-juju/internal/testing"
 )
 
+func TestSetMachineAddresses(t *testing.T) {
+	ctx := context.Background()
+	var data machineResourceModel
+	diags := setMachineAddresses(ctx, &data, juju.ReadMachineResponse{
+		InstanceID:  "juju-3ea3f6-0",
+		Hostname:    "juju-3ea3f6-0",
+		IPAddresses: []string{"10.150.70.12", "fd42:9e1b::1"},
+	})
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, "juju-3ea3f6-0", data.InstanceID.ValueString())
+	assert.Equal(t, "juju-3ea3f6-0", data.Hostname.ValueString())
+	var addresses []string
+	require.False(t, data.IPAddresses.ElementsAs(ctx, &addresses, false).HasError())
+	assert.Equal(t, []string{"10.150.70.12", "fd42:9e1b::1"}, addresses)
+
+	// Before the machine is started, its addresses are empty.
+	diags = setMachineAddresses(ctx, &data, juju.ReadMachineResponse{})
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, "", data.InstanceID.ValueString())
+	assert.Equal(t, 0, len(data.IPAddresses.Elements()))
+}
+
+func TestMachinePlacement(t *testing.T) {
+	assert.Equal(t, "zone=us-east-1a", machinePlacement(machineResourceModel{
+		Placement: types.StringValue("zone=us-east-1a"),
+	}))
+	assert.Equal(t, "lxd:3", machinePlacement(machineResourceModel{
+		ParentMachine: types.StringValue("3"),
+		ContainerType: types.StringValue("lxd"),
+	}))
+	// Without parent, the container is added to a new machine.
+	assert.Equal(t, "kvm", machinePlacement(machineResourceModel{
+		ContainerType: types.StringValue("kvm"),
+	}))
+}
+
+func TestMachineParentAndContainerType(t *testing.T) {
+	parent, containerType := machineParentAndContainerType("3/lxd/0")
+	assert.Equal(t, "3", parent.ValueString())
+	assert.Equal(t, "lxd", containerType.ValueString())
+
+	parent, containerType = machineParentAndContainerType("3/lxd/0/kvm/1")
+	assert.Equal(t, "3/lxd/0", parent.ValueString())
+	assert.Equal(t, "kvm", containerType.ValueString())
+
+	parent, containerType = machineParentAndContainerType("3")
+	assert.Equal(t, "", parent.ValueString())
+	assert.Equal(t, "", containerType.ValueString())
+}
+
+func TestMachineSeriesRenamed(t *testing.T) {
+	state := map[string]interface{}{"series": "jammy", "base": nil}
+	require.NoError(t, upgradeRenamedAttributes(machineSeriesRenamed)(state))
+	assert.Equal(t, "ubuntu@22.04", state["base"])
+
+	state = map[string]interface{}{"series": "unknown", "base": nil}
+	assert.Error(t, upgradeRenamedAttributes(machineSeriesRenamed)(state))
+
+	series, err := seriesFromBase("ubuntu@22.04")
+	require.NoError(t, err)
+	assert.Equal(t, "jammy", series)
+}
+
 func TestAcc_ResourceMachine(t *testing.T) {
 	if testingCloud != LXDCloudTesting {
 		t.Skip(t.Name() + " only runs with LXD")
@@ -81,6 +150,8 @@ func TestAcc_ResourceMachine_WithPlacement(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "model", modelName),
 					resource.TestCheckResourceAttr(resourceName, "machine_id", "0/lxd/0"),
 					resource.TestCheckResourceAttr(resourceName, "placement", "lxd:0"),
+					resource.TestCheckResourceAttr(resourceName, "parent_machine", "0"),
+					resource.TestCheckResourceAttr(resourceName, "container_type", "lxd"),
 				),
 			},
 			{
@@ -92,6 +163,138 @@ func TestAcc_ResourceMachine_WithPlacement(t *testing.T) {
 	})
 }
 
+func TestAcc_ResourceMachine_Constraints(t *testing.T) {
+	if testingCloud != LXDCloudTesting {
+		t.Skip(t.Name() + " only runs with LXD")
+	}
+	modelName := acctest.RandomWithPrefix("tf-test-machine")
+	resourceName := "juju_machine.this"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceMachineConstraints(modelName, "mem=2G cores=1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "constraints", "mem=2G cores=1"),
+					resource.TestCheckResourceAttr(resourceName, "machine_id", "0"),
+				),
+			},
+			{
+				// Equivalent constraints do not replace the machine.
+				Config: testAccResourceMachineConstraints(modelName, "cores=1 mem=2048M"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "constraints", "cores=1 mem=2048M"),
+					resource.TestCheckResourceAttr(resourceName, "machine_id", "0"),
+				),
+			},
+			{
+				Config: testAccResourceMachineConstraints(modelName, "cores=2 mem=2048M"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "constraints", "cores=2 mem=2048M"),
+					resource.TestCheckResourceAttr(resourceName, "machine_id", "1"),
+				),
+			},
+			{
+				ImportStateVerify: true,
+				ImportState:       true,
+				ResourceName:      resourceName,
+			},
+		},
+	})
+}
+
+func testAccResourceMachineConstraints(modelName, constraints string) string {
+	return fmt.Sprintf(`
+resource "juju_model" "this" {
+	name = %q
+}
+
+resource "juju_machine" "this" {
+	model       = juju_model.this.name
+	constraints = %q
+}
+`, modelName, constraints)
+}
+
+func TestAcc_ResourceMachine_Wait(t *testing.T) {
+	if testingCloud != LXDCloudTesting {
+		t.Skip(t.Name() + " only runs with LXD")
+	}
+	modelName := acctest.RandomWithPrefix("tf-test-machine")
+	resourceName := "juju_machine.this"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "juju_model" "this" {
+	name = %q
+}
+
+resource "juju_machine" "this" {
+	model = juju_model.this.name
+	wait  = true
+}
+`, modelName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "instance_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "hostname"),
+					resource.TestCheckResourceAttrSet(resourceName, "ip_addresses.0"),
+				),
+			},
+			{
+				ImportStateVerify:       true,
+				ImportState:             true,
+				ImportStateVerifyIgnore: []string{"wait"},
+				ResourceName:            resourceName,
+			},
+		},
+	})
+}
+
+func TestAcc_ResourceMachine_Container(t *testing.T) {
+	if testingCloud != LXDCloudTesting {
+		t.Skip(t.Name() + " only runs with LXD")
+	}
+	modelName := acctest.RandomWithPrefix("tf-test-machine")
+	resourceName := "juju_machine.container"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "juju_model" "this" {
+	name = %q
+}
+
+resource "juju_machine" "host" {
+	model = juju_model.this.name
+}
+
+resource "juju_machine" "container" {
+	model          = juju_model.this.name
+	parent_machine = juju_machine.host.machine_id
+	container_type = "lxd"
+}
+`, modelName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "machine_id", "0/lxd/0"),
+					resource.TestCheckResourceAttr(resourceName, "parent_machine", "0"),
+					resource.TestCheckResourceAttr(resourceName, "container_type", "lxd"),
+				),
+			},
+			{
+				ImportStateVerify: true,
+				ImportState:       true,
+				ResourceName:      resourceName,
+			},
+		},
+	})
+}
+
 func testAccResourceMachineBasicMinimal(modelName string) string {
 	return fmt.Sprintf(`
 resource "juju_model" "this" {
@@ -6,24 +6,30 @@ package provider
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/juju/errors"
 	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/rpc/params"
 	"github.com/juju/names/v4"
 	"github.com/juju/utils/v3"
 
@@ -33,6 +39,9 @@ import (
 var _ resource.Resource = &modelResource{}
 var _ resource.ResourceWithConfigure = &modelResource{}
 var _ resource.ResourceWithImportState = &modelResource{}
+var _ resource.ResourceWithUpgradeState = &modelResource{}
+var _ resource.ResourceWithValidateConfig = &modelResource{}
+var _ resource.ResourceWithIdentity = &modelResource{}
 
 func NewModelResource() resource.Resource {
 	return &modelResource{}
@@ -46,24 +55,44 @@ type modelResource struct {
 }
 
 type modelResourceModel struct {
-	Name        types.String `tfsdk:"name"`
-	Cloud       types.List   `tfsdk:"cloud"`
-	Config      types.Map    `tfsdk:"config"`
-	Constraints types.String `tfsdk:"constraints"`
-	Credential  types.String `tfsdk:"credential"`
-	Type        types.String `tfsdk:"type"`
+	Name           types.String   `tfsdk:"name"`
+	Cloud          types.List     `tfsdk:"cloud"`
+	Config         types.Map      `tfsdk:"config"`
+	Constraints    types.String   `tfsdk:"constraints"`
+	Credential     types.String   `tfsdk:"credential"`
+	Type           types.String   `tfsdk:"type"`
+	ForceDestroy   types.Bool     `tfsdk:"force_destroy"`
+	DestroyMaxWait types.String   `tfsdk:"destroy_max_wait"`
+	DestroyOptions types.Object   `tfsdk:"destroy_options"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+	RetainOnDelete types.Bool     `tfsdk:"retain_on_delete"`
+	SLA            types.String   `tfsdk:"sla"`
+	SLABudget      types.String   `tfsdk:"sla_budget"`
+	UUID           types.String   `tfsdk:"uuid"`
+	ControllerUUID types.String   `tfsdk:"controller_uuid"`
+	DefaultSpace   types.String   `tfsdk:"default_space"`
+	Status         types.Object   `tfsdk:"status"`
 	// ID required by the testing framework
 	ID types.String `tfsdk:"id"`
 }
 
+// nestedModelDestroyOptions represents the destroy_options block of the
+// model resource schema.
+type nestedModelDestroyOptions struct {
+	DestroyStorage types.Bool   `tfsdk:"destroy_storage"`
+	Force          types.Bool   `tfsdk:"force"`
+	Timeout        types.String `tfsdk:"timeout"`
+}
+
 // nestedCloud represents an element in a Cloud list of a model resource
 type nestedCloud struct {
 	Name   types.String `tfsdk:"name"`
 	Region types.String `tfsdk:"region"`
 }
 
-func (r *modelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *modelResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     modelSchemaVersion,
 		Description: "A resource that represent a Juju Model.",
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
@@ -82,16 +111,19 @@ func (r *modelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"constraints": schema.StringAttribute{
-				Description: "Constraints imposed to this model",
-				Optional:    true,
+				Description: "Constraints imposed to this model, e.g. `arch=amd64 cores=2 mem=4G`, like " +
+					"`juju set-model-constraints`. They are the default constraints of the machines added " +
+					"in the model. Changes made outside of Terraform are detected when they are set.",
+				Optional: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"credential": schema.StringAttribute{
-				Description: "Credential used to add the model",
-				Optional:    true,
-				Computed:    true,
+				Description: "Credential used to add the model. Changing it changes the credential of the " +
+					"model, like `juju set-credential`, without replacing the model.",
+				Optional: true,
+				Computed: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
@@ -103,6 +135,87 @@ func (r *modelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"force_destroy":    forceDestroyAttribute("model", "All the applications, machines and storage of the model are removed with it. "),
+			"destroy_max_wait": destroyMaxWaitAttribute("model"),
+			"retain_on_delete": schema.BoolAttribute{
+				Description: "Remove the model from the Terraform state without destroying it in Juju when the " +
+					"resource is deleted, e.g. to hand the model over to another team. The resources of the " +
+					"model managed by Terraform, e.g. applications, are still destroyed. Defaults to false.",
+				Optional: true,
+			},
+			"sla": schema.StringAttribute{
+				Description: "The support level of the model, like `juju sla`. The levels other than " +
+					"`unsupported` are authorized with the commercial support service of the controller, " +
+					"at its `metering-url`. Defaults to `unsupported`.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(juju.SLAUnsupported, juju.SLAEssential, juju.SLAStandard, juju.SLAAdvanced),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"sla_budget": schema.StringAttribute{
+				Description: "The maximum spend of the support of the model, authorized with its support " +
+					"level, e.g. `100`. Changing it authorizes the support level again.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("sla")),
+				},
+			},
+			"default_space": schema.StringAttribute{
+				Description: "The space the applications and machines of the model are bound to by default, " +
+					"the `default-space` key of its config, which must not be set in `config` too. The space " +
+					"must be known by the model, it is validated when set.",
+				Optional: true,
+			},
+			"status": schema.SingleNestedAttribute{
+				Description: "The status of the model, as shown by `juju show-model` and `juju status`, " +
+					"refreshed when the model is read. It can be asserted in checks, e.g. that the model " +
+					"is alive and available.",
+				Computed: true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"life": schema.StringAttribute{
+						Description: "The life of the model, e.g. alive or dying.",
+						Computed:    true,
+					},
+					"status": schema.StringAttribute{
+						Description: "The status of the model, e.g. available or busy.",
+						Computed:    true,
+					},
+					"machine_count": schema.Int64Attribute{
+						Description: "The number of machines in the model.",
+						Computed:    true,
+					},
+					"application_count": schema.Int64Attribute{
+						Description: "The number of applications in the model.",
+						Computed:    true,
+					},
+					"last_updated": schema.StringAttribute{
+						Description: "When the status of the model last changed, in RFC 3339 format.",
+						Computed:    true,
+					},
+				},
+			},
+			"uuid": schema.StringAttribute{
+				Description: "The UUID of the model, e.g. to reference the model in the resources " +
+					"requiring its UUID.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"controller_uuid": schema.StringAttribute{
+				Description: "The UUID of the controller of the model.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"id": schema.StringAttribute{
 				Computed: true,
 				PlanModifiers: []planmodifier.String{
@@ -111,8 +224,10 @@ func (r *modelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			},
 		},
 		Blocks: map[string]schema.Block{
+			TimeoutsKey: timeoutsBlock(ctx, "model"),
 			"cloud": schema.ListNestedBlock{
-				Description: "JuJu Cloud where the model will operate",
+				Description: "JuJu Cloud where the model will operate. The cloud and its region are validated " +
+					"against the clouds known by the controller on plan.",
 				PlanModifiers: []planmodifier.List{
 					listplanmodifier.RequiresReplace(),
 				},
@@ -136,10 +251,40 @@ func (r *modelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					},
 				},
 			},
+			DestroyOptionsKey: schema.SingleNestedBlock{
+				Description: "How the model is removed when destroyed, like the options of `juju destroy-model`, " +
+					"so that models with persistent storage or stuck units can be destroyed. Changing them " +
+					"only updates the state.",
+				Attributes: map[string]schema.Attribute{
+					"destroy_storage": schema.BoolAttribute{
+						Description: "Destroy the storage of the model. When false, the storage is released " +
+							"from Juju and kept in the cloud, like `--release-storage`. Defaults to true.",
+						Optional: true,
+					},
+					"force": schema.BoolAttribute{
+						Description: "Force the removal of the model, like `force_destroy`. Defaults to false.",
+						Optional:    true,
+					},
+					"timeout": schema.StringAttribute{
+						Description: "How long the destroy of the model may take before it fails, e.g. `1h`. " +
+							"Defaults to the delete timeout of the resource, else 30m.",
+						Optional: true,
+						Validators: []validator.String{
+							StringIsDurationValidator{},
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
+// UpgradeState returns the state upgraders from the prior schema
+// versions of the resource, keyed by version.
+func (r *modelResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
 func (r *modelResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -155,18 +300,112 @@ func (r *modelResource) Configure(ctx context.Context, req resource.ConfigureReq
 		return
 	}
 	r.client = client
-	r.subCtx = tflog.NewSubsystem(ctx, LogResourceModel)
+	r.subCtx = r.client.NewLogSubsystem(ctx, LogResourceModel)
+}
+
+// ValidateConfig validates the cloud block against the clouds known by
+// the controller, so that an unknown cloud or region fails the plan.
+// The provider is only configured on plan, the cloud block is not
+// validated by terraform validate.
+func (r *modelResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config map[string]types.String
+	var defaultSpace types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("config"), &config)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("default_space"), &defaultSpace)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if _, ok := config[modelDefaultSpaceKey]; ok && !defaultSpace.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("config"), "Conflicting Config",
+			fmt.Sprintf("The %q config key is set by default_space, it cannot be set in config too.", modelDefaultSpaceKey))
+	}
+
+	if r.client == nil {
+		return
+	}
+
+	var cloud types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("cloud"), &cloud)...)
+	if resp.Diagnostics.HasError() || cloud.IsNull() || cloud.IsUnknown() {
+		return
+	}
+	var clouds []nestedCloud
+	resp.Diagnostics.Append(cloud.ElementsAs(ctx, &clouds, false)...)
+	if resp.Diagnostics.HasError() || len(clouds) == 0 {
+		return
+	}
+	if clouds[0].Name.IsUnknown() || clouds[0].Region.IsUnknown() {
+		return
+	}
+
+	cloudName := clouds[0].Name.ValueString()
+	err := r.client.Credentials.ValidateCloudRegion(ctx, cloudName, clouds[0].Region.ValueString())
+	switch {
+	case errors.Is(err, errors.NotFound):
+		resp.Diagnostics.AddAttributeError(path.Root("cloud").AtListIndex(0).AtName("name"),
+			"Invalid Cloud", fmt.Sprintf("Unable to add the model to %s: %s", cloudName, err))
+	case errors.Is(err, errors.NotValid):
+		resp.Diagnostics.AddAttributeError(path.Root("cloud").AtListIndex(0).AtName("region"),
+			"Invalid Cloud Region", fmt.Sprintf("Unable to add the model to %s: %s", cloudName, err))
+	case err != nil:
+		// The cloud is validated again by the controller on apply.
+		r.trace(fmt.Sprintf("unable to validate cloud %q: %s", cloudName, err))
+	}
 }
 
 func (r *modelResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_model"
 }
 
+// ImportState imports the model with its name. When imported with the
+// identity of the resource, the name of the model is read from its UUID
+// first, the following read telling the import apart by its ID not
+// being a UUID.
 func (r *modelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if req.ID == "" && req.Identity != nil {
+		uuid, diags := modelIdentityFormat.importID(ctx, req)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		// Prevent panic if the provider has not been configured.
+		if r.client == nil {
+			addClientNotConfiguredError(&resp.Diagnostics, "model", "import")
+			return
+		}
+		response, err := r.client.Models.ReadModel(ctx, uuid)
+		if err != nil {
+			addClientError(&resp.Diagnostics, err, "Unable to read model for import")
+			return
+		}
+		owner, err := names.ParseUserTag(response.ModelInfo.OwnerTag)
+		if err != nil {
+			addClientError(&resp.Diagnostics, err, "Unable to parse owner of model for import")
+			return
+		}
+		req.ID = owner.Id() + "/" + response.ModelInfo.Name
+	}
+	modelIDFormat.importState(ctx, req, resp)
+}
+
+// IdentitySchema returns the schema of the identity of the resource,
+// the UUID of the model, which does not change when it is renamed.
+func (r *modelResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = modelIdentityFormat.identitySchema()
 }
 
+// modelIDFormat is the format of the ID with which models are
+// imported, the ID of models being their UUID.
+var modelIDFormat = idFormat{parts: []string{"model_name"}}
+
+// modelIdentityFormat is the format of the ID of models, their UUID,
+// which is their identity.
+var modelIdentityFormat = idFormat{parts: []string{"model_uuid"}}
+
 func (r *modelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_model", "Create", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if r.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "model", "create")
@@ -180,6 +419,11 @@ func (r *modelResource) Create(ctx context.Context, req resource.CreateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := withOperationTimeout(ctx, plan.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Acquire modelName, clouds, config, credential & constraints from the model plan
 	modelName := plan.Name.ValueString()
@@ -203,7 +447,7 @@ func (r *modelResource) Create(ctx context.Context, req resource.CreateRequest,
 		// resource_model can avoid importing juju/core/constraints
 		parsedConstraints, err = constraints.Parse(readConstraints)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse constraints, got error: %s", err))
+			addClientError(&resp.Diagnostics, err, "Unable to parse constraints")
 			return
 		}
 	}
@@ -215,20 +459,45 @@ func (r *modelResource) Create(ctx context.Context, req resource.CreateRequest,
 		cloudRegionInput = clouds[0].Region.ValueString()
 	}
 
-	response, err := r.client.Models.CreateModel(juju.CreateModelInput{
+	response, err := r.client.Models.CreateModel(ctx, juju.CreateModelInput{
 		Name:        modelName,
 		CloudName:   cloudNameInput,
 		CloudRegion: cloudRegionInput,
 		Config:      config,
 		Constraints: parsedConstraints,
 		Credential:  credential,
+		SLA:         modelSLA(plan),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create model, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to create model")
+		if response.UUID != "" {
+			// The model is created, save it so that it is replaced
+			// rather than created again.
+			plan.Type = types.StringValue(response.Type)
+			plan.ID = types.StringValue(response.UUID)
+			plan.UUID = types.StringValue(response.UUID)
+			plan.ControllerUUID = types.StringValue(response.ControllerUUID)
+			resp.Diagnostics.Append(setPartialState(ctx, &resp.State, &plan)...)
+		}
 		return
 	}
 	r.trace(fmt.Sprintf("model created : %q", modelName))
 
+	// The spaces of the model are only known once it is created.
+	if space := plan.DefaultSpace.ValueString(); space != "" {
+		err = r.setDefaultSpace(ctx, response.UUID, space)
+		if err != nil {
+			addClientError(&resp.Diagnostics, err, "Unable to set default space of model")
+			plan.DefaultSpace = types.StringNull()
+			plan.Type = types.StringValue(response.Type)
+			plan.ID = types.StringValue(response.UUID)
+			plan.UUID = types.StringValue(response.UUID)
+			plan.ControllerUUID = types.StringValue(response.ControllerUUID)
+			resp.Diagnostics.Append(setPartialState(ctx, &resp.State, &plan)...)
+			return
+		}
+	}
+
 	if !plan.Cloud.IsNull() {
 		// Set the cloud value if required
 		newCloud := []nestedCloud{{
@@ -247,14 +516,36 @@ func (r *modelResource) Create(ctx context.Context, req resource.CreateRequest,
 	plan.Credential = types.StringValue(response.CloudCredentialName)
 	plan.Type = types.StringValue(response.Type)
 	plan.ID = types.StringValue(response.UUID)
+	plan.UUID = types.StringValue(response.UUID)
+	plan.ControllerUUID = types.StringValue(response.ControllerUUID)
+
+	readResponse, err := r.client.Models.ReadModel(ctx, response.UUID)
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to read status of model")
+		resp.Diagnostics.Append(setPartialState(ctx, &resp.State, &plan)...)
+		return
+	}
+	var dErr diag.Diagnostics
+	plan.Status, dErr = modelStatusValue(ctx, readResponse)
+	resp.Diagnostics.Append(dErr...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.SLA.IsUnknown() {
+		plan.SLA = types.StringValue(juju.SLAUnsupported)
+	}
 
 	r.trace(fmt.Sprintf("model resource created: %q", modelName))
 
 	// Write the state plan into the Response.State
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(modelIdentityFormat.setIdentity(ctx, plan.ID, resp.Identity)...)
 }
 
 func (r *modelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_model", "Read", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if r.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "model", "read")
@@ -269,31 +560,24 @@ func (r *modelResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	// Find the model name. If the Id is a UUID, this is
-	// not an Import followed by a Read. If the Id string
-	// is not a UUID, find the model name in the Id, rather
-	// than Name as we're doing a Read after Import. Either
-	// way, we need the model name.
-	var modelName string
-	var imported bool
-	if utils.IsValidUUIDString(state.ID.ValueString()) {
-		modelName = state.Name.ValueString()
-	} else {
-		imported = true
-		modelName = state.ID.ValueString()
-	}
-
-	response, err := r.client.Models.ReadModel(modelName)
+	// If the Id is a UUID, this is not an Import followed by
+	// a Read, the model is read by UUID so that it is found
+	// if renamed outside of Terraform. If the Id string is
+	// not a UUID, it is the model name as we're doing a Read
+	// after Import.
+	imported := !utils.IsValidUUIDString(state.ID.ValueString())
+	response, err := r.client.Models.ReadModel(ctx, state.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.Append(handleModelNotFoundError(ctx, err, &resp.State)...)
+		handleReadError(ctx, r.client, err, &resp.State, &resp.Diagnostics, "Unable to read model")
 		return
 	}
+	modelName := response.ModelInfo.Name
 	r.trace(fmt.Sprintf("found model: %v", modelName))
 
 	// Acquire cloud, credential, and config
 	tag, err := names.ParseCloudCredentialTag(response.ModelInfo.CloudCredentialTag)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse cloud credential tag for model, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to parse cloud credential tag for model")
 		return
 	}
 	credential := tag.Name()
@@ -315,8 +599,9 @@ func (r *modelResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		state.Cloud = newStateCloud
 	}
 
-	// Constraints
-	if (imported && response.ModelConstraints.String() != "") || !state.Constraints.IsNull() {
+	// Constraints, the value written is kept if it is equivalent to the
+	// constraints read, e.g. mem=4G for mem=4096M.
+	if (imported && response.ModelConstraints.String() != "") || (!state.Constraints.IsNull() && !sameConstraints(state.Constraints, response.ModelConstraints, "")) {
 		state.Constraints = types.StringValue(response.ModelConstraints.String())
 	}
 
@@ -362,18 +647,58 @@ func (r *modelResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		state.Config = newStateConfig
 	}
 
+	// On import, the config keys set on the model, rather than
+	// defaulted, are imported so that the config adopting the model
+	// plans no changes.
+	if imported {
+		config, err := r.client.Models.ReadModelConfig(ctx, juju.ReadModelConfigInput{ModelName: response.ModelInfo.UUID})
+		if err != nil {
+			addClientError(&resp.Diagnostics, err, "Unable to read model config")
+			return
+		}
+		importedConfig := importedModelConfig(config.Config)
+		state.Config = types.MapNull(types.StringType)
+		if len(importedConfig) > 0 {
+			newStateConfig, errDiag := types.MapValueFrom(ctx, types.StringType, importedConfig)
+			resp.Diagnostics.Append(errDiag...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			state.Config = newStateConfig
+		}
+	}
+
+	// Default space
+	if defaultSpace, _ := response.ModelConfig[modelDefaultSpaceKey].(string); (imported && defaultSpace != "") ||
+		!state.DefaultSpace.IsNull() {
+		state.DefaultSpace = types.StringValue(defaultSpace)
+	}
+
 	// Name, Type, Credential, and Id.
 	state.Name = types.StringValue(modelName)
 	state.Type = types.StringValue(response.ModelInfo.Type)
 	state.Credential = types.StringValue(credential)
 	state.ID = types.StringValue(response.ModelInfo.UUID)
+	state.UUID = types.StringValue(response.ModelInfo.UUID)
+	state.ControllerUUID = types.StringValue(response.ModelInfo.ControllerUUID)
+	state.SLA = types.StringValue(modelSLALevel(response.ModelInfo.SLA))
+	var dErr diag.Diagnostics
+	state.Status, dErr = modelStatusValue(ctx, response)
+	resp.Diagnostics.Append(dErr...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	r.trace(fmt.Sprintf("Read model resource for: %v", modelName))
 	// Set the state onto the Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(modelIdentityFormat.setIdentity(ctx, state.ID, resp.Identity)...)
 }
 
 func (r *modelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_model", "Update", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if r.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "model", "update")
@@ -387,6 +712,11 @@ func (r *modelResource) Update(ctx context.Context, req resource.UpdateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := withOperationTimeout(ctx, plan.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	var err error
 	noChange := true
@@ -416,17 +746,35 @@ func (r *modelResource) Update(ctx context.Context, req resource.UpdateRequest,
 		configMap = newConfigMap
 	}
 
+	// Check the default space, validated before it is set.
+	if !plan.DefaultSpace.Equal(state.DefaultSpace) {
+		noChange = false
+		if space := plan.DefaultSpace.ValueString(); space != "" {
+			err = r.checkSpace(ctx, state.ID.ValueString(), space)
+			if err != nil {
+				addClientError(&resp.Diagnostics, err, "Unable to set default space of model")
+				return
+			}
+			if configMap == nil {
+				configMap = map[string]string{}
+			}
+			configMap[modelDefaultSpaceKey] = space
+		} else {
+			unsetConfigKeys = append(unsetConfigKeys, modelDefaultSpaceKey)
+		}
+	}
+
 	// Check the constraints
 	newConstraints, err := constraints.Parse(state.Constraints.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse constraints for model, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to parse constraints for model")
 		return
 	}
 	if !plan.Constraints.Equal(state.Constraints) {
 		noChange = false
 		newConstraints, err = constraints.Parse(plan.Constraints.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse constraints for model, got error: %s", err))
+			addClientError(&resp.Diagnostics, err, "Unable to parse constraints for model")
 			return
 		}
 	}
@@ -438,7 +786,24 @@ func (r *modelResource) Update(ctx context.Context, req resource.UpdateRequest,
 		credentialUpdate = plan.Credential.ValueString()
 	}
 
+	// Check the support level, authorized again when its budget changes
+	var slaUpdate *juju.ModelSLA
+	if !plan.SLA.Equal(state.SLA) || !plan.SLABudget.Equal(state.SLABudget) {
+		slaUpdate = modelSLA(plan)
+		if slaUpdate != nil {
+			noChange = false
+		}
+	}
+
 	if noChange {
+		// Only the destroy options changed, they are not saved in juju.
+		state.ForceDestroy = plan.ForceDestroy
+		state.DestroyMaxWait = plan.DestroyMaxWait
+		state.DestroyOptions = plan.DestroyOptions
+		state.Timeouts = plan.Timeouts
+		state.RetainOnDelete = plan.RetainOnDelete
+		state.SLABudget = plan.SLABudget
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 		return
 	}
 
@@ -454,16 +819,17 @@ func (r *modelResource) Update(ctx context.Context, req resource.UpdateRequest,
 		cloudNameInput = clouds[0].Name.ValueString()
 	}
 
-	err = r.client.Models.UpdateModel(juju.UpdateModelInput{
-		Name:        plan.Name.ValueString(),
+	err = r.client.Models.UpdateModel(ctx, juju.UpdateModelInput{
+		Name:        state.ID.ValueString(),
 		CloudName:   cloudNameInput,
 		Config:      configMap,
 		Unset:       unsetConfigKeys,
 		Constraints: &newConstraints,
 		Credential:  credentialUpdate,
+		SLA:         slaUpdate,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update model, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to update model")
 		return
 	}
 
@@ -472,6 +838,9 @@ func (r *modelResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *modelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_model", "Delete", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if r.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "model", "delete")
@@ -485,27 +854,149 @@ func (r *modelResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := withOperationTimeout(ctx, state.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	err := r.client.Models.DestroyModel(juju.DestroyModelInput{
-		UUID: state.ID.ValueString(),
-	})
+	if state.RetainOnDelete.ValueBool() {
+		r.trace(fmt.Sprintf("model retained, removed from the state only: %q", state.Name.ValueString()))
+		return
+	}
+
+	input, dErr := modelDestroyInput(ctx, state)
+	resp.Diagnostics.Append(dErr...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.Models.DestroyModel(ctx, input)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete model, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to delete model")
 		return
 	}
 	r.trace(fmt.Sprintf("model deleted : %q", state.Name.ValueString()))
 }
 
-func handleModelNotFoundError(ctx context.Context, err error, st *tfsdk.State) diag.Diagnostics {
-	if errors.As(err, &juju.ModelNotFoundError) {
-		// Model manually removed
-		st.RemoveResource(ctx)
-		return diag.Diagnostics{}
+// modelDestroyInput returns the input of the destroy of the model from
+// its force_destroy and destroy_max_wait attributes and its
+// destroy_options block.
+func modelDestroyInput(ctx context.Context, model modelResourceModel) (juju.DestroyModelInput, diag.Diagnostics) {
+	input := juju.DestroyModelInput{
+		UUID:           model.ID.ValueString(),
+		DestroyOptions: destroyOptions(model.ForceDestroy, model.DestroyMaxWait),
+	}
+	if model.DestroyOptions.IsNull() || model.DestroyOptions.IsUnknown() {
+		return input, nil
+	}
+	var options nestedModelDestroyOptions
+	diags := model.DestroyOptions.As(ctx, &options, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return input, diags
+	}
+	input.Force = input.Force || options.Force.ValueBool()
+	input.KeepStorage = !options.DestroyStorage.IsNull() && !options.DestroyStorage.ValueBool()
+	// The timeout is validated with the configuration.
+	if timeout, err := time.ParseDuration(options.Timeout.ValueString()); err == nil {
+		input.Timeout = timeout
+	}
+	return input, diags
+}
+
+// modelStatusModel represents the status object of the model resource
+// schema.
+type modelStatusModel struct {
+	Life             types.String `tfsdk:"life"`
+	Status           types.String `tfsdk:"status"`
+	MachineCount     types.Int64  `tfsdk:"machine_count"`
+	ApplicationCount types.Int64  `tfsdk:"application_count"`
+	LastUpdated      types.String `tfsdk:"last_updated"`
+}
+
+var modelStatusAttrTypes = map[string]attr.Type{
+	"life":              types.StringType,
+	"status":            types.StringType,
+	"machine_count":     types.Int64Type,
+	"application_count": types.Int64Type,
+	"last_updated":      types.StringType,
+}
+
+// modelStatusValue returns the status object of the model read.
+func modelStatusValue(ctx context.Context, response *juju.ReadModelResponse) (types.Object, diag.Diagnostics) {
+	lastUpdated := ""
+	if since := response.ModelInfo.Status.Since; since != nil {
+		lastUpdated = since.UTC().Format(time.RFC3339)
+	}
+	return types.ObjectValueFrom(ctx, modelStatusAttrTypes, modelStatusModel{
+		Life:             types.StringValue(string(response.ModelInfo.Life)),
+		Status:           types.StringValue(response.ModelInfo.Status.Status.String()),
+		MachineCount:     types.Int64Value(int64(response.MachineCount)),
+		ApplicationCount: types.Int64Value(int64(response.ApplicationCount)),
+		LastUpdated:      types.StringValue(lastUpdated),
+	})
+}
+
+// modelDefaultSpaceKey is the config key of the default space of a
+// model.
+const modelDefaultSpaceKey = "default-space"
+
+// setDefaultSpace sets the default space of the model, once validated.
+func (r *modelResource) setDefaultSpace(ctx context.Context, uuid, space string) error {
+	if err := r.checkSpace(ctx, uuid, space); err != nil {
+		return err
+	}
+	return r.client.Models.UpdateModel(ctx, juju.UpdateModelInput{
+		Name:   uuid,
+		Config: map[string]string{modelDefaultSpaceKey: space},
+	})
+}
+
+// checkSpace returns an error if the space is not known by the model,
+// listing the spaces known.
+func (r *modelResource) checkSpace(ctx context.Context, uuid, space string) error {
+	output, err := r.client.Models.ListSpaces(ctx, juju.ListSpacesInput{ModelName: uuid})
+	if err != nil {
+		return err
+	}
+	known := make([]string, 0, len(output.Spaces))
+	for _, s := range output.Spaces {
+		if s.Name == space {
+			return nil
+		}
+		known = append(known, s.Name)
+	}
+	return errors.NewNotFound(nil, fmt.Sprintf("space %q not found in the model, its spaces are: %s",
+		space, strings.Join(known, ", ")))
+}
+
+// importedModelConfig returns the config of an imported model, the keys
+// set on the model except those set by the controller, and the default
+// space which is imported as default_space.
+func importedModelConfig(current map[string]juju.ModelConfigValue) map[string]string {
+	config := managedModelConfig(nil, current)
+	delete(config, modelDefaultSpaceKey)
+	return config
+}
+
+// modelSLA returns the support level of the model to set, nil if not
+// configured.
+func modelSLA(model modelResourceModel) *juju.ModelSLA {
+	if model.SLA.IsNull() || model.SLA.IsUnknown() {
+		return nil
+	}
+	return &juju.ModelSLA{
+		Level:  model.SLA.ValueString(),
+		Budget: model.SLABudget.ValueString(),
 	}
+}
 
-	var diags diag.Diagnostics
-	diags.AddError("Client Error", err.Error())
-	return diags
+// modelSLALevel returns the support level of a model read, unsupported
+// if not set.
+func modelSLALevel(sla *params.ModelSLAInfo) string {
+	if sla == nil || sla.Level == "" {
+		return juju.SLAUnsupported
+	}
+	return sla.Level
 }
 
 func (r *modelResource) trace(msg string, additionalFields ...map[string]interface{}) {
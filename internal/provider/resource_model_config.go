@@ -0,0 +1,343 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/juju/errors"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &modelConfigResource{}
+var _ resource.ResourceWithConfigure = &modelConfigResource{}
+var _ resource.ResourceWithImportState = &modelConfigResource{}
+var _ resource.ResourceWithUpgradeState = &modelConfigResource{}
+var _ resource.ResourceWithIdentity = &modelConfigResource{}
+
+func NewModelConfigResource() resource.Resource {
+	return &modelConfigResource{}
+}
+
+type modelConfigResource struct {
+	client *juju.Client
+
+	// subCtx is the context created with the new tflog subsystem for model config.
+	subCtx context.Context
+}
+
+type modelConfigResourceModel struct {
+	ModelName types.String `tfsdk:"model"`
+	Config    types.Map    `tfsdk:"config"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+func (r *modelConfigResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_model_config"
+}
+
+func (r *modelConfigResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: modelConfigSchemaVersion,
+		Description: "A resource that manages a subset of the config keys of an existing model, independently " +
+			"of the model resource, e.g. of a shared model created by another workspace. Only the keys configured " +
+			"here are managed, they are reset to their defaults when removed. The keys must not be set in the " +
+			"config of `juju_model` too.",
+		Attributes: map[string]schema.Attribute{
+			"model": schema.StringAttribute{
+				Description: "The name or the UUID of the model to configure.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"config": schema.MapAttribute{
+				Description: "The config keys to set, by name, e.g. `logging-config` or `apt-mirror`. Must evaluate " +
+					"to a string, integer or boolean.",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// UpgradeState returns the state upgraders from the prior schema
+// versions of the resource, keyed by version.
+func (r *modelConfigResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+func (r *modelConfigResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+	// Create the local logging subsystem here, using the TF context when creating it.
+	r.subCtx = r.client.NewLogSubsystem(ctx, LogResourceModelConfig)
+}
+
+// ImportState is called when the provider must import the state of a
+// resource instance. The ID is the name or the UUID of the model. The
+// keys set on the model are imported, except those set by the
+// controller, e.g. agent-version or authorized-keys.
+func (r *modelConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	modelConfigIDFormat.importState(ctx, req, resp)
+}
+
+// IdentitySchema returns the schema of the identity of the resource,
+// made of the parts of its ID.
+func (r *modelConfigResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = modelConfigIDFormat.identitySchema()
+}
+
+func (r *modelConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_model_config", "Create", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "model_config", "create")
+		return
+	}
+
+	var plan modelConfigResourceModel
+
+	// Read Terraform configuration from the request into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config := make(map[string]string)
+	resp.Diagnostics.Append(plan.Config.ElementsAs(ctx, &config, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	modelName := plan.ModelName.ValueString()
+	err := r.client.Models.UpdateModel(ctx, juju.UpdateModelInput{
+		Name:   modelName,
+		Config: config,
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to set config of model %q", modelName)
+		return
+	}
+	r.trace(fmt.Sprintf("set config of model %q", modelName))
+
+	plan.ID = types.StringValue(modelConfigIDFormat.format(modelName))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(modelConfigIDFormat.setIdentity(ctx, plan.ID, resp.Identity)...)
+}
+
+func (r *modelConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_model_config", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "model_config", "read")
+		return
+	}
+
+	var state modelConfigResourceModel
+
+	// Read Terraform prior state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, dErr := modelConfigIDFormat.parse(state.ID.ValueString())
+	if dErr.HasError() {
+		resp.Diagnostics.Append(dErr...)
+		return
+	}
+	modelName := id[0]
+
+	response, err := r.client.Models.ReadModelConfig(ctx, juju.ReadModelConfigInput{
+		ModelName: modelName,
+	})
+	if err != nil {
+		handleReadError(ctx, r.client, err, &resp.State, &resp.Diagnostics, "Unable to read model config")
+		return
+	}
+	r.trace(fmt.Sprintf("read config of model %q", modelName))
+
+	var managed map[string]string
+	if !state.Config.IsNull() {
+		resp.Diagnostics.Append(state.Config.ElementsAs(ctx, &managed, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	state.Config, dErr = types.MapValueFrom(ctx, types.StringType, managedModelConfig(managed, response.Config))
+	if dErr.HasError() {
+		resp.Diagnostics.Append(dErr...)
+		return
+	}
+	state.ModelName = types.StringValue(modelName)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(modelConfigIDFormat.setIdentity(ctx, state.ID, resp.Identity)...)
+}
+
+func (r *modelConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_model_config", "Update", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "model_config", "update")
+		return
+	}
+
+	var plan, state modelConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planConfig := make(map[string]string)
+	stateConfig := make(map[string]string)
+	resp.Diagnostics.Append(plan.Config.ElementsAs(ctx, &planConfig, false)...)
+	resp.Diagnostics.Append(state.Config.ElementsAs(ctx, &stateConfig, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keys removed from the plan are reset.
+	modelName := plan.ModelName.ValueString()
+	err := r.client.Models.UpdateModel(ctx, juju.UpdateModelInput{
+		Name:   modelName,
+		Config: planConfig,
+		Unset:  removedConfigKeys(planConfig, stateConfig),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to update config of model %q", modelName)
+		return
+	}
+	r.trace(fmt.Sprintf("updated config of model %q", modelName))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *modelConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_model_config", "Delete", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "model_config", "delete")
+		return
+	}
+
+	var state modelConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config := make(map[string]string)
+	resp.Diagnostics.Append(state.Config.ElementsAs(ctx, &config, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(config) == 0 {
+		return
+	}
+	modelName := state.ModelName.ValueString()
+	err := r.client.Models.UpdateModel(ctx, juju.UpdateModelInput{
+		Name:  modelName,
+		Unset: removedConfigKeys(nil, config),
+	})
+	// Nothing to reset once the model is destroyed.
+	if err != nil && !errors.Is(err, errors.NotFound) {
+		addClientError(&resp.Diagnostics, err, "Unable to reset config of model %q", modelName)
+		return
+	}
+	r.trace(fmt.Sprintf("reset config of model %q", modelName))
+}
+
+// modelConfigIDFormat is the format of the ID of the model config
+// resource, the name or the UUID of the model.
+var modelConfigIDFormat = idFormat{parts: []string{"model_name"}}
+
+// modelConfigControllerKeys are the keys of the config of a model set
+// by the controller and the cloud provider when the model is created,
+// rather than by its users: the keys identifying the model, its agent
+// version, the SSH keys of its machines, which are managed with
+// juju_ssh_key, and the default storage sources of the provider. They
+// are not imported, the first plan after the import would unset them
+// otherwise, and are only managed when configured explicitly.
+var modelConfigControllerKeys = []string{
+	"name",
+	"type",
+	"uuid",
+	"agent-version",
+	"authorized-keys",
+	"charmhub-url",
+	"firewall-mode",
+	"storage-default-block-source",
+	"storage-default-filesystem-source",
+}
+
+// managedModelConfig returns the current values of the config keys
+// managed, those in the prior state, which are still set on the model.
+// Without prior state, on import, the keys set on the model are
+// returned, except modelConfigControllerKeys.
+func managedModelConfig(managed map[string]string, current map[string]juju.ModelConfigValue) map[string]string {
+	config := make(map[string]string)
+	for key, value := range current {
+		if value.Source != "model" {
+			continue
+		}
+		if _, found := managed[key]; found || (managed == nil && !slices.Contains(modelConfigControllerKeys, key)) {
+			config[key] = value.Value
+		}
+	}
+	return config
+}
+
+func (r *modelConfigResource) trace(msg string, additionalFields ...map[string]interface{}) {
+	if r.subCtx == nil {
+		return
+	}
+
+	//SubsystemTrace(subCtx, "my-subsystem", "hello, world", map[string]interface{}{"foo": 123})
+	// Output:
+	// {"@level":"trace","@message":"hello, world","@module":"provider.my-subsystem","foo":123}
+	tflog.SubsystemTrace(r.subCtx, LogResourceModelConfig, msg, additionalFields...)
+}
@@ -0,0 +1,137 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+func TestManagedModelConfig(t *testing.T) {
+	current := map[string]juju.ModelConfigValue{
+		"name":                              {Value: "development", Source: "model"},
+		"agent-version":                     {Value: "3.5.1", Source: "model"},
+		"authorized-keys":                   {Value: "ssh-ed25519 AAAA juju-client-key", Source: "model"},
+		"storage-default-filesystem-source": {Value: "lxd", Source: "model"},
+		"logging-config":                    {Value: "<root>=DEBUG", Source: "model"},
+		"apt-mirror":                        {Value: "", Source: "default"},
+		"update-status-hook-interval":       {Value: "10m", Source: "model"},
+	}
+	// Keys reset outside of Terraform are not managed anymore.
+	assert.Equal(t, map[string]string{"logging-config": "<root>=DEBUG"},
+		managedModelConfig(map[string]string{"logging-config": "<root>=INFO", "apt-mirror": "http://mirror"}, current))
+	// On import, the keys set on the model are managed, except those
+	// set by the controller.
+	assert.Equal(t, map[string]string{"logging-config": "<root>=DEBUG", "update-status-hook-interval": "10m"},
+		managedModelConfig(nil, current))
+	// The keys set by the controller are managed when configured.
+	assert.Equal(t, map[string]string{"storage-default-filesystem-source": "lxd"},
+		managedModelConfig(map[string]string{"storage-default-filesystem-source": "lxd"}, current))
+}
+
+func TestModelConfigResourceImportDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	models := NewMockModelsClient(ctrl)
+	models.EXPECT().ReadModelConfig(gomock.Any(), juju.ReadModelConfigInput{ModelName: "shared"}).Return(
+		juju.ReadModelConfigOutput{Config: map[string]juju.ModelConfigValue{
+			"name":            {Value: "shared", Source: "model"},
+			"agent-version":   {Value: "3.5.1", Source: "model"},
+			"authorized-keys": {Value: "ssh-ed25519 AAAA juju-client-key", Source: "model"},
+			"logging-config":  {Value: "<root>=DEBUG", Source: "model"},
+		}}, nil)
+	// Only the keys imported are reset, not the SSH keys nor the agent
+	// version of the shared model.
+	models.EXPECT().UpdateModel(gomock.Any(), juju.UpdateModelInput{
+		Name:  "shared",
+		Unset: []string{"logging-config"},
+	}).Return(nil)
+
+	ctx := context.Background()
+	r := &modelConfigResource{client: &juju.Client{Models: models}}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	emptyState := func() tfsdk.State {
+		return tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+	}
+
+	importResp := &fwresource.ImportStateResponse{State: emptyState()}
+	r.ImportState(ctx, fwresource.ImportStateRequest{ID: "shared"}, importResp)
+	require.False(t, importResp.Diagnostics.HasError(), importResp.Diagnostics)
+
+	readResp := &fwresource.ReadResponse{State: importResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: importResp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+
+	var imported modelConfigResourceModel
+	require.False(t, readResp.State.Get(ctx, &imported).HasError())
+	assert.Equal(t, map[string]attr.Value{"logging-config": types.StringValue("<root>=DEBUG")}, imported.Config.Elements())
+
+	deleteResp := &fwresource.DeleteResponse{State: readResp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: readResp.State}, deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+}
+
+func TestAcc_ResourceModelConfig(t *testing.T) {
+	modelName := acctest.RandomWithPrefix("tf-test-model-config")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceModelConfig(modelName, "<root>=DEBUG"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_model_config.this", "id", modelName),
+					resource.TestCheckResourceAttr("juju_model_config.this", "config.logging-config", "<root>=DEBUG"),
+				),
+			},
+			{
+				Config: testAccResourceModelConfig(modelName, "<root>=INFO"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("juju_model_config.this", "config.logging-config", "<root>=INFO"),
+				),
+			},
+			{
+				ImportStateVerify: true,
+				ImportState:       true,
+				// Every key set on the model by its users is imported,
+				// not only those of this configuration.
+				ImportStateVerifyIgnore: []string{"config"},
+				ResourceName:            "juju_model_config.this",
+			},
+		},
+	})
+}
+
+func testAccResourceModelConfig(modelName, loggingConfig string) string {
+	return fmt.Sprintf(`
+resource "juju_model" "this" {
+  name = %q
+}
+
+resource "juju_model_config" "this" {
+  model = juju_model.this.name
+  config = {
+    logging-config = %q
+  }
+}
+`, modelName, loggingConfig)
+}
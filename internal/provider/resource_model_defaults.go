@@ -0,0 +1,343 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/juju/errors"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &modelDefaultsResource{}
+var _ resource.ResourceWithConfigure = &modelDefaultsResource{}
+var _ resource.ResourceWithImportState = &modelDefaultsResource{}
+var _ resource.ResourceWithUpgradeState = &modelDefaultsResource{}
+var _ resource.ResourceWithIdentity = &modelDefaultsResource{}
+
+func NewModelDefaultsResource() resource.Resource {
+	return &modelDefaultsResource{}
+}
+
+type modelDefaultsResource struct {
+	client *juju.Client
+
+	// subCtx is the context created with the new tflog subsystem for model defaults.
+	subCtx context.Context
+}
+
+type modelDefaultsResourceModel struct {
+	Cloud  types.String `tfsdk:"cloud"`
+	Region types.String `tfsdk:"region"`
+	Config types.Map    `tfsdk:"config"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+func (r *modelDefaultsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_model_defaults"
+}
+
+func (r *modelDefaultsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: modelDefaultsSchemaVersion,
+		Description: "A resource that manages the defaults of the config of the models of a cloud, or of one of " +
+			"its regions, like `juju model-defaults`. The models created in the cloud or the region inherit them " +
+			"unless they set the keys in their own config. Only the keys configured here are managed, they are " +
+			"reset when removed.",
+		Attributes: map[string]schema.Attribute{
+			"cloud": schema.StringAttribute{
+				Description: "The name of the cloud of the defaults.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				Description: "The region of the cloud of the defaults. The defaults of a region take precedence " +
+					"over those of its cloud. The defaults of the cloud are managed if not set.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"config": schema.MapAttribute{
+				Description: "The defaults to set, by config key, e.g. `automatically-retry-hooks` or " +
+					"`image-stream`. Must evaluate to a string, integer or boolean.",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// UpgradeState returns the state upgraders from the prior schema
+// versions of the resource, keyed by version.
+func (r *modelDefaultsResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+func (r *modelDefaultsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+	// Create the local logging subsystem here, using the TF context when creating it.
+	r.subCtx = r.client.NewLogSubsystem(ctx, LogResourceModelDefaults)
+}
+
+// ImportState is called when the provider must import the state of a
+// resource instance. The ID is the cloud, optionally followed by the
+// region. Every default set for them is imported.
+func (r *modelDefaultsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	modelDefaultsIDFormat.importState(ctx, req, resp)
+}
+
+// IdentitySchema returns the schema of the identity of the resource,
+// made of the parts of its ID.
+func (r *modelDefaultsResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = modelDefaultsIDFormat.identitySchema()
+}
+
+func (r *modelDefaultsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_model_defaults", "Create", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "model_defaults", "create")
+		return
+	}
+
+	var plan modelDefaultsResourceModel
+
+	// Read Terraform configuration from the request into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config := make(map[string]string)
+	resp.Diagnostics.Append(plan.Config.ElementsAs(ctx, &config, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cloud, region := plan.Cloud.ValueString(), plan.Region.ValueString()
+	err := r.client.Models.UpdateModelDefaults(ctx, juju.UpdateModelDefaultsInput{
+		Cloud:  cloud,
+		Region: region,
+		Config: config,
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to set model defaults of cloud %q", cloud)
+		return
+	}
+	r.trace(fmt.Sprintf("set model defaults of cloud %q, region %q", cloud, region))
+
+	plan.ID = types.StringValue(modelDefaultsID(cloud, region))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(modelDefaultsIDFormat.setIdentity(ctx, plan.ID, resp.Identity)...)
+}
+
+func (r *modelDefaultsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_model_defaults", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "model_defaults", "read")
+		return
+	}
+
+	var state modelDefaultsResourceModel
+
+	// Read Terraform prior state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, dErr := modelDefaultsIDFormat.parse(state.ID.ValueString())
+	if dErr.HasError() {
+		resp.Diagnostics.Append(dErr...)
+		return
+	}
+	cloud, region := id[0], id[1]
+
+	response, err := r.client.Models.ReadModelDefaults(ctx, juju.ReadModelDefaultsInput{
+		Cloud:  cloud,
+		Region: region,
+	})
+	if err != nil {
+		handleReadError(ctx, r.client, err, &resp.State, &resp.Diagnostics, "Unable to read model defaults")
+		return
+	}
+	r.trace(fmt.Sprintf("read model defaults of cloud %q, region %q", cloud, region))
+
+	var managed map[string]string
+	if !state.Config.IsNull() {
+		resp.Diagnostics.Append(state.Config.ElementsAs(ctx, &managed, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	state.Config, dErr = types.MapValueFrom(ctx, types.StringType, managedModelDefaults(managed, response.Config))
+	if dErr.HasError() {
+		resp.Diagnostics.Append(dErr...)
+		return
+	}
+	state.Cloud = types.StringValue(cloud)
+	if region != "" {
+		state.Region = types.StringValue(region)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(modelDefaultsIDFormat.setIdentity(ctx, state.ID, resp.Identity)...)
+}
+
+func (r *modelDefaultsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_model_defaults", "Update", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "model_defaults", "update")
+		return
+	}
+
+	var plan, state modelDefaultsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planConfig := make(map[string]string)
+	stateConfig := make(map[string]string)
+	resp.Diagnostics.Append(plan.Config.ElementsAs(ctx, &planConfig, false)...)
+	resp.Diagnostics.Append(state.Config.ElementsAs(ctx, &stateConfig, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keys removed from the plan are reset.
+	cloud, region := plan.Cloud.ValueString(), plan.Region.ValueString()
+	err := r.client.Models.UpdateModelDefaults(ctx, juju.UpdateModelDefaultsInput{
+		Cloud:  cloud,
+		Region: region,
+		Config: planConfig,
+		Unset:  removedConfigKeys(planConfig, stateConfig),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to update model defaults of cloud %q", cloud)
+		return
+	}
+	r.trace(fmt.Sprintf("updated model defaults of cloud %q, region %q", cloud, region))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *modelDefaultsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_model_defaults", "Delete", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "model_defaults", "delete")
+		return
+	}
+
+	var state modelDefaultsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config := make(map[string]string)
+	resp.Diagnostics.Append(state.Config.ElementsAs(ctx, &config, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(config) == 0 {
+		return
+	}
+	cloud, region := state.Cloud.ValueString(), state.Region.ValueString()
+	err := r.client.Models.UpdateModelDefaults(ctx, juju.UpdateModelDefaultsInput{
+		Cloud:  cloud,
+		Region: region,
+		Unset:  removedConfigKeys(nil, config),
+	})
+	// Nothing to reset once the cloud is removed.
+	if err != nil && !errors.Is(err, errors.NotFound) {
+		addClientError(&resp.Diagnostics, err, "Unable to reset model defaults of cloud %q", cloud)
+		return
+	}
+	r.trace(fmt.Sprintf("reset model defaults of cloud %q, region %q", cloud, region))
+}
+
+// modelDefaultsIDFormat is the format of the ID of the model defaults
+// resource, the cloud and the region, if any.
+var modelDefaultsIDFormat = idFormat{parts: []string{"cloud"}, optional: []string{"region"}}
+
+// modelDefaultsID returns the ID of the defaults of the cloud, or of
+// the region if not empty.
+func modelDefaultsID(cloud, region string) string {
+	if region == "" {
+		return modelDefaultsIDFormat.format(cloud)
+	}
+	return modelDefaultsIDFormat.format(cloud, region)
+}
+
+// managedModelDefaults returns the current values of the defaults
+// managed, those in the prior state, which are still set. Without prior
+// state, on import, all the defaults set are returned.
+func managedModelDefaults(managed, current map[string]string) map[string]string {
+	config := make(map[string]string)
+	for key, value := range current {
+		if _, found := managed[key]; found || managed == nil {
+			config[key] = value
+		}
+	}
+	return config
+}
+
+func (r *modelDefaultsResource) trace(msg string, additionalFields ...map[string]interface{}) {
+	if r.subCtx == nil {
+		return
+	}
+
+	//SubsystemTrace(subCtx, "my-subsystem", "hello, world", map[string]interface{}{"foo": 123})
+	// Output:
+	// {"@level":"trace","@message":"hello, world","@module":"provider.my-subsystem","foo":123}
+	tflog.SubsystemTrace(r.subCtx, LogResourceModelDefaults, msg, additionalFields...)
+}
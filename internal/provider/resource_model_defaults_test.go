@@ -0,0 +1,74 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManagedModelDefaults(t *testing.T) {
+	current := map[string]string{
+		"image-stream":                "daily",
+		"update-status-hook-interval": "10m",
+	}
+	// Defaults reset outside of Terraform are not managed anymore.
+	assert.Equal(t, map[string]string{"image-stream": "daily"},
+		managedModelDefaults(map[string]string{"image-stream": "released", "apt-mirror": "http://mirror"}, current))
+	// On import, all the defaults set are managed.
+	assert.Equal(t, current, managedModelDefaults(nil, current))
+}
+
+func TestModelDefaultsID(t *testing.T) {
+	assert.Equal(t, "aws", modelDefaultsID("aws", ""))
+	assert.Equal(t, "aws:us-east-1", modelDefaultsID("aws", "us-east-1"))
+}
+
+func TestAcc_ResourceModelDefaults(t *testing.T) {
+	if testingCloud != LXDCloudTesting {
+		t.Skip(t.Name() + " only runs with LXD")
+	}
+	resourceName := "juju_model_defaults.this"
+	// The defaults apply to the whole controller, the test does not run
+	// in parallel with the others.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceModelDefaults(testingCloud.CloudName(), "10m"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", testingCloud.CloudName()+":localhost"),
+					resource.TestCheckResourceAttr(resourceName, "config.update-status-hook-interval", "10m"),
+				),
+			},
+			{
+				Config: testAccResourceModelDefaults(testingCloud.CloudName(), "15m"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "config.update-status-hook-interval", "15m"),
+				),
+			},
+			{
+				ImportStateVerify: true,
+				ImportState:       true,
+				ResourceName:      resourceName,
+			},
+		},
+	})
+}
+
+func testAccResourceModelDefaults(cloudName, interval string) string {
+	return fmt.Sprintf(`
+resource "juju_model_defaults" "this" {
+  cloud  = %q
+  region = "localhost"
+  config = {
+    update-status-hook-interval = %q
+  }
+}
+`, cloudName, interval)
+}
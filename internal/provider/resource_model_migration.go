@@ -0,0 +1,376 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &modelMigrationResource{}
+var _ resource.ResourceWithConfigure = &modelMigrationResource{}
+var _ resource.ResourceWithUpgradeState = &modelMigrationResource{}
+
+func NewModelMigrationResource() resource.Resource {
+	return &modelMigrationResource{}
+}
+
+// modelMigrationFeature is the experimental feature gating the model
+// migration resource.
+const modelMigrationFeature = "model_migration"
+
+// defaultMigrationTimeout is how long the migration of a model is waited
+// for without a create timeout.
+const defaultMigrationTimeout = 30 * time.Minute
+
+type modelMigrationResource struct {
+	client *juju.Client
+
+	// subCtx is the context created with the new tflog subsystem for model migrations.
+	subCtx context.Context
+}
+
+type modelMigrationResourceModel struct {
+	ModelName             types.String   `tfsdk:"model"`
+	TargetControllerUUID  types.String   `tfsdk:"target_controller_uuid"`
+	TargetControllerAlias types.String   `tfsdk:"target_controller_alias"`
+	TargetAddresses       types.List     `tfsdk:"target_addresses"`
+	TargetCACert          types.String   `tfsdk:"target_ca_cert"`
+	TargetUser            types.String   `tfsdk:"target_user"`
+	TargetPassword        types.String   `tfsdk:"target_password"`
+	Wait                  types.Bool     `tfsdk:"wait"`
+	ModelUUID             types.String   `tfsdk:"model_uuid"`
+	Status                types.String   `tfsdk:"status"`
+	Migrated              types.Bool     `tfsdk:"migrated"`
+	Start                 types.String   `tfsdk:"start"`
+	End                   types.String   `tfsdk:"end"`
+	Timeouts              timeouts.Value `tfsdk:"timeouts"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+func (r *modelMigrationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_model_migration"
+}
+
+func (r *modelMigrationResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: modelMigrationSchemaVersion,
+		Description: "A resource that migrates a model to another controller, like `juju migrate`, and tracks " +
+			"the migration. Changing any of its arguments migrates the model again. Once migrated, the model " +
+			"is not found on the controller of the provider anymore, its resources must be removed from the " +
+			"state, or managed with a provider configured for the target controller. Deleting the resource " +
+			"does not migrate the model back. Experimental, enabled with the `" + modelMigrationFeature + "` " +
+			"experimental feature.",
+		Attributes: map[string]schema.Attribute{
+			"model": schema.StringAttribute{
+				Description: "The name of the model to migrate.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_controller_uuid": schema.StringAttribute{
+				Description: "The UUID of the target controller.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_controller_alias": schema.StringAttribute{
+				Description: "The name of the target controller, used to redirect the clients of the model.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_addresses": schema.ListAttribute{
+				Description: "The API addresses of the target controller, e.g. `[\"10.0.0.2:17070\"]`.",
+				ElementType: types.StringType,
+				Required:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_ca_cert": schema.StringAttribute{
+				Description: "The CA certificate of the target controller.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_user": schema.StringAttribute{
+				Description: "The user of the target controller the model is migrated as. It must be a " +
+					"superuser of the target controller.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_password": schema.StringAttribute{
+				Description: "The password of the user of the target controller.",
+				Required:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait": schema.BoolAttribute{
+				Description: "Wait for the migration to end when the resource is created, failing if it is " +
+					"aborted. The wait is bounded by the create timeout, 30m if not set. Defaults to true.",
+				Optional: true,
+			},
+			"model_uuid": schema.StringAttribute{
+				Description: "The UUID of the model migrated.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "The status of the latest migration of the model, as shown by `juju show-model`. " +
+					"Empty once the model is migrated.",
+				Computed: true,
+			},
+			"migrated": schema.BoolAttribute{
+				Description: "Whether the model is migrated, i.e. it has left the controller of the provider.",
+				Computed:    true,
+			},
+			"start": schema.StringAttribute{
+				Description: "When the latest migration of the model started, in RFC 3339 format.",
+				Computed:    true,
+			},
+			"end": schema.StringAttribute{
+				Description: "When the latest migration of the model ended, if aborted, in RFC 3339 format.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			TimeoutsKey: timeoutsBlock(ctx, "model migration"),
+		},
+	}
+}
+
+// UpgradeState returns the state upgraders from the prior schema
+// versions of the resource, keyed by version.
+func (r *modelMigrationResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+func (r *modelMigrationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	if !checkExperimentalFeature(client, modelMigrationFeature, "juju_model_migration", &resp.Diagnostics) {
+		return
+	}
+	r.client = client
+	// Create the local logging subsystem here, using the TF context when creating it.
+	r.subCtx = r.client.NewLogSubsystem(ctx, LogResourceModelMigration)
+}
+
+func (r *modelMigrationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_model_migration", "Create", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "model_migration", "create")
+		return
+	}
+
+	var plan modelMigrationResourceModel
+
+	// Read Terraform configuration from the request into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, plan.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var addresses []string
+	resp.Diagnostics.Append(plan.TargetAddresses.ElementsAs(ctx, &addresses, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	modelName := plan.ModelName.ValueString()
+	modelInfo, err := r.client.Models.GetModelByName(ctx, modelName)
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to read model %q", modelName)
+		return
+	}
+	migrationID, err := r.client.Models.MigrateModel(ctx, juju.MigrateModelInput{
+		UUID:                  modelInfo.UUID,
+		TargetControllerUUID:  plan.TargetControllerUUID.ValueString(),
+		TargetControllerAlias: plan.TargetControllerAlias.ValueString(),
+		TargetAddresses:       addresses,
+		TargetCACert:          plan.TargetCACert.ValueString(),
+		TargetUser:            plan.TargetUser.ValueString(),
+		TargetPassword:        plan.TargetPassword.ValueString(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err, "Unable to migrate model %q", modelName)
+		return
+	}
+	r.trace(fmt.Sprintf("initiated migration %q of model %q", migrationID, modelName))
+	plan.ID = types.StringValue(migrationID)
+	plan.ModelUUID = types.StringValue(modelInfo.UUID)
+
+	var migration *juju.ReadModelMigrationResponse
+	if plan.Wait.IsNull() || plan.Wait.ValueBool() {
+		timeout := defaultMigrationTimeout
+		if deadline, ok := ctx.Deadline(); ok {
+			timeout = time.Until(deadline)
+		}
+		migration, err = r.client.Models.WaitForModelMigration(ctx, modelInfo.UUID, timeout)
+		if err == nil && !migration.Migrated {
+			err = fmt.Errorf("the migration of model %q was aborted: %s", modelName, migration.Status)
+		}
+	} else {
+		migration, err = r.client.Models.ReadModelMigration(ctx, modelInfo.UUID)
+	}
+	if err != nil {
+		// The migration is initiated, save it so that it is tracked.
+		addClientError(&resp.Diagnostics, err, "Unable to migrate model %q", modelName)
+		if migration != nil {
+			setModelMigration(&plan, migration)
+		}
+		resp.Diagnostics.Append(setPartialState(ctx, &resp.State, &plan)...)
+		return
+	}
+	setModelMigration(&plan, migration)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *modelMigrationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_model_migration", "Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		addClientNotConfiguredError(&resp.Diagnostics, "model_migration", "read")
+		return
+	}
+
+	var state modelMigrationResourceModel
+
+	// Read Terraform prior state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A migrated model is not found anymore, the migration is kept.
+	migration, err := r.client.Models.ReadModelMigration(ctx, state.ModelUUID.ValueString())
+	if err != nil {
+		handleReadError(ctx, r.client, err, &resp.State, &resp.Diagnostics, "Unable to read model migration")
+		return
+	}
+	r.trace(fmt.Sprintf("read migration of model %q", state.ModelName.ValueString()))
+	setModelMigration(&state, migration)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update only saves the wait attribute and the timeouts, the others
+// require the resource to be replaced.
+func (r *modelMigrationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_model_migration", "Update", &resp.Diagnostics)
+	defer endSpan()
+
+	var plan, state modelMigrationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Wait = plan.Wait
+	state.Timeouts = plan.Timeouts
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Delete removes the migration from the state only, a migration cannot
+// be reverted.
+func (r *modelMigrationResource) Delete(ctx context.Context, _ resource.DeleteRequest, resp *resource.DeleteResponse) {
+	_, endSpan := traceOperation(ctx, r.client, "juju_model_migration", "Delete", &resp.Diagnostics)
+	defer endSpan()
+
+	r.trace("removed model migration from the state")
+}
+
+// setModelMigration sets the computed attributes of the migration from
+// the status read.
+func setModelMigration(migration *modelMigrationResourceModel, response *juju.ReadModelMigrationResponse) {
+	migration.Status = types.StringValue(response.Status)
+	migration.Migrated = types.BoolValue(response.Migrated)
+	// The times of the migration of a migrated model are not known
+	// anymore, those read before are kept.
+	if response.Migrated && response.Start == nil {
+		if migration.Start.IsUnknown() {
+			migration.Start = types.StringValue("")
+		}
+		if migration.End.IsUnknown() {
+			migration.End = types.StringValue("")
+		}
+		return
+	}
+	migration.Start = timeStringValue(response.Start)
+	migration.End = timeStringValue(response.End)
+}
+
+// timeStringValue returns t in RFC 3339 format, empty if nil.
+func timeStringValue(t *time.Time) types.String {
+	if t == nil {
+		return types.StringValue("")
+	}
+	return types.StringValue(t.UTC().Format(time.RFC3339))
+}
+
+func (r *modelMigrationResource) trace(msg string, additionalFields ...map[string]interface{}) {
+	if r.subCtx == nil {
+		return
+	}
+
+	//SubsystemTrace(subCtx, "my-subsystem", "hello, world", map[string]interface{}{"foo": 123})
+	// Output:
+	// {"@level":"trace","@message":"hello, world","@module":"provider.my-subsystem","foo":123}
+	tflog.SubsystemTrace(r.subCtx, LogResourceModelMigration, msg, additionalFields...)
+}
@@ -0,0 +1,37 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+func TestSetModelMigration(t *testing.T) {
+	start := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	migration := modelMigrationResourceModel{
+		Start: types.StringUnknown(),
+		End:   types.StringUnknown(),
+	}
+	setModelMigration(&migration, &juju.ReadModelMigrationResponse{
+		Status: "migrating: importing model into target controller",
+		Start:  &start,
+	})
+	assert.Equal(t, "migrating: importing model into target controller", migration.Status.ValueString())
+	assert.False(t, migration.Migrated.ValueBool())
+	assert.Equal(t, "2024-06-01T10:00:00Z", migration.Start.ValueString())
+	assert.Equal(t, "", migration.End.ValueString())
+
+	// Once migrated, the times read before are kept.
+	setModelMigration(&migration, &juju.ReadModelMigrationResponse{Migrated: true})
+	assert.True(t, migration.Migrated.ValueBool())
+	assert.Equal(t, "", migration.Status.ValueString())
+	assert.Equal(t, "2024-06-01T10:00:00Z", migration.Start.ValueString())
+	assert.Equal(t, "", migration.End.ValueString())
+}
@@ -4,14 +4,30 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/juju/errors"
 	"github.com/juju/juju/api/client/modelconfig"
 	"github.com/juju/juju/rpc/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
 )
 
 func TestAcc_ResourceModel(t *testing.T) {
@@ -29,6 +45,11 @@ func TestAcc_ResourceModel(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "name", modelName),
 					resource.TestCheckResourceAttr(resourceName, "config.logging-config", fmt.Sprintf("<root>=%s", logLevelInfo)),
+					resource.TestCheckResourceAttrPair(resourceName, "uuid", resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "controller_uuid"),
+					resource.TestCheckResourceAttr(resourceName, "status.life", "alive"),
+					resource.TestCheckResourceAttr(resourceName, "status.status", "available"),
+					resource.TestCheckResourceAttr(resourceName, "status.application_count", "0"),
 				),
 			},
 			{
@@ -43,6 +64,16 @@ func TestAcc_ResourceModel(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "constraints", "cores=1 mem=1024M"),
 				),
 			},
+			{
+				Config: testAccConstraintsModel(modelName, testingCloud.CloudName(), "mem=2G cores=1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "constraints", "mem=2G cores=1"),
+				),
+			},
+			{
+				Config:   testAccConstraintsModel(modelName, testingCloud.CloudName(), "mem=2G cores=1"),
+				PlanOnly: true,
+			},
 			{
 				ImportStateVerify: true,
 				ImportState:       true,
@@ -142,9 +173,41 @@ func TestAcc_ResourceModel_UpgradeProvider(t *testing.T) {
 	})
 }
 
+func TestAcc_ResourceModel_RetainOnDelete(t *testing.T) {
+	modelName := acctest.RandomWithPrefix("tf-test-model")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		CheckDestroy:             testAccCheckModelRetained(modelName),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "juju_model" "this" {
+  name             = %q
+  retain_on_delete = true
+}`, modelName),
+				Check: resource.TestCheckResourceAttr("juju_model.this", "retain_on_delete", "true"),
+			},
+		},
+	})
+}
+
+// testAccCheckModelRetained checks that the model still exists once
+// its resource is destroyed, then destroys it.
+func testAccCheckModelRetained(modelName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		model, err := TestClient.Models.GetModelByName(context.Background(), modelName)
+		if err != nil {
+			return fmt.Errorf("expecting model %q to be retained: %w", modelName, err)
+		}
+		return TestClient.Models.DestroyModel(context.Background(), juju.DestroyModelInput{UUID: model.UUID})
+	}
+}
+
 func testAccCheckDevelopmentConfigIsUnset(modelName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		conn, err := TestClient.Models.GetConnection(&modelName)
+		conn, err := TestClient.Models.GetConnection(context.Background(), &modelName)
 		if err != nil {
 			return err
 		}
@@ -175,6 +238,42 @@ func testAccCheckDevelopmentConfigIsUnset(modelName string) resource.TestCheckFu
 	}
 }
 
+func TestAcc_ResourceModel_ImportPlansNoChanges(t *testing.T) {
+	modelName := acctest.RandomWithPrefix("tf-test-model")
+	config := testAccResourceModel(modelName, testingCloud.CloudName(), "INFO")
+
+	resourceName := "juju_model.model"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: frameworkProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				Config:             config,
+				ImportState:        true,
+				ImportStateId:      modelName,
+				ImportStatePersist: true,
+				ResourceName:       resourceName,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected one model imported, got %d", len(states))
+					}
+					if value := states[0].Attributes["config.logging-config"]; value != "<root>=INFO" {
+						return fmt.Errorf("expected logging-config to be imported, got %q", value)
+					}
+					return nil
+				},
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 func testAccResourceModel(modelName string, cloudName string, logLevel string) string {
 	return fmt.Sprintf(`
 resource "juju_model" "model" {
@@ -204,3 +303,210 @@ resource "juju_model" "model" {
   constraints = "%s"
 }`, modelName, cloudName, constraints)
 }
+
+func TestModelDestroyInput(t *testing.T) {
+	optionTypes := map[string]attr.Type{
+		"destroy_storage": types.BoolType,
+		"force":           types.BoolType,
+		"timeout":         types.StringType,
+	}
+	model := modelResourceModel{
+		ID:             types.StringValue("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
+		ForceDestroy:   types.BoolNull(),
+		DestroyMaxWait: types.StringNull(),
+		DestroyOptions: types.ObjectNull(optionTypes),
+	}
+	input, diags := modelDestroyInput(context.Background(), model)
+	require.False(t, diags.HasError())
+	assert.Equal(t, "f47ac10b-58cc-4372-a567-0e02b2c3d479", input.UUID)
+	assert.False(t, input.Force)
+	assert.False(t, input.KeepStorage)
+	assert.Zero(t, input.Timeout)
+
+	model.DestroyOptions = types.ObjectValueMust(optionTypes, map[string]attr.Value{
+		"destroy_storage": types.BoolValue(false),
+		"force":           types.BoolValue(true),
+		"timeout":         types.StringValue("1h"),
+	})
+	input, diags = modelDestroyInput(context.Background(), model)
+	require.False(t, diags.HasError())
+	assert.True(t, input.Force)
+	assert.True(t, input.KeepStorage)
+	assert.Equal(t, time.Hour, input.Timeout)
+}
+
+func TestImportedModelConfig(t *testing.T) {
+	// The keys set by the controller on new models are not imported,
+	// the first plan after import would unset them otherwise.
+	assert.Equal(t, map[string]string{"logging-config": "<root>=INFO"}, importedModelConfig(map[string]juju.ModelConfigValue{
+		"name":                              {Value: "development", Source: "model"},
+		"type":                              {Value: "lxd", Source: "model"},
+		"uuid":                              {Value: "f47ac10b-58cc-4372-a567-0e02b2c3d479", Source: "model"},
+		"agent-version":                     {Value: "3.5.1", Source: "model"},
+		"authorized-keys":                   {Value: "ssh-ed25519 AAAA juju-client-key", Source: "model"},
+		"storage-default-filesystem-source": {Value: "lxd", Source: "model"},
+		"default-space":                     {Value: "alpha", Source: "model"},
+		"logging-config":                    {Value: "<root>=INFO", Source: "model"},
+		"apt-mirror":                        {Value: "", Source: "default"},
+	}))
+}
+
+func TestModelSLA(t *testing.T) {
+	model := modelResourceModel{
+		SLA:       types.StringUnknown(),
+		SLABudget: types.StringNull(),
+	}
+	assert.Nil(t, modelSLA(model))
+
+	model.SLA = types.StringValue("essential")
+	model.SLABudget = types.StringValue("100")
+	assert.Equal(t, &juju.ModelSLA{Level: "essential", Budget: "100"}, modelSLA(model))
+
+	assert.Equal(t, "unsupported", modelSLALevel(nil))
+	assert.Equal(t, "unsupported", modelSLALevel(&params.ModelSLAInfo{}))
+	assert.Equal(t, "standard", modelSLALevel(&params.ModelSLAInfo{Level: "standard", Owner: "bob"}))
+}
+
+func TestModelResourceValidateConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	credentials := NewMockCredentialsClient(ctrl)
+	credentials.EXPECT().ValidateCloudRegion(gomock.Any(), "aws", "eu-west-9").
+		Return(errors.NewNotValid(nil, `region "eu-west-9" is not a region of cloud "aws"`))
+	credentials.EXPECT().ValidateCloudRegion(gomock.Any(), "aws", "eu-west-1").Return(nil)
+
+	ctx := context.Background()
+	r := &modelResource{client: &juju.Client{Credentials: credentials}}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	cloudType := schemaResp.Schema.GetBlocks()["cloud"].(schema.ListNestedBlock).NestedObject.Type()
+	timeoutsType := schemaResp.Schema.GetBlocks()[TimeoutsKey].(schema.SingleNestedBlock).CustomType.(timeouts.Type)
+
+	validate := func(region string) fwresource.ValidateConfigResponse {
+		cloud, diags := types.ListValueFrom(ctx, cloudType, []nestedCloud{{
+			Name:   types.StringValue("aws"),
+			Region: types.StringValue(region),
+		}})
+		require.False(t, diags.HasError(), diags)
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+		diags = state.Set(ctx, &modelResourceModel{
+			Name:           types.StringValue("test-model"),
+			Cloud:          cloud,
+			Config:         types.MapNull(types.StringType),
+			DestroyOptions: types.ObjectNull(schemaResp.Schema.GetBlocks()[DestroyOptionsKey].Type().(types.ObjectType).AttrTypes),
+			Status:         types.ObjectNull(modelStatusAttrTypes),
+			Timeouts:       timeouts.Value{Object: types.ObjectNull(timeoutsType.AttrTypes)},
+		})
+		require.False(t, diags.HasError(), diags)
+
+		resp := fwresource.ValidateConfigResponse{}
+		r.ValidateConfig(ctx, fwresource.ValidateConfigRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw},
+		}, &resp)
+		return resp
+	}
+
+	resp := validate("eu-west-9")
+	require.Len(t, resp.Diagnostics.Errors(), 1)
+	assert.Equal(t, "Invalid Cloud Region", resp.Diagnostics.Errors()[0].Summary())
+
+	resp = validate("eu-west-1")
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+}
+
+func TestModelResourceCheckSpace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	models := NewMockModelsClient(ctrl)
+	models.EXPECT().ListSpaces(gomock.Any(), juju.ListSpacesInput{ModelName: "f47ac10b-58cc-4372-a567-0e02b2c3d479"}).
+		Return(juju.ListSpacesOutput{Spaces: []juju.Space{{Name: "alpha"}, {Name: "public"}}}, nil).Times(2)
+
+	ctx := context.Background()
+	r := &modelResource{client: &juju.Client{Models: models}}
+	assert.NoError(t, r.checkSpace(ctx, "f47ac10b-58cc-4372-a567-0e02b2c3d479", "public"))
+
+	err := r.checkSpace(ctx, "f47ac10b-58cc-4372-a567-0e02b2c3d479", "internal")
+	assert.True(t, errors.Is(err, errors.NotFound))
+	assert.EqualError(t, err, `space "internal" not found in the model, its spaces are: alpha, public`)
+}
+
+func TestModelResourceUpdateCredential(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	models := NewMockModelsClient(ctrl)
+	models.EXPECT().UpdateModel(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, input juju.UpdateModelInput) error {
+			assert.Equal(t, "f47ac10b-58cc-4372-a567-0e02b2c3d479", input.Name)
+			assert.Equal(t, "rotated", input.Credential)
+			assert.Nil(t, input.Config)
+			assert.Nil(t, input.SLA)
+			return nil
+		})
+
+	ctx := context.Background()
+	r := &modelResource{client: &juju.Client{Models: models}}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	cloudType := schemaResp.Schema.GetBlocks()["cloud"].(schema.ListNestedBlock).NestedObject.Type()
+	timeoutsType := schemaResp.Schema.GetBlocks()[TimeoutsKey].(schema.SingleNestedBlock).CustomType.(timeouts.Type)
+	destroyOptionsType := schemaResp.Schema.GetBlocks()[DestroyOptionsKey].Type().(types.ObjectType).AttrTypes
+
+	model := modelResourceModel{
+		Name:           types.StringValue("test-model"),
+		Cloud:          types.ListNull(cloudType),
+		Config:         types.MapNull(types.StringType),
+		Credential:     types.StringValue("initial"),
+		DestroyOptions: types.ObjectNull(destroyOptionsType),
+		SLA:            types.StringValue("unsupported"),
+		Status:         types.ObjectNull(modelStatusAttrTypes),
+		Timeouts:       timeouts.Value{Object: types.ObjectNull(timeoutsType.AttrTypes)},
+		ID:             types.StringValue("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
+	}
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	require.False(t, state.Set(ctx, &model).HasError())
+	model.Credential = types.StringValue("rotated")
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	require.False(t, plan.Set(ctx, &model).HasError())
+
+	resp := &fwresource.UpdateResponse{State: state}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var got modelResourceModel
+	require.False(t, resp.State.Get(ctx, &got).HasError())
+	assert.Equal(t, "rotated", got.Credential.ValueString())
+	assert.Equal(t, "f47ac10b-58cc-4372-a567-0e02b2c3d479", got.ID.ValueString())
+
+	// The credential is changed in place, the model is not replaced.
+	credentialAttr := schemaResp.Schema.Attributes["credential"].(schema.StringAttribute)
+	for _, modifier := range credentialAttr.PlanModifiers {
+		assert.NotContains(t, modifier.Description(ctx), "replace")
+	}
+}
+
+func TestModelStatusValue(t *testing.T) {
+	since := time.Date(2024, 6, 1, 10, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	value, diags := modelStatusValue(context.Background(), &juju.ReadModelResponse{
+		ModelInfo: params.ModelInfo{
+			Life:   "alive",
+			Status: params.EntityStatus{Status: "available", Since: &since},
+		},
+		MachineCount:     2,
+		ApplicationCount: 3,
+	})
+	require.False(t, diags.HasError(), diags)
+
+	var status modelStatusModel
+	require.False(t, value.As(context.Background(), &status, basetypes.ObjectAsOptions{}).HasError())
+	assert.Equal(t, "alive", status.Life.ValueString())
+	assert.Equal(t, "available", status.Status.ValueString())
+	assert.Equal(t, int64(2), status.MachineCount.ValueInt64())
+	assert.Equal(t, int64(3), status.ApplicationCount.ValueInt64())
+	assert.Equal(t, "2024-06-01T08:00:00Z", status.LastUpdated.ValueString())
+}
@@ -8,13 +8,10 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -25,6 +22,8 @@ import (
 var _ resource.Resource = &offerResource{}
 var _ resource.ResourceWithConfigure = &offerResource{}
 var _ resource.ResourceWithImportState = &offerResource{}
+var _ resource.ResourceWithUpgradeState = &offerResource{}
+var _ resource.ResourceWithIdentity = &offerResource{}
 
 func NewOfferResource() resource.Resource {
 	return &offerResource{}
@@ -53,6 +52,7 @@ func (o *offerResource) Metadata(_ context.Context, req resource.MetadataRequest
 
 func (o *offerResource) Schema(_ context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     offerSchemaVersion,
 		Description: "A resource that represent a Juju Offer.",
 		Attributes: map[string]schema.Attribute{
 			"model": schema.StringAttribute{
@@ -89,6 +89,9 @@ func (o *offerResource) Schema(_ context.Context, req resource.SchemaRequest, re
 			"url": schema.StringAttribute{
 				Description: "The offer URL.",
 				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -101,6 +104,9 @@ func (o *offerResource) Schema(_ context.Context, req resource.SchemaRequest, re
 }
 
 func (o *offerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := traceOperation(ctx, o.client, "juju_offer", "Create", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if o.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "offer", "create")
@@ -116,9 +122,9 @@ func (o *offerResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	modelName := plan.ModelName.ValueString()
-	modelInfo, err := o.client.Models.GetModelByName(modelName)
+	modelInfo, err := o.client.Models.GetModelByName(ctx, modelName)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get model %q, got error: %s", modelName, err))
+		addClientError(&resp.Diagnostics, err, "Unable to get model %q", modelName)
 		return
 	}
 	// TODO (cderici): Leaking Juju info here:
@@ -133,7 +139,7 @@ func (o *offerResource) Create(ctx context.Context, req resource.CreateRequest,
 		offerName = plan.ApplicationName.ValueString()
 	}
 
-	response, errs := o.client.Offers.CreateOffer(&juju.CreateOfferInput{
+	response, errs := o.client.Offers.CreateOffer(ctx, &juju.CreateOfferInput{
 		ModelName:       modelName,
 		ModelOwner:      modelOwner,
 		Name:            offerName,
@@ -148,7 +154,7 @@ func (o *offerResource) Create(ctx context.Context, req resource.CreateRequest,
 		//
 		// Why do we pass the CreateOfferInput as a pointer?
 		for _, err := range errs {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create offer, got error: %s", err))
+			addClientError(&resp.Diagnostics, err, "Unable to create offer")
 		}
 		return
 	}
@@ -160,9 +166,13 @@ func (o *offerResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	// Set the plan onto the Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(offerIDFormat.setIdentity(ctx, plan.ID, resp.Identity)...)
 }
 
 func (o *offerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, o.client, "juju_offer", "Read", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if o.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "offer", "read")
@@ -175,11 +185,11 @@ func (o *offerResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	response, err := o.client.Offers.ReadOffer(&juju.ReadOfferInput{
+	response, err := o.client.Offers.ReadOffer(ctx, &juju.ReadOfferInput{
 		OfferURL: state.ID.ValueString(),
 	})
 	if err != nil {
-		resp.Diagnostics.Append(handleOfferNotFoundError(ctx, err, &resp.State)...)
+		handleReadError(ctx, o.client, err, &resp.State, &resp.Diagnostics, "Unable to read offer")
 		return
 	}
 
@@ -193,6 +203,7 @@ func (o *offerResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	state.ID = types.StringValue(response.OfferURL)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(offerIDFormat.setIdentity(ctx, state.ID, resp.Identity)...)
 }
 
 func (o *offerResource) Update(context.Context, resource.UpdateRequest, *resource.UpdateResponse) {
@@ -210,6 +221,9 @@ func (o *offerResource) Update(context.Context, resource.UpdateRequest, *resourc
 // Juju refers to deletion as "destroy" so we call the Destroy function of our client here rather than delete
 // This function remains named Delete for parity across the provider and to stick within terraform naming conventions
 func (o *offerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := traceOperation(ctx, o.client, "juju_offer", "Delete", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if o.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "offer", "delete")
@@ -223,16 +237,22 @@ func (o *offerResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	err := o.client.Offers.DestroyOffer(&juju.DestroyOfferInput{
+	err := o.client.Offers.DestroyOffer(ctx, &juju.DestroyOfferInput{
 		OfferURL: plan.URL.ValueString(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete offer, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to delete offer")
 		return
 	}
 	o.trace(fmt.Sprintf("delete offer resource %q", plan.URL))
 }
 
+// UpgradeState returns the state upgraders from the prior schema
+// versions of the resource, keyed by version.
+func (o *offerResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
 func (o *offerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -250,13 +270,23 @@ func (o *offerResource) Configure(ctx context.Context, req resource.ConfigureReq
 
 	o.client = client
 	// Create the local logging subsystem here, using the TF context when creating it.
-	o.subCtx = tflog.NewSubsystem(ctx, LogResourceOffer)
+	o.subCtx = o.client.NewLogSubsystem(ctx, LogResourceOffer)
 }
 
 func (o *offerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	offerIDFormat.importState(ctx, req, resp)
 }
 
+// IdentitySchema returns the schema of the identity of the resource,
+// made of the parts of its ID.
+func (o *offerResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = offerIDFormat.identitySchema()
+}
+
+// offerIDFormat is the format of the ID of offers, their URL as in
+// the output of juju show-offer, e.g. admin/development.mysql.
+var offerIDFormat = idFormat{parts: []string{"offer_url"}}
+
 func (o *offerResource) trace(msg string, additionalFields ...map[string]interface{}) {
 	if o.subCtx == nil {
 		return
@@ -267,19 +297,3 @@ func (o *offerResource) trace(msg string, additionalFields ...map[string]interfa
 	// {"@level":"trace","@message":"hello, world","@module":"provider.my-subsystem","foo":123}
 	tflog.SubsystemTrace(o.subCtx, LogResourceOffer, msg, additionalFields...)
 }
-
-func isOfferNotFound(err error) bool {
-	return strings.Contains(err.Error(), "expected to find one result for url")
-}
-
-func handleOfferNotFoundError(ctx context.Context, err error, st *tfsdk.State) diag.Diagnostics {
-	if isOfferNotFound(err) {
-		// Offer manually removed
-		st.RemoveResource(ctx)
-		return diag.Diagnostics{}
-	}
-
-	var diags diag.Diagnostics
-	diags.AddError("Client Error", err.Error())
-	return diags
-}
@@ -6,8 +6,8 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -22,6 +22,8 @@ import (
 var _ resource.Resource = &secretResource{}
 var _ resource.ResourceWithConfigure = &secretResource{}
 var _ resource.ResourceWithImportState = &secretResource{}
+var _ resource.ResourceWithUpgradeState = &secretResource{}
+var _ resource.ResourceWithIdentity = &secretResource{}
 
 func NewSecretResource() resource.Resource {
 	return &secretResource{}
@@ -51,7 +53,8 @@ type secretResourceModel struct {
 }
 
 // ImportState reads the secret based on the model name and secret name to be
-// imported into terraform.
+// imported into terraform, or on the model name and secret ID when imported
+// with the identity of the resource.
 func (s *secretResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Prevent panic if the provider has not been configured.
 	if s.client == nil {
@@ -59,24 +62,16 @@ func (s *secretResource) ImportState(ctx context.Context, req resource.ImportSta
 		return
 	}
 
-	// model:name
-	parts := strings.Split(req.ID, ":")
-	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
-		resp.Diagnostics.AddError(
-			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: <modelname>:<secretname>. Got: %q", req.ID),
-		)
+	input, diags := secretImportInput(ctx, req)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
 		return
 	}
-	modelName := parts[0]
-	secretName := parts[1]
+	modelName := input.ModelName
 
-	readSecretOutput, err := s.client.Secrets.ReadSecret(&juju.ReadSecretInput{
-		ModelName: modelName,
-		Name:      &secretName,
-	})
+	readSecretOutput, err := s.client.Secrets.ReadSecret(ctx, input)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read secret for import, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to read secret for import")
 		return
 	}
 
@@ -104,12 +99,19 @@ func (s *secretResource) ImportState(ctx context.Context, req resource.ImportSta
 	s.trace(fmt.Sprintf("import secret resource %q", state.SecretId))
 }
 
+// IdentitySchema returns the schema of the identity of the resource,
+// the parts of its ID, with the ID of the secret rather than its name.
+func (s *secretResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = secretIDFormat.identitySchema()
+}
+
 func (s *secretResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_secret"
 }
 
 func (s *secretResource) Schema(_ context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     secretSchemaVersion,
 		Description: "A resource that represents a Juju secret.",
 		Attributes: map[string]schema.Attribute{
 			"model": schema.StringAttribute{
@@ -151,6 +153,12 @@ func (s *secretResource) Schema(_ context.Context, req resource.SchemaRequest, r
 	}
 }
 
+// UpgradeState returns the state upgraders from the prior schema
+// versions of the resource, keyed by version.
+func (s *secretResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
 // Configure sets up the Juju client for the secret resource.
 func (s *secretResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
@@ -167,12 +175,16 @@ func (s *secretResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 	s.client = client
+	checkMinJujuVersion(client, "juju_secret", &resp.Diagnostics)
 	// Create the local logging subsystem here, using the TF context when creating it.
-	s.subCtx = tflog.NewSubsystem(ctx, LogResourceSecret)
+	s.subCtx = s.client.NewLogSubsystem(ctx, LogResourceSecret)
 }
 
 // Create creates a new secret in the Juju model.
 func (s *secretResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := traceOperation(ctx, s.client, "juju_secret", "Create", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if s.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "secret", "create")
@@ -192,14 +204,14 @@ func (s *secretResource) Create(ctx context.Context, req resource.CreateRequest,
 	secretValue := make(map[string]string)
 	resp.Diagnostics.Append(plan.Value.ElementsAs(ctx, &secretValue, false)...)
 
-	createSecretOutput, err := s.client.Secrets.CreateSecret(&juju.CreateSecretInput{
+	createSecretOutput, err := s.client.Secrets.CreateSecret(ctx, &juju.CreateSecretInput{
 		ModelName: plan.Model.ValueString(),
 		Name:      plan.Name.ValueString(),
 		Value:     secretValue,
 		Info:      plan.Info.ValueString(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add secret, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to add secret")
 		return
 	}
 
@@ -216,12 +228,16 @@ func (s *secretResource) Create(ctx context.Context, req resource.CreateRequest,
 		})
 	// Save plan into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(secretIDFormat.setIdentity(ctx, plan.ID, resp.Identity)...)
 
 	s.trace(fmt.Sprintf("created secret resource %s", plan.SecretId))
 }
 
 // Read reads the details of a secret in the Juju model.
 func (s *secretResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, s.client, "juju_secret", "Read", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if s.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "secret", "read")
@@ -238,12 +254,12 @@ func (s *secretResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	s.trace(fmt.Sprintf("reading secret resource %q", state.SecretId))
 
-	readSecretOutput, err := s.client.Secrets.ReadSecret(&juju.ReadSecretInput{
+	readSecretOutput, err := s.client.Secrets.ReadSecret(ctx, &juju.ReadSecretInput{
 		SecretId:  state.SecretId.ValueString(),
 		ModelName: state.Model.ValueString(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read secret, got error: %s", err))
+		handleReadError(ctx, s.client, err, &resp.State, &resp.Diagnostics, "Unable to read secret")
 		return
 	}
 
@@ -265,12 +281,16 @@ func (s *secretResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	// Save state into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(secretIDFormat.setIdentity(ctx, state.ID, resp.Identity)...)
 
 	s.trace(fmt.Sprintf("read secret resource %s", state.SecretId))
 }
 
 // Update updates the details of a secret in the Juju model.
 func (s *secretResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := traceOperation(ctx, s.client, "juju_secret", "Update", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if s.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "secret", "update")
@@ -287,8 +307,6 @@ func (s *secretResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	s.trace(fmt.Sprintf("updating secret resource %q", state.SecretId))
-	s.trace(fmt.Sprintf("Update - current state: %v", state))
-	s.trace(fmt.Sprintf("Update - proposed plan: %v", plan))
 
 	var err error
 	noChange := true
@@ -329,9 +347,9 @@ func (s *secretResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	err = s.client.Secrets.UpdateSecret(&updatedSecretInput)
+	err = s.client.Secrets.UpdateSecret(ctx, &updatedSecretInput)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update secret, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to update secret")
 		return
 	}
 
@@ -343,6 +361,9 @@ func (s *secretResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 // Delete removes a secret from the Juju model.
 func (s *secretResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := traceOperation(ctx, s.client, "juju_secret", "Delete", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if s.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "secret", "delete")
@@ -359,12 +380,12 @@ func (s *secretResource) Delete(ctx context.Context, req resource.DeleteRequest,
 
 	s.trace(fmt.Sprintf("deleting secret resource %q", state.SecretId))
 
-	err := s.client.Secrets.DeleteSecret(&juju.DeleteSecretInput{
+	err := s.client.Secrets.DeleteSecret(ctx, &juju.DeleteSecretInput{
 		ModelName: state.Model.ValueString(),
 		SecretId:  state.SecretId.ValueString(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete secret, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to delete secret")
 		return
 	}
 
@@ -378,6 +399,37 @@ func (s *secretResource) trace(msg string, additionalFields ...map[string]interf
 	tflog.SubsystemTrace(s.subCtx, LogResourceSecret, msg, additionalFields...)
 }
 
+// secretImportIDFormat is the format of the ID with which secrets and
+// their access are imported, which differs from their ID made of the
+// model name and the secret URI.
+var secretImportIDFormat = idFormat{parts: []string{"model_name", "secret_name"}}
+
+// secretIDFormat is the format of the ID of secrets and their access,
+// which is their identity.
+var secretIDFormat = idFormat{parts: []string{"model_name", "secret_id"}}
+
 func newSecretID(model, secret string) string {
-	return fmt.Sprintf("%s:%s", model, secret)
+	return secretIDFormat.format(model, secret)
+}
+
+// secretImportInput returns the input reading the secret imported, by
+// name when imported with an ID, by secret ID when imported with the
+// identity of the resource.
+func secretImportInput(ctx context.Context, req resource.ImportStateRequest) (*juju.ReadSecretInput, diag.Diagnostics) {
+	if req.ID == "" && req.Identity != nil {
+		id, diags := secretIDFormat.importID(ctx, req)
+		if diags.HasError() {
+			return nil, diags
+		}
+		parts, diags := secretIDFormat.parse(id)
+		if diags.HasError() {
+			return nil, diags
+		}
+		return &juju.ReadSecretInput{ModelName: parts[0], SecretId: parts[1]}, nil
+	}
+	parts, diags := secretImportIDFormat.parse(req.ID)
+	if diags.HasError() {
+		return nil, diags
+	}
+	return &juju.ReadSecretInput{ModelName: parts[0], Name: &parts[1]}, nil
 }
@@ -6,10 +6,8 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -25,6 +23,8 @@ import (
 var _ resource.Resource = &sshKeyResource{}
 var _ resource.ResourceWithConfigure = &sshKeyResource{}
 var _ resource.ResourceWithImportState = &sshKeyResource{}
+var _ resource.ResourceWithUpgradeState = &sshKeyResource{}
+var _ resource.ResourceWithIdentity = &sshKeyResource{}
 
 func NewSSHKeyResource() resource.Resource {
 	return &sshKeyResource{}
@@ -45,7 +45,19 @@ type sshKeyResourceModel struct {
 }
 
 func (s *sshKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	sshKeyIDFormat.importState(ctx, req, resp)
+}
+
+// IdentitySchema returns the schema of the identity of the resource,
+// made of the parts of its ID.
+func (s *sshKeyResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = sshKeyIDFormat.identitySchema()
+}
+
+// UpgradeState returns the state upgraders from the prior schema
+// versions of the resource, keyed by version.
+func (s *sshKeyResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
 }
 
 func (s *sshKeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -64,7 +76,7 @@ func (s *sshKeyResource) Configure(ctx context.Context, req resource.ConfigureRe
 	}
 	s.client = client
 	// Create the local logging subsystem here, using the TF context when creating it.
-	s.subCtx = tflog.NewSubsystem(ctx, LogResourceSSHKey)
+	s.subCtx = s.client.NewLogSubsystem(ctx, LogResourceSSHKey)
 }
 
 func (s *sshKeyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -73,6 +85,7 @@ func (s *sshKeyResource) Metadata(_ context.Context, req resource.MetadataReques
 
 func (s *sshKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     sshKeySchemaVersion,
 		Description: "Resource representing an SSH key.",
 		Attributes: map[string]schema.Attribute{
 			"model": schema.StringAttribute{
@@ -98,6 +111,9 @@ func (s *sshKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 }
 
 func (s *sshKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := traceOperation(ctx, s.client, "juju_ssh_key", "Create", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if s.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "ssh_key", "create")
@@ -121,38 +137,47 @@ func (s *sshKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	modelName := plan.ModelName.ValueString()
 
-	if err := s.client.SSHKeys.CreateSSHKey(&juju.CreateSSHKeyInput{
+	if err := s.client.SSHKeys.CreateSSHKey(ctx, &juju.CreateSSHKeyInput{
 		ModelName: modelName,
 		Payload:   payload,
 	}); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create ssh_key, got error %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to create ssh_key")
 		return
 	}
 	s.trace(fmt.Sprintf("created ssh_key for: %q", keyIdentifier))
 
 	plan.ID = types.StringValue(newSSHKeyID(modelName, keyIdentifier))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(sshKeyIDFormat.setIdentity(ctx, plan.ID, resp.Identity)...)
+}
+
+// Keys can be imported with the name of the model and the identifier of the key
+// sshkey:<modelName>:<ssh-key-identifier>, ssh_key being accepted as the prefix
+// as documented. The key identifier is currently based on the comment section
+// of the ssh key (e.g. user@hostname) (TODO: issue #267)
+var sshKeyIDFormat = idFormat{
+	prefix:        "sshkey",
+	prefixAliases: []string{"ssh_key"},
+	parts:         []string{"model_name", "key_identifier"},
 }
 
 func newSSHKeyID(modelName string, keyIdentifier string) string {
-	return fmt.Sprintf("sshkey:%s:%s", modelName, keyIdentifier)
+	return sshKeyIDFormat.format(modelName, keyIdentifier)
 }
 
-// Keys can be imported with the name of the model and the identifier of the key
-// ssh_key:<modelName>:<ssh-key-identifier>
-// the key identifier is currently based on the comment section of the ssh key
-// (e.g. user@hostname) (TODO: issue #267)
 func retrieveModelKeyNameFromID(id string, d *diag.Diagnostics) (string, string) {
-	tokens := strings.Split(id, ":")
-	//If importing with an incorrect ID we need to catch and provide a user-friendly error
-	if len(tokens) != 3 {
-		d.AddError("Malformed ID", fmt.Sprintf("unable to parse model name and user from provided ID: %q", id))
+	tokens, diags := sshKeyIDFormat.parse(id)
+	d.Append(diags...)
+	if diags.HasError() {
 		return "", ""
 	}
-	return tokens[1], tokens[2]
+	return tokens[0], tokens[1]
 }
 
 func (s *sshKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, s.client, "juju_ssh_key", "Read", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if s.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "ssh_key", "read")
@@ -172,12 +197,12 @@ func (s *sshKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	result, err := s.client.SSHKeys.ReadSSHKey(&juju.ReadSSHKeyInput{
+	result, err := s.client.SSHKeys.ReadSSHKey(ctx, &juju.ReadSSHKeyInput{
 		ModelName:     modelName,
 		KeyIdentifier: keyIdentifier,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read ssh key, got error: %s", err))
+		handleReadError(ctx, s.client, err, &resp.State, &resp.Diagnostics, "Unable to read ssh key")
 		return
 	}
 	s.trace(fmt.Sprintf("read ssh key resource %q", plan.ID.ValueString()))
@@ -187,9 +212,13 @@ func (s *sshKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	// Set the plan onto the Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(sshKeyIDFormat.setIdentity(ctx, plan.ID, resp.Identity)...)
 }
 
 func (s *sshKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := traceOperation(ctx, s.client, "juju_ssh_key", "Update", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if s.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "ssh_key", "update")
@@ -220,21 +249,21 @@ func (s *sshKeyResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Delete the key
-	if err := s.client.SSHKeys.DeleteSSHKey(&juju.DeleteSSHKeyInput{
+	if err := s.client.SSHKeys.DeleteSSHKey(ctx, &juju.DeleteSSHKeyInput{
 		ModelName:     modelName,
 		KeyIdentifier: keyIdentifier,
 	}); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete ssh key for updating, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to delete ssh key for updating")
 		return
 	}
 	s.trace(fmt.Sprintf("ssh key deleted : %q", state.ID.ValueString()))
 
 	// Create a new key
-	if err := s.client.SSHKeys.CreateSSHKey(&juju.CreateSSHKeyInput{
+	if err := s.client.SSHKeys.CreateSSHKey(ctx, &juju.CreateSSHKeyInput{
 		ModelName: plan.ModelName.ValueString(),
 		Payload:   plan.Payload.ValueString(),
 	}); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create ssh key for updating, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to create ssh key for updating")
 		return
 	}
 	s.trace(fmt.Sprintf("ssh key created : %q", plan.ID.ValueString()))
@@ -253,6 +282,9 @@ func (s *sshKeyResource) Update(ctx context.Context, req resource.UpdateRequest,
 // Juju refers to deletion as "destroy" so we call the Destroy function of our client here rather than delete
 // This function remains named Delete for parity across the provider and to stick within terraform naming conventions
 func (s *sshKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := traceOperation(ctx, s.client, "juju_ssh_key", "Delete", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if s.client == nil {
 		addClientNotConfiguredError(&resp.Diagnostics, "ssh_key", "delete")
@@ -273,11 +305,11 @@ func (s *sshKeyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 
 	// Delete the key
-	if err := s.client.SSHKeys.DeleteSSHKey(&juju.DeleteSSHKeyInput{
+	if err := s.client.SSHKeys.DeleteSSHKey(ctx, &juju.DeleteSSHKeyInput{
 		ModelName:     modelName,
 		KeyIdentifier: keyIdentifier,
 	}); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete ssh key during delete, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to delete ssh key during delete")
 		return
 	}
 	s.trace(fmt.Sprintf("delete ssh_key resource : %q", plan.ID.ValueString()))
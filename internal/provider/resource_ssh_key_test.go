@@ -4,11 +4,21 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
 )
 
 func TestAcc_ResourceSSHKey(t *testing.T) {
@@ -108,3 +118,66 @@ resource "juju_ssh_key" "this" {
 }
 `, modelName, sshKey)
 }
+
+func TestSSHKeyResourceRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sshKeys := NewMockSSHKeysClient(ctrl)
+	sshKeys.EXPECT().ReadSSHKey(gomock.Any(), &juju.ReadSSHKeyInput{
+		ModelName:     "test-model",
+		KeyIdentifier: "jimmy@somewhere",
+	}).Return(&juju.ReadSSHKeyOutput{
+		ModelName: "test-model",
+		Payload:   "ssh-rsa AAAA jimmy@somewhere",
+	}, nil)
+
+	ctx := context.Background()
+	r := &sshKeyResource{client: &juju.Client{SSHKeys: sshKeys}}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	diags := state.Set(ctx, &sshKeyResourceModel{
+		ModelName: types.StringValue("test-model"),
+		Payload:   types.StringValue("ssh-rsa AAAA jimmy@somewhere"),
+		ID:        types.StringValue("sshkey:test-model:jimmy@somewhere"),
+	})
+	require.False(t, diags.HasError(), diags)
+
+	resp := &fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var got sshKeyResourceModel
+	require.False(t, resp.State.Get(ctx, &got).HasError())
+	assert.Equal(t, "test-model", got.ModelName.ValueString())
+	assert.Equal(t, "ssh-rsa AAAA jimmy@somewhere", got.Payload.ValueString())
+}
+
+func TestSSHKeyResourceReadMalformedID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	// No calls are expected on the client.
+	sshKeys := NewMockSSHKeysClient(ctrl)
+
+	ctx := context.Background()
+	r := &sshKeyResource{client: &juju.Client{SSHKeys: sshKeys}}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	diags := state.Set(ctx, &sshKeyResourceModel{
+		ModelName: types.StringValue("test-model"),
+		Payload:   types.StringValue("ssh-rsa AAAA jimmy@somewhere"),
+		ID:        types.StringValue("test-model"),
+	})
+	require.False(t, diags.HasError(), diags)
+
+	resp := &fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+	assert.True(t, resp.Diagnostics.HasError())
+}
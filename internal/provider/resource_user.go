@@ -6,10 +6,8 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -24,6 +22,8 @@ import (
 var _ resource.Resource = &userResource{}
 var _ resource.ResourceWithConfigure = &userResource{}
 var _ resource.ResourceWithImportState = &userResource{}
+var _ resource.ResourceWithUpgradeState = &userResource{}
+var _ resource.ResourceWithIdentity = &userResource{}
 
 func NewUserResource() resource.Resource {
 	return &userResource{}
@@ -56,6 +56,7 @@ func (r *userResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 	// `juju add-user`, `juju remove-user`
 	// Display name is optional.
 	resp.Schema = schema.Schema{
+		Version: userSchemaVersion,
 		// This description is used by the documentation generator and the language server.
 		Description: "A resource that represents a Juju User.",
 		Attributes: map[string]schema.Attribute{
@@ -89,6 +90,12 @@ func (r *userResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 	}
 }
 
+// UpgradeState returns the state upgraders from the prior schema
+// versions of the resource, keyed by version.
+func (r *userResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
 // Configure enables provider-level data or clients to be set in the
 // provider-defined DataSource type. It is separately executed for each
 // ReadDataSource RPC.
@@ -109,13 +116,16 @@ func (r *userResource) Configure(ctx context.Context, req resource.ConfigureRequ
 
 	r.client = client
 	// Create the local logging subsystem here, using the TF context when creating it.
-	r.subCtx = tflog.NewSubsystem(ctx, LogResourceUser)
+	r.subCtx = r.client.NewLogSubsystem(ctx, LogResourceUser)
 }
 
 // Create is called when the provider must create a new resource. Config
 // and planned state values should be read from the
 // CreateRequest and new state values set on the CreateResponse.
 func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_user", "Create", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if r.client == nil {
 		resp.Diagnostics.AddError(
@@ -134,13 +144,13 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	_, err := r.client.Users.CreateUser(juju.CreateUserInput{
+	_, err := r.client.Users.CreateUser(ctx, juju.CreateUserInput{
 		Name:        data.Name.ValueString(),
 		DisplayName: data.DisplayName.ValueString(),
 		Password:    data.Password.ValueString(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create user resource, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to create user resource")
 		return
 	}
 	r.trace(fmt.Sprintf("created user resource %q", data.Name))
@@ -148,6 +158,7 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 	// Save data into Terraform state
 	data.ID = types.StringValue(newIDFromUserName(data.Name.ValueString()))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(userIDFormat.setIdentity(ctx, data.ID, resp.Identity)...)
 }
 
 // Read is called when the provider must read resource values in order
@@ -156,6 +167,9 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 // Take the juju api input from the ID, it may not exist in the plan.
 // Only set optional values if they exist.
 func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_user", "Read", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if r.client == nil {
 		resp.Diagnostics.AddError(
@@ -180,13 +194,10 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	response, err := r.client.Users.ReadUser(userName)
+	response, err := r.client.Users.ReadUser(ctx, userName)
 	if err != nil {
 		// TODO (hmlanigan) 2023-06-14
-		// Add a user NotFound error type to the client.
-		// On read, if NotFound, remove the resource:
-		// resp.State.RemoveResource()
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user resource, got error: %s", err))
+		handleReadError(ctx, r.client, err, &resp.State, &resp.Diagnostics, "Unable to read user resource")
 		return
 	}
 	r.trace(fmt.Sprintf("read user resource %q", data.Name.ValueString()))
@@ -207,12 +218,16 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		plan.DisplayName = types.StringNull()
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(userIDFormat.setIdentity(ctx, plan.ID, resp.Identity)...)
 }
 
 // Update is called to update the state of the resource. Config, planned
 // state, and prior state values should be read from the
 // UpdateRequest and new state values set on the UpdateResponse.
 func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_user", "Update", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if r.client == nil {
 		resp.Diagnostics.AddError(
@@ -243,11 +258,11 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	// Update user can only change the user's password. It is not currently
 	// possible to change the display name via terraform after the user is
 	// created. Nor is it possible to change an existing username.
-	if err := r.client.Users.UpdateUser(juju.UpdateUserInput{
+	if err := r.client.Users.UpdateUser(ctx, juju.UpdateUserInput{
 		Name:     data.Name.ValueString(),
 		Password: data.Password.ValueString(),
 	}); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update user resource, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to update user resource")
 		return
 	}
 	r.trace(fmt.Sprintf("updated user resource %q", data.Name))
@@ -270,6 +285,9 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 // call DeleteResponse.State.RemoveResource(), so it can be omitted
 // from provider logic.
 func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := traceOperation(ctx, r.client, "juju_user", "Delete", &resp.Diagnostics)
+	defer endSpan()
+
 	// Prevent panic if the provider has not been configured.
 	if r.client == nil {
 		resp.Diagnostics.AddError(
@@ -292,18 +310,24 @@ func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	err := r.client.Users.DestroyUser(juju.DestroyUserInput{
+	err := r.client.Users.DestroyUser(ctx, juju.DestroyUserInput{
 		Name: userName,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete user resource, got error: %s", err))
+		addClientError(&resp.Diagnostics, err, "Unable to delete user resource")
 		return
 	}
 	r.trace(fmt.Sprintf("deleted user resource %q", data.Name.ValueString()))
 }
 
 func (r *userResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	userIDFormat.importState(ctx, req, resp)
+}
+
+// IdentitySchema returns the schema of the identity of the resource,
+// made of the parts of its ID.
+func (r *userResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = userIDFormat.identitySchema()
 }
 
 func (r *userResource) info(msg string, additionalFields ...map[string]interface{}) {
@@ -328,17 +352,16 @@ func (r *userResource) trace(msg string, additionalFields ...map[string]interfac
 	tflog.SubsystemTrace(r.subCtx, LogResourceUser, msg, additionalFields...)
 }
 
-// ID is 'user:<username>'
+var userIDFormat = idFormat{prefix: "user", parts: []string{"user_name"}}
+
 func newIDFromUserName(value string) string {
-	return fmt.Sprintf("user:%s", value)
+	return userIDFormat.format(value)
 }
 
 func userNameFromID(value string) (string, diag.Diagnostics) {
-	var diags diag.Diagnostics
-	values := strings.Split(value, ":")
-	if len(values) != 2 || values[0] != "user" {
-		diags.AddError("Malformed ID", fmt.Sprintf("User ID %q is malformed, please use the format 'user:<username>'", value))
+	values, diags := userIDFormat.parse(value)
+	if diags.HasError() {
 		return "", diags
 	}
-	return values[1], diags
+	return values[0], diags
 }
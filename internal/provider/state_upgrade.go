@@ -0,0 +1,85 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// Schema versions of the resources. Bump the version of a resource when
+// renaming an attribute or changing its type, and register a state
+// upgrader from the previous version in the UpgradeState method of the
+// resource, e.g. for a renamed attribute
+//
+//	func (r *machineResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+//		return map[int64]resource.StateUpgrader{
+//			0: rawStateUpgrader(upgradeRenamedAttributes(machineSeriesRenamed)),
+//		}
+//	}
+//
+// State upgraders receive the state as stored by the prior version, so
+// they must not be removed once released.
+const (
+	accessModelSchemaVersion       = 0
+	accessSecretSchemaVersion      = 0
+	annotationsSchemaVersion       = 0
+	applicationSchemaVersion       = 0
+	applicationConfigSchemaVersion = 0
+	applicationExposeSchemaVersion = 0
+	backupSchemaVersion            = 0
+	bundleSchemaVersion            = 0
+	charmResourceSchemaVersion     = 0
+	credentialSchemaVersion        = 0
+	execSchemaVersion              = 0
+	firewallRuleSchemaVersion      = 0
+	integrationSchemaVersion       = 0
+	machineSchemaVersion           = 1
+	modelSchemaVersion             = 0
+	modelConfigSchemaVersion       = 0
+	modelDefaultsSchemaVersion     = 0
+	modelMigrationSchemaVersion    = 0
+	offerSchemaVersion             = 0
+	secretSchemaVersion            = 0
+	sshKeySchemaVersion            = 0
+	userSchemaVersion              = 0
+)
+
+// rawStateUpgrader returns a state upgrader working on the JSON
+// representation of the prior state. It does not need the prior schema
+// of the resource, which makes it suitable for attribute renames and
+// simple type changes.
+func rawStateUpgrader(upgrade func(state map[string]interface{}) error) resource.StateUpgrader {
+	return resource.StateUpgrader{
+		StateUpgrader: func(_ context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			if req.RawState == nil || req.RawState.JSON == nil {
+				resp.Diagnostics.AddError("Unable to Upgrade State", "The prior state is not stored as JSON.")
+				return
+			}
+			upgraded, err := upgradeRawState(req.RawState.JSON, upgrade)
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to Upgrade State", fmt.Sprintf("Unable to upgrade the prior state, got error: %s", err))
+				return
+			}
+			resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgraded}
+		},
+	}
+}
+
+// upgradeRawState decodes the JSON state, applies upgrade to it and
+// returns the encoded result.
+func upgradeRawState(raw []byte, upgrade func(state map[string]interface{}) error) ([]byte, error) {
+	var state map[string]interface{}
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return nil, err
+	}
+	if err := upgrade(state); err != nil {
+		return nil, err
+	}
+	return json.Marshal(state)
+}
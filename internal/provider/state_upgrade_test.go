@@ -0,0 +1,72 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRawStateUpgrader(t *testing.T) {
+	upgrader := rawStateUpgrader(upgradeRenamedAttributes(testRenamedAttribute))
+
+	req := resource.UpgradeStateRequest{
+		RawState: &tfprotov6.RawState{JSON: []byte(`{"id":"test","old_name":"value"}`)},
+	}
+	resp := &resource.UpgradeStateResponse{}
+	upgrader.StateUpgrader(context.Background(), req, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.NotNil(t, resp.DynamicValue)
+	assert.JSONEq(t, `{"id":"test","old_name":"value","new_name":"value"}`, string(resp.DynamicValue.JSON))
+}
+
+func TestRawStateUpgraderError(t *testing.T) {
+	upgrader := rawStateUpgrader(func(state map[string]interface{}) error {
+		return errors.New("invalid state")
+	})
+
+	req := resource.UpgradeStateRequest{
+		RawState: &tfprotov6.RawState{JSON: []byte(`{"id":"test"}`)},
+	}
+	resp := &resource.UpgradeStateResponse{}
+	upgrader.StateUpgrader(context.Background(), req, resp)
+
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Nil(t, resp.DynamicValue)
+
+	resp = &resource.UpgradeStateResponse{}
+	upgrader.StateUpgrader(context.Background(), resource.UpgradeStateRequest{}, resp)
+	assert.True(t, resp.Diagnostics.HasError())
+}
+
+func TestMachineResourceUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	r := &machineResource{}
+	upgrader, ok := r.UpgradeState(ctx)[0]
+	require.True(t, ok)
+
+	req := resource.UpgradeStateRequest{
+		RawState: &tfprotov6.RawState{JSON: []byte(`{"id":"test:0:machine-0","series":"jammy","base":null}`)},
+	}
+	resp := &resource.UpgradeStateResponse{}
+	upgrader.StateUpgrader(ctx, req, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.NotNil(t, resp.DynamicValue)
+	assert.JSONEq(t, `{"id":"test:0:machine-0","series":"jammy","base":"ubuntu@22.04"}`, string(resp.DynamicValue.JSON))
+
+	// The upgraded state is valid with the current schema.
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	assert.EqualValues(t, 1, schemaResp.Schema.Version)
+	_, err := resp.DynamicValue.Unmarshal(schemaResp.Schema.Type().TerraformType(ctx))
+	assert.NoError(t, err)
+}
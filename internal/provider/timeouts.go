@@ -0,0 +1,59 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// The long-running resources, e.g. models, applications and machines,
+// share the timeouts block bounding their operations. The context of an
+// operation expires with its timeout, which stops the waits and the
+// retries of the client, including the connection to the controller.
+
+// TimeoutsKey is the name of the timeouts block.
+const TimeoutsKey = "timeouts"
+
+// timeoutsBlock returns the timeouts block of the resources of the
+// given entity, bounding their create, update and delete.
+func timeoutsBlock(ctx context.Context, entity string) schema.Block {
+	description := func(operation string) string {
+		return fmt.Sprintf("How long the %s of the %s may take, e.g. `30m`. Unbounded if not set, "+
+			"other than by the timeouts of its own waits.", operation, entity)
+	}
+	block := timeouts.Block(ctx, timeouts.Opts{
+		Create:            true,
+		Update:            true,
+		Delete:            true,
+		CreateDescription: description("create"),
+		UpdateDescription: description("update"),
+		DeleteDescription: description("delete"),
+	}).(schema.SingleNestedBlock)
+	block.Description = fmt.Sprintf("The timeouts of the operations on the %s. The waits and the retries of an "+
+		"operation, including the connection to the controller, stop when its timeout expires.", entity)
+	return block
+}
+
+// withOperationTimeout returns ctx bounded by the timeout of an
+// operation, e.g. plan.Timeouts.Create, if set, and the function
+// releasing it. The diagnostics of reading the timeout are appended to
+// diags, ctx is not bounded on errors.
+func withOperationTimeout(
+	ctx context.Context,
+	timeout func(context.Context, time.Duration) (time.Duration, diag.Diagnostics),
+	diags *diag.Diagnostics,
+) (context.Context, context.CancelFunc) {
+	duration, timeoutDiags := timeout(ctx, 0)
+	diags.Append(timeoutDiags...)
+	if timeoutDiags.HasError() || duration <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, duration)
+}
@@ -0,0 +1,76 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithOperationTimeout(t *testing.T) {
+	attrTypes := map[string]attr.Type{
+		"create": types.StringType,
+		"update": types.StringType,
+		"delete": types.StringType,
+	}
+	object, objectDiags := types.ObjectValue(attrTypes, map[string]attr.Value{
+		"create": types.StringValue("30m"),
+		"update": types.StringNull(),
+		"delete": types.StringValue("15m"),
+	})
+	require.False(t, objectDiags.HasError(), objectDiags)
+	value := timeouts.Value{Object: object}
+
+	var diags diag.Diagnostics
+	ctx, cancel := withOperationTimeout(context.Background(), value.Create, &diags)
+	defer cancel()
+	require.False(t, diags.HasError(), diags)
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(30*time.Minute), deadline, time.Minute)
+
+	ctx, cancel = withOperationTimeout(context.Background(), value.Update, &diags)
+	defer cancel()
+	_, ok = ctx.Deadline()
+	assert.False(t, ok)
+
+	ctx, cancel = withOperationTimeout(context.Background(), timeouts.Value{Object: types.ObjectNull(attrTypes)}.Delete, &diags)
+	defer cancel()
+	_, ok = ctx.Deadline()
+	assert.False(t, ok)
+	assert.False(t, diags.HasError(), diags)
+
+	// The diagnostics of an invalid timeout are kept.
+	invalid := timeouts.Value{Object: types.ObjectValueMust(attrTypes, map[string]attr.Value{
+		"create": types.StringValue("soon"),
+		"update": types.StringNull(),
+		"delete": types.StringNull(),
+	})}
+	ctx, cancel = withOperationTimeout(context.Background(), invalid.Create, &diags)
+	defer cancel()
+	_, ok = ctx.Deadline()
+	assert.False(t, ok)
+	assert.True(t, diags.HasError())
+}
+
+func TestTimeoutsBlock(t *testing.T) {
+	block := timeoutsBlock(context.Background(), "model").(schema.SingleNestedBlock)
+	assert.Contains(t, block.Description, "operations on the model")
+	assert.Len(t, block.Attributes, 3)
+	assert.Contains(t, block.Attributes["delete"].GetDescription(), "delete of the model")
+	assert.Equal(t, timeouts.Type{ObjectType: types.ObjectType{AttrTypes: map[string]attr.Type{
+		"create": types.StringType,
+		"update": types.StringType,
+		"delete": types.StringType,
+	}}}, block.CustomType)
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// otlpTracesPath is the path of the traces endpoint of an OTLP/HTTP
+// collector.
+const otlpTracesPath = "/v1/traces"
+
+// getTracingConfig returns the configuration of the export of traces,
+// set in the plan or by the standard OpenTelemetry environment
+// variables. Tracing is disabled when no endpoint is set.
+func getTracingConfig(ctx context.Context, data jujuProviderModel) (juju.TracingConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	endpoint := data.OTLPEndpoint
+	if endpoint.ValueString() == "" {
+		endpoint = getEnvVar(JujuOTLPEndpointEnvKey)
+	}
+	if endpoint.ValueString() == "" {
+		return juju.TracingConfig{}, diags
+	}
+	u, err := url.Parse(endpoint.ValueString())
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		diags.AddAttributeError(path.Root(JujuOTLPEndpoint), "Invalid OTLP Endpoint",
+			fmt.Sprintf("Expected an http or https URL, got %q.", endpoint.ValueString()))
+		return juju.TracingConfig{}, diags
+	}
+
+	headers := map[string]string{}
+	if !data.OTLPHeaders.IsNull() && !data.OTLPHeaders.IsUnknown() {
+		diags.Append(data.OTLPHeaders.ElementsAs(ctx, &headers, false)...)
+		if diags.HasError() {
+			return juju.TracingConfig{}, diags
+		}
+	} else if value := getEnvVar(JujuOTLPHeadersEnvKey); value.ValueString() != "" {
+		for _, header := range strings.Split(value.ValueString(), ",") {
+			k, v, ok := strings.Cut(header, "=")
+			if !ok || strings.TrimSpace(k) == "" {
+				diags.AddError("Invalid OTLP Headers",
+					fmt.Sprintf("Expected %s to be a comma separated list of key=value pairs.", JujuOTLPHeadersEnvKey))
+				return juju.TracingConfig{}, diags
+			}
+			headers[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+	}
+
+	return juju.TracingConfig{
+		Endpoint: strings.TrimSuffix(endpoint.ValueString(), "/") + otlpTracesPath,
+		Headers:  headers,
+	}, diags
+}
+
+// traceOperation starts the span of the operation of a resource or
+// data source, the connections and facade calls of which are recorded
+// as its children. The returned function ends the span, failed if
+// diags has errors by then, and logs the counters of the client so far
+// at debug level. client may be nil.
+func traceOperation(ctx context.Context, client *juju.Client, typeName, operation string, diags *diag.Diagnostics) (context.Context, func()) {
+	ctx, span := client.StartSpan(ctx, typeName+"."+operation, map[string]string{
+		juju.SpanAttributeResourceType: typeName,
+		juju.SpanAttributeOperation:    operation,
+	})
+	return ctx, func() {
+		var err error
+		for _, d := range diags.Errors() {
+			err = errors.Join(err, errors.New(d.Summary()))
+		}
+		span.End(err)
+		if client != nil {
+			tflog.Debug(ctx, fmt.Sprintf("juju client stats after %s.%s", typeName, operation), client.Stats().Fields())
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+func TestGetTracingConfig(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv(JujuOTLPEndpointEnvKey, "")
+	t.Setenv(JujuOTLPHeadersEnvKey, "")
+
+	config, diags := getTracingConfig(ctx, jujuProviderModel{OTLPHeaders: types.MapNull(types.StringType)})
+	require.False(t, diags.HasError())
+	assert.Equal(t, juju.TracingConfig{}, config)
+
+	config, diags = getTracingConfig(ctx, jujuProviderModel{
+		OTLPEndpoint: types.StringValue("https://collector.example.com:4318/"),
+		OTLPHeaders: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"Authorization": types.StringValue("Bearer token"),
+		}),
+	})
+	require.False(t, diags.HasError())
+	assert.Equal(t, juju.TracingConfig{
+		Endpoint: "https://collector.example.com:4318/v1/traces",
+		Headers:  map[string]string{"Authorization": "Bearer token"},
+	}, config)
+
+	t.Setenv(JujuOTLPEndpointEnvKey, "http://localhost:4318")
+	t.Setenv(JujuOTLPHeadersEnvKey, "Authorization=Bearer token, X-Tenant=ops")
+	config, diags = getTracingConfig(ctx, jujuProviderModel{OTLPHeaders: types.MapNull(types.StringType)})
+	require.False(t, diags.HasError())
+	assert.Equal(t, juju.TracingConfig{
+		Endpoint: "http://localhost:4318/v1/traces",
+		Headers:  map[string]string{"Authorization": "Bearer token", "X-Tenant": "ops"},
+	}, config)
+
+	t.Setenv(JujuOTLPHeadersEnvKey, "Authorization")
+	_, diags = getTracingConfig(ctx, jujuProviderModel{OTLPHeaders: types.MapNull(types.StringType)})
+	assert.True(t, diags.HasError())
+
+	_, diags = getTracingConfig(ctx, jujuProviderModel{
+		OTLPEndpoint: types.StringValue("localhost:4317"),
+		OTLPHeaders:  types.MapNull(types.StringType),
+	})
+	assert.True(t, diags.HasError())
+}
+
+func TestTraceOperationWithoutClient(t *testing.T) {
+	var diags diag.Diagnostics
+	ctx := context.Background()
+	spanCtx, endSpan := traceOperation(ctx, nil, "juju_model", "Create", &diags)
+	assert.Equal(t, ctx, spanCtx)
+	diags.AddError("Client Error", "boom")
+	endSpan()
+}
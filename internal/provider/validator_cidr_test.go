@@ -0,0 +1,66 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/juju/terraform-provider-juju/internal/provider"
+)
+
+func TestCIDRValidatorValid(t *testing.T) {
+	validCIDRs := []types.String{
+		types.StringValue("192.168.1.0/24"),
+		types.StringValue("0.0.0.0/0"),
+		types.StringValue("::/0"),
+		types.StringNull(),
+		types.StringUnknown(),
+	}
+
+	cidrValidator := provider.StringIsCIDRValidator{}
+	for _, cidr := range validCIDRs {
+		req := validator.StringRequest{
+			ConfigValue: cidr,
+		}
+		var resp validator.StringResponse
+		cidrValidator.ValidateString(context.Background(), req, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Errorf("errors %v", resp.Diagnostics.Errors())
+		}
+	}
+}
+
+func TestCIDRValidatorInvalid(t *testing.T) {
+	invalidCIDRs := []struct {
+		str types.String
+		err string
+	}{{
+		str: types.StringValue("192.168.1.1"),
+		err: "invalid CIDR address: 192.168.1.1",
+	}, {
+		str: types.StringValue("10.0.0.0/33"),
+		err: "invalid CIDR address: 10.0.0.0/33",
+	}}
+
+	cidrValidator := provider.StringIsCIDRValidator{}
+	for _, test := range invalidCIDRs {
+		req := validator.StringRequest{
+			ConfigValue: test.str,
+		}
+		var resp validator.StringResponse
+		cidrValidator.ValidateString(context.Background(), req, &resp)
+
+		if c := resp.Diagnostics.ErrorsCount(); c != 1 {
+			t.Errorf("expected one error, got %d", c)
+		}
+		if deets := resp.Diagnostics.Errors()[0].Detail(); deets != test.err {
+			t.Errorf("expected error %q, got %q", test.err, deets)
+		}
+	}
+}
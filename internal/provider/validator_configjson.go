@@ -0,0 +1,78 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+type StringIsConfigJSONValidator struct{}
+
+// Description returns a plain text description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v StringIsConfigJSONValidator) Description(context.Context) string {
+	return "string must be a JSON object the values of which are strings, numbers or booleans"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v StringIsConfigJSONValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// Validate runs the main validation logic of the validator, reading configuration data out of `req` and updating `resp` with diagnostics.
+func (v StringIsConfigJSONValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	// If the value is unknown or null, there is nothing to validate.
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	if _, err := parseConfigJSON(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Config JSON",
+			err.Error(),
+		)
+	}
+}
+
+// parseConfigJSON parses a JSON object of config values to the strings
+// Juju converts to the types of the config options of the charm.
+// Integral numbers are formatted as integers, e.g. 3.0 as 3, for
+// options of type int.
+func parseConfigJSON(s string) (map[string]string, error) {
+	decoder := json.NewDecoder(strings.NewReader(s))
+	decoder.UseNumber()
+	var values map[string]interface{}
+	if err := decoder.Decode(&values); err != nil || values == nil {
+		return nil, fmt.Errorf("expected a JSON object of config values")
+	}
+	config := make(map[string]string, len(values))
+	for k, v := range values {
+		switch value := v.(type) {
+		case string:
+			config[k] = value
+		case bool:
+			config[k] = strconv.FormatBool(value)
+		case json.Number:
+			if i, err := value.Int64(); err == nil {
+				config[k] = strconv.FormatInt(i, 10)
+			} else if f, err := value.Float64(); err != nil {
+				return nil, fmt.Errorf("value of %q is not a valid number", k)
+			} else if f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+				config[k] = strconv.FormatInt(int64(f), 10)
+			} else {
+				config[k] = strconv.FormatFloat(f, 'f', -1, 64)
+			}
+		default:
+			return nil, fmt.Errorf("value of %q must be a string, number or boolean", k)
+		}
+	}
+	return config, nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/juju/terraform-provider-juju/internal/provider"
+)
+
+func TestConfigJSONValidatorValid(t *testing.T) {
+	validConfigs := []types.String{
+		types.StringValue(`{"port": 8080, "debug": true, "ratio": 0.5, "name": "wordpress"}`),
+		types.StringValue(`{}`),
+		types.StringNull(),
+		types.StringUnknown(),
+	}
+
+	configJSONValidator := provider.StringIsConfigJSONValidator{}
+	for _, config := range validConfigs {
+		req := validator.StringRequest{
+			ConfigValue: config,
+		}
+		var resp validator.StringResponse
+		configJSONValidator.ValidateString(context.Background(), req, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Errorf("errors %v", resp.Diagnostics.Errors())
+		}
+	}
+}
+
+func TestConfigJSONValidatorInvalid(t *testing.T) {
+	invalidConfigs := []struct {
+		str types.String
+		err string
+	}{{
+		str: types.StringValue(`["port"]`),
+		err: "expected a JSON object of config values",
+	}, {
+		str: types.StringValue(`null`),
+		err: "expected a JSON object of config values",
+	}, {
+		str: types.StringValue(`{"ports": [80, 443]}`),
+		err: `value of "ports" must be a string, number or boolean`,
+	}, {
+		str: types.StringValue(`{"name": null}`),
+		err: `value of "name" must be a string, number or boolean`,
+	}}
+
+	configJSONValidator := provider.StringIsConfigJSONValidator{}
+	for _, test := range invalidConfigs {
+		req := validator.StringRequest{
+			ConfigValue: test.str,
+		}
+		var resp validator.StringResponse
+		configJSONValidator.ValidateString(context.Background(), req, &resp)
+
+		if c := resp.Diagnostics.ErrorsCount(); c != 1 {
+			t.Errorf("expected one error, got %d", c)
+		}
+		if deets := resp.Diagnostics.Errors()[0].Detail(); deets != test.err {
+			t.Errorf("expected error %q, got %q", test.err, deets)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/juju/terraform-provider-juju/internal/provider"
+)
+
+func TestConstraintsValidatorValid(t *testing.T) {
+	validConstraints := []types.String{
+		types.StringValue("arch=amd64 cores=2 mem=4G"),
+		types.StringValue(""),
+		types.StringNull(),
+		types.StringUnknown(),
+	}
+
+	constraintsValidator := provider.StringIsConstraintsValidator{}
+	for _, cons := range validConstraints {
+		req := validator.StringRequest{
+			ConfigValue: cons,
+		}
+		var resp validator.StringResponse
+		constraintsValidator.ValidateString(context.Background(), req, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Errorf("errors %v", resp.Diagnostics.Errors())
+		}
+	}
+}
+
+func TestConstraintsValidatorInvalid(t *testing.T) {
+	invalidConstraints := []struct {
+		str types.String
+		err string
+	}{{
+		str: types.StringValue("cores=two"),
+		err: `bad "cores" constraint: must be a non-negative integer`,
+	}, {
+		str: types.StringValue("memory=4G"),
+		err: `unknown constraint "memory"`,
+	}}
+
+	constraintsValidator := provider.StringIsConstraintsValidator{}
+	for _, test := range invalidConstraints {
+		req := validator.StringRequest{
+			ConfigValue: test.str,
+		}
+		var resp validator.StringResponse
+		constraintsValidator.ValidateString(context.Background(), req, &resp)
+
+		if c := resp.Diagnostics.ErrorsCount(); c != 1 {
+			t.Errorf("expected one error, got %d", c)
+		}
+		if deets := resp.Diagnostics.Errors()[0].Detail(); deets != test.err {
+			t.Errorf("expected error %q, got %q", test.err, deets)
+		}
+	}
+}
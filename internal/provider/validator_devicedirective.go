@@ -0,0 +1,55 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/juju/juju/core/devices"
+)
+
+type StringIsDeviceDirectiveValidator struct{}
+
+// Description returns a plain text description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v StringIsDeviceDirectiveValidator) Description(context.Context) string {
+	return "string must conform to a device directive: [<count>,]<type>[,<attributes>]"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v StringIsDeviceDirectiveValidator) MarkdownDescription(context.Context) string {
+	return "string must conform to a device directive `[<count>,]<type>[,<attributes>]`"
+}
+
+// Validate runs the main validation logic of the validator, reading configuration data out of `req` and updating `resp` with diagnostics.
+func (v StringIsDeviceDirectiveValidator) ValidateMap(ctx context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	// If the value is unknown or null, there is nothing to validate.
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	// If the value of any element is unknown or null, there is nothing to validate.
+	for _, element := range req.ConfigValue.Elements() {
+		if element.IsUnknown() || element.IsNull() {
+			return
+		}
+	}
+
+	var deviceDirectives map[string]string
+	resp.Diagnostics.Append(req.ConfigValue.ElementsAs(ctx, &deviceDirectives, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for name, directive := range deviceDirectives {
+		if _, err := devices.ParseConstraints(directive); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.Path,
+				"Invalid Device Directive",
+				fmt.Sprintf("%q fails to parse with error: %s", name, err),
+			)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/juju/terraform-provider-juju/internal/provider"
+)
+
+func TestDeviceDirectiveValidatorValid(t *testing.T) {
+	validDirectives := []types.Map{
+		types.MapValueMust(types.StringType, map[string]attr.Value{
+			"bitcoinminer": types.StringValue("10,nvidia.com/gpu"),
+			"gpu":          types.StringValue("nvidia.com/gpu"),
+			"tpu":          types.StringValue("2,gpu,gpu=nvidia-tesla-p100;zone=us-east1"),
+		}),
+		types.MapNull(types.StringType),
+		types.MapUnknown(types.StringType),
+	}
+
+	deviceValidator := provider.StringIsDeviceDirectiveValidator{}
+	for _, directives := range validDirectives {
+		req := validator.MapRequest{
+			ConfigValue: directives,
+		}
+		var resp validator.MapResponse
+		deviceValidator.ValidateMap(context.Background(), req, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Errorf("errors %v", resp.Diagnostics.Errors())
+		}
+	}
+}
+
+func TestDeviceDirectiveValidatorInvalid(t *testing.T) {
+	invalidDirectives := []struct {
+		directive string
+		err       string
+	}{{
+		directive: "0,nvidia.com/gpu",
+		err:       `"gpu" fails to parse with error: count must be greater than zero, got "0"`,
+	}, {
+		directive: "1,nvidia.com/gpu,gpu",
+		err:       `"gpu" fails to parse with error: device attribute key/value pair has bad format: "gpu"`,
+	}}
+
+	deviceValidator := provider.StringIsDeviceDirectiveValidator{}
+	for _, test := range invalidDirectives {
+		req := validator.MapRequest{
+			ConfigValue: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"gpu": types.StringValue(test.directive),
+			}),
+		}
+		var resp validator.MapResponse
+		deviceValidator.ValidateMap(context.Background(), req, &resp)
+
+		if c := resp.Diagnostics.ErrorsCount(); c != 1 {
+			t.Fatalf("expected one error, got %d", c)
+		}
+		if deets := resp.Diagnostics.Errors()[0].Detail(); deets != test.err {
+			t.Errorf("expected error %q, got %q", test.err, deets)
+		}
+	}
+}
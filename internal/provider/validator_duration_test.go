@@ -0,0 +1,68 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/juju/terraform-provider-juju/internal/provider"
+)
+
+func TestDurationValidatorValid(t *testing.T) {
+	validDurations := []types.String{
+		types.StringValue("30s"),
+		types.StringValue("1h5m"),
+		types.StringNull(),
+		types.StringUnknown(),
+	}
+
+	durationValidator := provider.StringIsDurationValidator{}
+	for _, duration := range validDurations {
+		req := validator.StringRequest{
+			ConfigValue: duration,
+		}
+		var resp validator.StringResponse
+		durationValidator.ValidateString(context.Background(), req, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Errorf("errors %v", resp.Diagnostics.Errors())
+		}
+	}
+}
+
+func TestDurationValidatorInvalid(t *testing.T) {
+	invalidDurations := []struct {
+		str types.String
+		err string
+	}{{
+		str: types.StringValue("5"),
+		err: `time: missing unit in duration "5"`,
+	}, {
+		str: types.StringValue("0s"),
+		err: "Duration must be positive, e.g. 30s or 5m",
+	}, {
+		str: types.StringValue("-1m"),
+		err: "Duration must be positive, e.g. 30s or 5m",
+	}}
+
+	durationValidator := provider.StringIsDurationValidator{}
+	for _, test := range invalidDurations {
+		req := validator.StringRequest{
+			ConfigValue: test.str,
+		}
+		var resp validator.StringResponse
+		durationValidator.ValidateString(context.Background(), req, &resp)
+
+		if c := resp.Diagnostics.ErrorsCount(); c != 1 {
+			t.Errorf("expected one error, got %d", c)
+		}
+		if deets := resp.Diagnostics.Errors()[0].Detail(); deets != test.err {
+			t.Errorf("expected error %q, got %q", test.err, deets)
+		}
+	}
+}
@@ -37,27 +37,40 @@ func (v StringIsResourceKeyValidator) ValidateMap(ctx context.Context, req valid
 		return
 	}
 	for name, value := range resourceKey {
-		providedRev, err := strconv.Atoi(value)
-		if err != nil {
-			imageUrlPattern := `(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9]):[\w][\w.-]{0,127}`
-			urlRegex := regexp.MustCompile(imageUrlPattern)
-			if urlRegex.MatchString(value) {
-				continue
-			}
+		if !isValidResourceValue(value) {
 			resp.Diagnostics.AddAttributeError(
 				req.Path,
 				"Invalid resource value",
 				fmt.Sprintf("value of %q should be a valid revision number or image URL.", name),
 			)
-			continue
-		}
-		if providedRev <= 0 {
-			resp.Diagnostics.AddAttributeError(
-				req.Path,
-				"Invalid resource value",
-				fmt.Sprintf("value of %q should be a valid revision number or image URL.", name),
-			)
-			continue
 		}
 	}
 }
+
+// ValidateString runs the validation logic on a single resource value.
+func (v StringIsResourceKeyValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	// If the value is unknown or null, there is nothing to validate.
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	if !isValidResourceValue(req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid resource value",
+			fmt.Sprintf("value %q should be a valid revision number or image URL.", req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// isValidResourceValue reports whether the value is a positive revision
+// number or an OCI image URL.
+func isValidResourceValue(value string) bool {
+	providedRev, err := strconv.Atoi(value)
+	if err != nil {
+		imageUrlPattern := `(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9]):[\w][\w.-]{0,127}`
+		urlRegex := regexp.MustCompile(imageUrlPattern)
+		return urlRegex.MatchString(value)
+	}
+	return providedRev > 0
+}
@@ -0,0 +1,61 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+// Command acctest bootstraps a disposable Juju controller, runs the
+// acceptance tests against it and destroys it. Arguments following the
+// flags are passed to go test, e.g.
+//
+//	go run ./internal/testing/cmd/acctest -cloud lxd -- -run TestAcc_ResourceModel -v
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+
+	"github.com/juju/terraform-provider-juju/internal/testing/controller"
+)
+
+func main() {
+	var config controller.Config
+	flag.StringVar(&config.Cloud, "cloud", controller.LXD, "cloud to bootstrap the controller on, lxd or microk8s")
+	flag.StringVar(&config.Name, "controller", "", "name of the controller, random by default")
+	flag.StringVar(&config.AgentVersion, "agent-version", os.Getenv("JUJU_AGENT_VERSION"), "version of Juju to bootstrap")
+	keep := flag.Bool("keep", false, "keep the controller once the tests are run")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	os.Exit(run(ctx, config, *keep, flag.Args()))
+}
+
+func run(ctx context.Context, config controller.Config, keep bool, args []string) int {
+	c, err := controller.Bootstrap(ctx, config)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if keep {
+		fmt.Fprintf(os.Stderr, "keeping controller %q\n", c.Name)
+	} else {
+		defer func() {
+			if err := c.Destroy(context.WithoutCancel(ctx)); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+		}()
+	}
+
+	err = c.RunAcceptance(ctx, ".", controller.AcceptancePackages, args...)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	} else if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
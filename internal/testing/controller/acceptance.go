@@ -0,0 +1,25 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package controller
+
+import (
+	"context"
+	"os"
+	"os/exec"
+)
+
+// AcceptancePackages are the packages holding the acceptance tests.
+var AcceptancePackages = []string{"./internal/provider/..."}
+
+// RunAcceptance runs go test with TF_ACC set against the controller,
+// from dir, the root of the repository. args are passed to go test
+// after the packages, e.g. -run TestAcc_ResourceModel.
+func (c *Controller) RunAcceptance(ctx context.Context, dir string, packages []string, args ...string) error {
+	cmd := exec.CommandContext(ctx, "go", append(append([]string{"test"}, packages...), args...)...)
+	cmd.Dir = dir
+	cmd.Env = append(append(os.Environ(), "TF_ACC=1"), c.Env()...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
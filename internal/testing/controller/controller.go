@@ -0,0 +1,184 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+// Package controller bootstraps disposable Juju controllers, on LXD or
+// MicroK8s, to run the acceptance tests of the provider against without
+// a preconfigured environment. It drives the juju CLI, which must be
+// installed with the cloud set up.
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Clouds the controllers can be bootstrapped on, as expected by the
+// TEST_CLOUD environment variable of the acceptance tests.
+const (
+	LXD      = "lxd"
+	MicroK8s = "microk8s"
+)
+
+// Config configures the bootstrap of a controller.
+type Config struct {
+	// Cloud is the cloud to bootstrap on, LXD or MicroK8s.
+	Cloud string
+	// Name is the name of the controller, it defaults to a random
+	// name starting with tf-acc-.
+	Name string
+	// AgentVersion is the version of Juju to bootstrap, it defaults to
+	// the version of the juju CLI.
+	AgentVersion string
+	// Juju is the path of the juju CLI, it defaults to juju.
+	Juju string
+	// BootstrapArgs are passed as is to juju bootstrap.
+	BootstrapArgs []string
+}
+
+// Controller is a bootstrapped controller, with the details the
+// provider connects with.
+type Controller struct {
+	Name      string
+	Cloud     string
+	Addresses []string
+	CACert    string
+	Username  string
+	Password  string
+	// AgentVersion is the version of Juju the controller runs, which
+	// some acceptance tests require a minimum of.
+	AgentVersion string
+
+	juju string
+}
+
+// Bootstrap bootstraps a controller as configured. The controller is
+// destroyed if it cannot be described once bootstrapped, otherwise the
+// caller is expected to call Destroy.
+func Bootstrap(ctx context.Context, config Config) (*Controller, error) {
+	cloud, err := bootstrapCloud(config.Cloud)
+	if err != nil {
+		return nil, err
+	}
+	c := &Controller{
+		Name:         config.Name,
+		Cloud:        config.Cloud,
+		AgentVersion: config.AgentVersion,
+		juju:         config.Juju,
+	}
+	if c.Name == "" {
+		c.Name = fmt.Sprintf("tf-acc-%06d", rand.Intn(1000000))
+	}
+	if c.juju == "" {
+		c.juju = "juju"
+	}
+
+	args := []string{"bootstrap", cloud, c.Name, "--no-switch"}
+	if config.AgentVersion != "" {
+		args = append(args, "--agent-version", config.AgentVersion)
+	}
+	args = append(args, config.BootstrapArgs...)
+	if _, err := c.run(ctx, args...); err != nil {
+		return nil, fmt.Errorf("bootstrapping controller %q: %w", c.Name, err)
+	}
+
+	out, err := c.run(ctx, "show-controller", c.Name, "--show-password", "--format", "json")
+	if err == nil {
+		err = c.parseShowController(out)
+	}
+	if err != nil {
+		err = fmt.Errorf("describing controller %q: %w", c.Name, err)
+		if destroyErr := c.Destroy(context.WithoutCancel(ctx)); destroyErr != nil {
+			err = fmt.Errorf("%w, then destroying it: %w", err, destroyErr)
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+// bootstrapCloud returns the name of the cloud juju bootstrap expects.
+func bootstrapCloud(cloud string) (string, error) {
+	switch strings.ToLower(cloud) {
+	case LXD:
+		return "localhost", nil
+	case MicroK8s:
+		return "microk8s", nil
+	default:
+		return "", fmt.Errorf("unsupported cloud %q, expected %q or %q", cloud, LXD, MicroK8s)
+	}
+}
+
+// showController is the output of juju show-controller.
+type showController map[string]struct {
+	Details struct {
+		APIEndpoints []string `json:"api-endpoints"`
+		CACert       string   `json:"ca-cert"`
+		AgentVersion string   `json:"agent-version"`
+	} `json:"details"`
+	Account struct {
+		User     string `json:"user"`
+		Password string `json:"password"`
+	} `json:"account"`
+}
+
+func (c *Controller) parseShowController(out []byte) error {
+	var shown showController
+	if err := json.Unmarshal(out, &shown); err != nil {
+		return err
+	}
+	details, ok := shown[c.Name]
+	if !ok {
+		return fmt.Errorf("controller not found")
+	}
+	if len(details.Details.APIEndpoints) == 0 {
+		return fmt.Errorf("controller has no API endpoints")
+	}
+	c.Addresses = details.Details.APIEndpoints
+	c.CACert = details.Details.CACert
+	c.Username = details.Account.User
+	c.Password = details.Account.Password
+	if details.Details.AgentVersion != "" {
+		c.AgentVersion = details.Details.AgentVersion
+	}
+	return nil
+}
+
+// Env returns the environment variables configuring the provider, and
+// the acceptance tests, to use the controller.
+func (c *Controller) Env() []string {
+	return []string{
+		"JUJU_CONTROLLER_ADDRESSES=" + strings.Join(c.Addresses, ","),
+		"JUJU_USERNAME=" + c.Username,
+		"JUJU_PASSWORD=" + c.Password,
+		"JUJU_CA_CERT=" + c.CACert,
+		"JUJU_AGENT_VERSION=" + c.AgentVersion,
+		"TEST_CLOUD=" + c.Cloud,
+	}
+}
+
+// Destroy destroys the controller with all its models and storage.
+func (c *Controller) Destroy(ctx context.Context) error {
+	_, err := c.run(ctx, "kill-controller", c.Name, "--no-prompt", "--timeout", "10m")
+	if err != nil {
+		return fmt.Errorf("destroying controller %q: %w", c.Name, err)
+	}
+	return nil
+}
+
+// run runs the juju CLI, streaming its error output, and returns its
+// output.
+func (c *Controller) run(ctx context.Context, args ...string) ([]byte, error) {
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.juju, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("juju %s: %w", args[0], err)
+	}
+	return stdout.Bytes(), nil
+}
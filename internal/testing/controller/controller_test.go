@@ -0,0 +1,47 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const showControllerOutput = `{
+  "tf-acc-1": {
+    "details": {
+      "api-endpoints": ["10.1.2.3:17070", "[fd42::1]:17070"],
+      "ca-cert": "-----BEGIN CERTIFICATE-----\n-----END CERTIFICATE-----\n",
+      "agent-version": "3.5.1"
+    },
+    "account": {"user": "admin", "access": "superuser", "password": "secret"}
+  }
+}`
+
+func TestParseShowController(t *testing.T) {
+	c := &Controller{Name: "tf-acc-1", Cloud: LXD}
+	require.NoError(t, c.parseShowController([]byte(showControllerOutput)))
+	assert.Equal(t, []string{
+		"JUJU_CONTROLLER_ADDRESSES=10.1.2.3:17070,[fd42::1]:17070",
+		"JUJU_USERNAME=admin",
+		"JUJU_PASSWORD=secret",
+		"JUJU_CA_CERT=-----BEGIN CERTIFICATE-----\n-----END CERTIFICATE-----\n",
+		"JUJU_AGENT_VERSION=3.5.1",
+		"TEST_CLOUD=lxd",
+	}, c.Env())
+
+	c = &Controller{Name: "other"}
+	assert.EqualError(t, c.parseShowController([]byte(showControllerOutput)), "controller not found")
+}
+
+func TestBootstrapCloud(t *testing.T) {
+	cloud, err := bootstrapCloud("LXD")
+	require.NoError(t, err)
+	assert.Equal(t, "localhost", cloud)
+
+	_, err = bootstrapCloud("aws")
+	assert.EqualError(t, err, `unsupported cloud "aws", expected "lxd" or "microk8s"`)
+}
@@ -4,16 +4,24 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
+	"github.com/juju/terraform-provider-juju/internal/juju"
 	"github.com/juju/terraform-provider-juju/internal/provider"
 )
 
+// tracingShutdownTimeout is how long the spans not exported yet may
+// take to export once Terraform stopped the provider, which it kills
+// shortly after.
+const tracingShutdownTimeout = 2 * time.Second
+
 // Run "go generate" to format example terraform files and generate the docs for the registry/website
 
 // If you do not have terraform installed, you can remove the formatting command, but it's suggested to
@@ -46,11 +54,19 @@ func main() {
 		zerolog.SetGlobalLevel(zerolog.DebugLevel)
 	}
 
-	if err := tf6server.Serve(
+	err := tf6server.Serve(
 		"registry.terraform.io/juju/juju",
 		providerserver.NewProtocol6(provider.NewJujuProvider(version)),
 		serveOpts...,
-	); err != nil {
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	if shutdownErr := juju.ShutdownTracing(ctx); shutdownErr != nil {
+		log.Warn().Msg("unable to export traces: " + shutdownErr.Error())
+	}
+	cancel()
+
+	if err != nil {
 		log.Fatal().Msg(err.Error())
 	}
 }